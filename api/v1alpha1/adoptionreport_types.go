@@ -0,0 +1,93 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdoptionCandidate is an AWS secret following the naming convention of the operator that is not
+// managed by it, with the Database that would adopt it
+type AdoptionCandidate struct {
+	// SecretName is the name of the secret
+	SecretName string `json:"secretName"`
+
+	// SecretARN is the ARN of the secret
+	SecretARN string `json:"secretARN,omitempty"`
+
+	// Region is the AWS region of the secret
+	Region string `json:"region"`
+
+	// Engine is the database engine, taken from the secret name
+	Engine DatabaseEngine `json:"engine"`
+
+	// DatabaseName is the name of the database, taken from the secret name
+	DatabaseName string `json:"databaseName"`
+
+	// CreatedAt is when the secret was created
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// Manifest is a suggested Database manifest adopting the secret and the user and database it
+	// belongs to. The admin connection has to be added before it is applied.
+	Manifest string `json:"manifest"`
+}
+
+// AdoptionReportStatus is the result of the last adoption scan
+type AdoptionReportStatus struct {
+	// Prefix is the secret name prefix that was scanned
+	Prefix string `json:"prefix,omitempty"`
+
+	// Regions are the AWS regions that were scanned
+	Regions []string `json:"regions,omitempty"`
+
+	// LastScanTime is when the last scan completed
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+
+	// CandidateCount is the number of candidates
+	CandidateCount int32 `json:"candidateCount,omitempty"`
+
+	// Candidates are the secrets that no Database manages, sorted by region and name
+	// +optional
+	Candidates []AdoptionCandidate `json:"candidates,omitempty"`
+
+	// Errors are the regions that could not be scanned with the reason
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=adoption
+// +kubebuilder:printcolumn:name="Prefix",type=string,JSONPath=`.status.prefix`
+// +kubebuilder:printcolumn:name="Candidates",type=integer,JSONPath=`.status.candidateCount`
+// +kubebuilder:printcolumn:name="Last Scan",type=date,JSONPath=`.status.lastScanTime`
+
+// AdoptionReport is written by the adoption scanner of the operator, started with
+// --adoption-scan-interval. It lists the AWS secrets matching the naming convention of the
+// operator that no Database manages, e.g. secrets created by a previous provisioning process,
+// with suggested Database manifests to migrate them onto the operator.
+type AdoptionReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status AdoptionReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AdoptionReportList contains a list of AdoptionReport
+type AdoptionReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AdoptionReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AdoptionReport{}, &AdoptionReportList{})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterDatabaseSpec defines the desired state of ClusterDatabase
+type ClusterDatabaseSpec struct {
+	DatabaseSpec `json:",inline"`
+
+	// SecretNamespace is the namespace of the Kubernetes resources of the ClusterDatabase,
+	// such as the Secret referenced by connectionStringSecretRef
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SecretNamespace string `json:"secretNamespace"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cdb
+// +kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engine`
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.databaseName`
+// +kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.status.actualUsername`
+// +kubebuilder:printcolumn:name="SecretName",type=string,JSONPath=`.status.actualSecretName`
+// +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.status.secretRegion`
+// +kubebuilder:printcolumn:name="Account",type=string,JSONPath=`.status.secretAccountID`,priority=1
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterDatabase is the Schema for the clusterdatabases API
+// It manages a platform-owned database that is not tied to a tenant namespace
+type ClusterDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDatabaseSpec `json:"spec,omitempty"`
+	Status DatabaseStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterDatabaseList contains a list of ClusterDatabase
+type ClusterDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDatabase `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDatabase{}, &ClusterDatabaseList{})
+}
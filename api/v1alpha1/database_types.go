@@ -8,18 +8,74 @@ See LICENSE file in the project root for full license information.
 package v1alpha1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DatabaseEngine defines the type of database
-// +kubebuilder:validation:Enum=postgres;postgresql;mysql;mariadb
+// +kubebuilder:validation:Enum=postgres;postgresql;yugabyte;mysql;mariadb;cassandra;snowflake
 type DatabaseEngine string
 
 const (
 	DatabaseEnginePostgres   DatabaseEngine = "postgres"
 	DatabaseEnginePostgreSQL DatabaseEngine = "postgresql"
+	DatabaseEngineYugabyte   DatabaseEngine = "yugabyte"
 	DatabaseEngineMySQL      DatabaseEngine = "mysql"
 	DatabaseEngineMariaDB    DatabaseEngine = "mariadb"
+	DatabaseEngineCassandra  DatabaseEngine = "cassandra"
+	DatabaseEngineSnowflake  DatabaseEngine = "snowflake"
+)
+
+// AnnotationAllowRename allows a change of spec.databaseName to rename the existing database
+// Only PostgreSQL databases can be renamed, their connections are terminated before the rename.
+const AnnotationAllowRename = "database.opzkit.io/allow-rename"
+
+// UsernameLengthPolicy defines what happens to a username longer than the engine allows
+// +kubebuilder:validation:Enum=Reject;Truncate
+type UsernameLengthPolicy string
+
+const (
+	// UsernameLengthPolicyReject rejects the Database with a configuration error
+	UsernameLengthPolicyReject UsernameLengthPolicy = "Reject"
+	// UsernameLengthPolicyTruncate shortens the username and ends it with a hash of the full name
+	UsernameLengthPolicyTruncate UsernameLengthPolicy = "Truncate"
+)
+
+// DeletionFailurePolicy defines what happens when the cleanup on deletion fails
+// +kubebuilder:validation:Enum=Retry;Orphan
+type DeletionFailurePolicy string
+
+const (
+	// DeletionFailurePolicyRetry keeps the finalizer and retries the cleanup until it succeeds
+	DeletionFailurePolicyRetry DeletionFailurePolicy = "Retry"
+	// DeletionFailurePolicyOrphan removes the finalizer and leaves the resources that could not be deleted
+	DeletionFailurePolicyOrphan DeletionFailurePolicy = "Orphan"
+)
+
+// PrivilegePreset names a curated set of privileges granted to the user of a Database
+// +kubebuilder:validation:Enum=readOnly;readWrite;ddl;migrationRunner
+type PrivilegePreset string
+
+const (
+	// PrivilegePresetReadOnly reads data
+	PrivilegePresetReadOnly PrivilegePreset = "readOnly"
+	// PrivilegePresetReadWrite reads and writes data but cannot change the schema
+	PrivilegePresetReadWrite PrivilegePreset = "readWrite"
+	// PrivilegePresetDDL changes the schema
+	PrivilegePresetDDL PrivilegePreset = "ddl"
+	// PrivilegePresetMigrationRunner changes the schema and migrates data
+	PrivilegePresetMigrationRunner PrivilegePreset = "migrationRunner"
+)
+
+// SecretFormat defines how the credentials are encoded in the secret value
+// +kubebuilder:validation:Enum=json;connectionStringOnly
+type SecretFormat string
+
+const (
+	// SecretFormatJSON stores a JSON object with the connection details, see spec.secretTemplate
+	SecretFormatJSON SecretFormat = "json"
+	// SecretFormatConnectionStringOnly stores the database URL as the whole secret value
+	SecretFormatConnectionStringOnly SecretFormat = "connectionStringOnly"
 )
 
 // DatabaseSpec defines the desired state of Database
@@ -30,12 +86,19 @@ type DatabaseSpec struct {
 	Engine DatabaseEngine `json:"engine"`
 
 	// DatabaseName is the name of the database to create
+	// It cannot be changed once the database is created, unless the annotation
+	// database.opzkit.io/allow-rename is "true" on a PostgreSQL database, which is then renamed.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=63
 	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
 	DatabaseName string `json:"databaseName"`
 
+	// CloneFrom creates the database as a copy of another database on the same server
+	// Only read when the database is created, see CloneSource.
+	// +optional
+	CloneFrom *CloneSource `json:"cloneFrom,omitempty"`
+
 	// ConnectionStringSecretRef references a Kubernetes Secret containing the admin connection string
 	// to the existing database instance. Must have proper permissions to create databases and users.
 	// Either ConnectionStringSecretRef or ConnectionStringAWSSecretRef must be specified.
@@ -49,6 +112,42 @@ type DatabaseSpec struct {
 	// +optional
 	ConnectionStringAWSSecretRef *AWSSecretReference `json:"connectionStringAWSSecretRef,omitempty"`
 
+	// AdminConnection assembles the admin connection from separate host, port, username and password
+	// values, e.g. the username and password of an RDS-managed master user secret, instead of a
+	// connection string. Exclusive with ConnectionStringSecretRef and ConnectionStringAWSSecretRef.
+	// +optional
+	AdminConnection *AdminConnection `json:"adminConnection,omitempty"`
+
+	// CloudSQL connects to a Google Cloud SQL instance through the Cloud SQL Go connector
+	// instead of the host of the admin connection string
+	// +optional
+	CloudSQL *CloudSQLConfig `json:"cloudSQL,omitempty"`
+
+	// Azure authenticates the admin connection to an Azure Database flexible server with Microsoft Entra ID
+	// +optional
+	Azure *AzureConfig `json:"azure,omitempty"`
+
+	// ConnectionParams are set on the admin connection string and appended to the database URL of the
+	// secret, e.g. connect_timeout, application_name or options on PostgreSQL. They replace parameters
+	// of the same name of the admin connection string. PostgreSQL and MySQL engines only.
+	// +kubebuilder:validation:MaxProperties=32
+	// +optional
+	ConnectionParams map[string]string `json:"connectionParams,omitempty"`
+
+	// ApplicationEndpoint is the endpoint written into the secret and its URL instead of the admin
+	// endpoint, e.g. a reader endpoint, an RDS Proxy or a PgBouncer. The operator keeps administering
+	// the server through the admin connection.
+	// +optional
+	ApplicationEndpoint *ApplicationEndpoint `json:"applicationEndpoint,omitempty"`
+
+	// PortOverride is the port written into the secret and its URL instead of the port of the admin
+	// connection, e.g. 6432 of a PgBouncer in front of the server applications should connect to.
+	// The operator keeps administering the server through the admin connection.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	PortOverride int32 `json:"portOverride,omitempty"`
+
 	// Username for the database user to be created
 	// Defaults to the DatabaseName if not specified
 	// +optional
@@ -56,8 +155,16 @@ type DatabaseSpec struct {
 	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
 	Username string `json:"username,omitempty"`
 
+	// UsernameLengthPolicy determines what happens when the username, or the name of the migration
+	// user, is longer than the engine allows, e.g. 32 characters on MySQL
+	// Reject fails with a configuration error, Truncate shortens the name deterministically and ends it
+	// with a hash of the full name, the actual name is reported in status.actualUsername
+	// +optional
+	UsernameLengthPolicy UsernameLengthPolicy `json:"usernameLengthPolicy,omitempty"`
+
 	// SecretName is the name/path for storing the created credentials in AWS Secrets Manager
-	// Defaults to rds/<engine>/<databaseName>
+	// May also be a full secret ARN (aws, aws-cn or aws-us-gov partition), in which case the ARN's region is used
+	// Defaults to rds/<engine>/<databaseName>, the prefix is set with the --default-secret-prefix flag of the operator
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
 
@@ -66,12 +173,63 @@ type DatabaseSpec struct {
 	// +optional
 	Privileges []string `json:"privileges,omitempty"`
 
+	// PrivilegePreset grants the curated privileges of the preset for the engine instead of ALL PRIVILEGES
+	// On PostgreSQL, readOnly and readWrite databases created by the operator are owned by the admin user
+	// +optional
+	PrivilegePreset PrivilegePreset `json:"privilegePreset,omitempty"`
+
+	// MigrationUser provisions a second user with schema change privileges next to the user of the
+	// Database, e.g. for the schema migrations of CI pipelines, with its own secret
+	// +optional
+	MigrationUser *MigrationUserConfig `json:"migrationUser,omitempty"`
+
+	// Hooks are Jobs the operator runs after the database was provisioned or before it is deleted,
+	// e.g. to run schema migrations or export the data, with the credentials of the user in their environment
+	// +optional
+	Hooks *DatabaseHooks `json:"hooks,omitempty"`
+
+	// RestartTargets are Deployments and StatefulSets reading the secret, in the namespace of the Database
+	// or in secretNamespace of a ClusterDatabase. Their pods are restarted when the secret changes.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	RestartTargets []RestartTarget `json:"restartTargets,omitempty"`
+
 	// RetainOnDelete determines whether to retain the database and user when the CR is deleted
 	// Defaults to true (retains resources on deletion)
 	// +optional
 	// +kubebuilder:default=true
 	RetainOnDelete *bool `json:"retainOnDelete,omitempty"`
 
+	// DeletionFailurePolicy determines what happens when the cleanup with retainOnDelete: false fails
+	// Retry keeps the finalizer and retries, Orphan lets the resource be deleted and leaves the
+	// database, user or secret that could not be deleted behind
+	// +optional
+	// +kubebuilder:default=Retry
+	DeletionFailurePolicy DeletionFailurePolicy `json:"deletionFailurePolicy,omitempty"`
+
+	// ResyncInterval is the interval between periodic reconciliations of a ready Database, e.g. 1h
+	// Defaults to the --resync-interval of the operator. Changes of the spec are reconciled immediately.
+	// +optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+
+	// TTL is the lifetime of the Database measured from its creation, e.g. 72h for a preview environment
+	// Once it elapsed the operator deletes the Database, the database, user and secret are then
+	// removed or retained according to retainOnDelete
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// MaintenanceWindow restricts when operations that disrupt clients of the database may run:
+	// dropping the database and user on deletion, updating an existing secret, migrating the secret
+	// to a new format or region, renaming the database and revoking privileges or roles.
+	// Outside the window they are deferred until it opens. Creating missing resources is not restricted.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// DryRun computes the changes a reconciliation would make and reports them in status.plannedChanges
+	// without changing the database server or AWS Secrets Manager
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
 	// AWSSecretsManager contains AWS Secrets Manager specific configuration for storing created credentials
 	// All created credentials are stored in AWS Secrets Manager regardless of connection string source
 	// +optional
@@ -83,16 +241,276 @@ type DatabaseSpec struct {
 	// The template must produce valid JSON
 	// +optional
 	SecretTemplate string `json:"secretTemplate,omitempty"`
+
+	// SecretKeyNames renames keys of the default secret format, e.g. DB_PASSWORD to PGPASSWORD
+	// Cannot be combined with spec.secretTemplate
+	// +optional
+	SecretKeyNames *SecretKeyNames `json:"secretKeyNames,omitempty"`
+
+	// SecretFormat is the encoding of the secret value, json or connectionStringOnly for the plain
+	// database URL without JSON. connectionStringOnly cannot be combined with spec.secretTemplate,
+	// spec.secretKeyNames or the key pair of a Snowflake user. Defaults to json.
+	// +optional
+	SecretFormat SecretFormat `json:"secretFormat,omitempty"`
+
+	// SecretBinary stores the secret value as SecretBinary instead of SecretString, e.g. a .env or
+	// Java properties file rendered by spec.secretTemplate for pods mounting it with the CSI driver.
+	// The output of the template does not have to be JSON then.
+	// +optional
+	SecretBinary bool `json:"secretBinary,omitempty"`
+
+	// SecretProviderClass creates a SecretProviderClass of the Secrets Store CSI Driver AWS provider
+	// that mounts the secret into pods as files
+	// +optional
+	SecretProviderClass *SecretProviderClassConfig `json:"secretProviderClass,omitempty"`
+
+	// ExternalManagement hands parts of the Database to another tool, e.g. Terraform or Crossplane,
+	// which the operator then reads but never writes
+	// +optional
+	ExternalManagement *ExternalManagement `json:"externalManagement,omitempty"`
+
+	// MemberOf lists DatabaseRole resources in the same namespace whose roles are granted to the user
+	// Roles removed from the list are revoked
+	// +optional
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// MySQL contains MySQL and MariaDB specific settings of the database
+	// Requires the mysql or mariadb engine
+	// +optional
+	MySQL *MySQLConfig `json:"mysql,omitempty"`
+
+	// MariaDB contains settings of the user that only MariaDB servers support
+	// Requires the mysql or mariadb engine and a MariaDB server, detected from its version
+	// +optional
+	MariaDB *MariaDBConfig `json:"mariadb,omitempty"`
+
+	// Postgres contains PostgreSQL specific settings of the created user and database
+	// Requires the postgres, postgresql or yugabyte engine
+	// +optional
+	Postgres *PostgresConfig `json:"postgres,omitempty"`
+
+	// Snowflake contains Snowflake specific settings of the created user
+	// Requires the snowflake engine
+	// +optional
+	Snowflake *SnowflakeConfig `json:"snowflake,omitempty"`
+}
+
+// CloudSQLConfig selects the Cloud SQL instance the operator connects to
+// The operator authenticates to Google Cloud with Application Default Credentials, e.g. GKE Workload
+// Identity, and logs the user of the admin connection string in with IAM database authentication.
+type CloudSQLConfig struct {
+	// InstanceConnectionName is the connection name of the instance, project:region:instance
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([a-z0-9.-]+:)?[a-z0-9-]+:[a-z0-9-]+:[a-z0-9-]+$`
+	InstanceConnectionName string `json:"instanceConnectionName"`
+
+	// IPType is the address of the instance the connector dials
+	// +optional
+	// +kubebuilder:default=public
+	// +kubebuilder:validation:Enum=public;private;psc
+	IPType string `json:"ipType,omitempty"`
+}
+
+// AzureConfig authenticates to an Azure Database for PostgreSQL or MySQL flexible server
+type AzureConfig struct {
+	// EntraAuthentication logs the user of the admin connection string in with a Microsoft Entra access
+	// token of the operator's workload identity instead of the password of the connection string
+	// +optional
+	EntraAuthentication bool `json:"entraAuthentication,omitempty"`
+}
+
+// DatabaseHooks are the Jobs run at points of the lifecycle of a Database
+// The Jobs of each hook run one after the other in the order listed, a failed Job stops the hook.
+type DatabaseHooks struct {
+	// PostCreate Jobs run once after the database, user and secret were provisioned, and after the
+	// Job copying the source of spec.cloneFrom
+	// +optional
+	PostCreate []HookJob `json:"postCreate,omitempty"`
+
+	// PreDelete Jobs run when the Database is deleted, before its resources are dropped or retained
+	// +optional
+	PreDelete []HookJob `json:"preDelete,omitempty"`
+}
+
+// HookJob is a Job run by a hook
+// The operator passes DB_HOST, DB_PORT, DB_NAME, DB_USERNAME and DB_PASSWORD to all containers of the
+// Job from a secret that is deleted once the Job succeeded.
+type HookJob struct {
+	// Name identifies the Job within the hook, the Job is named <name of the Database>-<hook>-<name>
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Template is the spec of the Job. restartPolicy defaults to Never.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template batchv1.JobSpec `json:"template"`
+}
+
+// RestartTarget is a workload restarted when the secret of a Database changes
+type RestartTarget struct {
+	// Kind of the workload
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	Kind string `json:"kind"`
+
+	// Name of the workload
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// MaintenanceWindow is a recurring time window, e.g. every Saturday and Sunday from 02:00 for 4h
+type MaintenanceWindow struct {
+	// Days are the days of the week the window opens on, defaults to every day
+	// +optional
+	// +kubebuilder:validation:items:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+	Days []string `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, in 24-hour HH:MM format
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open, e.g. 4h, at most 168h
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of start, e.g. Europe/Stockholm, defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CloneSource names the database a new database is copied from
+// PostgreSQL copies it with CREATE DATABASE ... TEMPLATE, which fails while other sessions are
+// connected to the source. Snowflake creates a zero-copy clone. For MySQL and MariaDB the database is
+// created empty and a Job copies the source with mysqldump. Cassandra and YugabyteDB are not supported.
+type CloneSource struct {
+	// DatabaseName is the name of the source database
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
+	DatabaseName string `json:"databaseName"`
+}
+
+// SnowflakeConfig contains Snowflake specific settings of the created user
+type SnowflakeConfig struct {
+	// Warehouse is granted to the role of the user with USAGE and made the user's default warehouse
+	// Removing it keeps the grant and the default.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_$]*$`
+	Warehouse string `json:"warehouse,omitempty"`
+
+	// KeyPair authenticates the user with a generated RSA key pair in addition to the password
+	// The private key is stored PEM encoded in the secret as DB_PRIVATE_KEY.
+	// Disabling it removes the public key from the user.
+	// +optional
+	KeyPair bool `json:"keyPair,omitempty"`
+}
+
+// PostgresConfig contains PostgreSQL specific settings of the created user and database
+type PostgresConfig struct {
+	// RoleSettings are configuration parameters applied to every session of the user with
+	// ALTER ROLE ... SET, e.g. statement_timeout: 30s or search_path: app, public
+	// Parameters removed from the map are reset.
+	// +optional
+	RoleSettings map[string]string `json:"roleSettings,omitempty"`
+
+	// DatabaseConnectionLimit limits the concurrent connections to the database with
+	// ALTER DATABASE ... CONNECTION LIMIT, so one application cannot use up the connections of the
+	// whole instance. -1 removes the limit, unset leaves the current limit unchanged.
+	// Superusers are not subject to the limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	DatabaseConnectionLimit *int32 `json:"databaseConnectionLimit,omitempty"`
+}
+
+// MigrationUserConfig configures the migration user of a Database
+type MigrationUserConfig struct {
+	// Enabled provisions the migration user
+	// Disabling it keeps the user and its secret, they are removed with the Database
+	Enabled bool `json:"enabled"`
+
+	// Suffix is appended to the username and the secret name of the Database
+	// Defaults to _migrator.
+	// +optional
+	// +kubebuilder:validation:MaxLength=30
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]+$`
+	Suffix string `json:"suffix,omitempty"`
+
+	// Privileges are granted to the migration user on the database
+	// Defaults to the privileges of the migrationRunner preset.
+	// +optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// SecretName is the name for storing the credentials of the migration user in AWS Secrets Manager
+	// Defaults to the secret name of the Database with the suffix, required when spec.secretName is an ARN
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// CharsetMismatchPolicy decides what happens when an existing database has another character set
+// +kubebuilder:validation:Enum=Report;Alter
+type CharsetMismatchPolicy string
+
+const (
+	// CharsetMismatchPolicyReport sets the CharsetMismatch condition and leaves the database unchanged
+	CharsetMismatchPolicyReport CharsetMismatchPolicy = "Report"
+	// CharsetMismatchPolicyAlter changes the character set and collation with ALTER DATABASE
+	CharsetMismatchPolicyAlter CharsetMismatchPolicy = "Alter"
+)
+
+// MySQLConfig contains MySQL and MariaDB specific settings of the database
+type MySQLConfig struct {
+	// CharacterSet is the default character set of the database
+	// Defaults to utf8mb4.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]+$`
+	CharacterSet string `json:"characterSet,omitempty"`
+
+	// Collation is the default collation of the database, only compared when set
+	// Defaults to utf8mb4_unicode_ci for databases the operator creates.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]+$`
+	Collation string `json:"collation,omitempty"`
+
+	// CharsetMismatchPolicy decides what happens when a database that already existed has another
+	// character set or collation. Databases created by the operator are always altered.
+	// +optional
+	// +kubebuilder:default=Report
+	CharsetMismatchPolicy CharsetMismatchPolicy `json:"charsetMismatchPolicy,omitempty"`
+}
+
+// MariaDBConfig contains MariaDB specific settings of the created user
+type MariaDBConfig struct {
+	// AccountLocked locks the account with ACCOUNT LOCK, false unlocks it
+	// Unset leaves the account as it is. Requires MariaDB 10.4.2.
+	// +optional
+	AccountLocked *bool `json:"accountLocked,omitempty"`
+
+	// PasswordExpireIntervalDays expires the password after this number of days, 0 never expires it
+	// Unset keeps the server default. Requires MariaDB 10.4.3.
+	// Expired passwords are not rotated by the operator.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	PasswordExpireIntervalDays *int32 `json:"passwordExpireIntervalDays,omitempty"`
 }
 
 // AWSSecretsManagerConfig contains AWS Secrets Manager specific settings
 type AWSSecretsManagerConfig struct {
 	// Region is the AWS region for Secrets Manager
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=us-east-1;us-east-2;us-west-1;us-west-2;us-gov-west-1;us-gov-east-1;af-south-1;ap-east-1;ap-south-1;ap-south-2;ap-northeast-1;ap-northeast-2;ap-northeast-3;ap-southeast-1;ap-southeast-2;ap-southeast-3;ap-southeast-4;ca-central-1;ca-west-1;eu-central-1;eu-central-2;eu-west-1;eu-west-2;eu-west-3;eu-south-1;eu-south-2;eu-north-1;me-south-1;me-central-1;sa-east-1;cn-north-1;cn-northwest-1;il-central-1
+	// +kubebuilder:validation:Pattern=`^[a-z]{2}(-[a-z]+)+-[0-9]+$`
 	Region string `json:"region"`
 
-	// Description is the description for the AWS Secrets Manager secret
+	// Description is the description for the AWS Secrets Manager secret, a Go template with the fields
+	// .Kind, .Namespace, .Name, .Cluster, .DatabaseName, .Engine and .Username
+	// The kind and UID of the resource are appended. Defaults to "Database credentials for {{ .DatabaseName }}".
 	// +optional
 	Description string `json:"description,omitempty"`
 
@@ -101,6 +519,79 @@ type AWSSecretsManagerConfig struct {
 	Tags map[string]string `json:"tags,omitempty"`
 }
 
+// ExternalManagement marks parts of a Database that are owned by another tool
+type ExternalManagement struct {
+	// Secret leaves the AWS secret to another tool: the operator reads the password from it but never
+	// creates, updates, tags, restores or deletes it, and only manages the database and the user. The
+	// user is created with the password of the secret and reset when a new version of the secret
+	// appears. Cannot be combined with spec.secretTemplate, spec.secretBinary, the tags and
+	// description of spec.awsSecretsManager or spec.snowflake.keyPair.
+	// +optional
+	Secret bool `json:"secret,omitempty"`
+}
+
+// SecretProviderClassConfig configures the SecretProviderClass created for the secret
+// The secret is mounted as credentials.json, and each of Keys as a file of the same name.
+type SecretProviderClassConfig struct {
+	// Enabled creates the SecretProviderClass and keeps it in sync with the secret
+	// The SecretProviderClass is deleted with the Database; disabling it later keeps it
+	Enabled bool `json:"enabled"`
+
+	// Name of the SecretProviderClass, defaults to the name of the resource
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the SecretProviderClass, required for ClusterDatabases and ignored for Databases,
+	// whose SecretProviderClass is created in their own namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Keys of the JSON secret mounted as files of their own. The AWS provider only mounts string values.
+	// Defaults to DB_HOST, DB_NAME, DB_USERNAME, DB_PASSWORD and <ENGINE>_URL without spec.secretTemplate
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+}
+
+// SecretKeyNames renames keys of the default secret format, an unset name keeps the default key
+type SecretKeyNames struct {
+	// Host replaces the DB_HOST key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=128
+	Host string `json:"host,omitempty"`
+
+	// Port replaces the DB_PORT key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=128
+	Port string `json:"port,omitempty"`
+
+	// Name replaces the DB_NAME key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name,omitempty"`
+
+	// Username replaces the DB_USERNAME key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=128
+	Username string `json:"username,omitempty"`
+
+	// Password replaces the DB_PASSWORD key
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=128
+	Password string `json:"password,omitempty"`
+
+	// URL replaces the <ENGINE>_URL key, e.g. POSTGRES_URL
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=128
+	URL string `json:"url,omitempty"`
+}
+
 // SecretKeyReference references a key in a Kubernetes Secret
 type SecretKeyReference struct {
 	// Name of the secret
@@ -126,10 +617,75 @@ type AWSSecretReference struct {
 
 	// Region is the AWS region for Secrets Manager
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=us-east-1;us-east-2;us-west-1;us-west-2;us-gov-west-1;us-gov-east-1;af-south-1;ap-east-1;ap-south-1;ap-south-2;ap-northeast-1;ap-northeast-2;ap-northeast-3;ap-southeast-1;ap-southeast-2;ap-southeast-3;ap-southeast-4;ca-central-1;ca-west-1;eu-central-1;eu-central-2;eu-west-1;eu-west-2;eu-west-3;eu-south-1;eu-south-2;eu-north-1;me-south-1;me-central-1;sa-east-1;cn-north-1;cn-northwest-1;il-central-1
+	// +kubebuilder:validation:Pattern=`^[a-z]{2}(-[a-z]+)+-[0-9]+$`
 	Region string `json:"region"`
 }
 
+// AdminConnection defines the admin connection from separate values
+type AdminConnection struct {
+	// Host of the database server, the account identifier on Snowflake
+	// Exactly one of Host and HostRef must be specified.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// HostRef reads the host from a secret, the key defaults to "host"
+	// +optional
+	HostRef *ConnectionValueRef `json:"hostRef,omitempty"`
+
+	// Port of the database server, defaults to the port of the engine
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// PortRef reads the port from a secret, the key defaults to "port". Exclusive with Port.
+	// +optional
+	PortRef *ConnectionValueRef `json:"portRef,omitempty"`
+
+	// UsernameRef reads the admin username from a secret, the key defaults to "username"
+	// +kubebuilder:validation:Required
+	UsernameRef ConnectionValueRef `json:"usernameRef"`
+
+	// PasswordRef reads the admin password from a secret, the key defaults to "password"
+	// +kubebuilder:validation:Required
+	PasswordRef ConnectionValueRef `json:"passwordRef"`
+
+	// Database the admin connects to, defaults to postgres on PostgreSQL
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// SSLMode of the connection, only supported on PostgreSQL and Cassandra
+	// +optional
+	// +kubebuilder:validation:Enum=disable;allow;prefer;require;verify-ca;verify-full
+	SSLMode string `json:"sslmode,omitempty"`
+}
+
+// ApplicationEndpoint is the endpoint applications connect to
+type ApplicationEndpoint struct {
+	// Host applications connect to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Port applications connect to, defaults to portOverride or the port of the admin connection
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+}
+
+// ConnectionValueRef references a value of the admin connection
+// Exactly one of SecretKeyRef and AWSSecretKeyRef must be specified.
+type ConnectionValueRef struct {
+	// SecretKeyRef selects a key of a Kubernetes Secret in the namespace of the Database
+	// +optional
+	SecretKeyRef *SecretKeyReference `json:"secretKeyRef,omitempty"`
+
+	// AWSSecretKeyRef selects a key of a JSON AWS Secrets Manager secret
+	// +optional
+	AWSSecretKeyRef *AWSSecretReference `json:"awsSecretKeyRef,omitempty"`
+}
+
 // DatabaseStatus defines the observed state of Database
 type DatabaseStatus struct {
 	// Conditions represent the latest available observations of the Database's state
@@ -142,6 +698,15 @@ type DatabaseStatus struct {
 	// ObservedGeneration is the most recent generation observed by the controller
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// SpecDigest is a hash of the normalized spec applied by the last successful reconciliation
+	// A new generation with the same digest, e.g. after changing only resyncInterval, is not reconciled again
+	// +optional
+	SpecDigest string `json:"specDigest,omitempty"`
+
+	// PlannedChanges lists the changes the last dry run found, only set while spec.dryRun is enabled
+	// +optional
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
 	// Message provides additional information about the current state
 	Message string `json:"message,omitempty"`
 
@@ -154,26 +719,209 @@ type DatabaseStatus struct {
 	// SecretCreated indicates whether the secret has been created
 	SecretCreated bool `json:"secretCreated,omitempty"`
 
+	// DatabaseCreatedAt is the time the operator created the database
+	// +optional
+	DatabaseCreatedAt *metav1.Time `json:"databaseCreatedAt,omitempty"`
+
+	// UserCreatedAt is the time the operator created the user
+	// +optional
+	UserCreatedAt *metav1.Time `json:"userCreatedAt,omitempty"`
+
+	// SecretLastSyncedAt is the last time the secret value was written to AWS Secrets Manager
+	// +optional
+	SecretLastSyncedAt *metav1.Time `json:"secretLastSyncedAt,omitempty"`
+
+	// LastReconcileTime is the last time the Database was successfully reconciled, not refreshed
+	// when the reconciliation was skipped because nothing changed
+	// It is refreshed at most once per minute
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// LastCheckedTime is the last time the operator examined the Database, also when the
+	// reconciliation was skipped or failed with an unchanged error. It is refreshed at most once
+	// per minute, a stale value means the Database is no longer being reconciled. Each refresh
+	// writes the status and so changes the resourceVersion of the Database
+	// +optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+
+	// ExpiresAt is the time the operator deletes the Database, set while spec.ttl is set
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// ConsecutiveFailures is the number of reconciliations in a row that failed with the reason of
+	// the Ready condition, it restarts when the reason changes and is cleared on success
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// NextRetryTime is the earliest time a failed Database is reconciled again unless its spec
+	// changes, so the backoff survives a restart of the operator
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// ServerHealthy reports whether the last health check of the admin endpoint connected and ran a query
+	// Only set when the operator runs with --server-health-interval
+	// +optional
+	ServerHealthy *bool `json:"serverHealthy,omitempty"`
+
+	// ServerLatencyMilliseconds is the time the last health check took to connect and run a query
+	// +optional
+	ServerLatencyMilliseconds int64 `json:"serverLatencyMilliseconds,omitempty"`
+
+	// ServerHealthMessage is the error of the last failed health check
+	// +optional
+	ServerHealthMessage string `json:"serverHealthMessage,omitempty"`
+
+	// ServerHealthCheckedAt is the time of the last health check recorded in the status
+	// The status is written when the health changes, otherwise at most every five minutes
+	// +optional
+	ServerHealthCheckedAt *metav1.Time `json:"serverHealthCheckedAt,omitempty"`
+
 	// SecretARN is the ARN of the created AWS Secrets Manager secret (if applicable)
 	SecretARN string `json:"secretARN,omitempty"`
 
 	// SecretVersion is the version ID of the secret
 	SecretVersion string `json:"secretVersion,omitempty"`
 
+	// SecretChecksum is the SHA-256 checksum of the rendered secret, it changes with the content of
+	// the secret without revealing it, e.g. for tools restarting the applications using the secret
+	// +optional
+	SecretChecksum string `json:"secretChecksum,omitempty"`
+
+	// SecretRevision counts the changes of SecretChecksum, starting at 1 with the first secret
+	// +optional
+	SecretRevision int64 `json:"secretRevision,omitempty"`
+
 	// SecretFormatVersion tracks the secret structure version (v1=old format, v2=new format with DB_HOST, etc.)
 	SecretFormatVersion string `json:"secretFormatVersion,omitempty"`
 
 	// ActualUsername is the actual username that was created
 	ActualUsername string `json:"actualUsername,omitempty"`
 
+	// GrantedPrivileges are the privileges last granted to the user on the database
+	// ALL unless spec.privilegePreset is set
+	// +optional
+	GrantedPrivileges []string `json:"grantedPrivileges,omitempty"`
+
+	// MigrationUser is the migration user provisioned for spec.migrationUser
+	// +optional
+	MigrationUser *MigrationUserStatus `json:"migrationUser,omitempty"`
+
 	// ActualSecretName is the actual secret name that was created
 	ActualSecretName string `json:"actualSecretName,omitempty"`
 
 	// SecretRegion is the AWS region where the secret is stored
 	SecretRegion string `json:"secretRegion,omitempty"`
 
+	// SecretAccountID is the AWS account ID of the secret, taken from its ARN
+	SecretAccountID string `json:"secretAccountID,omitempty"`
+
+	// SecretPartition is the AWS partition of the secret, taken from its ARN, e.g. aws or aws-us-gov
+	SecretPartition string `json:"secretPartition,omitempty"`
+
 	// ConnectionInfo provides non-sensitive connection information
 	ConnectionInfo ConnectionInfo `json:"connectionInfo,omitempty"`
+
+	// MemberOf lists the database roles granted to the user
+	// +optional
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// RoleSettings lists the names of the configuration parameters set from spec.postgres.roleSettings
+	// +optional
+	RoleSettings []string `json:"roleSettings,omitempty"`
+
+	// PendingOperation records the multi-step operation in progress
+	// It is persisted before each step so that a reconciliation interrupted by a restart resumes
+	// where it left off, and cleared once the operation completed
+	// +optional
+	PendingOperation *PendingOperation `json:"pendingOperation,omitempty"`
+
+	// RegionMigration records an in-progress migration of the secret to a new region
+	// It is cleared once the secret in the source region has been deleted
+	// +optional
+	RegionMigration *RegionMigrationStatus `json:"regionMigration,omitempty"`
+
+	// History lists the last changes of the outcome of the reconciliations, oldest first
+	// A reconciliation with the same result, reason and generation as the last entry is not recorded,
+	// so the status is not written on every periodic reconciliation.
+	// +optional
+	History []ReconcileHistoryEntry `json:"history,omitempty"`
+}
+
+// ReconcileHistoryEntry records a reconciliation whose outcome differs from the previous one
+type ReconcileHistoryEntry struct {
+	// Time is the time the reconciliation started
+	Time metav1.Time `json:"time"`
+
+	// Duration is the time the reconciliation took
+	Duration metav1.Duration `json:"duration"`
+
+	// Result is Success or Error
+	Result string `json:"result"`
+
+	// Reason is the class of the error, e.g. Transient or ConfigError, unset on success
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration is the generation that was reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// PendingOperation describes a multi-step operation in progress
+type PendingOperation struct {
+	// Type is the operation: Provision or SecretRegionMigration
+	Type string `json:"type"`
+
+	// Step is the step in progress
+	Step string `json:"step"`
+
+	// StepNumber is the 1-based number of the step in progress
+	StepNumber int32 `json:"stepNumber,omitempty"`
+
+	// TotalSteps is the number of steps of the operation
+	TotalSteps int32 `json:"totalSteps,omitempty"`
+
+	// StartedAt is the time the operation started
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+}
+
+// MigrationUserStatus records the migration user provisioned for a Database
+type MigrationUserStatus struct {
+	// Username is the name of the migration user
+	Username string `json:"username,omitempty"`
+
+	// SecretName is the name of the secret of the migration user
+	SecretName string `json:"secretName,omitempty"`
+
+	// SecretARN is the ARN of the secret of the migration user
+	SecretARN string `json:"secretARN,omitempty"`
+
+	// GrantedPrivileges are the privileges last granted to the migration user on the database
+	// +optional
+	GrantedPrivileges []string `json:"grantedPrivileges,omitempty"`
+}
+
+// RegionMigrationStatus tracks the migration of a secret from one AWS region to another
+type RegionMigrationStatus struct {
+	// Phase is the migration phase
+	// Pending: the secret in the target region has not been verified yet
+	// Verified: the secret in the target region was verified and the source secret awaits deletion
+	Phase string `json:"phase,omitempty"`
+
+	// SourceRegion is the region the secret is migrated from
+	SourceRegion string `json:"sourceRegion,omitempty"`
+
+	// SourceSecretID is the ARN (or name) of the secret in the source region
+	SourceSecretID string `json:"sourceSecretID,omitempty"`
+
+	// TargetRegion is the region the secret is migrated to
+	TargetRegion string `json:"targetRegion,omitempty"`
+
+	// CleanupAttempts is the number of failed attempts to delete the source secret
+	CleanupAttempts int32 `json:"cleanupAttempts,omitempty"`
+
+	// LastError is the last error encountered during the migration
+	LastError string `json:"lastError,omitempty"`
 }
 
 // ConnectionInfo provides non-sensitive connection information
@@ -192,6 +940,14 @@ type ConnectionInfo struct {
 
 	// Engine is the database engine
 	Engine string `json:"engine,omitempty"`
+
+	// ServerVersion is the version reported by the database server
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// Capabilities lists the version-dependent features of the database server used by the operator
+	// +optional
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -202,6 +958,7 @@ type ConnectionInfo struct {
 // +kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.status.actualUsername`
 // +kubebuilder:printcolumn:name="SecretName",type=string,JSONPath=`.status.actualSecretName`
 // +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.status.secretRegion`
+// +kubebuilder:printcolumn:name="Account",type=string,JSONPath=`.status.secretAccountID`,priority=1
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
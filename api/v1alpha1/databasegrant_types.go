@@ -0,0 +1,172 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatabaseGrantSpec defines the desired state of DatabaseGrant
+type DatabaseGrantSpec struct {
+	// Engine specifies the database engine type
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=postgres
+	Engine DatabaseEngine `json:"engine"`
+
+	// Username is the user the privileges are granted to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
+	Username string `json:"username"`
+
+	// ExistingUser declares that the user is created and authenticated outside the operator,
+	// for example an IAM authentication user. The operator never creates, changes or drops the
+	// user and stores no credentials, it only manages the privileges.
+	// Must be false with EntraPrincipal, otherwise only existing users are supported; use a
+	// Database to have the operator create a user with a password.
+	// +kubebuilder:validation:Required
+	ExistingUser bool `json:"existingUser"`
+
+	// EntraPrincipal has the operator create the user on an Azure Database flexible server, mapped to
+	// a Microsoft Entra principal that logs in with its access token. The user stores no credentials.
+	// Requires azure.entraAuthentication, the admin must be a Microsoft Entra administrator.
+	// +optional
+	EntraPrincipal *EntraPrincipal `json:"entraPrincipal,omitempty"`
+
+	// DatabaseName is the database the privileges are granted on
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
+	DatabaseName string `json:"databaseName"`
+
+	// Privileges defines what privileges to grant to the user on the database
+	// Privileges removed from the list are revoked. At least one of Privileges or Objects is required.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	Privileges []string `json:"privileges,omitempty"`
+
+	// Objects grants privileges on single tables and stored routines of the database instead of all of its objects
+	// Privileges and objects removed from the list are revoked. Only supported by MySQL and MariaDB.
+	// +optional
+	Objects []GrantObject `json:"objects,omitempty"`
+
+	// ConnectionStringSecretRef references a Kubernetes Secret containing the admin connection string
+	// Either ConnectionStringSecretRef or ConnectionStringAWSSecretRef must be specified.
+	// +optional
+	ConnectionStringSecretRef *SecretKeyReference `json:"connectionStringSecretRef,omitempty"`
+
+	// ConnectionStringAWSSecretRef references an AWS Secrets Manager secret containing the admin connection string
+	// Either ConnectionStringSecretRef or ConnectionStringAWSSecretRef must be specified.
+	// +optional
+	ConnectionStringAWSSecretRef *AWSSecretReference `json:"connectionStringAWSSecretRef,omitempty"`
+
+	// CloudSQL connects to a Google Cloud SQL instance through the Cloud SQL Go connector
+	// instead of the host of the admin connection string
+	// +optional
+	CloudSQL *CloudSQLConfig `json:"cloudSQL,omitempty"`
+
+	// Azure authenticates the admin connection to an Azure Database flexible server with Microsoft Entra ID
+	// +optional
+	Azure *AzureConfig `json:"azure,omitempty"`
+
+	// RetainOnDelete determines whether to keep the granted privileges when the CR is deleted
+	// Defaults to true (the privileges stay granted on deletion)
+	// +optional
+	// +kubebuilder:default=true
+	RetainOnDelete *bool `json:"retainOnDelete,omitempty"`
+}
+
+// GrantObject is a grant of privileges on a single table or stored routine
+type GrantObject struct {
+	// Type is the type of the object
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=table;procedure;function
+	Type string `json:"type"`
+
+	// Name is the name of the object in the database
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_$]+$`
+	Name string `json:"name"`
+
+	// Privileges are the privileges granted on the object, e.g. SELECT on a table or EXECUTE on a routine
+	// +kubebuilder:validation:MinItems=1
+	Privileges []string `json:"privileges"`
+}
+
+// EntraPrincipal is a Microsoft Entra principal a database user is mapped to
+type EntraPrincipal struct {
+	// ID is the object ID of the principal
+	// On Azure Database for MySQL, service principals and managed identities are identified by their client ID.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	ID string `json:"id"`
+
+	// Type is the type of the principal
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=user;group;servicePrincipal
+	Type string `json:"type"`
+}
+
+// DatabaseGrantStatus defines the observed state of DatabaseGrant
+type DatabaseGrantStatus struct {
+	// Conditions represent the latest available observations of the DatabaseGrant's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase represents the current phase of the DatabaseGrant
+	Phase string `json:"phase,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Message provides additional information about the current state
+	Message string `json:"message,omitempty"`
+
+	// GrantedPrivileges are the privileges granted by the operator, revoked once removed from the spec
+	GrantedPrivileges []string `json:"grantedPrivileges,omitempty"`
+
+	// GrantedObjects are the grants on tables and stored routines applied by the operator
+	GrantedObjects []GrantObject `json:"grantedObjects,omitempty"`
+
+	// LastGrantTime is when the privileges were last applied
+	LastGrantTime *metav1.Time `json:"lastGrantTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=dbgrant
+// +kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engine`
+// +kubebuilder:printcolumn:name="User",type=string,JSONPath=`.spec.username`
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.databaseName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DatabaseGrant is the Schema for the databasegrants API
+type DatabaseGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseGrantSpec   `json:"spec,omitempty"`
+	Status DatabaseGrantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseGrantList contains a list of DatabaseGrant
+type DatabaseGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatabaseGrant{}, &DatabaseGrantList{})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatabaseRoleSpec defines the desired state of DatabaseRole
+type DatabaseRoleSpec struct {
+	// Engine specifies the database engine type
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=postgres
+	Engine DatabaseEngine `json:"engine"`
+
+	// RoleName is the name of the role to create
+	// The role cannot log in, it groups privileges for the users that are members
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
+	RoleName string `json:"roleName"`
+
+	// DatabaseName is the database the privileges are granted on
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9_]*$`
+	DatabaseName string `json:"databaseName"`
+
+	// Privileges defines what privileges to grant to the role on the database
+	// PostgreSQL grants CONNECT, CREATE and TEMPORARY on the database and all other privileges
+	// on the tables of the public schema. Without privileges the role only groups its members.
+	// +optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// ConnectionStringSecretRef references a Kubernetes Secret containing the admin connection string
+	// Either ConnectionStringSecretRef or ConnectionStringAWSSecretRef must be specified.
+	// +optional
+	ConnectionStringSecretRef *SecretKeyReference `json:"connectionStringSecretRef,omitempty"`
+
+	// ConnectionStringAWSSecretRef references an AWS Secrets Manager secret containing the admin connection string
+	// Either ConnectionStringSecretRef or ConnectionStringAWSSecretRef must be specified.
+	// +optional
+	ConnectionStringAWSSecretRef *AWSSecretReference `json:"connectionStringAWSSecretRef,omitempty"`
+
+	// CloudSQL connects to a Google Cloud SQL instance through the Cloud SQL Go connector
+	// instead of the host of the admin connection string
+	// +optional
+	CloudSQL *CloudSQLConfig `json:"cloudSQL,omitempty"`
+
+	// Azure authenticates the admin connection to an Azure Database flexible server with Microsoft Entra ID
+	// +optional
+	Azure *AzureConfig `json:"azure,omitempty"`
+
+	// RetainOnDelete determines whether to retain the role when the CR is deleted
+	// Defaults to true (retains the role on deletion)
+	// +optional
+	// +kubebuilder:default=true
+	RetainOnDelete *bool `json:"retainOnDelete,omitempty"`
+}
+
+// DatabaseRoleStatus defines the observed state of DatabaseRole
+type DatabaseRoleStatus struct {
+	// Conditions represent the latest available observations of the DatabaseRole's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase represents the current phase of the DatabaseRole
+	Phase string `json:"phase,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Message provides additional information about the current state
+	Message string `json:"message,omitempty"`
+
+	// RoleCreated indicates whether the role exists on the server
+	RoleCreated bool `json:"roleCreated,omitempty"`
+
+	// ActualRoleName is the name of the role on the server
+	ActualRoleName string `json:"actualRoleName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=dbrole
+// +kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engine`
+// +kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.roleName`
+// +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.databaseName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DatabaseRole is the Schema for the databaseroles API
+type DatabaseRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseRoleSpec   `json:"spec,omitempty"`
+	Status DatabaseRoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseRoleList contains a list of DatabaseRole
+type DatabaseRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatabaseRole{}, &DatabaseRoleList{})
+}
@@ -54,41 +54,71 @@ func (in *AWSSecretsManagerConfig) DeepCopy() *AWSSecretsManagerConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConnectionInfo) DeepCopyInto(out *ConnectionInfo) {
+func (in *AdminConnection) DeepCopyInto(out *AdminConnection) {
 	*out = *in
+	if in.HostRef != nil {
+		in, out := &in.HostRef, &out.HostRef
+		*out = new(ConnectionValueRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PortRef != nil {
+		in, out := &in.PortRef, &out.PortRef
+		*out = new(ConnectionValueRef)
+		(*in).DeepCopyInto(*out)
+	}
+	in.UsernameRef.DeepCopyInto(&out.UsernameRef)
+	in.PasswordRef.DeepCopyInto(&out.PasswordRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionInfo.
-func (in *ConnectionInfo) DeepCopy() *ConnectionInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminConnection.
+func (in *AdminConnection) DeepCopy() *AdminConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectionInfo)
+	out := new(AdminConnection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Database) DeepCopyInto(out *Database) {
+func (in *AdoptionCandidate) DeepCopyInto(out *AdoptionCandidate) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionCandidate.
+func (in *AdoptionCandidate) DeepCopy() *AdoptionCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionReport) DeepCopyInto(out *AdoptionReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Database.
-func (in *Database) DeepCopy() *Database {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionReport.
+func (in *AdoptionReport) DeepCopy() *AdoptionReport {
 	if in == nil {
 		return nil
 	}
-	out := new(Database)
+	out := new(AdoptionReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Database) DeepCopyObject() runtime.Object {
+func (in *AdoptionReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -96,31 +126,31 @@ func (in *Database) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatabaseList) DeepCopyInto(out *DatabaseList) {
+func (in *AdoptionReportList) DeepCopyInto(out *AdoptionReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Database, len(*in))
+		*out = make([]AdoptionReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseList.
-func (in *DatabaseList) DeepCopy() *DatabaseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionReportList.
+func (in *AdoptionReportList) DeepCopy() *AdoptionReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(DatabaseList)
+	out := new(AdoptionReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DatabaseList) DeepCopyObject() runtime.Object {
+func (in *AdoptionReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -128,79 +158,1151 @@ func (in *DatabaseList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
+func (in *AdoptionReportStatus) DeepCopyInto(out *AdoptionReportStatus) {
 	*out = *in
-	if in.ConnectionStringSecretRef != nil {
-		in, out := &in.ConnectionStringSecretRef, &out.ConnectionStringSecretRef
-		*out = new(SecretKeyReference)
-		**out = **in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ConnectionStringAWSSecretRef != nil {
-		in, out := &in.ConnectionStringAWSSecretRef, &out.ConnectionStringAWSSecretRef
-		*out = new(AWSSecretReference)
-		**out = **in
+	if in.LastScanTime != nil {
+		in, out := &in.LastScanTime, &out.LastScanTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]AdoptionCandidate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.RetainOnDelete != nil {
-		in, out := &in.RetainOnDelete, &out.RetainOnDelete
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionReportStatus.
+func (in *AdoptionReportStatus) DeepCopy() *AdoptionReportStatus {
+	if in == nil {
+		return nil
 	}
-	if in.AWSSecretsManager != nil {
-		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
-		*out = new(AWSSecretsManagerConfig)
-		(*in).DeepCopyInto(*out)
+	out := new(AdoptionReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationEndpoint) DeepCopyInto(out *ApplicationEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationEndpoint.
+func (in *ApplicationEndpoint) DeepCopy() *ApplicationEndpoint {
+	if in == nil {
+		return nil
 	}
+	out := new(ApplicationEndpoint)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
-func (in *DatabaseSpec) DeepCopy() *DatabaseSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureConfig) DeepCopyInto(out *AzureConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureConfig.
+func (in *AzureConfig) DeepCopy() *AzureConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DatabaseSpec)
+	out := new(AzureConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
+func (in *CloneSource) DeepCopyInto(out *CloneSource) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneSource.
+func (in *CloneSource) DeepCopy() *CloneSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLConfig) DeepCopyInto(out *CloudSQLConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLConfig.
+func (in *CloudSQLConfig) DeepCopy() *CloudSQLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDatabase) DeepCopyInto(out *ClusterDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDatabase.
+func (in *ClusterDatabase) DeepCopy() *ClusterDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDatabaseList) DeepCopyInto(out *ClusterDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDatabase, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ConnectionInfo = in.ConnectionInfo
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
-func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDatabaseList.
+func (in *ClusterDatabaseList) DeepCopy() *ClusterDatabaseList {
 	if in == nil {
 		return nil
 	}
-	out := new(DatabaseStatus)
+	out := new(ClusterDatabaseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+func (in *ClusterDatabaseSpec) DeepCopyInto(out *ClusterDatabaseSpec) {
 	*out = *in
+	in.DatabaseSpec.DeepCopyInto(&out.DatabaseSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
-func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDatabaseSpec.
+func (in *ClusterDatabaseSpec) DeepCopy() *ClusterDatabaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeyReference)
+	out := new(ClusterDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionInfo) DeepCopyInto(out *ConnectionInfo) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionInfo.
+func (in *ConnectionInfo) DeepCopy() *ConnectionInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionValueRef) DeepCopyInto(out *ConnectionValueRef) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.AWSSecretKeyRef != nil {
+		in, out := &in.AWSSecretKeyRef, &out.AWSSecretKeyRef
+		*out = new(AWSSecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionValueRef.
+func (in *ConnectionValueRef) DeepCopy() *ConnectionValueRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionValueRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Database) DeepCopyInto(out *Database) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Database.
+func (in *Database) DeepCopy() *Database {
+	if in == nil {
+		return nil
+	}
+	out := new(Database)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Database) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrant) DeepCopyInto(out *DatabaseGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrant.
+func (in *DatabaseGrant) DeepCopy() *DatabaseGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrantList) DeepCopyInto(out *DatabaseGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrantList.
+func (in *DatabaseGrantList) DeepCopy() *DatabaseGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrantSpec) DeepCopyInto(out *DatabaseGrantSpec) {
+	*out = *in
+	if in.EntraPrincipal != nil {
+		in, out := &in.EntraPrincipal, &out.EntraPrincipal
+		*out = new(EntraPrincipal)
+		**out = **in
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]GrantObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConnectionStringSecretRef != nil {
+		in, out := &in.ConnectionStringSecretRef, &out.ConnectionStringSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.ConnectionStringAWSSecretRef != nil {
+		in, out := &in.ConnectionStringAWSSecretRef, &out.ConnectionStringAWSSecretRef
+		*out = new(AWSSecretReference)
+		**out = **in
+	}
+	if in.CloudSQL != nil {
+		in, out := &in.CloudSQL, &out.CloudSQL
+		*out = new(CloudSQLConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureConfig)
+		**out = **in
+	}
+	if in.RetainOnDelete != nil {
+		in, out := &in.RetainOnDelete, &out.RetainOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrantSpec.
+func (in *DatabaseGrantSpec) DeepCopy() *DatabaseGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrantStatus) DeepCopyInto(out *DatabaseGrantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GrantedPrivileges != nil {
+		in, out := &in.GrantedPrivileges, &out.GrantedPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GrantedObjects != nil {
+		in, out := &in.GrantedObjects, &out.GrantedObjects
+		*out = make([]GrantObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastGrantTime != nil {
+		in, out := &in.LastGrantTime, &out.LastGrantTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrantStatus.
+func (in *DatabaseGrantStatus) DeepCopy() *DatabaseGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseHooks) DeepCopyInto(out *DatabaseHooks) {
+	*out = *in
+	if in.PostCreate != nil {
+		in, out := &in.PostCreate, &out.PostCreate
+		*out = make([]HookJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = make([]HookJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseHooks.
+func (in *DatabaseHooks) DeepCopy() *DatabaseHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseList) DeepCopyInto(out *DatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Database, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseList.
+func (in *DatabaseList) DeepCopy() *DatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRole) DeepCopyInto(out *DatabaseRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRole.
+func (in *DatabaseRole) DeepCopy() *DatabaseRole {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRoleList) DeepCopyInto(out *DatabaseRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRoleList.
+func (in *DatabaseRoleList) DeepCopy() *DatabaseRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRoleSpec) DeepCopyInto(out *DatabaseRoleSpec) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionStringSecretRef != nil {
+		in, out := &in.ConnectionStringSecretRef, &out.ConnectionStringSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.ConnectionStringAWSSecretRef != nil {
+		in, out := &in.ConnectionStringAWSSecretRef, &out.ConnectionStringAWSSecretRef
+		*out = new(AWSSecretReference)
+		**out = **in
+	}
+	if in.CloudSQL != nil {
+		in, out := &in.CloudSQL, &out.CloudSQL
+		*out = new(CloudSQLConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureConfig)
+		**out = **in
+	}
+	if in.RetainOnDelete != nil {
+		in, out := &in.RetainOnDelete, &out.RetainOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRoleSpec.
+func (in *DatabaseRoleSpec) DeepCopy() *DatabaseRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRoleStatus) DeepCopyInto(out *DatabaseRoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRoleStatus.
+func (in *DatabaseRoleStatus) DeepCopy() *DatabaseRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
+	*out = *in
+	if in.CloneFrom != nil {
+		in, out := &in.CloneFrom, &out.CloneFrom
+		*out = new(CloneSource)
+		**out = **in
+	}
+	if in.ConnectionStringSecretRef != nil {
+		in, out := &in.ConnectionStringSecretRef, &out.ConnectionStringSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.ConnectionStringAWSSecretRef != nil {
+		in, out := &in.ConnectionStringAWSSecretRef, &out.ConnectionStringAWSSecretRef
+		*out = new(AWSSecretReference)
+		**out = **in
+	}
+	if in.AdminConnection != nil {
+		in, out := &in.AdminConnection, &out.AdminConnection
+		*out = new(AdminConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudSQL != nil {
+		in, out := &in.CloudSQL, &out.CloudSQL
+		*out = new(CloudSQLConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureConfig)
+		**out = **in
+	}
+	if in.ConnectionParams != nil {
+		in, out := &in.ConnectionParams, &out.ConnectionParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ApplicationEndpoint != nil {
+		in, out := &in.ApplicationEndpoint, &out.ApplicationEndpoint
+		*out = new(ApplicationEndpoint)
+		**out = **in
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigrationUser != nil {
+		in, out := &in.MigrationUser, &out.MigrationUser
+		*out = new(MigrationUserConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(DatabaseHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartTargets != nil {
+		in, out := &in.RestartTargets, &out.RestartTargets
+		*out = make([]RestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetainOnDelete != nil {
+		in, out := &in.RetainOnDelete, &out.RetainOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(AWSSecretsManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyNames != nil {
+		in, out := &in.SecretKeyNames, &out.SecretKeyNames
+		*out = new(SecretKeyNames)
+		**out = **in
+	}
+	if in.SecretProviderClass != nil {
+		in, out := &in.SecretProviderClass, &out.SecretProviderClass
+		*out = new(SecretProviderClassConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalManagement != nil {
+		in, out := &in.ExternalManagement, &out.ExternalManagement
+		*out = new(ExternalManagement)
+		**out = **in
+	}
+	if in.MemberOf != nil {
+		in, out := &in.MemberOf, &out.MemberOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MySQL != nil {
+		in, out := &in.MySQL, &out.MySQL
+		*out = new(MySQLConfig)
+		**out = **in
+	}
+	if in.MariaDB != nil {
+		in, out := &in.MariaDB, &out.MariaDB
+		*out = new(MariaDBConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = new(PostgresConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Snowflake != nil {
+		in, out := &in.Snowflake, &out.Snowflake
+		*out = new(SnowflakeConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
+func (in *DatabaseSpec) DeepCopy() *DatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DatabaseCreatedAt != nil {
+		in, out := &in.DatabaseCreatedAt, &out.DatabaseCreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.UserCreatedAt != nil {
+		in, out := &in.UserCreatedAt, &out.UserCreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SecretLastSyncedAt != nil {
+		in, out := &in.SecretLastSyncedAt, &out.SecretLastSyncedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCheckedTime != nil {
+		in, out := &in.LastCheckedTime, &out.LastCheckedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ServerHealthy != nil {
+		in, out := &in.ServerHealthy, &out.ServerHealthy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServerHealthCheckedAt != nil {
+		in, out := &in.ServerHealthCheckedAt, &out.ServerHealthCheckedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.GrantedPrivileges != nil {
+		in, out := &in.GrantedPrivileges, &out.GrantedPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigrationUser != nil {
+		in, out := &in.MigrationUser, &out.MigrationUser
+		*out = new(MigrationUserStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ConnectionInfo.DeepCopyInto(&out.ConnectionInfo)
+	if in.MemberOf != nil {
+		in, out := &in.MemberOf, &out.MemberOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleSettings != nil {
+		in, out := &in.RoleSettings, &out.RoleSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingOperation != nil {
+		in, out := &in.PendingOperation, &out.PendingOperation
+		*out = new(PendingOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegionMigration != nil {
+		in, out := &in.RegionMigration, &out.RegionMigration
+		*out = new(RegionMigrationStatus)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ReconcileHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
+func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraPrincipal) DeepCopyInto(out *EntraPrincipal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraPrincipal.
+func (in *EntraPrincipal) DeepCopy() *EntraPrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraPrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalManagement) DeepCopyInto(out *ExternalManagement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalManagement.
+func (in *ExternalManagement) DeepCopy() *ExternalManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantObject) DeepCopyInto(out *GrantObject) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantObject.
+func (in *GrantObject) DeepCopy() *GrantObject {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJob) DeepCopyInto(out *HookJob) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJob.
+func (in *HookJob) DeepCopy() *HookJob {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBConfig) DeepCopyInto(out *MariaDBConfig) {
+	*out = *in
+	if in.AccountLocked != nil {
+		in, out := &in.AccountLocked, &out.AccountLocked
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PasswordExpireIntervalDays != nil {
+		in, out := &in.PasswordExpireIntervalDays, &out.PasswordExpireIntervalDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBConfig.
+func (in *MariaDBConfig) DeepCopy() *MariaDBConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationUserConfig) DeepCopyInto(out *MigrationUserConfig) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationUserConfig.
+func (in *MigrationUserConfig) DeepCopy() *MigrationUserConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationUserConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationUserStatus) DeepCopyInto(out *MigrationUserStatus) {
+	*out = *in
+	if in.GrantedPrivileges != nil {
+		in, out := &in.GrantedPrivileges, &out.GrantedPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationUserStatus.
+func (in *MigrationUserStatus) DeepCopy() *MigrationUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLConfig) DeepCopyInto(out *MySQLConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLConfig.
+func (in *MySQLConfig) DeepCopy() *MySQLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingOperation) DeepCopyInto(out *PendingOperation) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingOperation.
+func (in *PendingOperation) DeepCopy() *PendingOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresConfig) DeepCopyInto(out *PostgresConfig) {
+	*out = *in
+	if in.RoleSettings != nil {
+		in, out := &in.RoleSettings, &out.RoleSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DatabaseConnectionLimit != nil {
+		in, out := &in.DatabaseConnectionLimit, &out.DatabaseConnectionLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfig.
+func (in *PostgresConfig) DeepCopy() *PostgresConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileHistoryEntry) DeepCopyInto(out *ReconcileHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileHistoryEntry.
+func (in *ReconcileHistoryEntry) DeepCopy() *ReconcileHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionMigrationStatus) DeepCopyInto(out *RegionMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionMigrationStatus.
+func (in *RegionMigrationStatus) DeepCopy() *RegionMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartTarget) DeepCopyInto(out *RestartTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartTarget.
+func (in *RestartTarget) DeepCopy() *RestartTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyNames) DeepCopyInto(out *SecretKeyNames) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyNames.
+func (in *SecretKeyNames) DeepCopy() *SecretKeyNames {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderClassConfig) DeepCopyInto(out *SecretProviderClassConfig) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderClassConfig.
+func (in *SecretProviderClassConfig) DeepCopy() *SecretProviderClassConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderClassConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowflakeConfig) DeepCopyInto(out *SnowflakeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowflakeConfig.
+func (in *SnowflakeConfig) DeepCopy() *SnowflakeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowflakeConfig)
 	in.DeepCopyInto(out)
 	return out
 }
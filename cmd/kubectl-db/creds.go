@@ -0,0 +1,112 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// maskedValue replaces sensitive values unless --reveal is given
+const maskedValue = "********"
+
+// credsCommand fetches the AWS secret of a Database and prints its decoded content
+func credsCommand() *command {
+	flags := flag.NewFlagSet("creds", flag.ExitOnError)
+	reveal := flags.Bool("reveal", false, "Print the password instead of masking it.")
+
+	return &command{
+		flags: flags,
+		run: func(ctx context.Context, db *databasev1alpha1.Database) error {
+			secretString, err := fetchSecretString(ctx, db)
+			if err != nil {
+				return err
+			}
+
+			values := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(secretString), &values); err != nil {
+				// Custom templates may produce non-JSON secrets
+				if *reveal {
+					fmt.Println(secretString)
+					return nil
+				}
+				return fmt.Errorf("secret is not JSON, use --reveal to print it as-is")
+			}
+			if !*reveal {
+				maskSecretValues(values)
+			}
+
+			var out bytes.Buffer
+			encoder := json.NewEncoder(&out)
+			encoder.SetEscapeHTML(false)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(values); err != nil {
+				return err
+			}
+			_, err = os.Stdout.Write(out.Bytes())
+			return err
+		},
+	}
+}
+
+// secretClient returns an AWS client for the region of the Database secret and the secret ID
+// The ARN from the status is preferred over the secret name
+func secretClient(ctx context.Context, db *databasev1alpha1.Database) (*secrets.AWSSecretsManagerClient, string, error) {
+	secretID := db.Status.SecretARN
+	if secretID == "" {
+		secretID = db.Status.ActualSecretName
+	}
+	if secretID == "" {
+		return nil, "", fmt.Errorf("database %s/%s has no secret yet", db.Namespace, db.Name)
+	}
+
+	awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, db.Status.SecretRegion)
+	if err != nil {
+		return nil, "", err
+	}
+	return awsClient, secretID, nil
+}
+
+// fetchSecretString reads the raw secret of a Database from AWS Secrets Manager
+func fetchSecretString(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
+	awsClient, secretID, err := secretClient(ctx, db)
+	if err != nil {
+		return "", err
+	}
+	return awsClient.GetSecretString(ctx, secretID)
+}
+
+// maskSecretValues masks passwords and the password part of connection URLs
+func maskSecretValues(values map[string]interface{}) {
+	for key, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.Contains(strings.ToLower(key), "password"):
+			values[key] = maskedValue
+		case strings.HasSuffix(key, "_URL"):
+			if u, err := url.Parse(s); err == nil && u.User != nil {
+				if _, hasPassword := u.User.Password(); hasPassword {
+					u.User = url.UserPassword(u.User.Username(), maskedValue)
+					values[key] = u.String()
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+// Command kubectl-db is a kubectl plugin for inspecting Database resources.
+//
+// Install it by placing the binary on the PATH, then run:
+//
+//	kubectl db status <name>   show conditions, secret location and region migration state
+//	kubectl db creds <name>    fetch and decode the AWS secret (requires secretsmanager:GetSecretValue)
+//	kubectl db verify <name>   connect to the database as the created user
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+const usage = `Inspect Database resources managed by the database-user-operator.
+
+Usage:
+  kubectl db status <name> [flags]   Show conditions, secret ARN and region migration state
+  kubectl db creds <name> [flags]    Fetch and decode the AWS secret of a Database
+  kubectl db verify <name> [flags]   Test-connect to the database as the created user
+
+Run 'kubectl db <command> -h' for the flags of a command.
+`
+
+// command is a kubectl-db subcommand
+type command struct {
+	flags *flag.FlagSet
+	run   func(ctx context.Context, db *databasev1alpha1.Database) error
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	commands := map[string]*command{
+		"status": statusCommand(),
+		"creds":  credsCommand(),
+		"verify": verifyCommand(),
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	namespace := cmd.flags.String("n", "", "Namespace of the Database. Defaults to the namespace of the current context.")
+	kubeconfig := cmd.flags.String("kubeconfig", "", "Path to the kubeconfig file. Defaults to the standard kubectl resolution.")
+	kubeContext := cmd.flags.String("context", "", "The kubeconfig context to use.")
+	if err := cmd.flags.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+	if cmd.flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "expected exactly one Database name\n\n%s", usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	db, err := getDatabase(ctx, *kubeconfig, *kubeContext, *namespace, cmd.flags.Arg(0))
+	if err == nil {
+		err = cmd.run(ctx, db)
+	}
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// getDatabase loads the named Database using the kubectl configuration
+func getDatabase(ctx context.Context, kubeconfig, kubeContext, namespace, name string) (*databasev1alpha1.Database, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext})
+
+	if namespace == "" {
+		ns, _, err := clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine namespace: %w", err)
+		}
+		namespace = ns
+	}
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	db := &databasev1alpha1.Database{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, db); err != nil {
+		return nil, fmt.Errorf("failed to get Database %s/%s: %w", namespace, name, err)
+	}
+	return db, nil
+}
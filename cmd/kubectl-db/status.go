@@ -0,0 +1,97 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// statusCommand prints a human-readable summary of a Database status
+func statusCommand() *command {
+	return &command{
+		flags: flag.NewFlagSet("status", flag.ExitOnError),
+		run: func(_ context.Context, db *databasev1alpha1.Database) error {
+			printStatus(db)
+			return nil
+		},
+	}
+}
+
+func printStatus(db *databasev1alpha1.Database) {
+	status := db.Status
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s/%s\n", db.Namespace, db.Name)
+	fmt.Fprintf(w, "Engine:\t%s\n", db.Spec.Engine)
+	fmt.Fprintf(w, "Phase:\t%s\n", status.Phase)
+	if status.Message != "" {
+		fmt.Fprintf(w, "Message:\t%s\n", status.Message)
+	}
+	fmt.Fprintf(w, "Observed Generation:\t%d (current %d)\n", status.ObservedGeneration, db.Generation)
+	fmt.Fprintf(w, "Database:\t%s (created: %t)\n", db.Spec.DatabaseName, status.DatabaseCreated)
+	fmt.Fprintf(w, "User:\t%s (created: %t)\n", status.ActualUsername, status.UserCreated)
+	fmt.Fprintf(w, "Endpoint:\t%s:%d\n", status.ConnectionInfo.Host, status.ConnectionInfo.Port)
+	if status.ConnectionInfo.ServerVersion != "" {
+		fmt.Fprintf(w, "Server Version:\t%s\n", status.ConnectionInfo.ServerVersion)
+	}
+	if len(status.ConnectionInfo.Capabilities) > 0 {
+		fmt.Fprintf(w, "Capabilities:\t%s\n", strings.Join(status.ConnectionInfo.Capabilities, ", "))
+	}
+	fmt.Fprintf(w, "Secret:\t%s (created: %t)\n", status.ActualSecretName, status.SecretCreated)
+	fmt.Fprintf(w, "Secret ARN:\t%s\n", status.SecretARN)
+	fmt.Fprintf(w, "Secret Region:\t%s\n", status.SecretRegion)
+	fmt.Fprintf(w, "Secret Account:\t%s (partition %s)\n", status.SecretAccountID, status.SecretPartition)
+	fmt.Fprintf(w, "Secret Version:\t%s (format %s)\n", status.SecretVersion, status.SecretFormatVersion)
+	printTimestamp(w, "Database Created At", status.DatabaseCreatedAt)
+	printTimestamp(w, "User Created At", status.UserCreatedAt)
+	printTimestamp(w, "Secret Last Synced", status.SecretLastSyncedAt)
+	printTimestamp(w, "Last Reconcile", status.LastReconcileTime)
+	printTimestamp(w, "Last Checked", status.LastCheckedTime)
+
+	if m := status.RegionMigration; m != nil {
+		fmt.Fprintf(w, "Region Migration:\t%s -> %s (%s, cleanup attempts: %d)\n",
+			m.SourceRegion, m.TargetRegion, m.Phase, m.CleanupAttempts)
+		if m.LastError != "" {
+			fmt.Fprintf(w, "  Last Error:\t%s\n", m.LastError)
+		}
+	}
+	_ = w.Flush()
+
+	fmt.Println()
+	fmt.Println("Conditions:")
+	if len(status.Conditions) == 0 {
+		fmt.Println("  <none>")
+		return
+	}
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+	for _, c := range status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+			c.Type, c.Status, c.Reason, c.LastTransitionTime.Format(time.RFC3339), c.Message)
+	}
+	_ = w.Flush()
+}
+
+// printTimestamp prints a status timestamp with its age, if it is set
+func printTimestamp(w io.Writer, label string, t *metav1.Time) {
+	if t == nil {
+		return
+	}
+	fmt.Fprintf(w, "%s:\t%s (%s ago)\n", label, t.Format(time.RFC3339), time.Since(t.Time).Round(time.Second))
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// verifyCommand connects to the database with the credentials stored in the AWS secret
+func verifyCommand() *command {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	params := flags.String("params", "", "Connection parameters appended to the connection URL, e.g. sslmode=disable.")
+	host := flags.String("host", "", "Override the database host, e.g. when connecting through a port-forward.")
+	port := flags.Int("port", 0, "Override the database port.")
+
+	return &command{
+		flags: flags,
+		run: func(ctx context.Context, db *databasev1alpha1.Database) error {
+			awsClient, secretID, err := secretClient(ctx, db)
+			if err != nil {
+				return err
+			}
+			secret, err := awsClient.GetSecretWithKeyNames(ctx, secretID, (*secrets.KeyNames)(db.Spec.SecretKeyNames))
+			if err != nil {
+				return err
+			}
+			if *host != "" {
+				secret.DBHost = *host
+			}
+			if *port != 0 {
+				secret.DBPort = *port
+			}
+
+			connectionString := userConnectionURL(string(db.Spec.Engine), secret, *params)
+			dbClient, err := database.NewClient(string(db.Spec.Engine), connectionString)
+			if err != nil {
+				return fmt.Errorf("failed to connect as %s to %s:%d/%s: %w",
+					secret.DBUsername, secret.DBHost, secret.DBPort, secret.DBName, err)
+			}
+			_ = dbClient.Close()
+
+			fmt.Printf("Connected as %s to %s:%d/%s\n", secret.DBUsername, secret.DBHost, secret.DBPort, secret.DBName)
+			return nil
+		},
+	}
+}
+
+// userConnectionURL builds a connection URL for the user stored in the secret
+func userConnectionURL(engine string, secret *secrets.DatabaseSecret, params string) string {
+	scheme := "mysql"
+	switch database.EngineFamily(engine) {
+	case "postgres":
+		scheme = "postgresql"
+	case "cassandra":
+		scheme = "cassandra"
+	case "snowflake":
+		scheme = "snowflake"
+	}
+
+	u := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(secret.DBUsername, secret.DBPassword),
+		Host:     net.JoinHostPort(secret.DBHost, strconv.Itoa(secret.DBPort)),
+		Path:     "/" + secret.DBName,
+		RawQuery: params,
+	}
+	return u.String()
+}
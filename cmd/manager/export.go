@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/controller"
+)
+
+// runExport implements the export subcommand and returns the process exit code
+// It writes a snapshot of all Database resources and their resolved status
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	namespace := flags.String("namespace", "", "Only export Database resources in this namespace. Defaults to all namespaces.")
+	format := flags.String("format", "yaml", "Output format: yaml or json.")
+	output := flags.String("output", "", "File to write the snapshot to. Defaults to stdout.")
+	pinResolved := flags.Bool("pin-resolved", true,
+		"Write the resolved username, secret name and region into the spec of the exported resources.")
+	logging := bindLoggingFlags(flags, false)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	logging.setupLogger()
+
+	if *format != "yaml" && *format != "json" {
+		setupLog.Error(fmt.Errorf("unsupported format %q", *format), "invalid --format, expected yaml or json")
+		return 2
+	}
+
+	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	dbList := &databasev1alpha1.DatabaseList{}
+	if err := k8sClient.List(ctx, dbList, client.InNamespace(*namespace)); err != nil {
+		setupLog.Error(err, "failed to list Database resources")
+		return 1
+	}
+
+	data, err := json.MarshalIndent(controller.ExportDatabases(dbList.Items, *pinResolved), "", "  ")
+	if err == nil && *format == "yaml" {
+		data, err = yaml.JSONToYAML(data)
+	}
+	if err != nil {
+		setupLog.Error(err, "failed to encode snapshot")
+		return 1
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			setupLog.Error(err, "failed to open output file")
+			return 1
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+	if _, err := w.Write(data); err != nil {
+		setupLog.Error(err, "failed to write snapshot")
+		return 1
+	}
+
+	setupLog.Info("exported Database resources", "count", len(dbList.Items))
+	return 0
+}
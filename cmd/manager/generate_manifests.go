@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"opzkit/database-user-operator/internal/manifests"
+)
+
+// runGenerateManifests implements the generate-manifests subcommand and returns the process exit code
+// It renders the CRDs, RBAC, Deployment and webhook configuration into a single YAML for
+// installations without Helm. The arguments after -- are the flags of the manager baked into the
+// Deployment, which are checked against managerFlags.
+func runGenerateManifests(args []string, managerFlags *flag.FlagSet) int {
+	flags := flag.NewFlagSet("generate-manifests", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: manager generate-manifests --image=IMAGE [options] [-- manager flags]\n")
+		flags.PrintDefaults()
+	}
+	image := flags.String("image", "", "Image of the manager, e.g. the mirror of the operator image in a private registry. Required.")
+	imagePullPolicy := flags.String("image-pull-policy", "IfNotPresent", "Image pull policy of the manager container.")
+	name := flags.String("name", "database-user-operator", "Prefix of the resource names, like the release name of the Helm chart.")
+	namespace := flags.String("namespace", "database-user-operator-system", "Namespace of the manager.")
+	createNamespace := flags.Bool("create-namespace", true, "Include the Namespace in the output.")
+	replicas := flags.Int("replicas", 1, "Replicas of the manager.")
+	output := flags.String("output", "", "File to write the manifests to. Defaults to stdout.")
+	// Like the values of the Helm chart, the optional features are only granted their permissions when enabled
+	cloneJobs := flags.Bool("clone-jobs", false, "Allow the Jobs copying the source of spec.cloneFrom into MySQL databases.")
+	hookJobs := flags.Bool("hook-jobs", false, "Allow the Jobs of spec.hooks.")
+	secretProviderClasses := flags.Bool("secret-provider-classes", false, "Allow the SecretProviderClasses of spec.secretProviderClass.")
+	restartTargets := flags.Bool("restart-targets", false, "Allow patching the Deployments and StatefulSets of spec.restartTargets.")
+	adoption := flags.Bool("adoption", false, "Allow the AdoptionReport. Implied by a baked --adoption-scan-interval.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	bakedFlags, err := parseManagerFlags(managerFlags, flags.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid manager flags: %v\n", err)
+		return 2
+	}
+	// A sharded installation runs one Deployment per --shard-index, which only the Helm chart renders,
+	// and a manager started with --preflight exits after its checks
+	if bakedFlags.Lookup("shard-count").Value.String() != "0" || bakedFlags.Lookup("preflight").Value.String() == "true" {
+		fmt.Fprintln(os.Stderr, "invalid manager flags: --shard-count and --preflight cannot be baked into the Deployment")
+		return 2
+	}
+	probePort, err := bindPort(bakedFlags.Lookup("health-probe-bind-address").Value.String())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --health-probe-bind-address: %v\n", err)
+		return 2
+	}
+	gracefulShutdownTimeout := bakedFlags.Lookup("graceful-shutdown-timeout").Value.(flag.Getter).Get().(time.Duration)
+
+	data, err := manifests.Render(manifests.Options{
+		Name:            *name,
+		Namespace:       *namespace,
+		CreateNamespace: *createNamespace,
+		Image:           *image,
+		ImagePullPolicy: *imagePullPolicy,
+		Replicas:        *replicas,
+		Args:            flags.Args(),
+		ProbePort:       probePort,
+		// Like the Helm chart, the pod is given 30s on top of the graceful shutdown of the manager
+		TerminationGracePeriod: gracefulShutdownTimeout + 30*time.Second,
+		Webhooks:               bakedFlags.Lookup("enable-webhooks").Value.String() == "true",
+		CloneJobs:              *cloneJobs,
+		HookJobs:               *hookJobs,
+		SecretProviderClasses:  *secretProviderClasses,
+		RestartTargets:         *restartTargets,
+		Adoption:               *adoption || bakedFlags.Lookup("adoption-scan-interval").Value.String() != "0s",
+		ClusterDatabases:       bakedFlags.Lookup("enable-cluster-databases").Value.String() == "true",
+		DatabaseRoles:          bakedFlags.Lookup("enable-database-roles").Value.String() == "true",
+		DatabaseGrants:         bakedFlags.Lookup("enable-database-grants").Value.String() == "true",
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid options: %v\n", err)
+		return 2
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to open output file: %v\n", err)
+			return 1
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+	if _, err := w.Write(data); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write manifests: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseManagerFlags parses the flags baked into the Deployment with the flags of the manager, so
+// unknown flags and invalid values are reported when the manifests are generated instead of by a
+// crashing pod. The returned flag set holds the parsed values.
+func parseManagerFlags(managerFlags *flag.FlagSet, args []string) (*flag.FlagSet, error) {
+	parsed := flag.NewFlagSet("manager", flag.ContinueOnError)
+	parsed.SetOutput(io.Discard)
+	managerFlags.VisitAll(func(f *flag.Flag) {
+		parsed.Var(f.Value, f.Name, f.Usage)
+	})
+	if err := parsed.Parse(args); err != nil {
+		return nil, err
+	}
+	if parsed.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", parsed.Arg(0))
+	}
+	return parsed, nil
+}
+
+// bindPort returns the port of a bind address like :8081
+func bindPort(address string) (int, error) {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(port)
+}
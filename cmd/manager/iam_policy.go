@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// runIAMPolicy implements the iam-policy subcommand and returns the process exit code
+// It prints the least-privilege IAM policy for the regions and secret prefixes of a deployment
+func runIAMPolicy(args []string) int {
+	flags := flag.NewFlagSet("iam-policy", flag.ExitOnError)
+	regions := flags.String("regions", "", "Comma-separated list of the AWS regions of the secrets. Required.")
+	accountID := flags.String("account-id", "", "Only allow secrets of this AWS account. Defaults to any account.")
+	secretPrefixes := flags.String("secret-prefixes", secrets.DefaultSecretPrefix,
+		"Comma-separated list of the name prefixes of the secrets the operator writes, covering spec.secretName of all Databases.")
+	adminSecretPrefixes := flags.String("admin-secret-prefixes", "",
+		"Comma-separated list of the name prefixes of the secrets referenced by connectionStringAWSSecretRef, which are only read.")
+	listSecrets := flags.Bool("list-secrets", false,
+		"Allow secretsmanager:ListSecrets, needed by --secret-gc-interval, --adoption-scan-interval and the migrate-secrets subcommand.")
+	snsTopicARNs := flags.String("sns-topic-arns", "",
+		"Comma-separated list of the ARNs of the SNS topics of --notify-sns-topic-arn, which the operator publishes to.")
+	eventBusARNs := flags.String("event-bus-arns", "",
+		"Comma-separated list of the ARNs of the EventBridge buses of --eventbridge-bus, which the operator puts events on.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	policy, err := secrets.LeastPrivilegePolicy(secrets.PolicyOptions{
+		Regions:             splitList(*regions),
+		AccountID:           *accountID,
+		SecretPrefixes:      splitList(*secretPrefixes),
+		AdminSecretPrefixes: splitList(*adminSecretPrefixes),
+		ListSecrets:         *listSecrets,
+		SNSTopicARNs:        splitList(*snsTopicARNs),
+		EventBusARNs:        splitList(*eventBusARNs),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid options: %v\n", err)
+		return 2
+	}
+
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode policy: %v\n", err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return 0
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"time"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// loggingOptions holds the logging flags shared by the manager and its subcommands
+type loggingOptions struct {
+	zap zap.Options
+
+	production         bool
+	samplingInitial    int
+	samplingThereafter int
+}
+
+// bindLoggingFlags registers the zap flags together with the production mode and sampling flags
+// development sets the default of --zap-devel
+func bindLoggingFlags(fs *flag.FlagSet, development bool) *loggingOptions {
+	o := &loggingOptions{zap: zap.Options{Development: development}}
+	o.zap.BindFlags(fs)
+	fs.BoolVar(&o.production, "zap-production", false,
+		"Use the production logging configuration: JSON encoder, info level and sampling. Overrides --zap-devel.")
+	fs.IntVar(&o.samplingInitial, "zap-sampling-initial", 0,
+		"In production mode, log the first N identical messages per second. 0 keeps the default of 100.")
+	fs.IntVar(&o.samplingThereafter, "zap-sampling-thereafter", 0,
+		"In production mode, log every Nth identical message per second after --zap-sampling-initial. 0 keeps the default of 100.")
+	return o
+}
+
+// setupLogger configures the logger of the operator, controller-runtime and client-go
+func (o *loggingOptions) setupLogger() {
+	if o.production {
+		o.zap.Development = false
+	}
+
+	// controller-runtime always samples production logs at 100 identical messages per second,
+	// a stricter sampler is applied in front of it
+	if !o.zap.Development && (o.samplingInitial > 0 || o.samplingThereafter > 0) {
+		initial, thereafter := o.samplingInitial, o.samplingThereafter
+		if initial <= 0 {
+			initial = 100
+		}
+		if thereafter <= 0 {
+			thereafter = 100
+		}
+		o.zap.ZapOpts = append(o.zap.ZapOpts, uberzap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, time.Second, initial, thereafter)
+		}))
+	}
+
+	logger := zap.New(zap.UseFlagOptions(&o.zap))
+	ctrl.SetLogger(logger)
+	klog.SetLogger(logger)
+}
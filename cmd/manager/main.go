@@ -2,21 +2,32 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
 	"opzkit/database-user-operator/internal/controller"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+	webhookv1alpha1 "opzkit/database-user-operator/internal/webhook/v1alpha1"
 )
 
 var (
@@ -30,19 +41,256 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate-secrets":
+			os.Exit(runMigrateSecrets(os.Args[2:]))
+		case "export":
+			os.Exit(runExport(os.Args[2:]))
+		case "iam-policy":
+			os.Exit(runIAMPolicy(os.Args[2:]))
+		}
+	}
+
 	var metricsAddr string
 	var probeAddr string
+	var managedByTagKey string
+	var managedByTagValue string
+	var clusterName string
+	var defaultSecretPrefix string
+	var orphanReportInterval time.Duration
+	var orphanReportServers string
+	var serverHealthInterval time.Duration
+	var secretGCInterval time.Duration
+	var awsTransport secrets.TransportOptions
+	var adminSecretPollInterval time.Duration
+	var secretGCDryRun bool
+	var secretGCRegions string
+	var adoptionScanInterval time.Duration
+	var adoptionScanPrefix string
+	var adoptionScanRegions string
+	var skipRegionValidation bool
+	var readinessCheckInterval time.Duration
+	var readinessAWSCheck bool
+	var readinessAWSRegion string
+	var readinessDatabaseCheck bool
+	var preflight bool
+	var startupSpread time.Duration
+	var resyncInterval time.Duration
+	var batchWindow time.Duration
+	var shard controller.Shard
+	var dryRun bool
+	var requeueJitter float64
+	var eventDedupWindow time.Duration
+	var statusHistoryLength int
+	var warningEventBudget int
+	var cloneJobImage string
+	var notifyWebhookURL string
+	var notifySlackWebhookURL string
+	var notifySNSTopicARN string
+	var notifyReasons string
+	var eventBridgeBus string
+	var eventBridgeSource string
+	var enableWebhooks bool
+	var enableDebugEndpoint bool
+	var enablePprof bool
+	var reconcileTimeout time.Duration
+	var gracefulShutdownTimeout time.Duration
+	var pprofAddr string
+	var productionNamespaceSelector string
+	var validateConnectionStrings bool
+	var enableClusterDatabases bool
+	var enableDatabaseRoles bool
+	var enableDatabaseGrants bool
+	var prehashPostgresPasswords bool
+	var skipAdminPrivilegeCheck bool
+	var skipSecretRestore bool
+	var revokeOwnerMembership bool
+	var tlsPolicy database.TLSPolicy
+	connectionPool := database.DefaultPoolConfig()
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&managedByTagKey, "managed-by-tag-key", controller.DefaultManagedByTagKey,
+		"Tag key applied to AWS secrets created by the operator. Set to an empty string to disable the tag.")
+	flag.StringVar(&managedByTagValue, "managed-by-tag-value", controller.DefaultManagedByTagValue,
+		"Tag value applied to AWS secrets created by the operator.")
+	flag.StringVar(&clusterName, "cluster-name", "",
+		"Name of the Kubernetes cluster, available as {{ .Cluster }} in spec.awsSecretsManager.description templates.")
+	flag.StringVar(&defaultSecretPrefix, "default-secret-prefix", secrets.DefaultSecretPrefix,
+		"Prefix of the AWS secret names of Databases without spec.secretName, followed by <engine>/<databaseName>.")
+	flag.DurationVar(&orphanReportInterval, "orphan-report-interval", 0,
+		"Interval for reporting managed users and databases without a Database resource. 0 disables the report.")
+	flag.StringVar(&orphanReportServers, "orphan-report-servers", "",
+		"Comma-separated list of servers the orphan report scans even without Database resources, each "+
+			"<engine>=<namespace>/<secret>[:<key>] referencing a Secret with the admin connection string.")
+	flag.DurationVar(&serverHealthInterval, "server-health-interval", 0,
+		"Interval for checking the admin endpoints of the database servers and recording status.serverHealthy. 0 disables the checks.")
+	flag.BoolVar(&skipRegionValidation, "skip-region-validation", false,
+		"Only check the format of AWS regions instead of matching them against the known AWS partitions.")
+	flag.DurationVar(&secretGCInterval, "secret-gc-interval", 0,
+		"Interval for garbage collecting managed AWS secrets not referenced by any Database. 0 disables the collector.")
+	flag.BoolVar(&secretGCDryRun, "secret-gc-dry-run", true,
+		"Only report stale secrets found by the secret garbage collector without deleting them. "+
+			"Deleting requires a --managed-by-tag-value unique to this installation.")
+	flag.StringVar(&secretGCRegions, "secret-gc-regions", "",
+		"Comma-separated list of additional AWS regions scanned by the secret garbage collector.")
+	flag.DurationVar(&adoptionScanInterval, "adoption-scan-interval", 0,
+		"Interval for reporting AWS secrets following the naming convention that no Database manages in the AdoptionReport. 0 disables the scanner.")
+	flag.StringVar(&adoptionScanPrefix, "adoption-scan-prefix", "",
+		"Secret name prefix scanned by the adoption scanner, followed by <engine>/<databaseName>. Defaults to --default-secret-prefix.")
+	flag.StringVar(&adoptionScanRegions, "adoption-scan-regions", "",
+		"Comma-separated list of additional AWS regions scanned by the adoption scanner.")
+	flag.DurationVar(&adminSecretPollInterval, "admin-secret-poll-interval", 0,
+		"Interval for polling the AWS secrets of admin connection strings for rotations. 0 disables polling.")
+	flag.StringVar(&awsTransport.CABundle, "aws-ca-bundle", "",
+		"Path of a PEM file with CA certificates trusted for AWS endpoints in addition to the system pool.")
+	flag.BoolVar(&awsTransport.InsecureSkipVerify, "aws-insecure-skip-tls-verify", false,
+		"Do not verify the TLS certificates of AWS endpoints. Only for testing.")
+	flag.StringVar(&awsTransport.ProxyURL, "aws-proxy-url", "",
+		"HTTP proxy for AWS endpoints. Defaults to the HTTPS_PROXY and NO_PROXY environment variables.")
+	flag.StringVar(&awsTransport.SecretsManagerEndpoint, "aws-secretsmanager-endpoint", "",
+		"Endpoint URL replacing AWS Secrets Manager, e.g. of a self-hosted compatible secret store.")
+	flag.Float64Var(&awsTransport.RequestsPerSecond, "aws-rate-limit", 20,
+		"Maximum AWS API requests per second of the whole operator, including retries. 0 disables the limit.")
+	flag.IntVar(&awsTransport.Burst, "aws-rate-burst", 40,
+		"Number of AWS API requests allowed at once above --aws-rate-limit.")
+	flag.DurationVar(&readinessCheckInterval, "readiness-check-interval", 30*time.Second,
+		"Interval between the readiness checks of AWS and database connectivity.")
+	flag.BoolVar(&readinessAWSCheck, "readiness-aws-check", false,
+		"Report not ready while AWS credentials cannot be verified with sts:GetCallerIdentity. "+
+			"An STS outage then also marks the pods unready, which removes the endpoints of the webhook Service.")
+	flag.StringVar(&readinessAWSRegion, "readiness-aws-region", "",
+		"AWS region used for the readiness AWS check. Defaults to the AWS SDK default region.")
+	flag.BoolVar(&readinessDatabaseCheck, "readiness-database-check", false,
+		"Report not ready while none of the database servers referenced by Database resources is reachable.")
+	flag.DurationVar(&startupSpread, "startup-spread", 2*time.Minute,
+		"Spread the first reconciliation of unchanged Databases after start over this duration. 0 disables it.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"Interval between periodic reconciliations of resources without spec.resyncInterval.")
+	flag.DurationVar(&batchWindow, "batch-window", 0,
+		"Keep database connections and AWS clients open for this long after a reconciliation, "+
+			"so resources on the same server reconciled in a row share them. 0 disables sharing.")
+	flag.IntVar(&shard.Count, "shard-count", 0,
+		"Split the resources by a hash of namespace/name across this many operator deployments. 0 or 1 disables sharding.")
+	flag.IntVar(&shard.Index, "shard-index", 0,
+		"Shard reconciled by this deployment, from 0 to --shard-count minus 1.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Report the changes to every Database in status.plannedChanges instead of making them. "+
+			"DatabaseRoles and DatabaseGrants are still reconciled.")
+	flag.Float64Var(&requeueJitter, "requeue-jitter", 0.1,
+		"Maximum fraction of the periodic requeue interval added as per-Database jitter.")
+	flag.DurationVar(&eventDedupWindow, "event-dedup-window", 5*time.Minute,
+		"Suppress identical events for the same Database within this window. 0 disables deduplication.")
+	flag.IntVar(&warningEventBudget, "warning-events-per-hour", controller.DefaultWarningEventBudget,
+		"Maximum number of Warning events recorded per Database and hour. 0 disables the limit.")
+	flag.IntVar(&statusHistoryLength, "status-history-length", controller.DefaultStatusHistoryLength,
+		"Number of changes of the reconciliation outcome kept in status.history of each Database. 0 disables the history.")
+	flag.StringVar(&cloneJobImage, "clone-job-image", controller.DefaultCloneJobImage,
+		"Image of the Jobs copying the source of spec.cloneFrom into MySQL databases, must contain bash, mysqldump and mysql.")
+	flag.StringVar(&notifyWebhookURL, "notify-webhook-url", os.Getenv("NOTIFY_WEBHOOK_URL"),
+		"URL the lifecycle events selected by --notify-reasons are posted to as JSON. Defaults to $NOTIFY_WEBHOOK_URL.")
+	flag.StringVar(&notifySlackWebhookURL, "notify-slack-webhook-url", os.Getenv("NOTIFY_SLACK_WEBHOOK_URL"),
+		"Slack incoming webhook the lifecycle events selected by --notify-reasons are sent to. Defaults to $NOTIFY_SLACK_WEBHOOK_URL.")
+	flag.StringVar(&notifySNSTopicARN, "notify-sns-topic-arn", "",
+		"ARN of the SNS topic the lifecycle events selected by --notify-reasons are published to.")
+	flag.StringVar(&notifyReasons, "notify-reasons", strings.Join(controller.DefaultNotificationReasons, ","),
+		"Comma-separated list of the event reasons sent to the notification sinks. Warning selects all Warning events.")
+	flag.StringVar(&eventBridgeBus, "eventbridge-bus", "",
+		"Name or ARN of the EventBridge bus the creation, rotation and deletion of secrets are published to. Empty disables the events.")
+	flag.StringVar(&eventBridgeSource, "eventbridge-source", secrets.DefaultEventSource,
+		"Source of the events published to --eventbridge-bus.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the validating webhook that returns warnings for risky Database configurations. Requires serving certificates.")
+	flag.StringVar(&productionNamespaceSelector, "production-namespace-selector", webhookv1alpha1.DefaultProductionNamespaceSelector,
+		"Label selector for namespaces in which the webhook warns about retainOnDelete=false. Empty disables the warning.")
+	flag.BoolVar(&validateConnectionStrings, "webhook-validate-connection-strings", false,
+		"Reject Databases whose connectionStringSecretRef does not contain a connection string of their engine.")
+	flag.BoolVar(&enableClusterDatabases, "enable-cluster-databases", false,
+		"Reconcile cluster-scoped ClusterDatabase resources. Requires the ClusterDatabase CRD and RBAC for clusterdatabases.")
+	flag.BoolVar(&enableDatabaseRoles, "enable-database-roles", false,
+		"Reconcile DatabaseRole resources and grant them to Databases listing them in spec.memberOf. Requires the DatabaseRole CRD.")
+	flag.BoolVar(&enableDatabaseGrants, "enable-database-grants", false,
+		"Reconcile DatabaseGrant resources managing the privileges of users created outside the operator. Requires the DatabaseGrant CRD.")
+	flag.BoolVar(&enableDebugEndpoint, "enable-debug-endpoint", false,
+		"Serve the last reconciliation of each Database as JSON under "+controller.DebugPath+" on the metrics server.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 2*time.Minute,
+		"Maximum duration of a single reconciliation. In-flight reconciliations are not cancelled on shutdown.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 2*time.Minute,
+		"Time to wait on shutdown for in-flight reconciliations to finish before exiting.")
+	flag.BoolVar(&enablePprof, "enable-pprof", false,
+		"Serve the net/http/pprof profiling endpoints on --pprof-bind-address.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "127.0.0.1:6060",
+		"The address the pprof endpoints bind to when --enable-pprof is set.")
+	flag.IntVar(&connectionPool.MaxOpenConns, "db-max-open-conns", connectionPool.MaxOpenConns,
+		"Maximum number of open connections of each database client. 0 means unlimited.")
+	flag.IntVar(&connectionPool.MaxIdleConns, "db-max-idle-conns", connectionPool.MaxIdleConns,
+		"Maximum number of idle connections kept by each database client. 0 keeps the Go default.")
+	flag.DurationVar(&connectionPool.ConnMaxLifetime, "db-conn-max-lifetime", connectionPool.ConnMaxLifetime,
+		"Maximum time a database connection is reused. 0 means unlimited.")
+	flag.DurationVar(&connectionPool.ConnMaxIdleTime, "db-conn-max-idle-time", connectionPool.ConnMaxIdleTime,
+		"Maximum time a database connection stays idle before it is closed. 0 means unlimited.")
+	flag.DurationVar(&connectionPool.StatementTimeout, "db-statement-timeout", connectionPool.StatementTimeout,
+		"Maximum time a statement of the operator runs on PostgreSQL and MySQL before it is canceled. 0 means unlimited.")
+	flag.BoolVar(&prehashPostgresPasswords, "postgres-prehash-passwords", false,
+		"Hash passwords into SCRAM-SHA-256 verifiers before sending them to PostgreSQL 10+ servers, "+
+			"so plaintext passwords never appear in server logs.")
+	flag.BoolVar(&skipAdminPrivilegeCheck, "skip-admin-privilege-check", false,
+		"Create databases and users without first checking that the admin user holds CREATEDB/CREATEROLE "+
+			"(PostgreSQL) or CREATE, CREATE USER and GRANT OPTION (MySQL).")
+	flag.BoolVar(&skipSecretRestore, "skip-secret-restore", false,
+		"Fail the reconciliation of a Database whose AWS secret is scheduled for deletion instead of restoring "+
+			"and reusing the secret.")
+	flag.BoolVar(&revokeOwnerMembership, "postgres-revoke-owner-membership", false,
+		"Remove the admin user from the role owning each database it creates on PostgreSQL, "+
+			"which it is made a member of to create the database.")
+	flag.StringVar(&tlsPolicy.PostgresSSLMode, "postgres-default-sslmode", "",
+		"sslmode of PostgreSQL and YugabyteDB admin connections whose connection string sets none, e.g. verify-full. "+
+			"Connection strings with a weaker sslmode are rejected.")
+	flag.BoolVar(&tlsPolicy.MySQLRequireTLS, "mysql-require-tls", false,
+		"Connect to MySQL and MariaDB with tls=true unless the admin connection string sets another tls value, "+
+			"and reject tls=false and tls=preferred.")
+	flag.BoolVar(&preflight, "preflight", false,
+		"Run preflight checks (CRD, AWS credentials, regions, database hosts), print a JSON report and exit.")
 
-	opts := zap.Options{
-		Development: true,
+	logging := bindLoggingFlags(flag.CommandLine, true)
+
+	// generate-manifests checks the flags it bakes into the Deployment against the flags above
+	if len(os.Args) > 1 && os.Args[1] == "generate-manifests" {
+		os.Exit(runGenerateManifests(os.Args[2:], flag.CommandLine))
 	}
-	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	logging.setupLogger()
+
+	if err := shard.Validate(); err != nil {
+		setupLog.Error(err, "invalid shard configuration")
+		os.Exit(1)
+	}
+	if err := tlsPolicy.Validate(); err != nil {
+		setupLog.Error(err, "invalid --postgres-default-sslmode")
+		os.Exit(1)
+	}
+
+	if err := secrets.ConfigureTransport(awsTransport); err != nil {
+		setupLog.Error(err, "invalid AWS transport configuration")
+		os.Exit(1)
+	}
+
+	if preflight {
+		os.Exit(runPreflight(readinessAWSRegion, skipRegionValidation))
+	}
+
+	// The manager only serves pprof when a bind address is set
+	if !enablePprof {
+		pprofAddr = ""
+	}
+
+	// Each shard elects its own leader, so the replicas of every shard reconcile in parallel
+	leaderElectionID := "database-user-operator.opzkit.io"
+	if shard.Enabled() {
+		leaderElectionID = fmt.Sprintf("shard-%d.%s", shard.Index, leaderElectionID)
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
@@ -53,28 +301,211 @@ func main() {
 			Port: 9443,
 		}),
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         true, // Always enabled for safe multi-replica operation
-		LeaderElectionID:       "database-user-operator.opzkit.io",
+		LeaderElectionID:       leaderElectionID,
+		// The lease is only released after in-flight reconciliations finished and the manager
+		// stopped, so the new leader never works on a Database concurrently
+		LeaderElectionReleaseOnCancel: true,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
+		// Clone Jobs and restart targets are read rarely, caching them would watch every one of the cluster
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{
+			&batchv1.Job{}, &appsv1.Deployment{}, &appsv1.StatefulSet{},
+		}}},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	if err = (&controller.DatabaseReconciler{
+	reconciler := &controller.DatabaseReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("database-controller"),
-	}).SetupWithManager(mgr); err != nil {
+
+		ManagedByTagKey:   managedByTagKey,
+		ManagedByTagValue: managedByTagValue,
+		ClusterName:       clusterName,
+
+		DefaultSecretPrefix: defaultSecretPrefix,
+
+		SkipRegionValidation: skipRegionValidation,
+
+		StartupSpread:  startupSpread,
+		ResyncInterval: resyncInterval,
+		RequeueJitter:  requeueJitter,
+		BatchWindow:    batchWindow,
+		Shard:          shard,
+		DryRun:         dryRun,
+
+		EventDedupWindow:    eventDedupWindow,
+		StatusHistoryLength: statusHistoryLength,
+		WarningEventBudget:  warningEventBudget,
+		ReconcileTimeout:    reconcileTimeout,
+
+		ClusterDatabases: enableClusterDatabases,
+		DatabaseRoles:    enableDatabaseRoles,
+
+		ConnectionPool:           connectionPool,
+		PrehashPostgresPasswords: prehashPostgresPasswords,
+		SkipAdminPrivilegeCheck:  skipAdminPrivilegeCheck,
+		SkipSecretRestore:        skipSecretRestore,
+		RevokeOwnerMembership:    revokeOwnerMembership,
+		TLSPolicy:                tlsPolicy,
+		CloneJobImage:            cloneJobImage,
+	}
+	notificationSinks, err := newNotificationSinks(notifyWebhookURL, notifySlackWebhookURL, notifySNSTopicARN)
+	if err != nil {
+		setupLog.Error(err, "invalid notification configuration")
+		os.Exit(1)
+	}
+	if len(notificationSinks) > 0 {
+		reconciler.Notifier = controller.NewNotifier(notificationSinks, splitList(notifyReasons))
+		if err := mgr.Add(reconciler.Notifier); err != nil {
+			setupLog.Error(err, "unable to add notifier")
+			os.Exit(1)
+		}
+	}
+	if eventBridgeBus != "" {
+		publisher, err := secrets.NewEventBridgePublisher(context.Background(), eventBridgeBus, eventBridgeSource)
+		if err != nil {
+			setupLog.Error(err, "invalid EventBridge configuration")
+			os.Exit(1)
+		}
+		reconciler.SecretEvents = controller.NewNotifier(
+			[]controller.NotificationSink{&controller.EventBridgeSink{Publisher: publisher}}, controller.SecretEventReasons)
+		if err := mgr.Add(reconciler.SecretEvents); err != nil {
+			setupLog.Error(err, "unable to add secret event publisher")
+			os.Exit(1)
+		}
+	}
+	if adminSecretPollInterval > 0 {
+		reconciler.AdminSecretWatcher = controller.NewAdminSecretWatcher(reconciler, adminSecretPollInterval)
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Database")
 		os.Exit(1)
 	}
+	if enableClusterDatabases {
+		if err = (&controller.ClusterDatabaseReconciler{DatabaseReconciler: reconciler}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterDatabase")
+			os.Exit(1)
+		}
+	}
+	if enableDatabaseRoles {
+		if err = (&controller.DatabaseRoleReconciler{DatabaseReconciler: reconciler}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DatabaseRole")
+			os.Exit(1)
+		}
+	}
+	if enableDatabaseGrants {
+		if err = (&controller.DatabaseGrantReconciler{DatabaseReconciler: reconciler}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DatabaseGrant")
+			os.Exit(1)
+		}
+	}
+
+	if enableDebugEndpoint {
+		if err := mgr.AddMetricsServerExtraHandler(controller.DebugPath, reconciler.DebugHandler()); err != nil {
+			setupLog.Error(err, "unable to add debug endpoint")
+			os.Exit(1)
+		}
+	}
+
+	if enableWebhooks {
+		productionNamespaces, err := labels.Parse(productionNamespaceSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid production namespace selector")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupDatabaseWebhookWithManager(mgr, productionNamespaces, validateConnectionStrings); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Database")
+			os.Exit(1)
+		}
+	}
+
+	// Orphans are AWS secrets of no resource at all, only the first shard looks for them
+	if orphanReportInterval > 0 && shard.Index == 0 {
+		servers, err := controller.ParseOrphanReportServers(orphanReportServers)
+		if err != nil {
+			setupLog.Error(err, "invalid --orphan-report-servers")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&controller.OrphanReporter{
+			Reconciler: reconciler,
+			Interval:   orphanReportInterval,
+			Servers:    servers,
+		}); err != nil {
+			setupLog.Error(err, "unable to add orphan reporter")
+			os.Exit(1)
+		}
+	}
+
+	if serverHealthInterval > 0 {
+		if err := mgr.Add(&controller.ServerHealthChecker{
+			Reconciler: reconciler,
+			Interval:   serverHealthInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add server health checker")
+			os.Exit(1)
+		}
+	}
+
+	if secretGCInterval > 0 && shard.Index == 0 {
+		secretGC := &controller.SecretGarbageCollector{
+			Reconciler: reconciler,
+			Interval:   secretGCInterval,
+			Regions:    splitList(secretGCRegions),
+			DryRun:     secretGCDryRun,
+		}
+		if err := secretGC.Validate(); err != nil {
+			setupLog.Error(err, "invalid secret garbage collection configuration")
+			os.Exit(1)
+		}
+		if err := mgr.Add(secretGC); err != nil {
+			setupLog.Error(err, "unable to add secret garbage collector")
+			os.Exit(1)
+		}
+	}
+
+	if adoptionScanInterval > 0 && shard.Index == 0 {
+		if adoptionScanPrefix == "" {
+			adoptionScanPrefix = defaultSecretPrefix
+		}
+		if err := mgr.Add(&controller.AdoptionScanner{
+			Reconciler: reconciler,
+			Interval:   adoptionScanInterval,
+			Prefix:     adoptionScanPrefix,
+			Regions:    splitList(adoptionScanRegions),
+		}); err != nil {
+			setupLog.Error(err, "unable to add adoption scanner")
+			os.Exit(1)
+		}
+	}
+
+	if reconciler.AdminSecretWatcher != nil {
+		if err := mgr.Add(reconciler.AdminSecretWatcher); err != nil {
+			setupLog.Error(err, "unable to add admin secret watcher")
+			os.Exit(1)
+		}
+	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	readiness := &controller.ReadinessChecker{
+		Reconciler:     reconciler,
+		Interval:       readinessCheckInterval,
+		CheckAWS:       readinessAWSCheck,
+		AWSRegion:      readinessAWSRegion,
+		CheckDatabases: readinessDatabaseCheck,
+	}
+	if err := mgr.Add(readiness); err != nil {
+		setupLog.Error(err, "unable to add readiness checker")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", readiness.Check); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
@@ -98,6 +529,64 @@ func main() {
 	}
 }
 
+// runPreflight runs the preflight checks against the current cluster, writes the report
+// as JSON to stdout and returns the process exit code
+func runPreflight(awsRegion string, skipRegionValidation bool) int {
+	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client for preflight checks")
+		return 1
+	}
+
+	reconciler := &controller.DatabaseReconciler{
+		Client:               k8sClient,
+		Scheme:               scheme,
+		SkipRegionValidation: skipRegionValidation,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	report := reconciler.Preflight(ctx, awsRegion)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		setupLog.Error(err, "unable to write preflight report")
+		return 1
+	}
+
+	if !report.Passed {
+		return 1
+	}
+	return 0
+}
+
+// newNotificationSinks returns the notification sinks of the configured URLs and SNS topic
+func newNotificationSinks(webhookURL, slackWebhookURL, snsTopicARN string) ([]controller.NotificationSink, error) {
+	var sinks []controller.NotificationSink
+	if webhookURL != "" {
+		if _, err := url.ParseRequestURI(webhookURL); err != nil {
+			return nil, fmt.Errorf("invalid notification webhook URL: %w", err)
+		}
+		sinks = append(sinks, &controller.WebhookSink{URL: webhookURL})
+	}
+	if slackWebhookURL != "" {
+		// The error of url.ParseRequestURI contains the URL, which is a credential
+		if _, err := url.ParseRequestURI(slackWebhookURL); err != nil {
+			return nil, errors.New("invalid Slack webhook URL")
+		}
+		sinks = append(sinks, &controller.SlackSink{WebhookURL: slackWebhookURL})
+	}
+	if snsTopicARN != "" {
+		publisher, err := secrets.NewSNSPublisher(context.Background(), snsTopicARN)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, &controller.SNSSink{Publisher: publisher})
+	}
+	return sinks, nil
+}
+
 // coverageRunnable implements manager.Runnable to flush coverage data on shutdown
 type coverageRunnable struct {
 	coverDir string
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/controller"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// migrationSummary counts the outcomes of a migrate-secrets run
+type migrationSummary struct {
+	counts map[string]int
+	failed int
+}
+
+func (s *migrationSummary) record(index, total int, target, outcome, reason string, err error) {
+	if err != nil {
+		s.failed++
+		fmt.Printf("[%d/%d] %s: failed: %v\n", index, total, target, err)
+		return
+	}
+	s.counts[outcome]++
+	if reason != "" {
+		fmt.Printf("[%d/%d] %s: %s (%s)\n", index, total, target, outcome, reason)
+		return
+	}
+	fmt.Printf("[%d/%d] %s: %s\n", index, total, target, outcome)
+}
+
+// runMigrateSecrets implements the migrate-secrets subcommand and returns the process exit code
+// It migrates v1-format secrets to v2 in bulk, either for all Database resources
+// or for all secrets with a name prefix in one AWS region.
+func runMigrateSecrets(args []string) int {
+	flags := flag.NewFlagSet("migrate-secrets", flag.ExitOnError)
+	namespace := flags.String("namespace", "", "Only migrate secrets of Database resources in this namespace. Defaults to all namespaces.")
+	prefix := flags.String("prefix", "", "Migrate all secrets with this name prefix in --region instead of walking Database resources.")
+	region := flags.String("region", "", "AWS region for --prefix. Defaults to the AWS SDK default region.")
+	dryRun := flags.Bool("dry-run", false, "Only report which secrets would be migrated.")
+	skipRegionValidation := flags.Bool("skip-region-validation", false,
+		"Only check the format of AWS regions instead of matching them against the known AWS partitions.")
+	logging := bindLoggingFlags(flags, false)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	logging.setupLogger()
+
+	ctx := ctrl.SetupSignalHandler()
+	summary := &migrationSummary{counts: make(map[string]int)}
+
+	var err error
+	if *prefix != "" {
+		err = migrateSecretsByPrefix(ctx, *region, *prefix, *dryRun, summary)
+	} else {
+		err = migrateDatabaseSecrets(ctx, *namespace, *dryRun, *skipRegionValidation, summary)
+	}
+	if err != nil {
+		setupLog.Error(err, "secret migration failed")
+		return 1
+	}
+
+	fmt.Printf("\n%d migrated, %d would migrate, %d skipped, %d failed\n",
+		summary.counts[controller.SecretMigrationMigrated], summary.counts[controller.SecretMigrationWouldMigrate],
+		summary.counts[controller.SecretMigrationSkipped], summary.failed)
+	if summary.failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// migrateDatabaseSecrets migrates the secrets of all Database resources
+func migrateDatabaseSecrets(ctx context.Context, namespace string, dryRun, skipRegionValidation bool, summary *migrationSummary) error {
+	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("unable to create client: %w", err)
+	}
+	reconciler := &controller.DatabaseReconciler{
+		Client:               k8sClient,
+		Scheme:               scheme,
+		SkipRegionValidation: skipRegionValidation,
+		// Databases are migrated one after another, share AWS clients and connections between them
+		BatchWindow: time.Minute,
+	}
+
+	dbList := &databasev1alpha1.DatabaseList{}
+	if err := k8sClient.List(ctx, dbList, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list Database resources: %w", err)
+	}
+
+	for i := range dbList.Items {
+		db := &dbList.Items[i]
+		outcome, reason, err := reconciler.MigrateDatabaseSecret(ctx, db, dryRun)
+		summary.record(i+1, len(dbList.Items), db.Namespace+"/"+db.Name, outcome, reason, err)
+	}
+	return nil
+}
+
+// migrateSecretsByPrefix migrates all v1-format secrets with the given name prefix
+// Database resources referencing these secrets record the new format on their next reconciliation
+func migrateSecretsByPrefix(ctx context.Context, region, prefix string, dryRun bool, summary *migrationSummary) error {
+	awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	list, err := awsClient.ListSecretsByPrefix(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range list {
+		outcome, reason, err := migrateSecret(ctx, awsClient, entry.ARN, dryRun)
+		summary.record(i+1, len(list), entry.Name, outcome, reason, err)
+	}
+	return nil
+}
+
+// migrateSecret migrates a single secret identified by its ARN
+func migrateSecret(ctx context.Context, awsClient *secrets.AWSSecretsManagerClient, secretID string, dryRun bool) (string, string, error) {
+	secretString, err := awsClient.GetSecretString(ctx, secretID)
+	if err != nil {
+		return "", "", err
+	}
+
+	converted, legacy, err := secrets.ConvertLegacySecret(secretString)
+	if err != nil {
+		return "", "", err
+	}
+	if !legacy {
+		return controller.SecretMigrationSkipped, "already v2", nil
+	}
+	if converted.Engine == "" {
+		return controller.SecretMigrationSkipped, "no engine in v1 secret, migrate it through its Database resource", nil
+	}
+	if dryRun {
+		return controller.SecretMigrationWouldMigrate, "", nil
+	}
+
+	if _, err := awsClient.UpdateSecret(ctx, secretID, converted); err != nil {
+		return "", "", err
+	}
+	return controller.SecretMigrationMigrated, "", nil
+}
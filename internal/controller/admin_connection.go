@@ -0,0 +1,202 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// validateAdminConnection validates spec.adminConnection
+func validateAdminConnection(cfg *databasev1alpha1.AdminConnection) error {
+	if (cfg.Host == "") == (cfg.HostRef == nil) {
+		return fmt.Errorf("exactly one of adminConnection.host and adminConnection.hostRef must be specified")
+	}
+	if cfg.Port != 0 && cfg.PortRef != nil {
+		return fmt.Errorf("adminConnection.port and adminConnection.portRef are exclusive")
+	}
+	refs := []struct {
+		field string
+		ref   *databasev1alpha1.ConnectionValueRef
+	}{
+		{"hostRef", cfg.HostRef},
+		{"portRef", cfg.PortRef},
+		{"usernameRef", &cfg.UsernameRef},
+		{"passwordRef", &cfg.PasswordRef},
+	}
+	for _, r := range refs {
+		if r.ref != nil && (r.ref.SecretKeyRef == nil) == (r.ref.AWSSecretKeyRef == nil) {
+			return fmt.Errorf("exactly one of adminConnection.%s.secretKeyRef and adminConnection.%s.awsSecretKeyRef must be specified",
+				r.field, r.field)
+		}
+	}
+	return nil
+}
+
+// adminConnectionResolver reads the values of spec.adminConnection
+// Each AWS secret is read once, the values of an RDS master user secret share a secret.
+type adminConnectionResolver struct {
+	r          *DatabaseReconciler
+	db         *databasev1alpha1.Database
+	awsSecrets map[string]map[string]interface{}
+}
+
+// value returns the value of a reference, whose key defaults to the name of the value
+func (a *adminConnectionResolver) value(ctx context.Context, ref *databasev1alpha1.ConnectionValueRef, name string) (string, error) {
+	if secretRef := ref.SecretKeyRef; secretRef != nil {
+		key := secretRef.Key
+		if key == "" {
+			key = name
+		}
+		namespace, err := a.r.secretNamespace(ctx, a.db)
+		if err != nil {
+			return "", err
+		}
+		secret := &corev1.Secret{}
+		if err := a.r.Get(ctx, client.ObjectKey{Name: secretRef.Name, Namespace: namespace}, secret); err != nil {
+			return "", err
+		}
+		value, ok := secret.Data[key]
+		if !ok || len(value) == 0 {
+			return "", fmt.Errorf("%s is empty in secret %s key %s", name, secretRef.Name, key)
+		}
+		return string(value), nil
+	}
+
+	awsRef := ref.AWSSecretKeyRef
+	key := awsRef.Key
+	if key == "" {
+		key = name
+	}
+	data, ok := a.awsSecrets[adminAWSSecretKey(awsRef)]
+	if !ok {
+		if err := a.r.validateRegion(awsRef.Region); err != nil {
+			return "", fmt.Errorf("invalid AWS region for admin %s: %w", name, err)
+		}
+		awsClient, err := a.r.awsClient(ctx, awsRef.Region)
+		if err != nil {
+			return "", fmt.Errorf("failed to create AWS Secrets Manager client (ensure pod has AWS permissions): %w", err)
+		}
+		secretValue, err := awsClient.GetSecretString(ctx, awsRef.SecretName)
+		if err != nil {
+			return "", fmt.Errorf("failed to get secret '%s' from AWS Secrets Manager (check IAM permissions and secret exists): %w", awsRef.SecretName, err)
+		}
+		// RDS secrets store the port as a number
+		decoder := json.NewDecoder(bytes.NewReader([]byte(secretValue)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&data); err != nil {
+			return "", fmt.Errorf("failed to parse AWS secret %s as JSON: %w", awsRef.SecretName, err)
+		}
+		a.awsSecrets[adminAWSSecretKey(awsRef)] = data
+	}
+
+	switch value := data[key].(type) {
+	case string:
+		if value != "" {
+			return value, nil
+		}
+	case json.Number:
+		return value.String(), nil
+	case nil:
+	default:
+		return "", fmt.Errorf("value for key %s in AWS secret %s is not a string", key, awsRef.SecretName)
+	}
+	return "", fmt.Errorf("%s is empty in AWS secret %s key %s", name, awsRef.SecretName, key)
+}
+
+// getConnectionStringFromAdminConnection assembles the admin connection string of spec.adminConnection
+func (r *DatabaseReconciler) getConnectionStringFromAdminConnection(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
+	cfg := db.Spec.AdminConnection
+	resolver := &adminConnectionResolver{r: r, db: db, awsSecrets: make(map[string]map[string]interface{})}
+
+	host := cfg.Host
+	if cfg.HostRef != nil {
+		value, err := resolver.value(ctx, cfg.HostRef, "host")
+		if err != nil {
+			return "", err
+		}
+		host = strings.TrimSpace(value)
+	}
+	port := int(cfg.Port)
+	if cfg.PortRef != nil {
+		value, err := resolver.value(ctx, cfg.PortRef, "port")
+		if err != nil {
+			return "", err
+		}
+		port, err = strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || port < 1 || port > 65535 {
+			return "", newConfigError(fmt.Errorf("port of adminConnection.portRef is not a port number: %q", value))
+		}
+	}
+	username, err := resolver.value(ctx, &cfg.UsernameRef, "username")
+	if err != nil {
+		return "", err
+	}
+	password, err := resolver.value(ctx, &cfg.PasswordRef, "password")
+	if err != nil {
+		return "", err
+	}
+
+	dbName := cfg.Database
+	if dbName == "" && database.EngineFamily(string(db.Spec.Engine)) == "postgres" {
+		dbName = "postgres"
+	}
+	connectionString, err := database.BuildConnectionString(string(db.Spec.Engine), host, port,
+		strings.TrimSpace(username), password, dbName, cfg.SSLMode)
+	if err != nil {
+		return "", newConfigError(fmt.Errorf("adminConnection: %w", err))
+	}
+	return connectionString, nil
+}
+
+// adminConnectionSecretRefs returns the Kubernetes secrets referenced by spec.adminConnection
+func adminConnectionSecretRefs(cfg *databasev1alpha1.AdminConnection) []*databasev1alpha1.SecretKeyReference {
+	var refs []*databasev1alpha1.SecretKeyReference
+	for _, ref := range adminConnectionValueRefs(cfg) {
+		if ref.SecretKeyRef != nil {
+			refs = append(refs, ref.SecretKeyRef)
+		}
+	}
+	return refs
+}
+
+// adminConnectionAWSSecretRefs returns the AWS secrets referenced by spec.adminConnection
+func adminConnectionAWSSecretRefs(cfg *databasev1alpha1.AdminConnection) []*databasev1alpha1.AWSSecretReference {
+	var refs []*databasev1alpha1.AWSSecretReference
+	for _, ref := range adminConnectionValueRefs(cfg) {
+		if ref.AWSSecretKeyRef != nil {
+			refs = append(refs, ref.AWSSecretKeyRef)
+		}
+	}
+	return refs
+}
+
+// adminConnectionValueRefs returns the references of spec.adminConnection
+func adminConnectionValueRefs(cfg *databasev1alpha1.AdminConnection) []*databasev1alpha1.ConnectionValueRef {
+	if cfg == nil {
+		return nil
+	}
+	refs := []*databasev1alpha1.ConnectionValueRef{&cfg.UsernameRef, &cfg.PasswordRef}
+	if cfg.HostRef != nil {
+		refs = append(refs, cfg.HostRef)
+	}
+	if cfg.PortRef != nil {
+		refs = append(refs, cfg.PortRef)
+	}
+	return refs
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+func TestValidateAdminConnection(t *testing.T) {
+	k8sRef := databasev1alpha1.ConnectionValueRef{SecretKeyRef: &databasev1alpha1.SecretKeyReference{Name: "admin"}}
+	awsRef := databasev1alpha1.ConnectionValueRef{AWSSecretKeyRef: &databasev1alpha1.AWSSecretReference{SecretName: "rds!db-1", Region: "us-east-1"}}
+
+	tests := []struct {
+		name    string
+		cfg     databasev1alpha1.AdminConnection
+		wantErr string
+	}{
+		{name: "host", cfg: databasev1alpha1.AdminConnection{Host: "db", UsernameRef: awsRef, PasswordRef: awsRef}},
+		{name: "host and port refs", cfg: databasev1alpha1.AdminConnection{HostRef: &k8sRef, PortRef: &k8sRef, UsernameRef: k8sRef, PasswordRef: k8sRef}},
+		{name: "no host", cfg: databasev1alpha1.AdminConnection{UsernameRef: k8sRef, PasswordRef: k8sRef}, wantErr: "adminConnection.host"},
+		{name: "host and host ref", cfg: databasev1alpha1.AdminConnection{Host: "db", HostRef: &k8sRef, UsernameRef: k8sRef, PasswordRef: k8sRef}, wantErr: "adminConnection.host"},
+		{name: "port and port ref", cfg: databasev1alpha1.AdminConnection{Host: "db", Port: 5432, PortRef: &k8sRef, UsernameRef: k8sRef, PasswordRef: k8sRef}, wantErr: "exclusive"},
+		{name: "empty password ref", cfg: databasev1alpha1.AdminConnection{Host: "db", UsernameRef: k8sRef}, wantErr: "adminConnection.passwordRef"},
+		{
+			name:    "both secret kinds",
+			cfg:     databasev1alpha1.AdminConnection{Host: "db", UsernameRef: databasev1alpha1.ConnectionValueRef{SecretKeyRef: k8sRef.SecretKeyRef, AWSSecretKeyRef: awsRef.AWSSecretKeyRef}, PasswordRef: k8sRef},
+			wantErr: "adminConnection.usernameRef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdminConnection(&tt.cfg)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateAdminConnection() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("validateAdminConnection() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetConnectionStringFromAdminConnection(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	r := &DatabaseReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "admin"},
+			Data: map[string][]byte{
+				"host":     []byte("db.example.com\n"),
+				"port":     []byte("5433"),
+				"username": []byte("admin"),
+				"password": []byte("p@ss/word"),
+			},
+		},
+	).Build()}
+	sm := newFakeSecretsManager(t)
+	sm.values["rds!db-1"] = `{"username": "master", "password": "s3cret", "port": 3306}`
+
+	k8sRef := func(key string) *databasev1alpha1.ConnectionValueRef {
+		return &databasev1alpha1.ConnectionValueRef{SecretKeyRef: &databasev1alpha1.SecretKeyReference{Name: "admin", Key: key}}
+	}
+	awsRef := func(key string) *databasev1alpha1.ConnectionValueRef {
+		return &databasev1alpha1.ConnectionValueRef{AWSSecretKeyRef: &databasev1alpha1.AWSSecretReference{SecretName: "rds!db-1", Region: "us-east-1", Key: key}}
+	}
+
+	tests := []struct {
+		name   string
+		engine databasev1alpha1.DatabaseEngine
+		cfg    databasev1alpha1.AdminConnection
+		want   database.ConnectionInfo
+	}{
+		{
+			name:   "Kubernetes secret",
+			engine: databasev1alpha1.DatabaseEnginePostgres,
+			cfg:    databasev1alpha1.AdminConnection{HostRef: k8sRef(""), PortRef: k8sRef(""), UsernameRef: *k8sRef(""), PasswordRef: *k8sRef(""), SSLMode: "verify-full"},
+			want:   database.ConnectionInfo{Host: "db.example.com", Port: "5433", Username: "admin", Password: "p@ss/word", Database: "postgres", SSLMode: "verify-full"},
+		},
+		{
+			name:   "RDS master user secret",
+			engine: databasev1alpha1.DatabaseEngineMySQL,
+			cfg:    databasev1alpha1.AdminConnection{Host: "mysql.example.com", PortRef: awsRef(""), UsernameRef: *awsRef(""), PasswordRef: *awsRef("")},
+			want:   database.ConnectionInfo{Host: "mysql.example.com", Port: "3306", Username: "master", Password: "s3cret"},
+		},
+		{
+			name:   "custom keys",
+			engine: databasev1alpha1.DatabaseEnginePostgres,
+			cfg:    databasev1alpha1.AdminConnection{Host: "pg.example.com", Database: "admin", UsernameRef: *awsRef("username"), PasswordRef: *k8sRef("password")},
+			want:   database.ConnectionInfo{Host: "pg.example.com", Port: "5432", Username: "master", Password: "p@ss/word", Database: "admin", SSLMode: "require"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders"},
+				Spec:       databasev1alpha1.DatabaseSpec{Engine: tt.engine, AdminConnection: &tt.cfg},
+			}
+			connectionString, err := r.getConnectionString(ctx, db)
+			if err != nil {
+				t.Fatalf("getConnectionString() error = %v", err)
+			}
+			info, err := database.ParseConnectionInfo(string(tt.engine), connectionString)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *info != tt.want {
+				t.Errorf("connection = %+v, want %+v", *info, tt.want)
+			}
+		})
+	}
+
+	if calls := sm.callCount("GetSecretValue"); calls != 2 {
+		t.Errorf("GetSecretValue called %d times, want once per reconciliation", calls)
+	}
+
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders"},
+		Spec: databasev1alpha1.DatabaseSpec{Engine: databasev1alpha1.DatabaseEngineMySQL, AdminConnection: &databasev1alpha1.AdminConnection{
+			Host: "mysql.example.com", UsernameRef: *k8sRef(""), PasswordRef: *k8sRef(""), SSLMode: "require",
+		}},
+	}
+	if _, err := r.getConnectionString(ctx, db); classifyError(err) != ReasonConfigError {
+		t.Errorf("sslmode on MySQL: error = %v, want reason %s", err, ReasonConfigError)
+	}
+}
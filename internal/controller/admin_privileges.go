@@ -0,0 +1,50 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"opzkit/database-user-operator/internal/database"
+)
+
+// adminPrivilegesError means the admin user lacks privileges to create the database or user
+type adminPrivilegesError struct {
+	admin   string
+	missing []string
+}
+
+func (e *adminPrivilegesError) Error() string {
+	return fmt.Sprintf("admin user %s lacks %s, required to create databases and users; grant them or use another admin connection",
+		e.admin, strings.Join(e.missing, ", "))
+}
+
+// isAdminPrivilegesError reports whether err is an adminPrivilegesError
+func isAdminPrivilegesError(err error) bool {
+	var privilegesErr *adminPrivilegesError
+	return errors.As(err, &privilegesErr)
+}
+
+// checkAdminPrivileges returns an adminPrivilegesError listing the privileges the admin user lacks,
+// so a missing right fails before any resource is created instead of halfway through provisioning
+func (r *DatabaseReconciler) checkAdminPrivileges(ctx context.Context, dbClient database.Client) error {
+	if r.SkipAdminPrivilegeCheck {
+		return nil
+	}
+	missing, err := dbClient.MissingAdminPrivileges(ctx)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &adminPrivilegesError{admin: dbClient.GetConnectionInfo().Username, missing: missing}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"opzkit/database-user-operator/internal/database"
+)
+
+// adminPrivilegesClient is a database client whose admin lacks the given privileges
+type adminPrivilegesClient struct {
+	database.Client
+	missing []string
+}
+
+func (c *adminPrivilegesClient) MissingAdminPrivileges(_ context.Context) ([]string, error) {
+	return c.missing, nil
+}
+
+func (c *adminPrivilegesClient) GetConnectionInfo() *database.ConnectionInfo {
+	return &database.ConnectionInfo{Username: "app_admin"}
+}
+
+func TestCheckAdminPrivileges(t *testing.T) {
+	ctx := context.Background()
+	r := &DatabaseReconciler{}
+
+	if err := r.checkAdminPrivileges(ctx, &adminPrivilegesClient{}); err != nil {
+		t.Fatalf("admin with all privileges: %v", err)
+	}
+
+	err := r.checkAdminPrivileges(ctx, &adminPrivilegesClient{missing: []string{"CREATEDB", "CREATEROLE"}})
+	if err == nil {
+		t.Fatal("expected an error for missing privileges")
+	}
+	if !strings.Contains(err.Error(), "app_admin lacks CREATEDB, CREATEROLE") {
+		t.Errorf("error = %q, want the admin user and its missing privileges", err)
+	}
+	reason := classifyError(err)
+	if reason != ReasonInsufficientAdminPrivileges {
+		t.Errorf("classifyError() = %q, want %q", reason, ReasonInsufficientAdminPrivileges)
+	}
+	if eventReason, _ := errorEvent(err, reason); eventReason != ReasonInsufficientAdminPrivileges {
+		t.Errorf("event reason = %q, want %q", eventReason, ReasonInsufficientAdminPrivileges)
+	}
+	if requeueAfter, ok := errorRequeue(err, reason); !ok || requeueAfter != terminalErrorRequeue {
+		t.Errorf("errorRequeue() = %v, %v, want %v", requeueAfter, ok, terminalErrorRequeue)
+	}
+
+	r.SkipAdminPrivilegeCheck = true
+	if err := r.checkAdminPrivileges(ctx, &adminPrivilegesClient{missing: []string{"CREATEDB"}}); err != nil {
+		t.Errorf("skipped check: %v", err)
+	}
+}
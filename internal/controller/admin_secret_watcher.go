@@ -0,0 +1,263 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// adminSecretIndex indexes Database and ClusterDatabase resources by the namespace/name of the
+// Kubernetes secret holding their admin connection string
+const adminSecretIndex = "spec.connectionStringSecretRef"
+
+// adminSecretKey returns the adminSecretIndex value of a Kubernetes secret
+func adminSecretKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// indexAdminSecret returns the adminSecretIndex values of a spec whose secrets live in namespace
+func indexAdminSecret(spec *databasev1alpha1.DatabaseSpec, namespace string) []string {
+	refs := adminConnectionSecretRefs(spec.AdminConnection)
+	if spec.ConnectionStringSecretRef != nil {
+		refs = append(refs, spec.ConnectionStringSecretRef)
+	}
+	var keys []string
+	for _, ref := range refs {
+		if key := adminSecretKey(namespace, ref.Name); ref.Name != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// databasesForSecret maps a Kubernetes secret to the Databases using it as admin connection string
+func (r *DatabaseReconciler) databasesForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	dbs := &databasev1alpha1.DatabaseList{}
+	if err := r.List(ctx, dbs, client.MatchingFields{adminSecretIndex: adminSecretKey(secret.GetNamespace(), secret.GetName())}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list Databases of admin secret",
+			"namespace", secret.GetNamespace(), "secret", secret.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(dbs.Items))
+	for i := range dbs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&dbs.Items[i])})
+	}
+	return r.Shard.filterRequests(requests)
+}
+
+// clusterDatabasesForSecret maps a Kubernetes secret to the ClusterDatabases using it as admin connection string
+func (r *DatabaseReconciler) clusterDatabasesForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	cdbs := &databasev1alpha1.ClusterDatabaseList{}
+	if err := r.List(ctx, cdbs, client.MatchingFields{adminSecretIndex: adminSecretKey(secret.GetNamespace(), secret.GetName())}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ClusterDatabases of admin secret",
+			"namespace", secret.GetNamespace(), "secret", secret.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(cdbs.Items))
+	for i := range cdbs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cdbs.Items[i])})
+	}
+	return r.Shard.filterRequests(requests)
+}
+
+// AdminSecretWatcher periodically polls the current version of the AWS secrets referenced by
+// spec.connectionStringAWSSecretRef and spec.adminConnection and requeues the Databases using a secret when its version changes.
+// Secrets Manager has no watch API, without the watcher a rotated admin password is only picked up
+// by the periodic reconciliation.
+type AdminSecretWatcher struct {
+	Reconciler *DatabaseReconciler
+	Interval   time.Duration
+
+	// versions are the current version IDs seen by the last poll, by adminAWSSecretKey
+	versions map[string]string
+
+	databases        chan event.GenericEvent
+	clusterDatabases chan event.GenericEvent
+}
+
+// NewAdminSecretWatcher creates an AdminSecretWatcher
+// Set it as the AdminSecretWatcher of the reconciler before the controllers are set up, so they
+// watch the requeues of the watcher, and add it to the manager.
+func NewAdminSecretWatcher(reconciler *DatabaseReconciler, interval time.Duration) *AdminSecretWatcher {
+	return &AdminSecretWatcher{
+		Reconciler:       reconciler,
+		Interval:         interval,
+		databases:        make(chan event.GenericEvent),
+		clusterDatabases: make(chan event.GenericEvent),
+	}
+}
+
+// databaseSource returns the source of the requeued Databases
+func (w *AdminSecretWatcher) databaseSource() source.Source {
+	return source.Channel(w.databases, &handler.EnqueueRequestForObject{})
+}
+
+// clusterDatabaseSource returns the source of the requeued ClusterDatabases
+func (w *AdminSecretWatcher) clusterDatabaseSource() source.Source {
+	return source.Channel(w.clusterDatabases, &handler.EnqueueRequestForObject{})
+}
+
+// Start runs the watcher until the context is cancelled
+// It implements manager.Runnable and only runs on the elected leader
+func (w *AdminSecretWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("admin-secret-watcher")
+	ctx = log.IntoContext(ctx, logger)
+
+	logger.Info("Starting admin secret watcher", "interval", w.Interval)
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the current version of all referenced AWS admin secrets and requeues the Databases
+// of the rotated ones
+func (w *AdminSecretWatcher) poll(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	dbs, err := w.Reconciler.listDatabases(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list databases")
+		return
+	}
+
+	// Other shards poll the secrets of their own Databases
+	owned := dbs[:0]
+	for _, db := range dbs {
+		if w.Reconciler.Shard.Owns(db.Namespace, db.Name) {
+			owned = append(owned, db)
+		}
+	}
+
+	dependents, refs := databasesByAdminAWSSecret(owned)
+	clients := make(map[string]*secrets.AWSSecretsManagerClient)
+	current := make(map[string]string, len(dependents))
+	for key := range dependents {
+		ref := refs[key]
+		if err := w.Reconciler.validateRegion(ref.Region); err != nil {
+			// Reported by the reconciliation of the Databases
+			continue
+		}
+
+		awsClient, ok := clients[ref.Region]
+		if !ok {
+			if awsClient, err = secrets.NewAWSSecretsManagerClient(ctx, ref.Region); err != nil {
+				logger.Error(err, "Failed to create AWS Secrets Manager client", "region", ref.Region)
+				continue
+			}
+			clients[ref.Region] = awsClient
+		}
+
+		version, err := awsClient.GetSecretVersionID(ctx, ref.SecretName)
+		if err != nil {
+			logger.Error(err, "Failed to get admin secret version", "region", ref.Region, "secretName", ref.SecretName)
+			// Keep the known version, so a rotation during the outage is still detected
+			if previous, ok := w.versions[key]; ok {
+				current[key] = previous
+			}
+			continue
+		}
+		current[key] = version
+	}
+
+	for _, key := range rotatedSecrets(w.versions, current) {
+		logger.Info("Admin secret rotated, requeueing its databases",
+			"secret", key, "version", current[key], "databases", len(dependents[key]))
+		for _, db := range dependents[key] {
+			w.requeue(ctx, db)
+		}
+	}
+	w.versions = current
+}
+
+// requeue sends a Database, or the ClusterDatabase it is a view of, to its controller
+func (w *AdminSecretWatcher) requeue(ctx context.Context, db *databasev1alpha1.Database) {
+	events := w.databases
+	var obj client.Object = db
+	if isClusterView(db) {
+		events = w.clusterDatabases
+		obj = &databasev1alpha1.ClusterDatabase{ObjectMeta: metav1.ObjectMeta{Name: db.Name}}
+	}
+
+	select {
+	case events <- event.GenericEvent{Object: obj}:
+	case <-ctx.Done():
+	}
+}
+
+// adminAWSSecretKey identifies an AWS admin secret by region and name
+func adminAWSSecretKey(ref *databasev1alpha1.AWSSecretReference) string {
+	return ref.Region + "/" + ref.SecretName
+}
+
+// databasesByAdminAWSSecret groups Databases by the AWS secrets holding their admin connection
+// string or the values of their admin connection, and returns the reference of each secret
+func databasesByAdminAWSSecret(dbs []databasev1alpha1.Database) (map[string][]*databasev1alpha1.Database, map[string]*databasev1alpha1.AWSSecretReference) {
+	grouped := make(map[string][]*databasev1alpha1.Database)
+	refs := make(map[string]*databasev1alpha1.AWSSecretReference)
+	for i := range dbs {
+		awsRefs := adminConnectionAWSSecretRefs(dbs[i].Spec.AdminConnection)
+		if ref := dbs[i].Spec.ConnectionStringAWSSecretRef; ref != nil {
+			awsRefs = append(awsRefs, ref)
+		}
+		for _, ref := range awsRefs {
+			key := adminAWSSecretKey(ref)
+			if ref.SecretName == "" || slices.Contains(grouped[key], &dbs[i]) {
+				continue
+			}
+			grouped[key] = append(grouped[key], &dbs[i])
+			refs[key] = ref
+		}
+	}
+	return grouped, refs
+}
+
+// rotatedSecrets returns the sorted keys of the secrets whose version changed between two polls
+// Secrets seen for the first time are not rotated, their Databases already use the current version
+func rotatedSecrets(previous, current map[string]string) []string {
+	var rotated []string
+	for key, version := range current {
+		if seen, ok := previous[key]; ok && seen != version {
+			rotated = append(rotated, key)
+		}
+	}
+	sort.Strings(rotated)
+	return rotated
+}
+
+// setupAdminSecretIndex registers the adminSecretIndex of a kind of object with the manager
+// spec returns the DatabaseSpec of an object and the namespace of its secrets
+func setupAdminSecretIndex(mgr ctrl.Manager, obj client.Object, spec func(client.Object) (*databasev1alpha1.DatabaseSpec, string)) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), obj, adminSecretIndex, func(obj client.Object) []string {
+		return indexAdminSecret(spec(obj))
+	})
+}
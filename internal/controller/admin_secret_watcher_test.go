@@ -0,0 +1,161 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestRotatedSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous map[string]string
+		current  map[string]string
+		want     []string
+	}{
+		{
+			name:     "first poll",
+			previous: nil,
+			current:  map[string]string{"us-east-1/admin": "v1"},
+			want:     nil,
+		},
+		{
+			name:     "unchanged",
+			previous: map[string]string{"us-east-1/admin": "v1"},
+			current:  map[string]string{"us-east-1/admin": "v1"},
+			want:     nil,
+		},
+		{
+			name:     "rotated secrets are returned sorted",
+			previous: map[string]string{"us-east-1/b": "v1", "us-east-1/a": "v1", "us-east-1/c": "v1"},
+			current:  map[string]string{"us-east-1/b": "v2", "us-east-1/a": "v2", "us-east-1/c": "v1"},
+			want:     []string{"us-east-1/a", "us-east-1/b"},
+		},
+		{
+			name:     "newly referenced secret",
+			previous: map[string]string{"us-east-1/a": "v1"},
+			current:  map[string]string{"us-east-1/a": "v1", "us-east-1/b": "v1"},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rotatedSecrets(tt.previous, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rotatedSecrets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabasesByAdminAWSSecret(t *testing.T) {
+	awsRef := func(region, name string) *databasev1alpha1.AWSSecretReference {
+		return &databasev1alpha1.AWSSecretReference{Region: region, SecretName: name}
+	}
+	dbs := []databasev1alpha1.Database{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: databasev1alpha1.DatabaseSpec{ConnectionStringAWSSecretRef: awsRef("us-east-1", "admin")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: databasev1alpha1.DatabaseSpec{ConnectionStringAWSSecretRef: awsRef("us-east-1", "admin")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c"}, Spec: databasev1alpha1.DatabaseSpec{ConnectionStringAWSSecretRef: awsRef("eu-west-1", "admin")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "d"}, Spec: databasev1alpha1.DatabaseSpec{ConnectionStringSecretRef: &databasev1alpha1.SecretKeyReference{Name: "admin"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "e"}, Spec: databasev1alpha1.DatabaseSpec{AdminConnection: &databasev1alpha1.AdminConnection{
+			Host:        "db.example.com",
+			UsernameRef: databasev1alpha1.ConnectionValueRef{AWSSecretKeyRef: awsRef("us-east-1", "rds!db-1")},
+			PasswordRef: databasev1alpha1.ConnectionValueRef{AWSSecretKeyRef: awsRef("us-east-1", "rds!db-1")},
+		}}},
+	}
+
+	grouped, _ := databasesByAdminAWSSecret(dbs)
+	got := make(map[string][]string)
+	for key, dbs := range grouped {
+		for _, db := range dbs {
+			got[key] = append(got[key], db.Name)
+		}
+	}
+	want := map[string][]string{
+		"us-east-1/admin":    {"a", "b"},
+		"eu-west-1/admin":    {"c"},
+		"us-east-1/rds!db-1": {"e"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("databasesByAdminAWSSecret() = %v, want %v", got, want)
+	}
+}
+
+func TestDatabasesForSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	database := func(namespace, name, secret string) *databasev1alpha1.Database {
+		return &databasev1alpha1.Database{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: databasev1alpha1.DatabaseSpec{
+				ConnectionStringSecretRef: &databasev1alpha1.SecretKeyReference{Name: secret},
+			},
+		}
+	}
+	cdb := testClusterDatabase()
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			database("team-a", "app", "admin"),
+			database("team-a", "billing", "admin"),
+			database("team-a", "other", "other-admin"),
+			database("team-b", "app", "admin"),
+			cdb,
+		).
+		WithIndex(&databasev1alpha1.Database{}, adminSecretIndex, func(obj client.Object) []string {
+			db := obj.(*databasev1alpha1.Database)
+			return indexAdminSecret(&db.Spec, db.Namespace)
+		}).
+		WithIndex(&databasev1alpha1.ClusterDatabase{}, adminSecretIndex, func(obj client.Object) []string {
+			cdb := obj.(*databasev1alpha1.ClusterDatabase)
+			return indexAdminSecret(&cdb.Spec.DatabaseSpec, cdb.Spec.SecretNamespace)
+		}).
+		Build()
+	r := &DatabaseReconciler{Client: c, Scheme: scheme}
+
+	ctx := context.Background()
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "admin"}}
+	got := r.databasesForSecret(ctx, secret)
+	want := []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "team-a", Name: "app"}},
+		{NamespacedName: client.ObjectKey{Namespace: "team-a", Name: "billing"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("databasesForSecret() = %v, want %v", got, want)
+	}
+
+	clusterSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cdb.Spec.SecretNamespace, Name: "admin"}}
+	if got := r.databasesForSecret(ctx, clusterSecret); len(got) != 0 {
+		t.Errorf("databasesForSecret() = %v, want no requests", got)
+	}
+	got = r.clusterDatabasesForSecret(ctx, clusterSecret)
+	want = []reconcile.Request{{NamespacedName: client.ObjectKey{Name: cdb.Name}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("clusterDatabasesForSecret() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// AdoptionReportName is the name of the AdoptionReport written by the adoption scanner
+const AdoptionReportName = "default"
+
+// adoptionDatabaseName matches the database names accepted by spec.databaseName
+var adoptionDatabaseName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// adoptionEngines are the engines of spec.engine that may appear in secret names
+var adoptionEngines = map[databasev1alpha1.DatabaseEngine]bool{
+	databasev1alpha1.DatabaseEnginePostgres:   true,
+	databasev1alpha1.DatabaseEnginePostgreSQL: true,
+	databasev1alpha1.DatabaseEngineYugabyte:   true,
+	databasev1alpha1.DatabaseEngineMySQL:      true,
+	databasev1alpha1.DatabaseEngineMariaDB:    true,
+	databasev1alpha1.DatabaseEngineCassandra:  true,
+	databasev1alpha1.DatabaseEngineSnowflake:  true,
+}
+
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=adoptionreports,verbs=get;list;watch;create;update
+
+// AdoptionScanner periodically lists the AWS secrets named <prefix><engine>/<databaseName>, the
+// naming convention of the operator, and reports those no Database manages in the AdoptionReport
+// with suggested Database manifests. Typical candidates are secrets created by the provisioning
+// process the operator replaces. It only reports; the secrets are adopted by applying the manifests.
+type AdoptionScanner struct {
+	Reconciler *DatabaseReconciler
+	Interval   time.Duration
+
+	// Prefix is the secret name prefix to scan, followed by <engine>/<databaseName>
+	Prefix string
+
+	// Regions are scanned in addition to the regions referenced by Database resources
+	Regions []string
+}
+
+// Start runs the scanner until the context is cancelled
+// It implements manager.Runnable and only runs on the elected leader
+func (s *AdoptionScanner) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("adoption-scanner")
+	ctx = log.IntoContext(ctx, logger)
+
+	logger.Info("Starting adoption scanner", "interval", s.Interval, "prefix", s.Prefix)
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.scan(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan performs a single scan across all regions and writes the AdoptionReport
+func (s *AdoptionScanner) scan(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	dbs, err := s.Reconciler.listDatabases(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list databases")
+		return
+	}
+
+	referenced := adoptedSecrets(s.Reconciler, dbs)
+	status := databasev1alpha1.AdoptionReportStatus{Prefix: s.Prefix}
+	scanned := make(map[string]bool)
+
+	DatabaseUserAdoptionCandidates.Reset()
+	for _, region := range secretRegions(s.Reconciler, s.Regions, dbs) {
+		awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+		if err != nil {
+			logger.Error(err, "Failed to create AWS client for adoption scan", "region", region)
+			status.Errors = append(status.Errors, fmt.Sprintf("%s: %v", region, err))
+			continue
+		}
+		// Databases without a region use the AWS SDK default, which is scanned once
+		region = awsClient.GetRegion()
+		if scanned[region] {
+			continue
+		}
+		scanned[region] = true
+		status.Regions = append(status.Regions, region)
+
+		candidates, err := s.scanRegion(ctx, awsClient, region, referenced)
+		if err != nil {
+			logger.Error(err, "Failed to scan region for adoption candidates", "region", region)
+			status.Errors = append(status.Errors, fmt.Sprintf("%s: %v", region, err))
+			continue
+		}
+		DatabaseUserAdoptionCandidates.WithLabelValues(region).Set(float64(len(candidates)))
+		status.Candidates = append(status.Candidates, candidates...)
+	}
+
+	sort.Strings(status.Regions)
+	sort.SliceStable(status.Candidates, func(i, j int) bool {
+		return status.Candidates[i].Region < status.Candidates[j].Region
+	})
+	status.CandidateCount = int32(len(status.Candidates))
+	status.LastScanTime = timestampPtr(time.Now())
+	if err := s.writeReport(ctx, status); err != nil {
+		logger.Error(err, "Failed to write adoption report")
+		return
+	}
+	logger.Info("Adoption scan completed", "regions", status.Regions, "candidates", status.CandidateCount)
+}
+
+// scanRegion returns the adoption candidates of one region
+func (s *AdoptionScanner) scanRegion(ctx context.Context, awsClient *secrets.AWSSecretsManagerClient,
+	region string, referenced map[string]bool) ([]databasev1alpha1.AdoptionCandidate, error) {
+	list, err := awsClient.ListSecretsByPrefix(ctx, s.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Secrets with the managed-by tag belong to the operator even if no Database references
+	// them anymore, those are left to the secret garbage collector
+	if s.Reconciler.ManagedByTagKey != "" {
+		managed, err := awsClient.ListSecretsByTag(ctx, s.Reconciler.ManagedByTagKey, s.Reconciler.ManagedByTagValue)
+		if err != nil {
+			return nil, err
+		}
+		referenced = maps.Clone(referenced)
+		for _, secret := range managed {
+			referenced[secret.ARN] = true
+		}
+	}
+
+	return findAdoptionCandidates(list, referenced, s.Prefix, region)
+}
+
+// writeReport creates or updates the AdoptionReport with the result of a scan
+func (s *AdoptionScanner) writeReport(ctx context.Context, status databasev1alpha1.AdoptionReportStatus) error {
+	report := &databasev1alpha1.AdoptionReport{}
+	err := s.Reconciler.Get(ctx, client.ObjectKey{Name: AdoptionReportName}, report)
+	if apierrors.IsNotFound(err) {
+		report = &databasev1alpha1.AdoptionReport{
+			ObjectMeta: metav1.ObjectMeta{Name: AdoptionReportName},
+			Status:     status,
+		}
+		return s.Reconciler.Create(ctx, report)
+	}
+	if err != nil {
+		return err
+	}
+	report.Status = status
+	return s.Reconciler.Update(ctx, report)
+}
+
+// adoptedSecrets returns the secrets referenced by the status of Database resources and the secrets
+// Database resources will use, so a Database that has not created its secret yet is not reported
+func adoptedSecrets(r *DatabaseReconciler, dbs []databasev1alpha1.Database) map[string]bool {
+	referenced := referencedSecrets(dbs)
+	for i := range dbs {
+		name := r.getSecretNameOrDefault(&dbs[i])
+		if secrets.IsSecretARN(name) {
+			referenced[name] = true
+			continue
+		}
+		referenced[r.getRegion(&dbs[i])+"/"+name] = true
+	}
+	return referenced
+}
+
+// findAdoptionCandidates returns the secrets of the region following the naming convention that
+// are not referenced, sorted by name
+func findAdoptionCandidates(list []secrets.SecretSummary, referenced map[string]bool, prefix, region string) ([]databasev1alpha1.AdoptionCandidate, error) {
+	var candidates []databasev1alpha1.AdoptionCandidate
+	for _, secret := range list {
+		// Databases without a region refer to the secret in the AWS SDK default region
+		if referenced[secret.ARN] || referenced[region+"/"+secret.Name] || referenced["/"+secret.Name] {
+			continue
+		}
+		engine, databaseName, ok := parseConventionalSecretName(secret.Name, prefix)
+		if !ok {
+			continue
+		}
+
+		candidate := databasev1alpha1.AdoptionCandidate{
+			SecretName:   secret.Name,
+			SecretARN:    secret.ARN,
+			Region:       region,
+			Engine:       engine,
+			DatabaseName: databaseName,
+		}
+		if !secret.CreatedDate.IsZero() {
+			candidate.CreatedAt = timestampPtr(secret.CreatedDate)
+		}
+		manifest, err := adoptionManifest(candidate)
+		if err != nil {
+			return nil, err
+		}
+		candidate.Manifest = manifest
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].SecretName < candidates[j].SecretName })
+	return candidates, nil
+}
+
+// parseConventionalSecretName returns the engine and database name of a secret named
+// <prefix><engine>/<databaseName>, the default secret name of a Database
+func parseConventionalSecretName(name, prefix string) (databasev1alpha1.DatabaseEngine, string, bool) {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return "", "", false
+	}
+	engine, databaseName, ok := strings.Cut(rest, "/")
+	if !ok || !adoptionEngines[databasev1alpha1.DatabaseEngine(engine)] || !adoptionDatabaseName.MatchString(databaseName) {
+		return "", "", false
+	}
+	return databasev1alpha1.DatabaseEngine(engine), databaseName, true
+}
+
+// adoptionManifest returns a Database manifest adopting the secret of a candidate
+// The secret name and region are pinned, so the Database uses the existing secret regardless of the
+// defaults of the operator, and the user is left to default to the database name.
+func adoptionManifest(candidate databasev1alpha1.AdoptionCandidate) (string, error) {
+	manifest := map[string]interface{}{
+		"apiVersion": databasev1alpha1.GroupVersion.String(),
+		"kind":       "Database",
+		"metadata": map[string]interface{}{
+			"name": strings.ReplaceAll(candidate.DatabaseName, "_", "-"),
+		},
+		"spec": map[string]interface{}{
+			"engine":       string(candidate.Engine),
+			"databaseName": candidate.DatabaseName,
+			"secretName":   candidate.SecretName,
+			"awsSecretsManager": map[string]interface{}{
+				"region": candidate.Region,
+			},
+		},
+	}
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to render manifest for secret %s: %w", candidate.SecretName, err)
+	}
+	return string(out), nil
+}
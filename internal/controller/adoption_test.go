@@ -0,0 +1,139 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+func TestParseConventionalSecretName(t *testing.T) {
+	tests := []struct {
+		name         string
+		secretName   string
+		wantEngine   databasev1alpha1.DatabaseEngine
+		wantDatabase string
+		wantOK       bool
+	}{
+		{name: "postgres", secretName: "rds/postgres/orders", wantEngine: "postgres", wantDatabase: "orders", wantOK: true},
+		{name: "mysql with underscore", secretName: "rds/mysql/shop_db", wantEngine: "mysql", wantDatabase: "shop_db", wantOK: true},
+		{name: "other prefix", secretName: "legacy/postgres/orders"},
+		{name: "unknown engine", secretName: "rds/oracle/orders"},
+		{name: "nested name", secretName: "rds/postgres/orders/readonly"},
+		{name: "invalid database name", secretName: "rds/postgres/Orders-DB"},
+		{name: "no database name", secretName: "rds/postgres/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, databaseName, ok := parseConventionalSecretName(tt.secretName, "rds/")
+			if ok != tt.wantOK || engine != tt.wantEngine || databaseName != tt.wantDatabase {
+				t.Errorf("parseConventionalSecretName() = %q, %q, %t, want %q, %q, %t",
+					engine, databaseName, ok, tt.wantEngine, tt.wantDatabase, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFindAdoptionCandidates(t *testing.T) {
+	r := &DatabaseReconciler{}
+	dbs := []databasev1alpha1.Database{
+		{
+			Spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", DatabaseName: "app"},
+			Status: databasev1alpha1.DatabaseStatus{
+				SecretARN:        "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbC123",
+				ActualSecretName: "rds/postgres/app",
+				SecretRegion:     "us-east-1",
+			},
+		},
+		// Not reconciled yet, its secret is not reported either
+		{
+			Spec: databasev1alpha1.DatabaseSpec{
+				Engine:            "mysql",
+				DatabaseName:      "shop",
+				AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "us-east-1"},
+			},
+		},
+	}
+	referenced := adoptedSecrets(r, dbs)
+
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	list := []secrets.SecretSummary{
+		{Name: "rds/postgres/orders", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/orders-QwE456", CreatedDate: created},
+		{Name: "rds/postgres/app", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbC123"},
+		{Name: "rds/mysql/shop", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/mysql/shop-XyZ789"},
+		{Name: "rds/mysql/billing_db", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/mysql/billing_db-RtY012"},
+		{Name: "rds/redis/cache", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/redis/cache-UiO345"},
+	}
+
+	candidates, err := findAdoptionCandidates(list, referenced, "rds/", "us-east-1")
+	if err != nil {
+		t.Fatalf("findAdoptionCandidates() error = %v", err)
+	}
+	if len(candidates) != 2 || candidates[0].SecretName != "rds/mysql/billing_db" || candidates[1].SecretName != "rds/postgres/orders" {
+		t.Fatalf("findAdoptionCandidates() = %+v, want rds/mysql/billing_db and rds/postgres/orders", candidates)
+	}
+	if candidates[1].CreatedAt == nil || !candidates[1].CreatedAt.Time.Equal(created) {
+		t.Errorf("createdAt = %v, want %v", candidates[1].CreatedAt, created)
+	}
+
+	// The manifest is a Database adopting the existing secret
+	db := &databasev1alpha1.Database{}
+	if err := yaml.UnmarshalStrict([]byte(candidates[0].Manifest), db); err != nil {
+		t.Fatalf("manifest is not a Database: %v\n%s", err, candidates[0].Manifest)
+	}
+	if db.Kind != "Database" || db.Name != "billing-db" || db.Spec.Engine != "mysql" || db.Spec.DatabaseName != "billing_db" ||
+		db.Spec.SecretName != "rds/mysql/billing_db" || db.Spec.AWSSecretsManager == nil || db.Spec.AWSSecretsManager.Region != "us-east-1" {
+		t.Errorf("manifest = %s", candidates[0].Manifest)
+	}
+}
+
+func TestAdoptionScannerWriteReport(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := &AdoptionScanner{Reconciler: &DatabaseReconciler{Client: c, Scheme: scheme}, Prefix: "rds/"}
+	ctx := context.Background()
+
+	first := databasev1alpha1.AdoptionReportStatus{
+		Prefix:         "rds/",
+		CandidateCount: 1,
+		Candidates:     []databasev1alpha1.AdoptionCandidate{{SecretName: "rds/postgres/orders", Region: "us-east-1"}},
+	}
+	if err := s.writeReport(ctx, first); err != nil {
+		t.Fatalf("writeReport() error = %v", err)
+	}
+	if err := s.writeReport(ctx, databasev1alpha1.AdoptionReportStatus{Prefix: "rds/", Errors: []string{"eu-west-1: denied"}}); err != nil {
+		t.Fatalf("writeReport() of an existing report error = %v", err)
+	}
+
+	report := &databasev1alpha1.AdoptionReport{}
+	if err := c.Get(ctx, client.ObjectKey{Name: AdoptionReportName}, report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Status.CandidateCount != 0 || len(report.Status.Candidates) != 0 ||
+		len(report.Status.Errors) != 1 || !strings.HasPrefix(report.Status.Errors[0], "eu-west-1") {
+		t.Errorf("report status = %+v, want the result of the last scan", report.Status)
+	}
+}
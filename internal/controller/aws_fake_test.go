@@ -0,0 +1,147 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// fakeSecretsManager serves the Secrets Manager API calls of the operator from memory
+// Handlers return the JSON response of an operation or an AWS error code.
+type fakeSecretsManager struct {
+	mu sync.Mutex
+
+	// tags are the tags of every secret
+	tags map[string]string
+	// description is the description of every secret
+	description string
+	// arn and versionID are the ARN and current version of every secret, returned when set
+	arn       string
+	versionID string
+	// values maps secret IDs to the SecretString returned by GetSecretValue
+	values map[string]string
+	// deleted is whether every secret is scheduled for deletion until RestoreSecret
+	deleted bool
+	// failures maps operations, e.g. DescribeSecret, to the AWS error code they fail with
+	failures map[string]string
+	// calls counts the calls of each operation
+	calls map[string]int
+}
+
+// newFakeSecretsManager starts a fake Secrets Manager and points the AWS clients of the test at it
+func newFakeSecretsManager(t *testing.T) *fakeSecretsManager {
+	t.Helper()
+	fake := &fakeSecretsManager{
+		tags:     map[string]string{},
+		values:   map[string]string{},
+		failures: map[string]string{},
+		calls:    map[string]int{},
+	}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	if err := secrets.ConfigureTransport(secrets.TransportOptions{SecretsManagerEndpoint: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = secrets.ConfigureTransport(secrets.TransportOptions{}) })
+	return fake
+}
+
+// client returns an AWS Secrets Manager client of the fake
+func (f *fakeSecretsManager) client(t *testing.T) *secrets.AWSSecretsManagerClient {
+	t.Helper()
+	c, err := secrets.NewAWSSecretsManagerClient(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func (f *fakeSecretsManager) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	operation := strings.TrimPrefix(req.Header.Get("X-Amz-Target"), "secretsmanager.")
+	var input struct {
+		SecretId    string
+		TagKeys     []string
+		Tags        []struct{ Key, Value string }
+		Description *string
+	}
+	_ = json.NewDecoder(req.Body).Decode(&input)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[operation]++
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	if code, ok := f.failures[operation]; ok {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"__type": code, "message": operation + " failed"})
+		return
+	}
+
+	output := map[string]interface{}{}
+	switch operation {
+	case "DescribeSecret":
+		var tags []map[string]string
+		for key, value := range f.tags {
+			tags = append(tags, map[string]string{"Key": key, "Value": value})
+		}
+		output["Tags"] = tags
+		output["Description"] = f.description
+		if f.arn != "" {
+			output["ARN"] = f.arn
+		}
+		if f.versionID != "" {
+			output["VersionIdsToStages"] = map[string][]string{f.versionID: {"AWSCURRENT"}}
+		}
+		if f.deleted {
+			output["DeletedDate"] = 1700000000
+		}
+	case "RestoreSecret":
+		f.deleted = false
+	case "GetSecretValue":
+		if value, ok := f.values[input.SecretId]; ok {
+			output["SecretString"] = value
+		}
+	case "UpdateSecret":
+		if input.Description != nil {
+			f.description = *input.Description
+		}
+	case "TagResource":
+		for _, tag := range input.Tags {
+			f.tags[tag.Key] = tag.Value
+		}
+	case "UntagResource":
+		for _, key := range input.TagKeys {
+			delete(f.tags, key)
+		}
+	}
+	_ = json.NewEncoder(w).Encode(output)
+}
+
+// callCount returns how often an operation was called
+func (f *fakeSecretsManager) callCount(operation string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[operation]
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// ConditionAWSThrottled is the condition type reporting that AWS throttled API calls of the operator
+// in the region of the secret within secrets.ThrottleWindow, i.e. the quota of the account is nearly
+// used up. It is removed once no call was throttled for that long.
+const ConditionAWSThrottled = "AWSThrottled"
+
+// ReasonThrottlingObserved is the reason of the AWSThrottled condition
+const ReasonThrottlingObserved = "ThrottlingObserved"
+
+// setAWSThrottledCondition sets or removes the AWSThrottled condition from the throttled AWS API
+// calls of the whole operator in the region of the secret, and reports whether it changed
+func (r *DatabaseReconciler) setAWSThrottledCondition(db *databasev1alpha1.Database, now time.Time) bool {
+	region := db.Status.SecretRegion
+	if region == "" {
+		region = r.getRegion(db)
+	}
+	if secrets.RecentThrottles(region, now) == 0 {
+		return meta.RemoveStatusCondition(&db.Status.Conditions, ConditionAWSThrottled)
+	}
+
+	where := "all regions"
+	if region != "" {
+		where = region
+	}
+	return meta.SetStatusCondition(&db.Status.Conditions, metav1.Condition{
+		Type:   ConditionAWSThrottled,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonThrottlingObserved,
+		// The message leaves out the number of throttled calls, so it does not change the status on
+		// every reconciliation
+		Message: fmt.Sprintf("AWS throttled API calls of the operator in %s within the last %s, consider lowering --aws-rate-limit",
+			where, secrets.ThrottleWindow),
+		ObservedGeneration: db.Generation,
+	})
+}
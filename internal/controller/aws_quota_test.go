@@ -0,0 +1,41 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestSetAWSThrottledCondition(t *testing.T) {
+	r := &DatabaseReconciler{}
+	db := &databasev1alpha1.Database{
+		Status: databasev1alpha1.DatabaseStatus{
+			SecretRegion: "us-east-1",
+			Conditions: []metav1.Condition{
+				{Type: ConditionAWSThrottled, Status: metav1.ConditionTrue, Reason: ReasonThrottlingObserved},
+			},
+		},
+	}
+
+	// No request in the region was throttled within the window
+	if !r.setAWSThrottledCondition(db, time.Now().Add(time.Hour)) {
+		t.Error("setAWSThrottledCondition() did not report the removed condition")
+	}
+	if meta.FindStatusCondition(db.Status.Conditions, ConditionAWSThrottled) != nil {
+		t.Error("AWSThrottled condition was not removed")
+	}
+	if r.setAWSThrottledCondition(db, time.Now().Add(time.Hour)) {
+		t.Error("setAWSThrottledCondition() reported a change without a condition")
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+const (
+	// errorBackoffBase is the delay after the first failure of a Database that is retried with
+	// exponential backoff, it doubles with every further failure
+	errorBackoffBase = 15 * time.Second
+	// errorBackoffMax caps the exponential backoff
+	errorBackoffMax = 60 * time.Second
+)
+
+// errorBackoff returns the exponential backoff after the given number of consecutive failures
+// It follows the rate limiter of the controllers, which forgets the failures on restart.
+func errorBackoff(failures int32) time.Duration {
+	delay := errorBackoffBase
+	for i := int32(1); i < failures && delay < errorBackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, errorBackoffMax)
+}
+
+// retryDelay returns the delay before a Database that failed with err is reconciled again
+// Errors with a fixed requeue interval keep it, all others back off exponentially with the number
+// of consecutive failures with the same reason.
+func retryDelay(err error, reason string, failures int32) time.Duration {
+	if requeueAfter, ok := errorRequeue(err, reason); ok {
+		return requeueAfter
+	}
+	return errorBackoff(failures)
+}
+
+// consecutiveFailures returns the number of consecutive failures including one with reason
+// The count restarts when the reason of the Ready condition changes, so a Database that gets past
+// one error is not held back by the backoff of another.
+func consecutiveFailures(status *databasev1alpha1.DatabaseStatus, reason string) int32 {
+	ready := meta.FindStatusCondition(status.Conditions, ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != reason {
+		return 1
+	}
+	return status.ConsecutiveFailures + 1
+}
+
+// recordFailure persists the failure count and the time of the next retry, and reports whether
+// the status changed
+func recordFailure(status *databasev1alpha1.DatabaseStatus, failures int32, nextRetry time.Time) bool {
+	next := metav1.NewTime(nextRetry.Truncate(time.Second))
+	if status.ConsecutiveFailures == failures && status.NextRetryTime != nil && status.NextRetryTime.Equal(&next) {
+		return false
+	}
+	status.ConsecutiveFailures = failures
+	status.NextRetryTime = &next
+	return true
+}
+
+// clearFailures drops the backoff of a Database that reconciled successfully
+func clearFailures(status *databasev1alpha1.DatabaseStatus) {
+	status.ConsecutiveFailures = 0
+	status.NextRetryTime = nil
+}
+
+// retryBackoff returns how long a failed Database still waits for status.nextRetryTime
+// A changed spec is reconciled right away, it may fix the error.
+func retryBackoff(db *databasev1alpha1.Database, now time.Time) time.Duration {
+	if db.Status.NextRetryTime == nil || db.Status.ObservedGeneration != db.Generation {
+		return 0
+	}
+	return max(db.Status.NextRetryTime.Sub(now), 0)
+}
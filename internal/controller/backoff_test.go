@@ -0,0 +1,118 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestErrorBackoff(t *testing.T) {
+	tests := []struct {
+		failures int32
+		want     time.Duration
+	}{
+		{failures: 0, want: 15 * time.Second},
+		{failures: 1, want: 15 * time.Second},
+		{failures: 2, want: 30 * time.Second},
+		{failures: 3, want: time.Minute},
+		{failures: 50, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := errorBackoff(tt.failures); got != tt.want {
+			t.Errorf("errorBackoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if got := retryDelay(newConfigError(errors.New("bad spec")), ReasonConfigError, 5); got != terminalErrorRequeue {
+		t.Errorf("retryDelay() of a config error = %s, want %s", got, terminalErrorRequeue)
+	}
+	if got := retryDelay(errors.New("connection reset"), ReasonTransient, 2); got != 30*time.Second {
+		t.Errorf("retryDelay() of a transient error = %s, want 30s", got)
+	}
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	failing := func(reason string) *databasev1alpha1.DatabaseStatus {
+		return &databasev1alpha1.DatabaseStatus{
+			ConsecutiveFailures: 3,
+			Conditions: []metav1.Condition{
+				{Type: ConditionReady, Status: metav1.ConditionFalse, Reason: reason},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		status *databasev1alpha1.DatabaseStatus
+		want   int32
+	}{
+		{name: "first failure", status: &databasev1alpha1.DatabaseStatus{}, want: 1},
+		{name: "same reason", status: failing(ReasonTransient), want: 4},
+		{name: "reason changed", status: failing(ReasonAuthError), want: 1},
+		{
+			name: "ready before",
+			status: &databasev1alpha1.DatabaseStatus{
+				ConsecutiveFailures: 3,
+				Conditions: []metav1.Condition{
+					{Type: ConditionReady, Status: metav1.ConditionTrue, Reason: ReasonReconciled},
+				},
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := consecutiveFailures(tt.status, ReasonTransient); got != tt.want {
+				t.Errorf("consecutiveFailures() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	now := time.Now()
+	status := databasev1alpha1.DatabaseStatus{}
+	if !recordFailure(&status, 2, now.Add(30*time.Second)) {
+		t.Fatal("recordFailure() did not report the changed status")
+	}
+	if recordFailure(&status, 2, now.Add(30*time.Second)) {
+		t.Error("recordFailure() reported an unchanged status")
+	}
+
+	db := &databasev1alpha1.Database{Status: status}
+	db.Generation = 4
+	db.Status.ObservedGeneration = 4
+	if got := retryBackoff(db, now); got <= 0 || got > 30*time.Second {
+		t.Errorf("retryBackoff() = %s, want up to 30s", got)
+	}
+	if got := retryBackoff(db, now.Add(time.Minute)); got != 0 {
+		t.Errorf("retryBackoff() after the retry time = %s, want 0", got)
+	}
+
+	// A changed spec is not held back
+	db.Generation = 5
+	if got := retryBackoff(db, now); got != 0 {
+		t.Errorf("retryBackoff() of a changed spec = %s, want 0", got)
+	}
+
+	clearFailures(&db.Status)
+	db.Generation = 4
+	if got := retryBackoff(db, now); got != 0 || db.Status.ConsecutiveFailures != 0 {
+		t.Errorf("retryBackoff() after success = %s, failures = %d", got, db.Status.ConsecutiveFailures)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// ConditionCharsetMismatch is the condition type reporting that a MySQL database that already existed has
+// another character set or collation than spec.mysql. It is removed once they match.
+const ConditionCharsetMismatch = "CharsetMismatch"
+
+// ReasonCharsetDiffers is the reason of the CharsetMismatch condition
+const ReasonCharsetDiffers = "CharsetDiffers"
+
+// reconcileCharset compares the character set and collation of a MySQL database with spec.mysql
+// Databases the operator created, and any database with charsetMismatchPolicy Alter, are altered to match.
+// Other mismatches are reported in the CharsetMismatch condition, since altering the database of an
+// application that relies on its character set is not always wanted.
+func (r *DatabaseReconciler) reconcileCharset(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client) error {
+	cfg := db.Spec.MySQL
+	if database.EngineFamily(string(db.Spec.Engine)) != "mysql" {
+		if cfg != nil {
+			return newConfigError(fmt.Errorf("spec.mysql requires the mysql or mariadb engine, got %s", db.Spec.Engine))
+		}
+		return nil
+	}
+	if cfg == nil {
+		cfg = &databasev1alpha1.MySQLConfig{}
+	}
+	charset := cfg.CharacterSet
+	if charset == "" {
+		charset = database.DefaultCharset
+	}
+	if err := database.ValidateCharset(charset, cfg.Collation); err != nil {
+		return newConfigError(fmt.Errorf("spec.mysql: %w", err))
+	}
+
+	// A database a dry run plans to create does not exist yet
+	if changePlanFrom(ctx) != nil {
+		exists, err := dbClient.DatabaseExists(ctx, db.Spec.DatabaseName)
+		if err != nil || !exists {
+			return err
+		}
+	}
+
+	currentCharset, currentCollation, err := dbClient.DatabaseCharset(ctx, db.Spec.DatabaseName)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(currentCharset, charset) && (cfg.Collation == "" || strings.EqualFold(currentCollation, cfg.Collation)) {
+		meta.RemoveStatusCondition(&db.Status.Conditions, ConditionCharsetMismatch)
+		return nil
+	}
+
+	current := currentCharset + " " + currentCollation
+	desired := strings.TrimSpace(charset + " " + cfg.Collation)
+	if cfg.CharsetMismatchPolicy != databasev1alpha1.CharsetMismatchPolicyAlter && db.Status.DatabaseCreatedAt == nil {
+		message := fmt.Sprintf("Database %s uses %s instead of %s, set spec.mysql.charsetMismatchPolicy to Alter to change it",
+			db.Spec.DatabaseName, current, desired)
+		meta.SetStatusCondition(&db.Status.Conditions, metav1.Condition{
+			Type:               ConditionCharsetMismatch,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonCharsetDiffers,
+			Message:            message,
+			ObservedGeneration: db.Generation,
+		})
+		r.recordEvent(db, corev1.EventTypeWarning, ConditionCharsetMismatch, "%s", message)
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Changing database character set",
+		"database", db.Spec.DatabaseName,
+		"from", current,
+		"to", desired)
+	if err := dbClient.SetDatabaseCharset(ctx, db.Spec.DatabaseName, charset, cfg.Collation); err != nil {
+		return err
+	}
+	r.recordNormal(db, EventReasonCharsetChanged, "Character set of database %s changed from %s to %s, existing tables are unchanged",
+		db.Spec.DatabaseName, current, desired)
+	meta.RemoveStatusCondition(&db.Status.Conditions, ConditionCharsetMismatch)
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// charsetClient is a database client that only implements DatabaseCharset and SetDatabaseCharset
+type charsetClient struct {
+	database.Client
+	charset   string
+	collation string
+	set       []string
+}
+
+func (c *charsetClient) DatabaseCharset(_ context.Context, _ string) (string, string, error) {
+	return c.charset, c.collation, nil
+}
+
+func (c *charsetClient) SetDatabaseCharset(_ context.Context, _, charset, collation string) error {
+	c.set = []string{charset, collation}
+	return nil
+}
+
+func TestReconcileCharset(t *testing.T) {
+	created := timestampPtr(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name          string
+		engine        databasev1alpha1.DatabaseEngine
+		mysql         *databasev1alpha1.MySQLConfig
+		createdAt     bool
+		charset       string
+		collation     string
+		wantReason    string
+		wantSet       bool
+		wantCondition bool
+	}{
+		{name: "postgres", engine: databasev1alpha1.DatabaseEnginePostgres},
+		{
+			name:       "postgres with spec.mysql",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			mysql:      &databasev1alpha1.MySQLConfig{CharacterSet: "utf8mb4"},
+			wantReason: ReasonConfigError,
+		},
+		{
+			name:      "default matches",
+			engine:    databasev1alpha1.DatabaseEngineMySQL,
+			charset:   "utf8mb4",
+			collation: "utf8mb4_0900_ai_ci",
+		},
+		{
+			name:          "latin1 is reported",
+			engine:        databasev1alpha1.DatabaseEngineMySQL,
+			charset:       "latin1",
+			collation:     "latin1_swedish_ci",
+			wantCondition: true,
+		},
+		{
+			name:      "latin1 is altered",
+			engine:    databasev1alpha1.DatabaseEngineMariaDB,
+			mysql:     &databasev1alpha1.MySQLConfig{CharsetMismatchPolicy: databasev1alpha1.CharsetMismatchPolicyAlter},
+			charset:   "latin1",
+			collation: "latin1_swedish_ci",
+			wantSet:   true,
+		},
+		{
+			name:      "collation of a created database is altered",
+			engine:    databasev1alpha1.DatabaseEngineMySQL,
+			mysql:     &databasev1alpha1.MySQLConfig{Collation: "utf8mb4_bin"},
+			createdAt: true,
+			charset:   "utf8mb4",
+			collation: "utf8mb4_unicode_ci",
+			wantSet:   true,
+		},
+		{
+			name:       "invalid collation",
+			engine:     databasev1alpha1.DatabaseEngineMySQL,
+			mysql:      &databasev1alpha1.MySQLConfig{Collation: "utf8mb4_bin; DROP"},
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{Recorder: record.NewFakeRecorder(10)}
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{Engine: tt.engine, DatabaseName: "shop", MySQL: tt.mysql}}
+			if tt.createdAt {
+				db.Status.DatabaseCreatedAt = created
+			}
+			dbClient := &charsetClient{charset: tt.charset, collation: tt.collation}
+
+			err := r.reconcileCharset(context.Background(), db, dbClient)
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("reconcileCharset() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (dbClient.set != nil) != tt.wantSet {
+				t.Errorf("SetDatabaseCharset called = %v, want %v", dbClient.set != nil, tt.wantSet)
+			}
+			if got := meta.IsStatusConditionTrue(db.Status.Conditions, ConditionCharsetMismatch); got != tt.wantCondition {
+				t.Errorf("CharsetMismatch condition = %v, want %v", got, tt.wantCondition)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// Multi-step operations recorded in status.pendingOperation
+const (
+	PendingOperationProvision             = "Provision"
+	PendingOperationSecretRegionMigration = "SecretRegionMigration"
+	PendingOperationClone                 = "Clone"
+	PendingOperationPostCreateHooks       = "PostCreateHooks"
+	PendingOperationPreDeleteHooks        = "PreDeleteHooks"
+)
+
+// Steps of each multi-step operation, in order
+var (
+	provisionSteps = []string{
+		"create-user",
+		"create-database",
+		"grant-privileges",
+		"store-secret",
+	}
+	secretRegionMigrationSteps = []string{
+		"write-target-secret",
+		"verify-target-secret",
+		"delete-source-secret",
+	}
+	cloneSteps = []string{
+		"copy-data",
+	}
+)
+
+// setPendingOperation records the given step of an operation in the status and reports whether
+// the recorded operation changed. The start time is kept while the same operation progresses.
+func setPendingOperation(status *databasev1alpha1.DatabaseStatus, opType string, steps []string, step string, now time.Time) bool {
+	var number int32
+	for i, s := range steps {
+		if s == step {
+			number = int32(i + 1)
+			break
+		}
+	}
+
+	current := status.PendingOperation
+	if current != nil && current.Type == opType && current.Step == step {
+		return false
+	}
+
+	startedAt := metav1.NewTime(now)
+	if current != nil && current.Type == opType {
+		startedAt = current.StartedAt
+	}
+	status.PendingOperation = &databasev1alpha1.PendingOperation{
+		Type:       opType,
+		Step:       step,
+		StepNumber: number,
+		TotalSteps: int32(len(steps)),
+		StartedAt:  startedAt,
+	}
+	return true
+}
+
+// clearPendingOperation removes the recorded operation if it is of the given type
+func clearPendingOperation(status *databasev1alpha1.DatabaseStatus, opType string) {
+	if status.PendingOperation != nil && status.PendingOperation.Type == opType {
+		status.PendingOperation = nil
+	}
+}
+
+// pendingOperationIs reports whether the status records an interrupted operation of the given type
+func pendingOperationIs(status *databasev1alpha1.DatabaseStatus, opType string) bool {
+	return status.PendingOperation != nil && status.PendingOperation.Type == opType
+}
+
+// checkpoint records the step about to run and persists the status right away, so that a
+// reconciliation interrupted by a restart resumes from this step instead of guessing from
+// the state of the database and the secret. A dry run only records the step in its copy of the status.
+func (r *DatabaseReconciler) checkpoint(ctx context.Context, db *databasev1alpha1.Database, opType string, steps []string, step string) error {
+	if !setPendingOperation(&db.Status, opType, steps, step, time.Now()) || changePlanFrom(ctx) != nil {
+		return nil
+	}
+
+	op := db.Status.PendingOperation
+	log.FromContext(ctx).V(1).Info("Recording checkpoint",
+		"operation", op.Type,
+		"step", fmt.Sprintf("%s %d/%d", op.Step, op.StepNumber, op.TotalSteps))
+	if err := r.updateStatus(ctx, db); err != nil {
+		return fmt.Errorf("failed to record %s checkpoint %q: %w", opType, step, err)
+	}
+	return nil
+}
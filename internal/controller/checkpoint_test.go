@@ -0,0 +1,114 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestSetPendingOperation(t *testing.T) {
+	started := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := started.Add(time.Minute)
+
+	tests := []struct {
+		name          string
+		current       *databasev1alpha1.PendingOperation
+		opType        string
+		steps         []string
+		step          string
+		wantChanged   bool
+		wantNumber    int32
+		wantTotal     int32
+		wantStartedAt time.Time
+	}{
+		{
+			name:          "new operation",
+			opType:        PendingOperationProvision,
+			steps:         provisionSteps,
+			step:          "create-user",
+			wantChanged:   true,
+			wantNumber:    1,
+			wantTotal:     4,
+			wantStartedAt: now,
+		},
+		{
+			name:          "next step keeps start time",
+			current:       &databasev1alpha1.PendingOperation{Type: PendingOperationProvision, Step: "create-user", StartedAt: metav1.NewTime(started)},
+			opType:        PendingOperationProvision,
+			steps:         provisionSteps,
+			step:          "grant-privileges",
+			wantChanged:   true,
+			wantNumber:    3,
+			wantTotal:     4,
+			wantStartedAt: started,
+		},
+		{
+			name:          "same step is unchanged",
+			current:       &databasev1alpha1.PendingOperation{Type: PendingOperationSecretRegionMigration, Step: "verify-target-secret", StepNumber: 2, TotalSteps: 3, StartedAt: metav1.NewTime(started)},
+			opType:        PendingOperationSecretRegionMigration,
+			steps:         secretRegionMigrationSteps,
+			step:          "verify-target-secret",
+			wantChanged:   false,
+			wantNumber:    2,
+			wantTotal:     3,
+			wantStartedAt: started,
+		},
+		{
+			name:          "other operation restarts",
+			current:       &databasev1alpha1.PendingOperation{Type: PendingOperationProvision, Step: "store-secret", StartedAt: metav1.NewTime(started)},
+			opType:        PendingOperationSecretRegionMigration,
+			steps:         secretRegionMigrationSteps,
+			step:          "write-target-secret",
+			wantChanged:   true,
+			wantNumber:    1,
+			wantTotal:     3,
+			wantStartedAt: now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &databasev1alpha1.DatabaseStatus{PendingOperation: tt.current}
+
+			if got := setPendingOperation(status, tt.opType, tt.steps, tt.step, now); got != tt.wantChanged {
+				t.Errorf("setPendingOperation() = %v, want %v", got, tt.wantChanged)
+			}
+			op := status.PendingOperation
+			if op.Type != tt.opType || op.Step != tt.step {
+				t.Errorf("operation = %s/%s, want %s/%s", op.Type, op.Step, tt.opType, tt.step)
+			}
+			if op.StepNumber != tt.wantNumber || op.TotalSteps != tt.wantTotal {
+				t.Errorf("step = %d/%d, want %d/%d", op.StepNumber, op.TotalSteps, tt.wantNumber, tt.wantTotal)
+			}
+			if !op.StartedAt.Time.Equal(tt.wantStartedAt) {
+				t.Errorf("StartedAt = %v, want %v", op.StartedAt.Time, tt.wantStartedAt)
+			}
+		})
+	}
+}
+
+func TestClearPendingOperation(t *testing.T) {
+	status := &databasev1alpha1.DatabaseStatus{
+		PendingOperation: &databasev1alpha1.PendingOperation{Type: PendingOperationSecretRegionMigration, Step: "delete-source-secret"},
+	}
+
+	clearPendingOperation(status, PendingOperationProvision)
+	if !pendingOperationIs(status, PendingOperationSecretRegionMigration) {
+		t.Fatal("clearing another operation type must keep the pending operation")
+	}
+
+	clearPendingOperation(status, PendingOperationSecretRegionMigration)
+	if status.PendingOperation != nil {
+		t.Errorf("PendingOperation = %v, want nil", status.PendingOperation)
+	}
+}
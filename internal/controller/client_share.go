@@ -0,0 +1,210 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// sharedClientMaxAge bounds how long a shared database client is handed out
+// Credentials baked into a client, e.g. Microsoft Entra access tokens, expire after an hour
+const sharedClientMaxAge = 10 * time.Minute
+
+// sharedClients keeps database and AWS clients open for BatchWindow after a reconciliation used them,
+// so Databases on the same server reconciled one after another, e.g. after an operator restart or
+// during a secret migration, share one connection and AWS client instead of opening their own
+type sharedClients struct {
+	mu       sync.Mutex
+	database map[string]*databaseLease
+	aws      map[string]*awsLease
+	now      func() time.Time
+}
+
+// databaseLease is a shared database client and the reconciliations using it
+type databaseLease struct {
+	client  database.Client
+	created time.Time
+	refs    int
+	window  time.Duration
+	timer   *time.Timer
+}
+
+// awsLease is a shared AWS client and the time it was last used
+type awsLease struct {
+	client   *secrets.AWSSecretsManagerClient
+	lastUsed time.Time
+}
+
+// leasedClient is a database client on loan from sharedClients, Close returns it
+type leasedClient struct {
+	database.Client
+	release func()
+	once    sync.Once
+}
+
+// Close returns the client to the shared clients instead of closing its connection
+func (c *leasedClient) Close() error {
+	c.once.Do(c.release)
+	return nil
+}
+
+func (s *sharedClients) timeNow() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// databaseClient returns a database client for the reconciliation of a resource
+// With a BatchWindow, clients of the same server and credentials are shared; Close must be called in any case.
+func (r *DatabaseReconciler) databaseClient(ctx context.Context, engine, connectionString string, cloudSQL *databasev1alpha1.CloudSQLConfig, azure *databasev1alpha1.AzureConfig) (database.Client, error) {
+	if r.BatchWindow <= 0 {
+		return r.newDatabaseClient(ctx, engine, connectionString, cloudSQL, azure)
+	}
+
+	key := sharedClientKey(engine, connectionString, cloudSQL, azure)
+	if client := r.clients.acquire(key); client != nil {
+		return client, nil
+	}
+
+	// Connect without holding the lock, a slow server must not block reconciliations of other servers
+	client, err := r.newDatabaseClient(ctx, engine, connectionString, cloudSQL, azure)
+	if err != nil {
+		return nil, err
+	}
+	return r.clients.add(ctx, key, client, r.BatchWindow), nil
+}
+
+// acquire returns the shared client of key, nil if there is none or it is too old
+func (s *sharedClients) acquire(key string) database.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, ok := s.database[key]
+	if !ok || s.timeNow().Sub(lease.created) >= sharedClientMaxAge {
+		return nil
+	}
+	lease.refs++
+	if lease.timer != nil {
+		lease.timer.Stop()
+		lease.timer = nil
+	}
+	return s.loan(key, lease)
+}
+
+// add shares a new client under key and returns it on loan
+// A client it replaces is closed once the reconciliations using it returned it.
+func (s *sharedClients) add(ctx context.Context, key string, client database.Client, window time.Duration) database.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.database == nil {
+		s.database = make(map[string]*databaseLease)
+	}
+	if previous, ok := s.database[key]; ok && previous.refs == 0 {
+		if previous.timer != nil {
+			previous.timer.Stop()
+		}
+		closeSharedClient(ctx, previous.client)
+	}
+	lease := &databaseLease{client: client, created: s.timeNow(), refs: 1, window: window}
+	s.database[key] = lease
+	return s.loan(key, lease)
+}
+
+// loan wraps the client of a lease so that closing it releases the lease
+func (s *sharedClients) loan(key string, lease *databaseLease) database.Client {
+	return &leasedClient{Client: lease.client, release: func() {
+		s.release(key, lease)
+	}}
+}
+
+// release returns a client, which is closed after the window of its lease unless it is acquired again
+// Clients replaced in the meantime or older than sharedClientMaxAge are closed right away.
+func (s *sharedClients) release(key string, lease *databaseLease) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease.refs--
+	if lease.refs > 0 {
+		return
+	}
+	current := s.database[key] == lease
+	if !current || lease.window <= 0 || s.timeNow().Sub(lease.created) >= sharedClientMaxAge {
+		if current {
+			delete(s.database, key)
+		}
+		closeSharedClient(context.Background(), lease.client)
+		return
+	}
+	lease.timer = time.AfterFunc(lease.window, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if lease.refs == 0 && s.database[key] == lease {
+			delete(s.database, key)
+			closeSharedClient(context.Background(), lease.client)
+		}
+	})
+}
+
+// closeSharedClient closes the connection of a shared client
+func closeSharedClient(ctx context.Context, client database.Client) {
+	if err := client.Close(); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to close shared database connection")
+	}
+}
+
+// awsClient returns an AWS Secrets Manager client for a region
+// With a BatchWindow, a client is reused until it was unused for the window.
+func (r *DatabaseReconciler) awsClient(ctx context.Context, region string) (*secrets.AWSSecretsManagerClient, error) {
+	if r.BatchWindow <= 0 {
+		return secrets.NewAWSSecretsManagerClient(ctx, region)
+	}
+
+	s := &r.clients
+	s.mu.Lock()
+	if lease, ok := s.aws[region]; ok && s.timeNow().Sub(lease.lastUsed) < r.BatchWindow {
+		lease.lastUsed = s.timeNow()
+		s.mu.Unlock()
+		return lease.client, nil
+	}
+	s.mu.Unlock()
+
+	client, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aws == nil {
+		s.aws = make(map[string]*awsLease)
+	}
+	s.aws[region] = &awsLease{client: client, lastUsed: s.timeNow()}
+	return client, nil
+}
+
+// sharedClientKey identifies the server and credentials of a database client
+func sharedClientKey(engine, connectionString string, cloudSQL *databasev1alpha1.CloudSQLConfig, azure *databasev1alpha1.AzureConfig) string {
+	key := engine + "\x00" + connectionString
+	if cloudSQL != nil {
+		key += "\x00cloudsql\x00" + cloudSQL.InstanceConnectionName + "\x00" + cloudSQL.IPType
+	}
+	if azure != nil && azure.EntraAuthentication {
+		key += "\x00entra"
+	}
+	return key
+}
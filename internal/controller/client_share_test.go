@@ -0,0 +1,138 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// closeCountingClient counts how often its connection was closed
+type closeCountingClient struct {
+	database.Client
+	closed atomic.Int32
+}
+
+func (c *closeCountingClient) Close() error {
+	c.closed.Add(1)
+	return nil
+}
+
+func TestSharedClientsReuse(t *testing.T) {
+	ctx := context.Background()
+	s := &sharedClients{}
+
+	if got := s.acquire("server"); got != nil {
+		t.Fatalf("acquire() on empty = %v, want nil", got)
+	}
+	client := &closeCountingClient{}
+	first := s.add(ctx, "server", client, time.Hour)
+
+	second := s.acquire("server")
+	if second == nil {
+		t.Fatal("acquire() of shared client = nil")
+	}
+	if second.(*leasedClient).Client != client {
+		t.Error("acquire() returned a different client")
+	}
+
+	_ = first.Close()
+	_ = first.Close()
+	_ = second.Close()
+	if got := client.closed.Load(); got != 0 {
+		t.Errorf("client closed %d times within the window, want 0", got)
+	}
+	if got := s.database["server"].refs; got != 0 {
+		t.Errorf("refs = %d after double Close, want 0", got)
+	}
+
+	// The released client is handed out again
+	third := s.acquire("server")
+	if third == nil {
+		t.Fatal("acquire() after release = nil")
+	}
+	_ = third.Close()
+	s.database["server"].timer.Stop()
+}
+
+func TestSharedClientsExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := &sharedClients{now: func() time.Time { return now }}
+
+	old := &closeCountingClient{}
+	inUse := s.add(ctx, "server", old, time.Hour)
+
+	now = now.Add(sharedClientMaxAge)
+	if got := s.acquire("server"); got != nil {
+		t.Fatal("acquire() of expired client succeeded")
+	}
+
+	// The replaced client is closed once it is returned
+	replacement := &closeCountingClient{}
+	fresh := s.add(ctx, "server", replacement, time.Hour)
+	if got := old.closed.Load(); got != 0 {
+		t.Errorf("client in use closed %d times, want 0", got)
+	}
+	_ = inUse.Close()
+	if got := old.closed.Load(); got != 1 {
+		t.Errorf("replaced client closed %d times, want 1", got)
+	}
+
+	// An expired client is closed on release instead of lingering
+	now = now.Add(sharedClientMaxAge)
+	_ = fresh.Close()
+	if got := replacement.closed.Load(); got != 1 {
+		t.Errorf("expired client closed %d times, want 1", got)
+	}
+	if _, ok := s.database["server"]; ok {
+		t.Error("expired client still shared")
+	}
+}
+
+func TestSharedClientsWindow(t *testing.T) {
+	s := &sharedClients{}
+	client := &closeCountingClient{}
+	_ = s.add(context.Background(), "server", client, 10*time.Millisecond).Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for client.closed.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("client not closed after the window")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := s.acquire("server"); got != nil {
+		t.Error("acquire() after the window succeeded")
+	}
+}
+
+func TestSharedClientKey(t *testing.T) {
+	const cs = "postgresql://admin:secret@db:5432/postgres"
+	keys := map[string]string{
+		"plain":    sharedClientKey("postgres", cs, nil, nil),
+		"engine":   sharedClientKey("mysql", cs, nil, nil),
+		"cloudSQL": sharedClientKey("postgres", cs, &databasev1alpha1.CloudSQLConfig{InstanceConnectionName: "p:r:i"}, nil),
+		"entra":    sharedClientKey("postgres", cs, nil, &databasev1alpha1.AzureConfig{EntraAuthentication: true}),
+	}
+	seen := map[string]string{}
+	for name, key := range keys {
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s share key %q", name, other, key)
+		}
+		seen[key] = name
+	}
+	if got := sharedClientKey("postgres", cs, nil, &databasev1alpha1.AzureConfig{}); got != keys["plain"] {
+		t.Error("azure without Entra authentication changed the key")
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// DefaultCloneJobImage is the default image of the Jobs copying MySQL databases, it must contain
+// bash, mysqldump and mysql
+const DefaultCloneJobImage = "mysql:8.4"
+
+// jobPollInterval is the requeue interval while a Job of the operator runs
+const jobPollInterval = 15 * time.Second
+
+// cloneJobBackoffLimit is the number of retries of a failed clone Job
+const cloneJobBackoffLimit = 2
+
+// cloneJobPasswordKey is the key of the admin password in the secret of a clone Job
+const cloneJobPasswordKey = "MYSQL_PWD"
+
+// cloneJobScript copies SOURCE_DATABASE into TARGET_DATABASE, the names are passed in the environment so
+// they are never interpreted by the shell. The dump drops and recreates each table, so a retry starts over.
+const cloneJobScript = `mysqldump --host="$DB_HOST" --port="$DB_PORT" --user="$DB_USER" \
+  --single-transaction --routines --triggers --events --set-gtid-purged=OFF "$SOURCE_DATABASE" |
+mysql --host="$DB_HOST" --port="$DB_PORT" --user="$DB_USER" "$TARGET_DATABASE"`
+
+// createClonedDatabase creates the database of a Database with spec.cloneFrom
+// PostgreSQL and Snowflake copy the source on the server. MySQL cannot: the database is created
+// empty and copyData reports that a Job has to copy the source once the credentials are stored.
+func (r *DatabaseReconciler) createClonedDatabase(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, owner string) (copyData bool, err error) {
+	source := db.Spec.CloneFrom.DatabaseName
+	if source == db.Spec.DatabaseName {
+		return false, newConfigError(fmt.Errorf("spec.cloneFrom.databaseName must differ from spec.databaseName"))
+	}
+	exists, err := dbClient.DatabaseExists(ctx, source)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if database exists: %w", err)
+	}
+	if !exists {
+		return false, newConfigError(fmt.Errorf("source database %s of spec.cloneFrom does not exist", source))
+	}
+
+	if database.EngineFamily(string(db.Spec.Engine)) == "mysql" {
+		if db.Spec.CloudSQL != nil || db.Spec.Azure != nil {
+			return false, newConfigError(fmt.Errorf("spec.cloneFrom of a MySQL database requires a password connection, " +
+				"the clone Job cannot use spec.cloudSQL or spec.azure"))
+		}
+		return true, dbClient.CreateDatabase(ctx, db.Spec.DatabaseName, owner)
+	}
+
+	if err := dbClient.CloneDatabase(ctx, db.Spec.DatabaseName, source, owner); err != nil {
+		if errors.Is(err, database.ErrCloneNotSupported) {
+			return false, newConfigError(fmt.Errorf("spec.cloneFrom: %w", err))
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+// cloneJobKey returns the namespace and name of the Job copying the data of a Database and of its secret
+func (r *DatabaseReconciler) cloneJobKey(ctx context.Context, db *databasev1alpha1.Database) (client.ObjectKey, error) {
+	return r.jobKey(ctx, db, "clone")
+}
+
+// jobKey returns the namespace and name of a Job the operator runs for a Database, <name>-<suffix>
+// The Job of a ClusterDatabase runs in its secretNamespace. Names are kept within the 63 characters
+// of the job-name label of the pods.
+func (r *DatabaseReconciler) jobKey(ctx context.Context, db *databasev1alpha1.Database, suffix string) (client.ObjectKey, error) {
+	namespace, err := r.secretNamespace(ctx, db)
+	if err != nil {
+		return client.ObjectKey{}, err
+	}
+	name := db.Name + "-" + suffix
+	if len(name) > 63 {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(db.Name))
+		name = fmt.Sprintf("%s-%s-%08x", db.Name[:63-len(suffix)-10], suffix, h.Sum32())
+	}
+	return client.ObjectKey{Namespace: namespace, Name: name}, nil
+}
+
+// cloneJobImage returns the image of the clone Jobs
+func (r *DatabaseReconciler) cloneJobImage() string {
+	if r.CloneJobImage == "" {
+		return DefaultCloneJobImage
+	}
+	return r.CloneJobImage
+}
+
+// cloneJob returns the Job copying the source of spec.cloneFrom with the admin connection
+func (r *DatabaseReconciler) cloneJob(db *databasev1alpha1.Database, key client.ObjectKey, connInfo *database.ConnectionInfo) *batchv1.Job {
+	backoffLimit := int32(cloneJobBackoffLimit)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       key.Namespace,
+			Name:            key.Name,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(db)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "clone",
+						Image:   r.cloneJobImage(),
+						Command: []string{"bash", "-o", "pipefail", "-c", cloneJobScript},
+						Env: []corev1.EnvVar{
+							{Name: "DB_HOST", Value: connInfo.Host},
+							{Name: "DB_PORT", Value: connInfo.Port},
+							{Name: "DB_USER", Value: connInfo.Username},
+							{Name: "SOURCE_DATABASE", Value: db.Spec.CloneFrom.DatabaseName},
+							{Name: "TARGET_DATABASE", Value: db.Spec.DatabaseName},
+							{Name: cloneJobPasswordKey, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: key.Name},
+								Key:                  cloneJobPasswordKey,
+							}}},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// reconcileCloneJob runs the Job copying the source of spec.cloneFrom into a new MySQL database
+// while status.pendingOperation records the Clone operation. The admin password is passed to the
+// Job in a secret that is deleted once the Job succeeded. A failed Job is kept for its logs and
+// is a configuration error until it is deleted, which starts a new Job.
+func (r *DatabaseReconciler) reconcileCloneJob(ctx context.Context, db *databasev1alpha1.Database, connInfo *database.ConnectionInfo) error {
+	if !pendingOperationIs(&db.Status, PendingOperationClone) {
+		return nil
+	}
+	if db.Spec.CloneFrom == nil {
+		log.FromContext(ctx).Info("spec.cloneFrom was removed, the database is not copied")
+		clearPendingOperation(&db.Status, PendingOperationClone)
+		startPostCreateHooks(db)
+		return nil
+	}
+	key, err := r.cloneJobKey(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, key, job)
+	if apierrors.IsForbidden(err) {
+		return newConfigError(fmt.Errorf("spec.cloneFrom of a MySQL database requires RBAC for Jobs, "+
+			"granted by the chart value cloneJobs.enabled: %w", err))
+	}
+	if apierrors.IsNotFound(err) {
+		if plannedChange(ctx, "start Job %s copying database %s into %s", key, db.Spec.CloneFrom.DatabaseName, db.Spec.DatabaseName) {
+			return nil
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       key.Namespace,
+				Name:            key.Name,
+				OwnerReferences: []metav1.OwnerReference{ownerReference(db)},
+			},
+			Data: map[string][]byte{cloneJobPasswordKey: []byte(connInfo.Password)},
+		}
+		if err := r.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create secret of clone Job %s: %w", key, err)
+		}
+		if err := r.Create(ctx, r.cloneJob(db, key, connInfo)); err != nil {
+			return fmt.Errorf("failed to create clone Job %s: %w", key, err)
+		}
+		log.FromContext(ctx).Info("Clone Job started", "job", key.String(), "source", db.Spec.CloneFrom.DatabaseName)
+		r.recordNormal(db, EventReasonCloneStarted, "Job %s started copying database %s into %s",
+			key, db.Spec.CloneFrom.DatabaseName, db.Spec.DatabaseName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get clone Job %s: %w", key, err)
+	}
+	if !isOwnedBy(job, db) {
+		return newConfigError(fmt.Errorf("clone Job %s already exists and is not owned by this resource", key))
+	}
+
+	switch {
+	case jobConditionTrue(job, batchv1.JobFailed):
+		return newConfigError(fmt.Errorf("clone Job %s failed to copy database %s into %s, see its logs and delete it to retry",
+			key, db.Spec.CloneFrom.DatabaseName, db.Spec.DatabaseName))
+	case jobConditionTrue(job, batchv1.JobComplete):
+		if plannedChange(ctx, "delete secret %s of the completed clone Job", key) {
+			return nil
+		}
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete secret of clone Job %s: %w", key, err)
+		}
+		clearPendingOperation(&db.Status, PendingOperationClone)
+		log.FromContext(ctx).Info("Clone Job completed", "job", key.String())
+		r.recordNormal(db, EventReasonCloneCompleted, "Database %s copied into %s", db.Spec.CloneFrom.DatabaseName, db.Spec.DatabaseName)
+		startPostCreateHooks(db)
+	}
+	return nil
+}
+
+// jobConditionTrue reports whether a condition of a Job is true
+func jobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// cloneClient is a database client that only implements DatabaseExists, CreateDatabase and CloneDatabase
+type cloneClient struct {
+	database.Client
+	databases map[string]bool
+	cloneErr  error
+	created   string
+	cloned    string
+}
+
+func (c *cloneClient) DatabaseExists(_ context.Context, name string) (bool, error) {
+	return c.databases[name], nil
+}
+
+func (c *cloneClient) CreateDatabase(_ context.Context, name, _ string) error {
+	c.created = name
+	return nil
+}
+
+func (c *cloneClient) CloneDatabase(_ context.Context, name, source, _ string) error {
+	if c.cloneErr != nil {
+		return c.cloneErr
+	}
+	c.cloned = source + ">" + name
+	return nil
+}
+
+func TestCreateClonedDatabase(t *testing.T) {
+	tests := []struct {
+		name         string
+		engine       databasev1alpha1.DatabaseEngine
+		source       string
+		cloudSQL     bool
+		cloneErr     error
+		wantReason   string
+		wantCopyData bool
+		wantCloned   bool
+	}{
+		{name: "postgres", engine: databasev1alpha1.DatabaseEnginePostgres, source: "prod", wantCloned: true},
+		{name: "mysql", engine: databasev1alpha1.DatabaseEngineMySQL, source: "prod", wantCopyData: true},
+		{name: "mysql with cloud sql", engine: databasev1alpha1.DatabaseEngineMySQL, source: "prod", cloudSQL: true, wantReason: ReasonConfigError},
+		{name: "missing source", engine: databasev1alpha1.DatabaseEnginePostgres, source: "staging", wantReason: ReasonConfigError},
+		{name: "same database", engine: databasev1alpha1.DatabaseEnginePostgres, source: "preview", wantReason: ReasonConfigError},
+		{
+			name:       "engine cannot clone",
+			engine:     databasev1alpha1.DatabaseEngineCassandra,
+			source:     "prod",
+			cloneErr:   database.ErrCloneNotSupported,
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{
+				Engine:       tt.engine,
+				DatabaseName: "preview",
+				CloneFrom:    &databasev1alpha1.CloneSource{DatabaseName: tt.source},
+			}}
+			if tt.cloudSQL {
+				db.Spec.CloudSQL = &databasev1alpha1.CloudSQLConfig{}
+			}
+			dbClient := &cloneClient{databases: map[string]bool{"prod": true, "preview": true}, cloneErr: tt.cloneErr}
+
+			copyData, err := (&DatabaseReconciler{}).createClonedDatabase(context.Background(), db, dbClient, "app")
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("createClonedDatabase() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if copyData != tt.wantCopyData {
+				t.Errorf("copyData = %v, want %v", copyData, tt.wantCopyData)
+			}
+			if (dbClient.cloned == "prod>preview") != tt.wantCloned {
+				t.Errorf("cloned = %q, want clone %v", dbClient.cloned, tt.wantCloned)
+			}
+			if tt.wantCopyData && dbClient.created != "preview" {
+				t.Errorf("created = %q, want the empty database preview", dbClient.created)
+			}
+		})
+	}
+}
+
+func TestReconcileCloneJob(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	foreign := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "taken-clone"}}
+	r := &DatabaseReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(foreign).WithStatusSubresource(&batchv1.Job{}).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "preview", UID: "uid-1"},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       databasev1alpha1.DatabaseEngineMySQL,
+			DatabaseName: "preview",
+			CloneFrom:    &databasev1alpha1.CloneSource{DatabaseName: "prod"},
+		},
+	}
+	connInfo := &database.ConnectionInfo{Host: "mysql.example.com", Port: "3306", Username: "admin", Password: "s3cret"}
+
+	if err := r.reconcileCloneJob(ctx, db, connInfo); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "preview-clone"}, &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Job without a pending clone: error = %v, want NotFound", err)
+	}
+
+	setPendingOperation(&db.Status, PendingOperationClone, cloneSteps, "copy-data", time.Now())
+	plan := &changePlan{}
+	if err := r.reconcileCloneJob(withChangePlan(ctx, plan), db, connInfo); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.changes) != 1 {
+		t.Fatalf("dry run: planned changes = %q, want 1", plan.changes)
+	}
+
+	if err := r.reconcileCloneJob(ctx, db, connInfo); err != nil {
+		t.Fatal(err)
+	}
+	key := client.ObjectKey{Namespace: "shop", Name: "preview-clone"}
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, key, job); err != nil {
+		t.Fatal(err)
+	}
+	if !isOwnedBy(job, db) {
+		t.Errorf("owner references = %v, want controller uid-1", job.OwnerReferences)
+	}
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if strings.Contains(env.Value, "s3cret") {
+			t.Errorf("env %s contains the admin password", env.Name)
+		}
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, key, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[cloneJobPasswordKey]) != "s3cret" {
+		t.Errorf("secret %s = %q, want the admin password", cloneJobPasswordKey, secret.Data[cloneJobPasswordKey])
+	}
+
+	// Running
+	if err := r.reconcileCloneJob(ctx, db, connInfo); err != nil {
+		t.Fatal(err)
+	}
+	if !pendingOperationIs(&db.Status, PendingOperationClone) {
+		t.Fatal("pending clone cleared while the Job runs")
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	if err := r.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileCloneJob(ctx, db, connInfo); classifyError(err) != ReasonConfigError {
+		t.Fatalf("failed Job: error = %v, want reason %s", err, ReasonConfigError)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := r.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileCloneJob(ctx, db, connInfo); err != nil {
+		t.Fatal(err)
+	}
+	if pendingOperationIs(&db.Status, PendingOperationClone) {
+		t.Error("pending clone not cleared after the Job completed")
+	}
+	if err := r.Get(ctx, key, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("secret of the completed Job: error = %v, want NotFound", err)
+	}
+
+	taken := db.DeepCopy()
+	taken.Name = "taken"
+	setPendingOperation(&taken.Status, PendingOperationClone, cloneSteps, "copy-data", time.Now())
+	if err := r.reconcileCloneJob(ctx, taken, connInfo); classifyError(err) != ReasonConfigError {
+		t.Errorf("Job not owned by the Database: error = %v, want reason %s", err, ReasonConfigError)
+	}
+}
+
+func TestCloneJobKey(t *testing.T) {
+	r := &DatabaseReconciler{}
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: strings.Repeat("a", 70)}}
+	key, err := r.cloneJobKey(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key.Name) > 63 || !strings.Contains(key.Name, "-clone-") {
+		t.Errorf("name = %s, want a truncated name of at most 63 characters", key.Name)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// ClusterDatabaseReconciler reconciles a ClusterDatabase object
+// It shares the configuration and state of the DatabaseReconciler and reconciles each ClusterDatabase
+// through its Database view: a Database without namespace carrying the spec and status of the
+// ClusterDatabase. Writes of the view are applied to the ClusterDatabase.
+type ClusterDatabaseReconciler struct {
+	*DatabaseReconciler
+}
+
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=clusterdatabases,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=clusterdatabases/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=clusterdatabases/finalizers,verbs=update
+
+func (r *ClusterDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := r.reconcileContext(ctx)
+	defer cancel()
+
+	trace := &ReconcileTrace{Name: req.Name, Time: time.Now()}
+	result, err := r.reconcile(ctx, req, trace)
+	r.traces.record(trace, result, err)
+	return result, err
+}
+
+// reconcile performs the reconciliation of a ClusterDatabase and records its decisions in trace
+func (r *ClusterDatabaseReconciler) reconcile(ctx context.Context, req ctrl.Request, trace *ReconcileTrace) (ctrl.Result, error) {
+	log.FromContext(ctx).Info("Starting reconciliation")
+
+	cdb := &databasev1alpha1.ClusterDatabase{}
+	if err := r.Get(ctx, req.NamespacedName, cdb); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.traces.forget(req.NamespacedName)
+			DatabaseUserErrorBudget.DeleteLabelValues(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	return r.reconcileObject(ctx, databaseView(cdb), trace)
+}
+
+func (r *ClusterDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := setupAdminSecretIndex(mgr, &databasev1alpha1.ClusterDatabase{}, func(obj client.Object) (*databasev1alpha1.DatabaseSpec, string) {
+		cdb := obj.(*databasev1alpha1.ClusterDatabase)
+		return &cdb.Spec.DatabaseSpec, cdb.Spec.SecretNamespace
+	}); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.ClusterDatabase{}, builder.WithPredicates(r.Shard.Predicate())).
+		Named("clusterdatabase").
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.clusterDatabasesForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		WithOptions(controllerOptions())
+	if r.AdminSecretWatcher != nil {
+		b = b.WatchesRawSource(r.AdminSecretWatcher.clusterDatabaseSource())
+	}
+	return b.Complete(r)
+}
+
+// databaseView returns the Database view of a ClusterDatabase
+func databaseView(cdb *databasev1alpha1.ClusterDatabase) *databasev1alpha1.Database {
+	return &databasev1alpha1.Database{
+		ObjectMeta: *cdb.ObjectMeta.DeepCopy(),
+		Spec:       *cdb.Spec.DatabaseSpec.DeepCopy(),
+		Status:     *cdb.Status.DeepCopy(),
+	}
+}
+
+// isClusterView reports whether db is the Database view of a ClusterDatabase
+// Database resources are namespaced, only views of the cluster-scoped kind have no namespace
+func isClusterView(db *databasev1alpha1.Database) bool {
+	return db.Namespace == ""
+}
+
+// clusterDatabaseFor returns the ClusterDatabase of a Database view
+func (r *DatabaseReconciler) clusterDatabaseFor(ctx context.Context, db *databasev1alpha1.Database) (*databasev1alpha1.ClusterDatabase, error) {
+	cdb := &databasev1alpha1.ClusterDatabase{}
+	if err := r.Get(ctx, client.ObjectKey{Name: db.Name}, cdb); err != nil {
+		return nil, fmt.Errorf("failed to get ClusterDatabase %s: %w", db.Name, err)
+	}
+	return cdb, nil
+}
+
+// updateStatus persists the status of a Database, or of the ClusterDatabase it is a view of
+func (r *DatabaseReconciler) updateStatus(ctx context.Context, db *databasev1alpha1.Database) error {
+	if !isClusterView(db) {
+		return updateStatusRetryingConflicts(ctx, r.Client, db, func(latest *databasev1alpha1.Database) {
+			latest.Status = db.Status
+		})
+	}
+
+	cdb, err := r.clusterDatabaseFor(ctx, db)
+	if err != nil {
+		return err
+	}
+	cdb.ResourceVersion = db.ResourceVersion
+	cdb.Status = db.Status
+	err = updateStatusRetryingConflicts(ctx, r.Client, cdb, func(latest *databasev1alpha1.ClusterDatabase) {
+		latest.Status = db.Status
+	})
+	if err != nil {
+		return err
+	}
+	db.ResourceVersion = cdb.ResourceVersion
+	return nil
+}
+
+// updateObject persists the metadata of a Database, or of the ClusterDatabase it is a view of
+// The reconciler only changes finalizers, the spec of a view is never written back
+func (r *DatabaseReconciler) updateObject(ctx context.Context, db *databasev1alpha1.Database) error {
+	if !isClusterView(db) {
+		return r.Update(ctx, db)
+	}
+
+	cdb, err := r.clusterDatabaseFor(ctx, db)
+	if err != nil {
+		return err
+	}
+	cdb.ResourceVersion = db.ResourceVersion
+	cdb.Finalizers = db.Finalizers
+	if err := r.Update(ctx, cdb); err != nil {
+		return err
+	}
+	db.ResourceVersion = cdb.ResourceVersion
+	return nil
+}
+
+// deleteObject deletes a Database, or the ClusterDatabase it is a view of
+// The UID precondition keeps a Database recreated under the same name from being deleted.
+func (r *DatabaseReconciler) deleteObject(ctx context.Context, db *databasev1alpha1.Database) error {
+	var obj client.Object = db
+	if isClusterView(db) {
+		obj = &databasev1alpha1.ClusterDatabase{ObjectMeta: metav1.ObjectMeta{Name: db.Name}}
+	}
+	return r.Delete(ctx, obj, client.Preconditions{UID: &db.UID})
+}
+
+// secretNamespace returns the namespace of the Kubernetes resources referenced by a Database
+// For ClusterDatabases it is spec.secretNamespace
+func (r *DatabaseReconciler) secretNamespace(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
+	if !isClusterView(db) {
+		return db.Namespace, nil
+	}
+
+	cdb, err := r.clusterDatabaseFor(ctx, db)
+	if err != nil {
+		return "", err
+	}
+	return cdb.Spec.SecretNamespace, nil
+}
+
+// eventObject returns the object events about a Database are recorded on
+func eventObject(db *databasev1alpha1.Database) client.Object {
+	if !isClusterView(db) {
+		return db
+	}
+	return &databasev1alpha1.ClusterDatabase{ObjectMeta: db.ObjectMeta}
+}
+
+// listDatabases returns all Database resources and, if enabled, the Database views of all ClusterDatabases
+func (r *DatabaseReconciler) listDatabases(ctx context.Context) ([]databasev1alpha1.Database, error) {
+	dbList := &databasev1alpha1.DatabaseList{}
+	if err := r.List(ctx, dbList); err != nil {
+		return nil, fmt.Errorf("failed to list Database resources: %w", err)
+	}
+	if !r.ClusterDatabases {
+		return dbList.Items, nil
+	}
+
+	cdbList := &databasev1alpha1.ClusterDatabaseList{}
+	if err := r.List(ctx, cdbList); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDatabase resources: %w", err)
+	}
+	dbs := dbList.Items
+	for i := range cdbList.Items {
+		dbs = append(dbs, *databaseView(&cdbList.Items[i]))
+	}
+	return dbs, nil
+}
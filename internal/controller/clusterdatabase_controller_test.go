@@ -0,0 +1,159 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func newClusterDatabaseTestReconciler(t *testing.T, objs ...client.Object) *DatabaseReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&databasev1alpha1.Database{}, &databasev1alpha1.ClusterDatabase{}).
+		Build()
+	return &DatabaseReconciler{Client: c, Scheme: scheme, ClusterDatabases: true}
+}
+
+func testClusterDatabase() *databasev1alpha1.ClusterDatabase {
+	return &databasev1alpha1.ClusterDatabase{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: databasev1alpha1.ClusterDatabaseSpec{
+			DatabaseSpec: databasev1alpha1.DatabaseSpec{
+				Engine:                    databasev1alpha1.DatabaseEnginePostgres,
+				DatabaseName:              "platform",
+				ConnectionStringSecretRef: &databasev1alpha1.SecretKeyReference{Name: "admin"},
+			},
+			SecretNamespace: "platform-system",
+		},
+	}
+}
+
+func TestClusterDatabaseViewWrites(t *testing.T) {
+	ctx := context.Background()
+	r := newClusterDatabaseTestReconciler(t, testClusterDatabase())
+
+	cdb := &databasev1alpha1.ClusterDatabase{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "platform"}, cdb); err != nil {
+		t.Fatal(err)
+	}
+	view := databaseView(cdb)
+	if !isClusterView(view) {
+		t.Fatal("expected Database view of a ClusterDatabase")
+	}
+
+	view.Finalizers = append(view.Finalizers, DatabaseFinalizer)
+	if err := r.updateObject(ctx, view); err != nil {
+		t.Fatalf("updateObject() error = %v", err)
+	}
+	view.Status.Phase = "Ready"
+	view.Status.ActualUsername = "platform"
+	if err := r.updateStatus(ctx, view); err != nil {
+		t.Fatalf("updateStatus() error = %v", err)
+	}
+
+	if err := r.Get(ctx, client.ObjectKey{Name: "platform"}, cdb); err != nil {
+		t.Fatal(err)
+	}
+	if len(cdb.Finalizers) != 1 || cdb.Finalizers[0] != DatabaseFinalizer {
+		t.Errorf("Finalizers = %v, want [%s]", cdb.Finalizers, DatabaseFinalizer)
+	}
+	if cdb.Status.Phase != "Ready" || cdb.Status.ActualUsername != "platform" {
+		t.Errorf("Status = %+v, want phase Ready and username platform", cdb.Status)
+	}
+	if cdb.Spec.SecretNamespace != "platform-system" {
+		t.Errorf("SecretNamespace = %q, want it unchanged", cdb.Spec.SecretNamespace)
+	}
+
+	namespace, err := r.secretNamespace(ctx, view)
+	if err != nil || namespace != "platform-system" {
+		t.Errorf("secretNamespace() = %q, %v, want platform-system", namespace, err)
+	}
+}
+
+func TestClusterDatabaseConnectionString(t *testing.T) {
+	ctx := context.Background()
+	r := newClusterDatabaseTestReconciler(t,
+		testClusterDatabase(),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "platform-system"},
+			Data:       map[string][]byte{"connectionString": []byte("postgres://admin:secret@db:5432/postgres")},
+		},
+	)
+
+	view := databaseView(testClusterDatabase())
+	got, err := r.getConnectionStringFromK8sSecret(ctx, view)
+	if err != nil {
+		t.Fatalf("getConnectionStringFromK8sSecret() error = %v", err)
+	}
+	if got != "postgres://admin:secret@db:5432/postgres" {
+		t.Errorf("connection string = %q", got)
+	}
+}
+
+func TestListDatabases(t *testing.T) {
+	ctx := context.Background()
+	objs := []client.Object{
+		testClusterDatabase(),
+		&databasev1alpha1.Database{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+			Spec:       databasev1alpha1.DatabaseSpec{Engine: databasev1alpha1.DatabaseEnginePostgres, DatabaseName: "app"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		clusterDatabases bool
+		want             []string
+	}{
+		{name: "namespaced only", clusterDatabases: false, want: []string{"team-a/app"}},
+		{name: "with cluster databases", clusterDatabases: true, want: []string{"team-a/app", "/platform"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newClusterDatabaseTestReconciler(t, objs...)
+			r.ClusterDatabases = tt.clusterDatabases
+
+			dbs, err := r.listDatabases(ctx)
+			if err != nil {
+				t.Fatalf("listDatabases() error = %v", err)
+			}
+			var got []string
+			for _, db := range dbs {
+				got = append(got, db.Namespace+"/"+db.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("listDatabases() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("listDatabases()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
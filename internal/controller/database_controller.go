@@ -9,25 +9,36 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
+	"hash/fnv"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
@@ -38,8 +49,31 @@ import (
 const (
 	DatabaseFinalizer = "database.opzkit.io/database-finalizer"
 
-	// Requeue interval for successful reconciliation
+	// Requeue interval for successful reconciliation when ResyncInterval is not set
 	requeueAfterSuccess = 10 * time.Minute
+
+	// minResyncInterval is the shortest periodic requeue interval, shorter intervals are raised to it
+	minResyncInterval = time.Minute
+
+	// defaultReconcileTimeout bounds a reconciliation when ReconcileTimeout is not set
+	defaultReconcileTimeout = 2 * time.Minute
+
+	// lastReconcileTimeResolution limits how often status.lastReconcileTime is refreshed
+	// Every refresh is a status change that triggers another reconciliation
+	lastReconcileTimeResolution = time.Minute
+
+	// currentSecretFormatVersion is the secret structure version written by the operator
+	currentSecretFormatVersion = "v2"
+
+	// Region migration phases recorded in status.regionMigration.phase
+	regionMigrationPending  = "Pending"
+	regionMigrationVerified = "Verified"
+
+	// DefaultManagedByTagKey is the default tag key used to mark secrets managed by the operator
+	DefaultManagedByTagKey = "ManagedBy"
+
+	// DefaultManagedByTagValue is the default tag value used to mark secrets managed by the operator
+	DefaultManagedByTagValue = "database-user-operator"
 )
 
 // DatabaseReconciler reconciles a Database object
@@ -47,6 +81,120 @@ type DatabaseReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// ManagedByTagKey is the tag key applied to every secret created by the operator
+	// An empty key disables the managed-by tag
+	ManagedByTagKey string
+
+	// ManagedByTagValue is the tag value applied together with ManagedByTagKey
+	ManagedByTagValue string
+
+	// ClusterName is the name of the Kubernetes cluster, available to secret description templates
+	ClusterName string
+
+	// DefaultSecretPrefix is the prefix of the default secret names, <prefix><engine>/<databaseName>
+	// Defaults to secrets.DefaultSecretPrefix.
+	DefaultSecretPrefix string
+
+	// SkipRegionValidation only checks the format of regions instead of the known AWS partitions
+	// Useful for regions in a geography not yet known to the operator
+	SkipRegionValidation bool
+
+	// StartupSpread spreads the first reconciliation of each Database after the operator starts
+	// over this duration, so a restart or leader change does not reconcile everything at once
+	// Databases with unobserved spec changes are reconciled immediately
+	StartupSpread time.Duration
+
+	// ResyncInterval is the periodic requeue interval of Databases without spec.resyncInterval,
+	// defaults to requeueAfterSuccess
+	ResyncInterval time.Duration
+
+	// RequeueJitter is the maximum fraction of the periodic requeue interval added per Database
+	RequeueJitter float64
+
+	// EventDedupWindow suppresses identical events for the same Database within this window
+	EventDedupWindow time.Duration
+
+	// StatusHistoryLength is the number of outcome changes kept in status.history, zero disables the history
+	StatusHistoryLength int
+
+	// ReconcileTimeout bounds a single reconciliation, defaults to defaultReconcileTimeout
+	// Reconciliations are not cancelled on shutdown, so in-flight secret writes complete
+	ReconcileTimeout time.Duration
+
+	// startupSeen records the Databases reconciled since the operator started
+	startupSeen sync.Map
+
+	// events deduplicates recorded events
+	events eventDeduplicator
+
+	// WarningEventBudget is the maximum number of Warning events recorded per Database and hour,
+	// zero disables the limit
+	WarningEventBudget int
+
+	// budget counts the Warning events of each Database against WarningEventBudget
+	budget eventBudget
+
+	// Notifier sends the recorded events of selected reasons to external sinks, nil disables notifications
+	Notifier *Notifier
+
+	// SecretEvents publishes the creation, rotation and deletion of secrets, nil disables them
+	SecretEvents *Notifier
+
+	// traces records the last reconciliation of each Database for the debug endpoint
+	traces reconcileTraces
+
+	// ClusterDatabases enables the ClusterDatabase kind, its resources are then also covered by
+	// the secret garbage collector, the orphan report and the readiness checks
+	ClusterDatabases bool
+
+	// DatabaseRoles enables the DatabaseRole kind, spec.memberOf of Databases is rejected without it
+	DatabaseRoles bool
+
+	// ConnectionPool bounds the connections each database client opens to a server
+	ConnectionPool database.PoolConfig
+
+	// TLSPolicy is the encryption required of admin connections that do not go through the Cloud SQL connector
+	TLSPolicy database.TLSPolicy
+
+	// PrehashPostgresPasswords sends passwords to PostgreSQL servers as SCRAM-SHA-256 verifiers
+	// instead of plaintext, see scramClient
+	PrehashPostgresPasswords bool
+
+	// SkipAdminPrivilegeCheck creates databases and users without checking the privileges of the admin
+	// user first, for admins whose privileges come from roles the check does not see
+	SkipAdminPrivilegeCheck bool
+
+	// SkipSecretRestore fails the reconciliation of a Database whose secret is scheduled for deletion
+	// instead of restoring the secret, see restoreScheduledSecret
+	SkipSecretRestore bool
+
+	// RevokeOwnerMembership removes the admin user from the owner of each database it creates on
+	// PostgreSQL, which it is made a member of to create the database
+	RevokeOwnerMembership bool
+
+	// BatchWindow keeps database connections and AWS clients open for this long after a reconciliation,
+	// so the next reconciliations of resources on the same server reuse them. Zero disables sharing.
+	BatchWindow time.Duration
+
+	// clients are the database and AWS clients shared within BatchWindow
+	clients sharedClients
+
+	// Shard limits the reconciled resources to those of one shard when several operator
+	// deployments split the work. The zero value reconciles all resources.
+	Shard Shard
+
+	// DryRun reports the changes to every Database in status.plannedChanges instead of making them,
+	// as if spec.dryRun were enabled on all of them
+	DryRun bool
+
+	// CloneJobImage is the image of the Jobs copying the source of spec.cloneFrom into MySQL databases,
+	// defaults to DefaultCloneJobImage
+	CloneJobImage string
+
+	// AdminSecretWatcher requeues Databases when the AWS secret of their admin connection string
+	// is rotated, nil disables polling the secrets
+	AdminSecretWatcher *AdminSecretWatcher
 }
 
 // +kubebuilder:rbac:groups=database.opzkit.io,resources=databases,verbs=get;list;watch;create;update;patch;delete
@@ -54,92 +202,185 @@ type DatabaseReconciler struct {
 // +kubebuilder:rbac:groups=database.opzkit.io,resources=databases/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create
+// +kubebuilder:rbac:groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasses,verbs=get;create;update
 
 func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := r.reconcileContext(ctx)
+	defer cancel()
+
+	trace := &ReconcileTrace{Namespace: req.Namespace, Name: req.Name, Time: time.Now()}
+	result, err := r.reconcile(ctx, req, trace)
+	r.traces.record(trace, result, err)
+	return result, err
+}
+
+// reconcileContext returns the context of a single reconciliation
+// It is detached from the cancellation of the manager so that a shutdown lets a reconciliation
+// finish instead of leaving a user created without its secret, and bounded by ReconcileTimeout
+func (r *DatabaseReconciler) reconcileContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := r.ReconcileTimeout
+	if timeout <= 0 {
+		timeout = defaultReconcileTimeout
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
+// reconcile performs the reconciliation of a Database and records its decisions in trace
+func (r *DatabaseReconciler) reconcile(ctx context.Context, req ctrl.Request, trace *ReconcileTrace) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Starting reconciliation")
 
 	db := &databasev1alpha1.Database{}
 	if err := r.Get(ctx, req.NamespacedName, db); err != nil {
 		if apierrors.IsNotFound(err) {
+			r.traces.forget(req.NamespacedName)
+			DatabaseUserErrorBudget.DeleteLabelValues(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
+	return r.reconcileObject(ctx, db, trace)
+}
+
+// reconcileObject reconciles a Database, or the Database view of a ClusterDatabase
+func (r *DatabaseReconciler) reconcileObject(ctx context.Context, db *databasev1alpha1.Database, trace *ReconcileTrace) (ctrl.Result, error) {
+	ctx = withObjectLogLevel(ctx, db)
+	logger := log.FromContext(ctx)
+	defer r.refreshErrorBudget(db)
+
+	trace.Generation = db.Generation
+	trace.SecretVersion = db.Status.SecretVersion
+
+	// Spread the first reconciliation of unchanged Databases after a restart
+	if db.DeletionTimestamp.IsZero() {
+		if delay := r.startupDelay(db); delay > 0 {
+			logger.Info("Delaying first reconciliation after operator start", "delay", delay)
+			trace.Branch = BranchStartupDelay
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+		// The backoff of a failed Database is persisted in its status, it holds across restarts
+		// and also for the reconciliation triggered by writing the failure to the status
+		if delay := retryBackoff(db, time.Now()); delay > 0 {
+			logger.V(1).Info("Waiting for the retry backoff of the last failure", "delay", delay,
+				"failures", db.Status.ConsecutiveFailures)
+			trace.Branch = BranchRetryBackoff
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+	}
 
 	// Record creation event on first reconciliation
 	if db.Status.ObservedGeneration == 0 {
-		r.Recorder.Event(db, corev1.EventTypeNormal, "Created", "Database created")
+		r.recordNormal(db, "Created", "Database created")
 	}
 
 	// Handle deletion
 	if !db.DeletionTimestamp.IsZero() {
+		trace.Branch = BranchDeleting
 		return r.reconcileDelete(ctx, db)
 	}
 
 	// Add finalizer if needed
 	if !controllerutil.ContainsFinalizer(db, DatabaseFinalizer) {
 		controllerutil.AddFinalizer(db, DatabaseFinalizer)
-		if err := r.Update(ctx, db); err != nil {
+		if err := r.updateObject(ctx, db); err != nil {
 			return ctrl.Result{}, err
 		}
+		trace.Branch = BranchFinalizerAdded
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
+	// Delete the Database once its spec.ttl elapsed
+	if expired, err := r.reconcileExpiry(ctx, db); expired || err != nil {
+		trace.Branch = BranchExpired
+		return ctrl.Result{}, err
+	}
+
+	// Only report the changes while dry run is enabled
+	if r.isDryRun(db) {
+		return r.reconcileDryRun(ctx, db, trace)
+	}
+
 	// Perform reconciliation
-	err := r.reconcileDatabase(ctx, db)
+	original := db.Status.DeepCopy()
+	err := r.reconcileDatabase(ctx, db, trace)
+	trace.SecretVersion = db.Status.SecretVersion
 
 	// Update status based on result
-	statusChanged := false
 	if err != nil {
+		reason := classifyError(err)
+		trace.Error = err.Error()
+		trace.Reason = reason
+
+		// A conflicting update is retried right away, the Database was changed concurrently
+		if reason == ReasonConflict {
+			logger.V(1).Info("Conflict during reconciliation, retrying", "error", err.Error())
+			return ctrl.Result{RequeueAfter: conflictRequeue}, nil
+		}
+
 		// Normalize error message to avoid status updates due to dynamic content (RequestIDs, etc.)
 		normalizedErrMsg := normalizeErrorMessage(err.Error())
+		failures := consecutiveFailures(original, reason)
+		retryAfter := retryDelay(err, reason, failures)
+		statusChanged := recordFailure(&db.Status, failures, time.Now().Add(retryAfter))
+		if meta.SetStatusCondition(&db.Status.Conditions, metav1.Condition{
+			Type:               ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            normalizedErrMsg,
+			ObservedGeneration: db.Generation,
+		}) {
+			statusChanged = true
+		}
 		if db.Status.Phase != "Error" || db.Status.Message != normalizedErrMsg {
 			db.Status.Phase = "Error"
 			db.Status.Message = normalizedErrMsg
-			db.Status.ObservedGeneration = db.Generation
 			statusChanged = true
 		}
+		if recordHistory(&db.Status, r.StatusHistoryLength, trace.Time, time.Now(), reason, db.Generation) {
+			statusChanged = true
+		}
+		if refreshExpiresAt(db) {
+			statusChanged = true
+		}
+		// A persisting error still refreshes the heartbeat, so a Database that keeps failing is
+		// told apart from one the operator stopped looking at
+		if refreshLastCheckedTime(&db.Status, time.Now()) {
+			statusChanged = true
+		}
+		if r.setAWSThrottledCondition(db, time.Now()) {
+			statusChanged = true
+		}
+		// Every transition is reported for the current generation, so tools waiting for
+		// observedGeneration, e.g. Argo CD, see that a changed spec failed
+		if statusChanged {
+			db.Status.ObservedGeneration = db.Generation
+		}
+		DatabaseUserConditions.WithLabelValues(db.Namespace, db.Name, ConditionReady).Set(0)
 
 		// Update status only if it changed to avoid triggering unnecessary reconciliations
 		if statusChanged {
-			if statusErr := r.Status().Update(ctx, db); statusErr != nil {
+			if statusErr := r.updateStatus(ctx, db); statusErr != nil {
 				logger.Error(statusErr, "Failed to update error status")
 			}
 		}
 
 		// Record event for user visibility (only once per error by checking if status changed)
 		if statusChanged {
-			if apierrors.IsNotFound(err) {
-				r.Recorder.Event(db, corev1.EventTypeWarning, "ConfigurationError", err.Error())
-			} else if isAWSPermissionError(err) {
-				r.Recorder.Event(db, corev1.EventTypeWarning, "PermissionError",
-					"AWS permission denied. Ensure the operator has IAM permissions for Secrets Manager. "+
-						"Grant secretsmanager:* on the secret ARN, or configure IRSA/instance profile.")
-			} else if isAWSResourceNotFoundError(err) {
-				r.Recorder.Event(db, corev1.EventTypeWarning, "ResourceNotFound",
-					"AWS resource not found. Verify the secret exists in AWS Secrets Manager and the name/region are correct in the Database spec.")
-			} else {
-				r.Recorder.Event(db, corev1.EventTypeWarning, "ReconciliationError", err.Error())
-			}
+			eventReason, message := errorEvent(err, reason)
+			r.recordEvent(db, corev1.EventTypeWarning, eventReason, "%s", message)
 		}
 
-		// Handle AWS permission errors with longer backoff since they require manual intervention
-		if isAWSPermissionError(err) {
-			logger.Error(err, "AWS permission error - requires IAM policy update",
-				"action", "Update IAM policy or IRSA configuration to grant Secrets Manager permissions",
-				"requeueAfter", "1m")
-			// Use 1 minute backoff to prevent log spam while allowing automatic recovery
-			return ctrl.Result{RequeueAfter: time.Minute}, nil
-		}
-
-		// Handle ResourceNotFoundException with longer backoff since it requires manual intervention
-		if isAWSResourceNotFoundError(err) {
-			logger.Error(err, "AWS resource not found - requires manual configuration",
-				"action", "Verify secret exists in AWS Secrets Manager and the name/region are correct",
-				"requeueAfter", "1m")
-			// Use 1 minute backoff to prevent log spam while allowing automatic recovery
-			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		// Errors that need manual intervention or a pause on the AWS side are retried at a
+		// fixed interval instead of with exponential backoff to prevent log spam
+		// while still allowing automatic recovery
+		if requeueAfter, ok := errorRequeue(err, reason); ok {
+			logger.Error(err, "Reconciliation failed, retrying at a fixed interval",
+				"reason", reason,
+				"requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 
 		// Return error to trigger rate limiter's exponential backoff
@@ -151,25 +392,141 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	db.Status.Phase = "Ready"
 	db.Status.Message = "Database, user, and secret are ready"
 	db.Status.ObservedGeneration = db.Generation
-	if err := r.Status().Update(ctx, db); err != nil {
-		logger.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
+	db.Status.SpecDigest = r.specDigest(db)
+	db.Status.PlannedChanges = nil
+	clearFailures(&db.Status)
+	meta.RemoveStatusCondition(&db.Status.Conditions, ConditionDryRun)
+	meta.SetStatusCondition(&db.Status.Conditions, metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonReconciled,
+		Message:            db.Status.Message,
+		ObservedGeneration: db.Generation,
+	})
+	DatabaseUserConditions.WithLabelValues(db.Namespace, db.Name, ConditionReady).Set(1)
+	// A skipped reconciliation only refreshes the heartbeat, lastReconcileTime is the last time the
+	// server and the secret were actually reconciled
+	if trace.Branch != BranchUpToDate {
+		refreshLastReconcileTime(&db.Status, time.Now())
+	}
+	refreshLastCheckedTime(&db.Status, time.Now())
+	r.setAWSThrottledCondition(db, time.Now())
+	recordHistory(&db.Status, r.StatusHistoryLength, trace.Time, time.Now(), "", db.Generation)
+	refreshExpiresAt(db)
+
+	// A reconciliation that changed nothing does not write the status, so watchers such as
+	// Argo CD do not see a new resourceVersion on every periodic reconciliation
+	statusChanged := !equality.Semantic.DeepEqual(original, &db.Status)
+	if statusChanged {
+		if err := r.updateStatus(ctx, db); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
 	}
 
+	requeueAfter := r.requeueInterval(db)
+	if pendingOperationIs(&db.Status, PendingOperationClone) || pendingOperationIs(&db.Status, PendingOperationPostCreateHooks) {
+		requeueAfter = jobPollInterval
+	}
+	if db.Status.ExpiresAt != nil {
+		requeueAfter = min(requeueAfter, max(time.Until(db.Status.ExpiresAt.Time), time.Second))
+	}
+	logger.V(2).Info("Reconciliation details",
+		"branch", trace.Branch,
+		"databaseExists", trace.DatabaseExists,
+		"userExists", trace.UserExists,
+		"secretExists", trace.SecretExists,
+		"secretVersion", db.Status.SecretVersion,
+		"statusChanged", statusChanged)
+	if eventVerbosity(db) == EmitEventsAll {
+		r.recordEvent(db, corev1.EventTypeNormal, EventReasonReconciled, "Reconciled, next reconciliation in %s", requeueAfter)
+	}
 	logger.Info("Reconciliation successful",
 		"database", db.Spec.DatabaseName,
 		"username", db.Status.ActualUsername,
 		"secretName", db.Status.ActualSecretName,
 		"secretARN", db.Status.SecretARN,
-		"requeueAfter", requeueAfterSuccess)
-	return ctrl.Result{RequeueAfter: requeueAfterSuccess}, nil
+		"requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// startupDelay returns how long to delay the first reconciliation of a Database after the
+// operator started. Each Database gets a stable offset within StartupSpread.
+// Later reconciliations and Databases with unobserved spec changes are not delayed.
+func (r *DatabaseReconciler) startupDelay(db *databasev1alpha1.Database) time.Duration {
+	if r.StartupSpread <= 0 {
+		return 0
+	}
+	if _, seen := r.startupSeen.LoadOrStore(db.UID, true); seen {
+		return 0
+	}
+	if db.Status.ObservedGeneration != db.Generation {
+		return 0
+	}
+	return time.Duration(objectSpread(db) * float64(r.StartupSpread))
+}
+
+// requeueInterval returns the periodic requeue interval for a Database
+// A stable per-Database jitter keeps periodic reconciliations spread out
+func (r *DatabaseReconciler) requeueInterval(db *databasev1alpha1.Database) time.Duration {
+	interval := r.resyncInterval()
+	if db.Spec.ResyncInterval != nil && db.Spec.ResyncInterval.Duration > 0 {
+		interval = max(db.Spec.ResyncInterval.Duration, minResyncInterval)
+	}
+	if r.RequeueJitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(r.RequeueJitter*objectSpread(db)*float64(interval))
+}
+
+// resyncInterval returns the operator-wide periodic requeue interval
+func (r *DatabaseReconciler) resyncInterval() time.Duration {
+	if r.ResyncInterval <= 0 {
+		return requeueAfterSuccess
+	}
+	return max(r.ResyncInterval, minResyncInterval)
+}
+
+// refreshLastReconcileTime sets status.lastReconcileTime to now unless it was set less than
+// lastReconcileTimeResolution ago
+func refreshLastReconcileTime(status *databasev1alpha1.DatabaseStatus, now time.Time) {
+	refreshTimestamp(&status.LastReconcileTime, now)
+}
+
+// refreshLastCheckedTime sets status.lastCheckedTime to now unless it was set less than
+// lastReconcileTimeResolution ago, and reports whether it changed
+func refreshLastCheckedTime(status *databasev1alpha1.DatabaseStatus, now time.Time) bool {
+	return refreshTimestamp(&status.LastCheckedTime, now)
+}
+
+// refreshTimestamp sets ts to now unless it was set less than lastReconcileTimeResolution ago
+func refreshTimestamp(ts **metav1.Time, now time.Time) bool {
+	if *ts != nil && now.Sub((*ts).Time) < lastReconcileTimeResolution {
+		return false
+	}
+	*ts = timestampPtr(now)
+	return true
+}
+
+// timestampPtr returns a pointer to a metav1.Time for the given time
+func timestampPtr(t time.Time) *metav1.Time {
+	ts := metav1.NewTime(t)
+	return &ts
+}
+
+// objectSpread maps a Database to a stable value in [0, 1) derived from its namespace and name
+func objectSpread(db *databasev1alpha1.Database) float64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(db.Namespace + "/" + db.Name))
+	return float64(h.Sum64()%10000) / 10000
 }
 
-func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *databasev1alpha1.Database) error {
+func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *databasev1alpha1.Database, trace *ReconcileTrace) error {
 	logger := log.FromContext(ctx)
 
 	// Check if reconciliation is needed
-	if !needsReconciliation(db) {
+	if !r.needsReconciliation(db) && !r.externalSecretChanged(ctx, db) {
+		trace.Branch = BranchUpToDate
 		logger.Info("Resources already exist and spec unchanged, skipping reconciliation",
 			"database", db.Spec.DatabaseName,
 			"username", db.Status.ActualUsername,
@@ -178,7 +535,43 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 		return nil
 	}
 
-	const currentSecretFormatVersion = "v2"
+	if err := validateSecretName(db); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if err := validateSecretKeyNames(db); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if err := validateSecretFormat(db); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if err := validateSecretBinary(db); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if err := validateExternalManagement(db); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if err := database.ValidateConnectionParams(string(db.Spec.Engine), db.Spec.ConnectionParams); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(fmt.Errorf("invalid spec.connectionParams: %w", err))
+	}
+	if err := validateAWSPartitions(db); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if err := validateMaintenanceWindow(db.Spec.MaintenanceWindow); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+	if _, err := fitUsername(db, getUsernameOrDefault(db)); err != nil {
+		trace.Branch = BranchValidationFailed
+		return newConfigError(err)
+	}
+
 	needsSecretUpdate := db.Status.SecretFormatVersion != currentSecretFormatVersion
 
 	if needsSecretUpdate {
@@ -202,7 +595,7 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 		return err
 	}
 
-	dbClient, err := database.NewClient(string(db.Spec.Engine), connectionString)
+	dbClient, err := r.databaseClient(ctx, string(db.Spec.Engine), connectionString, db.Spec.CloudSQL, db.Spec.Azure)
 	if err != nil {
 		return err
 	}
@@ -212,20 +605,44 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 			logger.Error(closeErr, "Failed to close database connection")
 		}
 	}()
+	if r.PrehashPostgresPasswords && database.EngineFamily(string(db.Spec.Engine)) == "postgres" {
+		dbClient = &scramClient{Client: dbClient}
+	}
+	if plan := changePlanFrom(ctx); plan != nil {
+		dbClient = &planningClient{Client: dbClient, plan: plan}
+	}
 
 	// Get connection info from the client
 	connInfo := dbClient.GetConnectionInfo()
 
+	if err := r.reconcileDatabaseRename(ctx, db, dbClient); err != nil {
+		return err
+	}
+
 	username := getUsernameOrDefault(db)
 
 	var password string
 
+	// privateKey is the private key of key-pair authentication stored in the secret, if any
+	var privateKey string
+
+	// provisioning is set when this reconciliation creates the user or the database,
+	// each of its steps is checkpointed in status.pendingOperation
+	var provisioning bool
+
+	// copyData is set when the database was created empty for spec.cloneFrom and a Job copies the source
+	var copyData bool
+
+	// external is the secret of spec.externalManagement.secret, which provides the password
+	var external *externalSecret
+
 	// If only updating secret format (user/db already exist), retrieve existing password from AWS
-	if needsSecretUpdate && db.Status.UserCreated && db.Status.DatabaseCreated && db.Status.SecretCreated {
+	if needsSecretUpdate && db.Status.UserCreated && db.Status.DatabaseCreated && db.Status.SecretCreated && !externalSecretManaged(db) {
+		trace.Branch = BranchFormatMigration
 		region := r.getRegion(db)
 
 		// Validate region
-		if err := secrets.ValidateRegion(region); err != nil {
+		if err := r.validateRegion(region); err != nil {
 			return fmt.Errorf("invalid AWS region for password retrieval: %w", err)
 		}
 
@@ -233,12 +650,13 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 			"secretName", db.Status.ActualSecretName,
 			"region", region)
 
-		awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+		awsClient, err := r.awsClient(ctx, region)
 		if err != nil {
 			return fmt.Errorf("failed to create AWS client for password retrieval: %w", err)
 		}
 
-		existingSecret, err := awsClient.GetSecret(ctx, db.Status.ActualSecretName)
+		secretID := resolveSecretID(db, db.Status.ActualSecretName, awsClient.GetRegion())
+		existingSecret, err := awsClient.GetSecretWithKeyNames(ctx, secretID, secretKeyNames(db))
 		if err != nil {
 			return fmt.Errorf("failed to retrieve existing secret for migration: %w", err)
 		}
@@ -247,6 +665,7 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 		if password == "" {
 			return fmt.Errorf("could not extract password from existing secret for migration")
 		}
+		privateKey = existingSecret.DBPrivateKey
 
 		logger.Info("Successfully retrieved existing password for secret format migration")
 	} else {
@@ -267,11 +686,11 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 		region := r.getRegion(db)
 
 		// Validate region
-		if err := secrets.ValidateRegion(region); err != nil {
+		if err := r.validateRegion(region); err != nil {
 			return fmt.Errorf("invalid AWS region: %w", err)
 		}
 
-		awsClient, err = secrets.NewAWSSecretsManagerClient(ctx, region)
+		awsClient, err = r.awsClient(ctx, region)
 		if err != nil {
 			return fmt.Errorf("failed to create AWS client: %w", err)
 		}
@@ -279,78 +698,77 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 		// Get the actual resolved region from the AWS client
 		region = awsClient.GetRegion()
 
-		// Determine secret name
-		secretName := getSecretNameOrDefault(db)
+		// Determine secret name and the identifier (stored ARN when available) used for AWS calls
+		secretName := r.getSecretNameOrDefault(db)
+		secretID := resolveSecretID(db, secretName, region)
 
-		secretExists, err = awsClient.SecretExists(ctx, secretName)
+		secretExists, err = awsClient.SecretExists(ctx, secretID)
 		if err != nil {
 			return fmt.Errorf("failed to check if secret exists: %w", err)
 		}
+		if !secretExists && secretID != secretName {
+			// The stored ARN is stale if the secret was recreated outside the operator
+			secretID = secretName
+			secretExists, err = awsClient.SecretExists(ctx, secretID)
+			if err != nil {
+				return fmt.Errorf("failed to check if secret exists: %w", err)
+			}
+		}
+		if externalSecretManaged(db) {
+			external, password, privateKey, err = r.readExternalSecret(ctx, db, awsClient, secretExists, secretID, secretName)
+			if err != nil {
+				return err
+			}
+		} else if secretExists {
+			if err := r.restoreScheduledSecret(ctx, db, awsClient, secretID, secretName); err != nil {
+				return err
+			}
+		}
+
+		trace.DatabaseExists = &dbExists
+		trace.UserExists = &userExists
+		trace.SecretExists = &secretExists
 
 		logger.Info("Checked resource existence",
 			"userExists", userExists,
 			"databaseExists", dbExists,
 			"secretExists", secretExists,
-			"secretName", secretName)
+			"secretName", secretName,
+			"secretID", secretID)
+
+		// A provisioning interrupted before its secret was stored is resumed: the password of
+		// the existing user is unknown, so it is reset and stored in a new secret
+		resumeProvision := pendingOperationIs(&db.Status, PendingOperationProvision) && !secretExists
 
 		// Decision logic based on resource existence
 		if dbExists && userExists && secretExists {
 			// All three exist - nothing to do, just verify and update status
+			trace.Branch = BranchAllExist
 			logger.Info("Database, user, and secret already exist - skipping creation",
 				"database", db.Spec.DatabaseName,
 				"username", username,
 				"secretName", secretName)
 
-			// Retrieve password from secret for grant operations
-			existingSecret, err := awsClient.GetSecret(ctx, secretName)
-			if err != nil {
-				return fmt.Errorf("failed to retrieve existing secret: %w", err)
-			}
-			password = existingSecret.DBPassword
-			if password == "" {
-				return fmt.Errorf("could not extract password from existing secret")
-			}
-
-			// Always update tags to ensure they're in sync with spec
-			desiredTags := map[string]string{"ManagedBy": "database-user-operator"}
-			if db.Spec.AWSSecretsManager != nil {
-				for k, v := range db.Spec.AWSSecretsManager.Tags {
-					desiredTags[k] = v
+			if external != nil {
+				if err := r.syncExternalPassword(ctx, db, dbClient, external, username, password); err != nil {
+					return err
 				}
-			}
-
-			// Get existing tags to determine what needs to be removed
-			existingTags, err := awsClient.GetSecretTags(ctx, secretName)
-			if err != nil {
-				logger.Error(err, "Failed to get existing secret tags, will still attempt to update tags",
-					"secretName", secretName)
-				existingTags = map[string]string{} // Continue with empty set
-			}
-
-			// Determine tags to remove (exist but not in desired)
-			var tagsToRemove []string
-			for existingKey := range existingTags {
-				if _, desired := desiredTags[existingKey]; !desired {
-					tagsToRemove = append(tagsToRemove, existingKey)
+			} else {
+				// Retrieve password from secret for grant operations
+				existingSecret, err := awsClient.GetSecretWithKeyNames(ctx, secretID, secretKeyNames(db))
+				if err != nil {
+					return fmt.Errorf("failed to retrieve existing secret: %w", err)
 				}
-			}
-
-			// Remove unwanted tags
-			if len(tagsToRemove) > 0 {
-				logger.Info("Removing tags from secret in AWS Secrets Manager",
-					"secretName", secretName,
-					"tagsToRemove", tagsToRemove)
-				if err := awsClient.UntagSecret(ctx, secretName, tagsToRemove); err != nil {
-					return fmt.Errorf("failed to remove secret tags: %w", err)
+				password = existingSecret.DBPassword
+				if password == "" {
+					return fmt.Errorf("could not extract password from existing secret")
 				}
-			}
+				privateKey = existingSecret.DBPrivateKey
 
-			// Add or update desired tags
-			logger.Info("Updating secret tags in AWS Secrets Manager",
-				"secretName", secretName,
-				"desiredTags", desiredTags)
-			if err := awsClient.TagSecret(ctx, secretName, desiredTags); err != nil {
-				return fmt.Errorf("failed to update secret tags: %w", err)
+				// Always update tags to ensure they're in sync with spec
+				if err := r.syncSecretTags(ctx, db, awsClient, secretID, secretName); err != nil {
+					return err
+				}
 			}
 
 			// Update status
@@ -360,24 +778,26 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 			db.Status.ActualUsername = username
 			db.Status.ActualSecretName = secretName
 
-		} else if (dbExists || userExists) && !secretExists {
+		} else if (dbExists || userExists) && !secretExists && !resumeProvision {
 			// Database and/or user exist but secret is missing
 			// Check if this is a region change scenario
 			regionChanged := db.Status.SecretRegion != "" && db.Status.SecretRegion != region
 
 			if regionChanged && db.Status.ActualSecretName != "" {
+				trace.Branch = BranchRegionChange
 				logger.Info("Region change detected - attempting to retrieve password from old region",
 					"oldRegion", db.Status.SecretRegion,
 					"newRegion", region,
 					"secretName", db.Status.ActualSecretName)
 
 				// Try to get password from old region
-				oldRegionClient, err := secrets.NewAWSSecretsManagerClient(ctx, db.Status.SecretRegion)
+				oldRegionClient, err := r.awsClient(ctx, db.Status.SecretRegion)
 				if err != nil {
 					return fmt.Errorf("failed to create AWS client for old region (%s): %w", db.Status.SecretRegion, err)
 				}
 
-				oldSecretExists, err := oldRegionClient.SecretExists(ctx, db.Status.ActualSecretName)
+				oldSecretID := previousSecretID(db)
+				oldSecretExists, err := oldRegionClient.SecretExists(ctx, oldSecretID)
 				if err != nil {
 					return fmt.Errorf("failed to check if secret exists in old region: %w", err)
 				}
@@ -387,7 +807,7 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 						"oldRegion", db.Status.SecretRegion,
 						"secretName", db.Status.ActualSecretName)
 
-					oldSecret, err := oldRegionClient.GetSecret(ctx, db.Status.ActualSecretName)
+					oldSecret, err := oldRegionClient.GetSecretWithKeyNames(ctx, oldSecretID, secretKeyNames(db))
 					if err != nil {
 						return fmt.Errorf("failed to retrieve secret from old region (%s): %w", db.Status.SecretRegion, err)
 					}
@@ -396,6 +816,7 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 					if password == "" {
 						return fmt.Errorf("could not extract password from secret in old region")
 					}
+					privateKey = oldSecret.DBPrivateKey
 
 					logger.Info("Successfully retrieved password from old region, will create secret in new region",
 						"oldRegion", db.Status.SecretRegion,
@@ -413,21 +834,40 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 				}
 			} else {
 				// Not a region change - this is an unrecoverable error
+				trace.Branch = BranchSecretMissing
 				return fmt.Errorf("database and/or user exist but secret is missing - cannot recover password (database exists: %v, user exists: %v, secret exists: %v). Please delete the Database CR and recreate it, or manually create the secret with the correct password",
 					dbExists, userExists, secretExists)
 			}
 
 		} else {
 			// Create missing resources
+			trace.Branch = BranchCreateMissing
+			if resumeProvision {
+				trace.Branch = BranchResumeProvision
+				logger.Info("Resuming interrupted provisioning",
+					"step", db.Status.PendingOperation.Step,
+					"startedAt", db.Status.PendingOperation.StartedAt)
+			}
+			provisioning = resumeProvision || !userExists || !dbExists
+			if provisioning {
+				if err := r.checkAdminPrivileges(ctx, dbClient); err != nil {
+					return err
+				}
+			}
 
-			// Generate new password for new resources
-			password, err = database.GeneratePassword(32)
-			if err != nil {
-				return err
+			// Generate new password for new resources, an externally managed secret provides it
+			if external == nil {
+				password, err = database.GeneratePassword(32)
+				if err != nil {
+					return err
+				}
 			}
 
 			// Create user if doesn't exist
 			if !userExists {
+				if err := r.checkpoint(ctx, db, PendingOperationProvision, provisionSteps, "create-user"); err != nil {
+					return err
+				}
 				logger.Info("Creating new database user",
 					"database", db.Spec.DatabaseName,
 					"username", username,
@@ -436,8 +876,22 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 				if err := dbClient.CreateUser(ctx, username, password); err != nil {
 					return err
 				}
+				r.warnPasswordLogging(ctx, db, dbClient)
 				logger.Info("Database user created successfully",
 					"username", username)
+				r.recordNormal(db, EventReasonUserCreated, "User %s created on %s", username, connInfo.Host)
+				db.Status.UserCreatedAt = timestampPtr(time.Now())
+			} else if resumeProvision {
+				logger.Info("Resetting password of user created by the interrupted provisioning",
+					"username", username)
+				if err := dbClient.SetPassword(ctx, username, password); err != nil {
+					return err
+				}
+				r.warnPasswordLogging(ctx, db, dbClient)
+			} else if external != nil {
+				if err := r.syncExternalPassword(ctx, db, dbClient, external, username, password); err != nil {
+					return err
+				}
 			} else {
 				logger.Info("User already exists",
 					"username", username)
@@ -447,16 +901,42 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 
 			// Create database if doesn't exist
 			if !dbExists {
+				if err := r.checkpoint(ctx, db, PendingOperationProvision, provisionSteps, "create-database"); err != nil {
+					return err
+				}
+				owner := username
+				if !database.PresetOwnsDatabase(string(db.Spec.PrivilegePreset)) {
+					owner = connInfo.Username
+				}
 				logger.Info("Creating new database",
 					"database", db.Spec.DatabaseName,
-					"owner", username,
+					"owner", owner,
 					"host", connInfo.Host)
 
-				if err := dbClient.CreateDatabase(ctx, db.Spec.DatabaseName, username); err != nil {
-					return err
+				if db.Spec.CloneFrom != nil {
+					copyData, err = r.createClonedDatabase(ctx, db, dbClient, owner)
+					if err != nil {
+						return err
+					}
+					logger.Info("Database created successfully",
+						"database", db.Spec.DatabaseName,
+						"cloneFrom", db.Spec.CloneFrom.DatabaseName)
+					r.recordNormal(db, EventReasonDatabaseCreated, "Database %s created on %s from %s",
+						db.Spec.DatabaseName, connInfo.Host, db.Spec.CloneFrom.DatabaseName)
+				} else {
+					if err := dbClient.CreateDatabase(ctx, db.Spec.DatabaseName, owner); err != nil {
+						return err
+					}
+					logger.Info("Database created successfully",
+						"database", db.Spec.DatabaseName)
+					r.recordNormal(db, EventReasonDatabaseCreated, "Database %s created on %s", db.Spec.DatabaseName, connInfo.Host)
 				}
-				logger.Info("Database created successfully",
-					"database", db.Spec.DatabaseName)
+				if r.RevokeOwnerMembership && owner != connInfo.Username {
+					if err := dbClient.RevokeOwnerMembership(ctx, owner); err != nil {
+						return err
+					}
+				}
+				db.Status.DatabaseCreatedAt = timestampPtr(time.Now())
 			} else {
 				logger.Info("Database already exists",
 					"database", db.Spec.DatabaseName)
@@ -470,64 +950,118 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, db *database
 		db.Status.ActualUsername = username
 	}
 
-	privileges := db.Spec.Privileges
-	if len(privileges) == 0 {
-		privileges = []string{"ALL"}
+	if provisioning {
+		if err := r.checkpoint(ctx, db, PendingOperationProvision, provisionSteps, "grant-privileges"); err != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcilePrivileges(ctx, db, dbClient, username); err != nil {
+		return err
 	}
-	logger.Info("Granting privileges",
-		"database", db.Spec.DatabaseName,
-		"username", username,
-		"privileges", privileges)
-	if err := dbClient.GrantAllPrivileges(ctx, db.Spec.DatabaseName, username); err != nil {
+
+	if err := r.reconcileMemberships(ctx, db, dbClient, username); err != nil {
+		return err
+	}
+	if err := r.reconcileAccountOptions(ctx, db, dbClient, username); err != nil {
+		return err
+	}
+	if err := r.reconcileRoleSettings(ctx, db, dbClient, username); err != nil {
+		return err
+	}
+	if err := r.reconcileDatabaseConnectionLimit(ctx, db, dbClient); err != nil {
+		return err
+	}
+	if err := r.reconcileCharset(ctx, db, dbClient); err != nil {
+		return err
+	}
+	privateKey, err = r.reconcileSnowflake(ctx, db, dbClient, username, privateKey)
+	if err != nil {
 		return err
 	}
-	logger.Info("Privileges granted successfully",
-		"database", db.Spec.DatabaseName,
-		"username", username)
 
-	port, _ := strconv.Atoi(connInfo.Port)
+	host, port := applicationEndpoint(db, connInfo)
+
+	if provisioning {
+		if err := r.checkpoint(ctx, db, PendingOperationProvision, provisionSteps, "store-secret"); err != nil {
+			return err
+		}
+	}
+
+	// Always store credentials in AWS Secrets Manager, unless another tool manages the secret
+	if external != nil {
+		recordExternalSecret(db, external, username, host, port)
+	} else if err := r.storeCredentialsInAWS(ctx, db, username, password, privateKey, host, port, needsSecretUpdate); err != nil {
+		return err
+	}
+	clearPendingOperation(&db.Status, PendingOperationProvision)
+
+	// The data of a MySQL database created for spec.cloneFrom is copied once its credentials are
+	// stored, Ready is then true while status.pendingOperation reports the running copy. The Jobs
+	// of spec.hooks.postCreate follow the same way, after the copy.
+	if copyData {
+		if err := r.checkpoint(ctx, db, PendingOperationClone, cloneSteps, "copy-data"); err != nil {
+			return err
+		}
+	} else if provisioning {
+		startPostCreateHooks(db)
+	}
+	if err := r.reconcileCloneJob(ctx, db, connInfo); err != nil {
+		return err
+	}
+	credentials := &secrets.DatabaseSecret{
+		DBHost:     host,
+		DBPort:     port,
+		DBName:     db.Spec.DatabaseName,
+		DBUsername: username,
+		DBPassword: password,
+	}
+	if err := r.reconcilePostCreateHooks(ctx, db, func(context.Context) (*secrets.DatabaseSecret, error) { return credentials, nil }); err != nil {
+		return err
+	}
 
-	// Always store credentials in AWS Secrets Manager
-	if err := r.storeCredentialsInAWS(ctx, db, username, password, connInfo, port, needsSecretUpdate); err != nil {
+	if err := r.reconcileSecretProviderClass(ctx, db); err != nil {
+		return err
+	}
+	if err := r.reconcileMigrationUser(ctx, db, dbClient, connInfo, host, port, username); err != nil {
+		return err
+	}
+	if err := r.reconcileRestartTargets(ctx, db); err != nil {
 		return err
 	}
 
+	// The server version is informational, failing to read it does not fail the reconciliation
+	if version, err := dbClient.ServerVersion(ctx); err != nil {
+		logger.Error(err, "Failed to read database server version")
+	} else {
+		db.Status.ConnectionInfo.ServerVersion = version.Raw
+		db.Status.ConnectionInfo.Capabilities = version.Capabilities(string(db.Spec.Engine))
+	}
+
 	return nil
 }
 
-func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *databasev1alpha1.Database, username, password string, connInfo *database.ConnectionInfo, port int, isMigration bool) error {
+func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *databasev1alpha1.Database, username, password, privateKey, host string, port int, isMigration bool) error {
 	logger := log.FromContext(ctx)
 
-	// Construct database URL
 	engine := string(db.Spec.Engine)
-	// Normalize engine name for URL scheme
-	urlScheme := engine
-	if strings.HasPrefix(strings.ToLower(engine), "postgres") {
-		urlScheme = "postgresql"
-	} else if strings.ToLower(engine) == "mariadb" {
-		urlScheme = "mysql" // MariaDB uses mysql:// scheme
-	}
-
-	databaseURL := fmt.Sprintf("%s://%s:%s@%s:%d/%s",
-		urlScheme,
-		url.QueryEscape(username),
-		url.QueryEscape(password),
-		connInfo.Host,
-		port,
-		db.Spec.DatabaseName,
-	)
 
 	secretValue := &secrets.DatabaseSecret{
-		DBHost:      connInfo.Host,
-		DBPort:      port,
-		DBName:      db.Spec.DatabaseName,
-		DBUsername:  username,
-		DBPassword:  password,
-		DatabaseURL: databaseURL,
-		Engine:      engine,
+		DBHost:       host,
+		DBPort:       port,
+		DBName:       db.Spec.DatabaseName,
+		DBUsername:   username,
+		DBPassword:   password,
+		DBPrivateKey: privateKey,
+		DatabaseURL:  databaseURL(db, username, password, host, port),
+		Engine:       engine,
+		KeyNames:     secretKeyNames(db),
+
+		ConnectionStringOnly: connectionStringOnly(db),
+		Binary:               db.Spec.SecretBinary,
 	}
 
-	secretName := getSecretNameOrDefault(db)
+	secretName := r.getSecretNameOrDefault(db)
 	db.Status.ActualSecretName = secretName
 
 	// Determine region: use awsSecretsManager.region if set, otherwise use connectionStringAWSSecretRef.region
@@ -535,26 +1069,24 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 	var regionSource string
 	if db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Region != "" {
 		regionSource = "spec.awsSecretsManager.region"
+	} else if secrets.IsSecretARN(db.Spec.SecretName) {
+		regionSource = "spec.secretName ARN"
 	} else if db.Spec.ConnectionStringAWSSecretRef != nil && db.Spec.ConnectionStringAWSSecretRef.Region != "" {
 		regionSource = "spec.connectionStringAWSSecretRef.region"
+	} else if len(adminConnectionAWSSecretRefs(db.Spec.AdminConnection)) > 0 {
+		regionSource = "spec.adminConnection"
 	} else {
 		regionSource = "AWS SDK default (environment/instance metadata)"
 	}
 
 	// Validate region
-	if err := secrets.ValidateRegion(region); err != nil {
+	if err := r.validateRegion(region); err != nil {
 		return fmt.Errorf("invalid AWS region: %w", err)
 	}
 
-	// Detect region changes
-	regionChanged := db.Status.SecretRegion != "" && db.Status.SecretRegion != region
-	if regionChanged {
-		logger.Info("Region change detected - secret will be created in new region",
-			"database", db.Spec.DatabaseName,
-			"secretName", secretName,
-			"oldRegion", db.Status.SecretRegion,
-			"newRegion", region,
-			"warning", fmt.Sprintf("Secret may still exist in old region (%s) and should be manually deleted if no longer needed", db.Status.SecretRegion))
+	description, err := r.secretDescription(db)
+	if err != nil {
+		return err
 	}
 
 	if isMigration {
@@ -571,7 +1103,7 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 			"regionSource", regionSource,
 			"username", username)
 	}
-	awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+	awsClient, err := r.awsClient(ctx, region)
 	if err != nil {
 		return fmt.Errorf("failed to create AWS Secrets Manager client for storing credentials (ensure pod has AWS permissions via IRSA, instance profile, or credentials): %w", err)
 	}
@@ -584,24 +1116,51 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 		"resolvedRegion", region,
 		"regionSource", regionSource)
 
+	// Detect region changes against the resolved region so that an empty spec region
+	// resolving to the current region is never treated as a migration
+	regionChanged := db.Status.SecretRegion != "" && db.Status.SecretRegion != region
+	if regionChanged {
+		logger.Info("Region change detected - secret will be migrated to new region",
+			"database", db.Spec.DatabaseName,
+			"secretName", secretName,
+			"oldRegion", db.Status.SecretRegion,
+			"newRegion", region)
+	}
+
+	// Use the stored ARN for AWS calls when it still refers to the desired secret
+	secretID := resolveSecretID(db, secretName, region)
+
 	// Check if secret exists in the target region
-	exists, err := awsClient.SecretExists(ctx, secretName)
+	exists, err := awsClient.SecretExists(ctx, secretID)
 	if err != nil {
 		return err
 	}
-
-	// If region changed and secret doesn't exist in new region, check old region
-	if regionChanged && !exists && db.Status.SecretRegion != "" {
-		logger.Info("Checking for secret in old region",
-			"secretName", secretName,
+	if !exists && secretID != secretName {
+		// The stored ARN is stale if the secret was recreated outside the operator
+		secretID = secretName
+		exists, err = awsClient.SecretExists(ctx, secretID)
+		if err != nil {
+			return err
+		}
+	}
+	if exists {
+		if err := r.restoreScheduledSecret(ctx, db, awsClient, secretID, secretName); err != nil {
+			return err
+		}
+	}
+
+	// If region changed and secret doesn't exist in new region, check old region
+	if regionChanged && !exists && db.Status.SecretRegion != "" {
+		logger.Info("Checking for secret in old region",
+			"secretName", secretName,
 			"oldRegion", db.Status.SecretRegion)
 
-		oldRegionClient, err := secrets.NewAWSSecretsManagerClient(ctx, db.Status.SecretRegion)
+		oldRegionClient, err := r.awsClient(ctx, db.Status.SecretRegion)
 		if err != nil {
 			logger.Error(err, "Failed to create client for old region",
 				"oldRegion", db.Status.SecretRegion)
 		} else {
-			oldExists, err := oldRegionClient.SecretExists(ctx, secretName)
+			oldExists, err := oldRegionClient.SecretExists(ctx, previousSecretID(db))
 			if err != nil {
 				logger.Error(err, "Failed to check secret in old region",
 					"oldRegion", db.Status.SecretRegion)
@@ -615,7 +1174,31 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 		}
 	}
 
+	// Record the migration before touching the target region so it can be resumed after a failure
+	if regionChanged {
+		if !pendingOperationIs(&db.Status, PendingOperationSecretRegionMigration) {
+			if err := requireMaintenanceWindow(ctx, db, "migration of secret %s from %s to %s",
+				secretName, db.Status.SecretRegion, region); err != nil {
+				return err
+			}
+		}
+		migration := db.Status.RegionMigration
+		if migration == nil || migration.SourceRegion != db.Status.SecretRegion || migration.TargetRegion != region {
+			migration = &databasev1alpha1.RegionMigrationStatus{
+				SourceRegion:   db.Status.SecretRegion,
+				SourceSecretID: previousSecretID(db),
+				TargetRegion:   region,
+			}
+		}
+		migration.Phase = regionMigrationPending
+		db.Status.RegionMigration = migration
+		if err := r.checkpoint(ctx, db, PendingOperationSecretRegionMigration, secretRegionMigrationSteps, "write-target-secret"); err != nil {
+			return err
+		}
+	}
+
 	var secretARN, versionID string
+	var updated bool
 	createSecret := !exists
 
 	if exists {
@@ -628,7 +1211,23 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 				"database", db.Spec.DatabaseName,
 				"secretName", secretName)
 		}
-		versionID, err = awsClient.UpdateSecretWithTemplate(ctx, secretName, secretValue, db.Spec.SecretTemplate)
+		if changePlanFrom(ctx) != nil {
+			updated, err = awsClient.SecretContentChanged(ctx, secretID, secretValue, db.Spec.SecretTemplate)
+			if err == nil && updated {
+				plannedChange(ctx, "update secret %s", secretName)
+			}
+		} else if deferErr := requireMaintenanceWindow(ctx, db, "update of secret %s", secretName); deferErr != nil &&
+			!pendingOperationIs(&db.Status, PendingOperationProvision) {
+			// Outside the maintenance window the secret is only compared, applications keep reading
+			// the current value until the window opens. A provisioning stores the password it just
+			// set and is never deferred.
+			updated, err = awsClient.SecretContentChanged(ctx, secretID, secretValue, db.Spec.SecretTemplate)
+			if err == nil && updated {
+				return deferErr
+			}
+		} else {
+			versionID, updated, err = awsClient.UpdateSecretIfChanged(ctx, secretID, secretValue, db.Spec.SecretTemplate)
+		}
 		if err != nil {
 			// Check if secret was deleted externally
 			var notFoundErr *secrets.SecretNotFoundError
@@ -647,10 +1246,19 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 					return err
 				}
 			}
+		} else if !updated {
+			logger.Info("Secret content unchanged, no new version written",
+				"database", db.Spec.DatabaseName,
+				"secretName", secretName,
+				"versionID", versionID)
+		} else if isMigration {
+			r.recordNormal(db, EventReasonSecretMigrated, "Secret %s migrated to format %s", secretName, currentSecretFormatVersion)
+		} else {
+			r.recordNormal(db, EventReasonSecretRotated, "Secret %s updated", secretName)
 		}
 
 		if !createSecret {
-			secretARN, _ = awsClient.GetSecretARN(ctx, secretName)
+			secretARN, _ = awsClient.GetSecretARN(ctx, secretID)
 			if isMigration {
 				logger.Info("Secret migrated successfully to v2 format in AWS Secrets Manager",
 					"database", db.Spec.DatabaseName,
@@ -659,7 +1267,7 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 					"versionID", versionID,
 					"region", region,
 					"format", "v2 (DB_HOST, DB_PORT, DB_NAME, DB_USERNAME, DB_PASSWORD, POSTGRES_URL)")
-			} else {
+			} else if updated {
 				logger.Info("Secret updated successfully in AWS Secrets Manager",
 					"database", db.Spec.DatabaseName,
 					"secretName", secretName,
@@ -667,25 +1275,33 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 					"versionID", versionID,
 					"region", region)
 			}
+			if updated {
+				reason := EventReasonSecretRotated
+				if isMigration {
+					reason = EventReasonSecretMigrated
+				}
+				r.publishSecretEvent(db, reason, NotificationSecret{Name: secretName, ARN: secretARN, Region: region, VersionID: versionID},
+					"Secret %s updated", secretName)
+			}
 		}
 	}
 
-	if createSecret {
-		description := "Database credentials for " + db.Spec.DatabaseName
-		tags := map[string]string{"ManagedBy": "database-user-operator"}
-		if db.Spec.AWSSecretsManager != nil {
-			if db.Spec.AWSSecretsManager.Description != "" {
-				description = db.Spec.AWSSecretsManager.Description
-			}
-			for k, v := range db.Spec.AWSSecretsManager.Tags {
-				tags[k] = v
-			}
+	if createSecret && plannedChange(ctx, "create secret %s in %s", secretName, region) {
+		// Without the secret there are no tags to sync and no migration to verify
+		if regionChanged {
+			plannedChange(ctx, "schedule deletion of secret %s in %s",
+				db.Status.RegionMigration.SourceSecretID, db.Status.RegionMigration.SourceRegion)
 		}
+		return nil
+	}
+
+	if createSecret {
+		tags := r.desiredSecretTags(db)
 		logger.Info("Creating new secret in AWS Secrets Manager",
 			"database", db.Spec.DatabaseName,
 			"secretName", secretName,
 			"description", description)
-		secretARN, versionID, err = awsClient.CreateSecretWithTemplate(ctx, secretName, description, secretValue, tags, db.Spec.SecretTemplate)
+		secretARN, versionID, err = awsClient.CreateSecretWithTemplate(ctx, secretID, description, secretValue, tags, db.Spec.SecretTemplate)
 		if err != nil {
 			return err
 		}
@@ -695,100 +1311,189 @@ func (r *DatabaseReconciler) storeCredentialsInAWS(ctx context.Context, db *data
 			"secretARN", secretARN,
 			"versionID", versionID,
 			"region", region)
+		r.recordNormal(db, EventReasonSecretCreated, "Secret %s created in %s", secretName, region)
+		r.publishSecretEvent(db, EventReasonSecretCreated, NotificationSecret{Name: secretName, ARN: secretARN, Region: region, VersionID: versionID},
+			"Secret %s created in %s", secretName, region)
 	}
 
-	// Always update tags to ensure they're in sync with spec
-	desiredTags := map[string]string{"ManagedBy": "database-user-operator"}
-	if db.Spec.AWSSecretsManager != nil {
-		for k, v := range db.Spec.AWSSecretsManager.Tags {
-			desiredTags[k] = v
-		}
+	// Key all further operations off the ARN returned by AWS
+	if secretARN != "" {
+		secretID = secretARN
 	}
 
-	// Get existing tags to determine what needs to be removed
-	existingTags, err := awsClient.GetSecretTags(ctx, secretName)
-	if err != nil {
-		logger.Error(err, "Failed to get existing secret tags, will still attempt to update tags",
-			"secretName", secretName)
-		existingTags = map[string]string{} // Continue with empty set
-	}
-
-	// Determine tags to remove (exist but not in desired)
-	var tagsToRemove []string
-	for existingKey := range existingTags {
-		if _, desired := desiredTags[existingKey]; !desired {
-			tagsToRemove = append(tagsToRemove, existingKey)
+	// Verify the secret in the new region before the source secret is scheduled for deletion
+	if regionChanged && !plannedChange(ctx, "schedule deletion of secret %s in %s",
+		db.Status.RegionMigration.SourceSecretID, db.Status.RegionMigration.SourceRegion) {
+		if err := r.checkpoint(ctx, db, PendingOperationSecretRegionMigration, secretRegionMigrationSteps, "verify-target-secret"); err != nil {
+			return err
+		}
+		if err := r.verifyMigratedSecret(ctx, db, awsClient, secretID, secretValue, createSecret); err != nil {
+			return err
 		}
-	}
 
-	// Remove unwanted tags
-	if len(tagsToRemove) > 0 {
-		logger.Info("Removing tags from secret in AWS Secrets Manager",
-			"secretName", secretName,
-			"tagsToRemove", tagsToRemove)
-		if err := awsClient.UntagSecret(ctx, secretName, tagsToRemove); err != nil {
-			return fmt.Errorf("failed to remove secret tags: %w", err)
+		// Switch the status to the verified target secret before the source secret is deleted,
+		// so an interrupted cleanup resumes with the deletion instead of migrating again
+		db.Status.SecretRegion = region
+		if secretARN != "" {
+			setSecretARN(&db.Status, secretARN)
+		}
+		if err := r.checkpoint(ctx, db, PendingOperationSecretRegionMigration, secretRegionMigrationSteps, "delete-source-secret"); err != nil {
+			return err
 		}
 	}
 
-	// Add or update desired tags
-	logger.Info("Updating secret tags in AWS Secrets Manager",
-		"secretName", secretName,
-		"desiredTags", desiredTags)
-	if err := awsClient.TagSecret(ctx, secretName, desiredTags); err != nil {
-		return fmt.Errorf("failed to update secret tags: %w", err)
+	// Always update tags to ensure they're in sync with spec
+	if err := r.syncSecretTags(ctx, db, awsClient, secretID, secretName); err != nil {
+		return err
 	}
-
-	// If region changed, delete secret from old region ONLY after successful creation in new region
-	// Only delete if we have a valid secretARN (confirming successful creation in new region)
-	if regionChanged && db.Status.SecretRegion != "" && secretARN != "" {
-		logger.Info("Deleting secret from old region after successful migration",
-			"secretName", secretName,
-			"oldRegion", db.Status.SecretRegion,
-			"newRegion", region,
-			"newSecretARN", secretARN)
-
-		oldRegionClient, err := secrets.NewAWSSecretsManagerClient(ctx, db.Status.SecretRegion)
-		if err != nil {
-			logger.Error(err, "Failed to create AWS client for old region to delete secret",
-				"oldRegion", db.Status.SecretRegion,
-				"warning", "Secret may still exist in old region and should be manually deleted")
-		} else {
-			// Use force delete to remove immediately without recovery window
-			if err := oldRegionClient.DeleteSecret(ctx, secretName, true); err != nil {
-				logger.Error(err, "Failed to delete secret from old region",
-					"oldRegion", db.Status.SecretRegion,
-					"secretName", secretName,
-					"warning", "Secret may still exist in old region and should be manually deleted")
-			} else {
-				logger.Info("Successfully deleted secret from old region",
-					"secretName", secretName,
-					"oldRegion", db.Status.SecretRegion)
-			}
+	if !createSecret {
+		if err := r.syncSecretDescription(ctx, awsClient, secretID, secretName, description); err != nil {
+			return err
 		}
-	} else if regionChanged && db.Status.SecretRegion != "" && secretARN == "" {
-		logger.Error(nil, "Region changed but secret not successfully created in new region - keeping secret in old region",
-			"oldRegion", db.Status.SecretRegion,
-			"newRegion", region,
-			"secretName", secretName)
 	}
 
 	db.Status.SecretCreated = true
-	db.Status.SecretARN = secretARN
-	db.Status.SecretVersion = versionID
-	db.Status.SecretFormatVersion = "v2"
+	if createSecret || updated || db.Status.SecretLastSyncedAt == nil {
+		db.Status.SecretLastSyncedAt = timestampPtr(time.Now())
+	}
+	setSecretARN(&db.Status, secretARN)
+	// Outside the maintenance window and in a dry run the secret is only compared, which reads no
+	// version: the recorded version is still the current one
+	if versionID != "" {
+		db.Status.SecretVersion = versionID
+	}
+	if changePlanFrom(ctx) == nil {
+		if err := setSecretChecksum(&db.Status, secretValue, db.Spec.SecretTemplate); err != nil {
+			return err
+		}
+	}
+	db.Status.SecretFormatVersion = currentSecretFormatVersion
 	db.Status.SecretRegion = region
 	db.Status.ConnectionInfo = databasev1alpha1.ConnectionInfo{
-		Host:     connInfo.Host,
+		Host:     host,
 		Port:     port,
 		Database: db.Spec.DatabaseName,
 		Username: username,
 		Engine:   string(db.Spec.Engine),
 	}
 
+	// Delete the source secret of a verified region migration (retried on subsequent reconciles)
+	r.cleanupMigratedSecret(ctx, db, region)
+
 	return nil
 }
 
+// applicationEndpoint returns the host and port written into the secret: those of
+// spec.applicationEndpoint, spec.portOverride and the admin connection, in that order
+func applicationEndpoint(db *databasev1alpha1.Database, connInfo *database.ConnectionInfo) (string, int) {
+	host := connInfo.Host
+	port, _ := strconv.Atoi(connInfo.Port)
+	if db.Spec.PortOverride != 0 {
+		port = int(db.Spec.PortOverride)
+	}
+	if endpoint := db.Spec.ApplicationEndpoint; endpoint != nil {
+		host = endpoint.Host
+		if endpoint.Port != 0 {
+			port = int(endpoint.Port)
+		}
+	}
+	return host, port
+}
+
+// setSecretChecksum records the checksum of the secret written to AWS in the status, and counts
+// the revision up when it changed
+func setSecretChecksum(status *databasev1alpha1.DatabaseStatus, secretValue *secrets.DatabaseSecret, tmpl string) error {
+	checksum, err := secretValue.ChecksumWithTemplate(tmpl)
+	if err != nil {
+		return newConfigError(fmt.Errorf("failed to render secret: %w", err))
+	}
+	if checksum != status.SecretChecksum {
+		status.SecretChecksum = checksum
+		status.SecretRevision++
+	}
+	return nil
+}
+
+// verifyMigratedSecret reads back the secret written to the target region of a migration and compares it
+// with the expected content. If verification fails, a secret created by this reconciliation is rolled back
+// so that the source region remains the single source of truth.
+func (r *DatabaseReconciler) verifyMigratedSecret(ctx context.Context, db *databasev1alpha1.Database, awsClient *secrets.AWSSecretsManagerClient, secretID string, secretValue *secrets.DatabaseSecret, created bool) error {
+	logger := log.FromContext(ctx)
+	migration := db.Status.RegionMigration
+
+	expected, err := secretValue.ToJSONWithTemplate(db.Spec.SecretTemplate)
+	if err == nil {
+		err = awsClient.VerifySecretContent(ctx, secretID, expected)
+	}
+	if err != nil {
+		migration.LastError = normalizeErrorMessage(err.Error())
+		if created {
+			logger.Info("Rolling back unverified secret in target region",
+				"secretID", secretID,
+				"targetRegion", migration.TargetRegion)
+			if deleteErr := awsClient.DeleteSecret(ctx, secretID, true); deleteErr != nil {
+				logger.Error(deleteErr, "Failed to roll back unverified secret in target region",
+					"secretID", secretID,
+					"targetRegion", migration.TargetRegion)
+			}
+		}
+		return fmt.Errorf("failed to verify secret in target region %s, keeping secret in source region %s: %w",
+			migration.TargetRegion, migration.SourceRegion, err)
+	}
+
+	logger.Info("Verified secret content in target region",
+		"secretID", secretID,
+		"sourceRegion", migration.SourceRegion,
+		"targetRegion", migration.TargetRegion)
+	migration.Phase = regionMigrationVerified
+	migration.LastError = ""
+	return nil
+}
+
+// cleanupMigratedSecret schedules the source secret of a verified region migration for deletion
+// using the default recovery window. Failures are recorded in status and retried on the next reconcile.
+func (r *DatabaseReconciler) cleanupMigratedSecret(ctx context.Context, db *databasev1alpha1.Database, region string) {
+	logger := log.FromContext(ctx)
+	migration := db.Status.RegionMigration
+	if migration == nil || migration.Phase != regionMigrationVerified || migration.TargetRegion != region {
+		return
+	}
+
+	logger.Info("Deleting secret from source region after verified migration",
+		"sourceSecretID", migration.SourceSecretID,
+		"sourceRegion", migration.SourceRegion,
+		"targetRegion", migration.TargetRegion,
+		"attempt", migration.CleanupAttempts+1)
+
+	if plannedChange(ctx, "schedule deletion of secret %s in %s", migration.SourceSecretID, migration.SourceRegion) {
+		return
+	}
+
+	oldRegionClient, err := r.awsClient(ctx, migration.SourceRegion)
+	if err == nil {
+		err = oldRegionClient.DeleteSecret(ctx, migration.SourceSecretID, false)
+	}
+	if err != nil {
+		migration.CleanupAttempts++
+		migration.LastError = normalizeErrorMessage(err.Error())
+		logger.Error(err, "Failed to delete secret from source region, will retry on next reconciliation",
+			"sourceSecretID", migration.SourceSecretID,
+			"sourceRegion", migration.SourceRegion,
+			"attempts", migration.CleanupAttempts)
+		r.recordEvent(db, corev1.EventTypeWarning, "RegionMigrationCleanupFailed",
+			"Failed to delete secret %s from source region %s: %s", migration.SourceSecretID, migration.SourceRegion, migration.LastError)
+		return
+	}
+
+	logger.Info("Region migration completed",
+		"sourceRegion", migration.SourceRegion,
+		"targetRegion", migration.TargetRegion)
+	r.recordNormal(db, "RegionMigrationCompleted",
+		"Secret migrated from %s to %s, source secret scheduled for deletion", migration.SourceRegion, migration.TargetRegion)
+	db.Status.RegionMigration = nil
+	clearPendingOperation(&db.Status, PendingOperationSecretRegionMigration)
+}
+
 func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1alpha1.Database) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -801,12 +1506,29 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 	if db.Spec.RetainOnDelete != nil {
 		retainOnDelete = *db.Spec.RetainOnDelete
 	}
+	// A dry run deletes nothing, the Database may not even own what exists under its names
+	if r.isDryRun(db) {
+		retainOnDelete = true
+	}
 
 	logger.Info("Processing deletion",
 		"database", db.Spec.DatabaseName,
 		"retainOnDelete", retainOnDelete)
 
+	// The Jobs of spec.hooks.preDelete run before anything is dropped or retained
+	hooksDone, err := r.reconcilePreDeleteHooks(ctx, db)
+	if err != nil {
+		r.setDeletionBlocked(ctx, db, ReasonPreDeleteHookFailed, err)
+		return ctrl.Result{}, err
+	}
+	if !hooksDone {
+		return ctrl.Result{RequeueAfter: jobPollInterval}, nil
+	}
+
 	if !retainOnDelete {
+		if err := requireMaintenanceWindow(ctx, db, "dropping database %s, its user and secret", db.Spec.DatabaseName); err != nil {
+			return r.deferDeletion(ctx, db, err)
+		}
 		logger.Info("Starting cleanup of database resources (retainOnDelete=false)",
 			"database", db.Spec.DatabaseName,
 			"username", db.Status.ActualUsername,
@@ -814,6 +1536,13 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 
 		// Track what was actually deleted and collect errors
 		var cleanupErrors []error
+		blockedReason := ""
+		fail := func(reason string, err error) {
+			if blockedReason == "" {
+				blockedReason = reason
+			}
+			cleanupErrors = append(cleanupErrors, err)
+		}
 		databaseDeleted := false
 		userDeleted := false
 		secretDeleted := false
@@ -822,12 +1551,12 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 		connectionString, connErr := r.getConnectionString(ctx, db)
 		if connErr != nil {
 			logger.Error(connErr, "Failed to get connection string for cleanup")
-			cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to get connection string: %w", connErr))
-		} else if connectionString != "" {
-			dbClient, err := database.NewClient(string(db.Spec.Engine), connectionString)
+			fail(ReasonConnectionStringUnavailable, fmt.Errorf("failed to get connection string: %w", connErr))
+		} else {
+			dbClient, err := r.databaseClient(ctx, string(db.Spec.Engine), connectionString, db.Spec.CloudSQL, db.Spec.Azure)
 			if err != nil {
 				logger.Error(err, "Failed to create database client for cleanup")
-				cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to create database client: %w", err))
+				fail(ReasonCleanupConnectionFailed, fmt.Errorf("failed to create database client: %w", err))
 			} else {
 				defer func() {
 					if closeErr := dbClient.Close(); closeErr != nil {
@@ -840,14 +1569,14 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 				if err != nil {
 					logger.Error(err, "Failed to check if database exists",
 						"database", db.Spec.DatabaseName)
-					cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to check database existence: %w", err))
+					fail(ReasonDatabaseDropFailed, fmt.Errorf("failed to check database existence: %w", err))
 				} else if dbExists {
 					logger.Info("Dropping database",
 						"database", db.Spec.DatabaseName)
 					if err := dbClient.DropDatabase(ctx, db.Spec.DatabaseName); err != nil {
 						logger.Error(err, "Failed to drop database",
 							"database", db.Spec.DatabaseName)
-						cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to drop database %s: %w", db.Spec.DatabaseName, err))
+						fail(ReasonDatabaseDropFailed, fmt.Errorf("failed to drop database %s: %w", db.Spec.DatabaseName, err))
 					} else {
 						databaseDeleted = true
 						logger.Info("Database dropped successfully",
@@ -869,14 +1598,14 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 				if err != nil {
 					logger.Error(err, "Failed to check if user exists",
 						"username", username)
-					cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to check user existence: %w", err))
+					fail(ReasonUserDropFailed, fmt.Errorf("failed to check user existence: %w", err))
 				} else if userExists {
 					logger.Info("Dropping user",
 						"username", username)
 					if err := dbClient.DropUser(ctx, username); err != nil {
 						logger.Error(err, "Failed to drop user",
 							"username", username)
-						cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to drop user %s: %w", username, err))
+						fail(ReasonUserDropFailed, fmt.Errorf("failed to drop user %s: %w", username, err))
 					} else {
 						userDeleted = true
 						logger.Info("User dropped successfully",
@@ -886,6 +1615,11 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 					logger.Info("User does not exist, skipping drop",
 						"username", username)
 				}
+
+				if err := r.dropMigrationUser(ctx, db, dbClient); err != nil {
+					logger.Error(err, "Failed to drop migration user")
+					fail(ReasonUserDropFailed, err)
+				}
 			}
 		}
 
@@ -894,15 +1628,15 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 		region := r.getRegion(db)
 
 		// Validate region
-		if err := secrets.ValidateRegion(region); err != nil {
+		if err := r.validateRegion(region); err != nil {
 			logger.Error(err, "Invalid AWS region for secret deletion")
-			cleanupErrors = append(cleanupErrors, fmt.Errorf("invalid AWS region %s: %w", region, err))
+			fail(ReasonSecretDeleteFailed, fmt.Errorf("invalid AWS region %s: %w", region, err))
 		} else {
-			awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+			awsClient, err := r.awsClient(ctx, region)
 			if err != nil {
 				logger.Error(err, "Failed to create AWS Secrets Manager client for deletion",
 					"region", region)
-				cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to create AWS client: %w", err))
+				fail(ReasonSecretDeleteFailed, fmt.Errorf("failed to create AWS client: %w", err))
 			} else {
 				// Get the actual resolved region
 				region = awsClient.GetRegion()
@@ -910,42 +1644,72 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 				// Determine the secret name to delete
 				secretName := db.Status.ActualSecretName
 				if secretName == "" {
-					secretName = getSecretNameOrDefault(db)
+					secretName = r.getSecretNameOrDefault(db)
 				}
 
-				logger.Info("Deleting secret from AWS Secrets Manager",
-					"secretName", secretName,
-					"region", region)
+				secretID := resolveSecretID(db, secretName, region)
 
-				if err := awsClient.DeleteSecret(ctx, secretName, true); err != nil {
-					// Ignore ResourceNotFoundException - secret doesn't exist, which is fine
-					if !isAWSResourceNotFoundError(err) {
-						logger.Error(err, "Failed to delete secret from AWS Secrets Manager",
-							"secretName", secretName,
-							"region", region)
-						cleanupErrors = append(cleanupErrors, fmt.Errorf("failed to delete secret %s: %w", secretName, err))
+				if externalSecretManaged(db) {
+					logger.Info("Secret is managed externally, leaving it in AWS Secrets Manager",
+						"secretName", secretName,
+						"region", region)
+				} else {
+					logger.Info("Deleting secret from AWS Secrets Manager",
+						"secretName", secretName,
+						"secretID", secretID,
+						"region", region)
+
+					if err := awsClient.DeleteSecret(ctx, secretID, true); err != nil {
+						// Ignore ResourceNotFoundException - secret doesn't exist, which is fine
+						if !isAWSResourceNotFoundError(err) {
+							logger.Error(err, "Failed to delete secret from AWS Secrets Manager",
+								"secretName", secretName,
+								"region", region)
+							fail(ReasonSecretDeleteFailed, fmt.Errorf("failed to delete secret %s: %w", secretName, err))
+						} else {
+							logger.Info("Secret does not exist, skipping deletion",
+								"secretName", secretName,
+								"region", region)
+						}
 					} else {
-						logger.Info("Secret does not exist, skipping deletion",
+						secretDeleted = true
+						logger.Info("Secret deleted successfully from AWS Secrets Manager",
 							"secretName", secretName,
 							"region", region)
+						r.publishSecretEvent(db, SecretEventDeleted, NotificationSecret{Name: secretName, ARN: db.Status.SecretARN, Region: region},
+							"Secret %s deleted", secretName)
 					}
-				} else {
-					secretDeleted = true
-					logger.Info("Secret deleted successfully from AWS Secrets Manager",
-						"secretName", secretName,
-						"region", region)
+				}
+
+				if err := r.deleteMigrationSecret(ctx, db, awsClient); err != nil {
+					logger.Error(err, "Failed to delete secret of migration user")
+					fail(ReasonSecretDeleteFailed, err)
 				}
 			}
 		}
 
-		// If there were any cleanup errors, return them to retry
 		if len(cleanupErrors) > 0 {
-			logger.Error(fmt.Errorf("cleanup failed with %d errors", len(cleanupErrors)), "Cleanup errors occurred - will retry",
+			cleanupErr := errors.Join(cleanupErrors...)
+			if db.Spec.DeletionFailurePolicy != databasev1alpha1.DeletionFailurePolicyOrphan {
+				logger.Error(cleanupErr, "Cleanup errors occurred - will retry",
+					"reason", blockedReason,
+					"databaseDeleted", databaseDeleted,
+					"userDeleted", userDeleted,
+					"secretDeleted", secretDeleted)
+				r.setDeletionBlocked(ctx, db, blockedReason, cleanupErr)
+				// Return the first error to trigger retry
+				return ctrl.Result{}, cleanupErrors[0]
+			}
+
+			logger.Error(cleanupErr, "Cleanup failed, orphaning remaining resources (deletionFailurePolicy=Orphan)",
+				"reason", blockedReason,
 				"databaseDeleted", databaseDeleted,
 				"userDeleted", userDeleted,
 				"secretDeleted", secretDeleted)
-			// Return the first error to trigger retry
-			return ctrl.Result{}, cleanupErrors[0]
+			r.recordEvent(db, corev1.EventTypeWarning, EventReasonDeletionOrphaned,
+				"Cleanup failed, resources left behind: %s", normalizeErrorMessage(cleanupErr.Error()))
+			controllerutil.RemoveFinalizer(db, DatabaseFinalizer)
+			return ctrl.Result{}, r.updateObject(ctx, db)
 		}
 
 		logger.Info("Cleanup completed successfully",
@@ -962,18 +1726,132 @@ func (r *DatabaseReconciler) reconcileDelete(ctx context.Context, db *databasev1
 			"databaseRetained", db.Status.DatabaseCreated,
 			"userRetained", db.Status.UserCreated,
 			"secretRetained", db.Status.SecretCreated)
+
+		if err := r.releaseRetainedSecrets(ctx, db); err != nil {
+			if db.Spec.DeletionFailurePolicy != databasev1alpha1.DeletionFailurePolicyOrphan {
+				logger.Error(err, "Failed to release retained secrets - will retry")
+				r.setDeletionBlocked(ctx, db, ReasonSecretReleaseFailed, err)
+				return ctrl.Result{}, err
+			}
+			logger.Error(err, "Failed to release retained secrets, orphaning them (deletionFailurePolicy=Orphan)")
+			r.recordEvent(db, corev1.EventTypeWarning, EventReasonDeletionOrphaned,
+				"Retained secrets keep the managed-by tag: %s", normalizeErrorMessage(err.Error()))
+		}
+	}
+
+	if retainOnDelete {
+		r.recordNormal(db, EventReasonDeleted, "Database resource deleted, database %s, user and secret retained", db.Spec.DatabaseName)
+	} else {
+		r.recordNormal(db, EventReasonDeleted, "Database %s, user and secret deleted", db.Spec.DatabaseName)
 	}
 
 	controllerutil.RemoveFinalizer(db, DatabaseFinalizer)
-	return ctrl.Result{}, r.Update(ctx, db)
+	return ctrl.Result{}, r.updateObject(ctx, db)
+}
+
+// releaseRetainedSecrets removes the managed-by tag from the secrets of a Database retained on delete
+// No Database references them anymore, with the tag the secret garbage collector would delete them.
+// Secrets managed externally never carry the tag, and a dry run writes nothing.
+func (r *DatabaseReconciler) releaseRetainedSecrets(ctx context.Context, db *databasev1alpha1.Database) error {
+	if r.ManagedByTagKey == "" || r.isDryRun(db) {
+		return nil
+	}
+	var secretIDs []string
+	region := r.getRegion(db)
+	if db.Status.SecretCreated && !externalSecretManaged(db) {
+		secretName := db.Status.ActualSecretName
+		if secretName == "" {
+			secretName = r.getSecretNameOrDefault(db)
+		}
+		secretIDs = append(secretIDs, resolveSecretID(db, secretName, region))
+	}
+	if migrator := db.Status.MigrationUser; migrator != nil && migrator.SecretName != "" {
+		secretIDs = append(secretIDs, migrator.SecretName)
+	}
+	if len(secretIDs) == 0 {
+		return nil
+	}
+
+	if err := r.validateRegion(region); err != nil {
+		return fmt.Errorf("invalid AWS region %s: %w", region, err)
+	}
+	awsClient, err := r.awsClient(ctx, region)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+	for _, secretID := range secretIDs {
+		log.FromContext(ctx).Info("Removing managed-by tag from retained secret", "secretID", secretID)
+		if err := awsClient.UntagSecret(ctx, secretID, []string{r.ManagedByTagKey}); err != nil && !isAWSResourceNotFoundError(err) {
+			return fmt.Errorf("failed to remove tag %s from secret %s: %w", r.ManagedByTagKey, secretID, err)
+		}
+	}
+	return nil
+}
+
+// setDeletionBlocked reports a failed cleanup in the DeletionBlocked condition and, when the
+// condition changed, in a Warning event
+func (r *DatabaseReconciler) setDeletionBlocked(ctx context.Context, db *databasev1alpha1.Database, reason string, err error) {
+	message, changed := setDeletionBlockedCondition(&db.Status.Conditions, db.Generation, reason, err)
+	if !changed {
+		return
+	}
+	if statusErr := r.updateStatus(ctx, db); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "Failed to update deletion status")
+	}
+	r.recordEvent(db, corev1.EventTypeWarning, EventReasonDeletionBlocked,
+		"Cleanup failed, finalizer kept until it succeeds: %s", message)
+}
+
+// reportDeletionBlocked reports the failed cleanup of a DatabaseRole or DatabaseGrant like setDeletionBlocked
+// conditions are the status conditions of obj
+func (r *DatabaseReconciler) reportDeletionBlocked(ctx context.Context, obj client.Object, conditions *[]metav1.Condition, reason string, err error) {
+	logger := log.FromContext(ctx)
+	logger.Error(err, "Cleanup failed - will retry", "reason", reason)
+
+	message, changed := setDeletionBlockedCondition(conditions, obj.GetGeneration(), reason, err)
+	if !changed {
+		return
+	}
+	if statusErr := r.Status().Update(ctx, obj); statusErr != nil {
+		logger.Error(statusErr, "Failed to update deletion status")
+	}
+	r.Recorder.Event(obj, corev1.EventTypeWarning, EventReasonDeletionBlocked,
+		"Cleanup failed, finalizer kept until it succeeds: "+message)
+}
+
+// setDeletionBlockedCondition sets the DeletionBlocked condition to a failed cleanup
+// It returns the normalized error message and whether the condition changed.
+func setDeletionBlockedCondition(conditions *[]metav1.Condition, generation int64, reason string, err error) (string, bool) {
+	message := normalizeErrorMessage(err.Error())
+	return message, meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionDeletionBlocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
 }
 
+// getConnectionString returns the admin connection string of a Database with spec.connectionParams set
 func (r *DatabaseReconciler) getConnectionString(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
+	connectionString, err := r.adminConnectionString(ctx, db)
+	if err != nil || len(db.Spec.ConnectionParams) == 0 {
+		return connectionString, err
+	}
+	connectionString, err = database.WithConnectionParams(string(db.Spec.Engine), connectionString, db.Spec.ConnectionParams)
+	if err != nil {
+		return "", newConfigError(fmt.Errorf("spec.connectionParams: %w", err))
+	}
+	return connectionString, nil
+}
+
+// adminConnectionString reads or assembles the admin connection string from its configured source
+func (r *DatabaseReconciler) adminConnectionString(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
 	logger := log.FromContext(ctx)
 
 	// Validate that only one source is configured
 	if err := validateConnectionSource(db); err != nil {
-		return "", err
+		return "", newConfigError(err)
 	}
 
 	// Check which source is configured
@@ -984,6 +1862,12 @@ func (r *DatabaseReconciler) getConnectionString(ctx context.Context, db *databa
 		return r.getConnectionStringFromK8sSecret(ctx, db)
 	}
 
+	if db.Spec.AdminConnection != nil {
+		logger.Info("Assembling admin connection string from spec.adminConnection",
+			"database", db.Spec.DatabaseName)
+		return r.getConnectionStringFromAdminConnection(ctx, db)
+	}
+
 	// Must be AWS secret (already validated)
 	logger.Info("Using AWS Secrets Manager for admin connection string",
 		"database", db.Spec.DatabaseName,
@@ -993,12 +1877,16 @@ func (r *DatabaseReconciler) getConnectionString(ctx context.Context, db *databa
 }
 
 func (r *DatabaseReconciler) getConnectionStringFromK8sSecret(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
+	namespace, err := r.secretNamespace(ctx, db)
+	if err != nil {
+		return "", err
+	}
 	secret := &corev1.Secret{}
-	if err := r.Get(ctx, client.ObjectKey{Name: db.Spec.ConnectionStringSecretRef.Name, Namespace: db.Namespace}, secret); err != nil {
+	if err := r.Get(ctx, client.ObjectKey{Name: db.Spec.ConnectionStringSecretRef.Name, Namespace: namespace}, secret); err != nil {
 		return "", err
 	}
 	key := getSecretKeyOrDefault(db.Spec.ConnectionStringSecretRef)
-	connectionString := string(secret.Data[key])
+	connectionString := database.SanitizeConnectionString(string(secret.Data[key]))
 	if connectionString == "" {
 		return "", fmt.Errorf("connection string is empty in secret %s key %s", db.Spec.ConnectionStringSecretRef.Name, key)
 	}
@@ -1010,14 +1898,14 @@ func (r *DatabaseReconciler) getConnectionStringFromAWSSecret(ctx context.Contex
 	awsRef := db.Spec.ConnectionStringAWSSecretRef
 
 	// Validate region
-	if err := secrets.ValidateRegion(awsRef.Region); err != nil {
+	if err := r.validateRegion(awsRef.Region); err != nil {
 		return "", fmt.Errorf("invalid AWS region for admin connection string: %w", err)
 	}
 
 	logger.Info("Creating AWS Secrets Manager client for admin credentials",
 		"database", db.Spec.DatabaseName,
 		"region", awsRef.Region)
-	awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, awsRef.Region)
+	awsClient, err := r.awsClient(ctx, awsRef.Region)
 	if err != nil {
 		return "", fmt.Errorf("failed to create AWS Secrets Manager client (ensure pod has AWS permissions): %w", err)
 	}
@@ -1054,20 +1942,36 @@ func (r *DatabaseReconciler) getConnectionStringFromAWSSecret(ctx context.Contex
 			return "", fmt.Errorf("value for key %s in AWS secret is not a string", key)
 		}
 
-		return connectionString, nil
+		return database.SanitizeConnectionString(connectionString), nil
 	}
 
 	// If not JSON, return the raw value (useful for simple string secrets)
+	secretValue = database.SanitizeConnectionString(secretValue)
+	if secretValue == "" {
+		return "", fmt.Errorf("connection string is empty in AWS secret %s", awsRef.SecretName)
+	}
 	return secretValue, nil
 }
 
+// databaseURL returns the database URL of a user of a Database with spec.connectionParams appended
+func databaseURL(db *databasev1alpha1.Database, username, password, host string, port int) string {
+	return database.URLWithParams(secrets.DatabaseURL(string(db.Spec.Engine), username, password, host, port, db.Spec.DatabaseName),
+		db.Spec.ConnectionParams)
+}
+
 // validateConnectionSource validates that only one connection string source is configured
 func validateConnectionSource(db *databasev1alpha1.Database) error {
 	if db.Spec.ConnectionStringSecretRef != nil && db.Spec.ConnectionStringAWSSecretRef != nil {
 		return fmt.Errorf("both ConnectionStringSecretRef and ConnectionStringAWSSecretRef are specified, only one is allowed")
 	}
+	if db.Spec.AdminConnection != nil {
+		if db.Spec.ConnectionStringSecretRef != nil || db.Spec.ConnectionStringAWSSecretRef != nil {
+			return fmt.Errorf("adminConnection cannot be combined with ConnectionStringSecretRef or ConnectionStringAWSSecretRef")
+		}
+		return validateAdminConnection(db.Spec.AdminConnection)
+	}
 	if db.Spec.ConnectionStringSecretRef == nil && db.Spec.ConnectionStringAWSSecretRef == nil {
-		return fmt.Errorf("neither ConnectionStringSecretRef nor ConnectionStringAWSSecretRef is specified")
+		return fmt.Errorf("neither ConnectionStringSecretRef, ConnectionStringAWSSecretRef nor AdminConnection is specified")
 	}
 	return nil
 }
@@ -1081,24 +1985,42 @@ func getSecretKeyOrDefault(ref *databasev1alpha1.SecretKeyReference) string {
 }
 
 // getUsernameOrDefault returns the username from the spec, or the database name if not specified
+// With spec.usernameLengthPolicy Truncate a name longer than the engine allows is truncated.
 func getUsernameOrDefault(db *databasev1alpha1.Database) string {
-	if db.Spec.Username != "" {
-		return db.Spec.Username
+	username := db.Spec.Username
+	if username == "" {
+		username = db.Spec.DatabaseName
 	}
-	return db.Spec.DatabaseName
+	if db.Spec.UsernameLengthPolicy == databasev1alpha1.UsernameLengthPolicyTruncate {
+		return database.TruncateUsername(username, database.MaxUsernameLength(string(db.Spec.Engine)))
+	}
+	return username
 }
 
-// needsReconciliation determines if the database resources need to be reconciled
-func needsReconciliation(db *databasev1alpha1.Database) bool {
-	const currentSecretFormatVersion = "v2"
+// fitUsername returns a username the engine of the Database accepts: name itself, or name truncated
+// with spec.usernameLengthPolicy Truncate. A longer name is an error otherwise.
+func fitUsername(db *databasev1alpha1.Database, name string) (string, error) {
+	maxLength := database.MaxUsernameLength(string(db.Spec.Engine))
+	if maxLength == 0 || len(name) <= maxLength {
+		return name, nil
+	}
+	if db.Spec.UsernameLengthPolicy == databasev1alpha1.UsernameLengthPolicyTruncate {
+		return database.TruncateUsername(name, maxLength), nil
+	}
+	return "", fmt.Errorf("username %s is %d characters long but %s allows at most %d, "+
+		"shorten it or set spec.usernameLengthPolicy to Truncate", name, len(name), db.Spec.Engine, maxLength)
+}
 
+// needsReconciliation determines if the database resources need to be reconciled
+func (r *DatabaseReconciler) needsReconciliation(db *databasev1alpha1.Database) bool {
 	// Need reconciliation if resources aren't created
 	if !db.Status.UserCreated || !db.Status.DatabaseCreated || !db.Status.SecretCreated {
 		return true
 	}
 
-	// Need reconciliation if generation changed (spec was updated)
-	if db.Status.ObservedGeneration != db.Generation {
+	// Need reconciliation if generation changed (spec was updated), unless the new generation
+	// normalizes to the spec the last successful reconciliation applied
+	if db.Status.ObservedGeneration != db.Generation && !r.specApplied(db) {
 		return true
 	}
 
@@ -1107,16 +2029,295 @@ func needsReconciliation(db *databasev1alpha1.Database) bool {
 		return true
 	}
 
+	// Need reconciliation until a region migration has deleted its source secret
+	if db.Status.RegionMigration != nil {
+		return true
+	}
+
+	// Need reconciliation to resume an interrupted multi-step operation
+	if db.Status.PendingOperation != nil {
+		return true
+	}
+
 	return false
 }
 
+// specApplied reports whether the Database is ready and its spec normalizes to status.specDigest
+func (r *DatabaseReconciler) specApplied(db *databasev1alpha1.Database) bool {
+	return db.Status.SpecDigest != "" &&
+		meta.IsStatusConditionTrue(db.Status.Conditions, ConditionReady) &&
+		db.Status.SpecDigest == r.specDigest(db)
+}
+
+// specDigest returns a hash of the spec fields that determine the database, user and secret
+// Defaults are filled in and lists sorted, so equivalent specs have the same digest.
+// retainOnDelete, deletionFailurePolicy, resyncInterval and ttl are left out, they are only read on
+// deletion and for requeueing, and so are dryRun, which does not change what is applied, and
+// cloneFrom, which is only read when the database is created.
+func (r *DatabaseReconciler) specDigest(db *databasev1alpha1.Database) string {
+	spec := db.Spec.DeepCopy()
+	spec.RetainOnDelete = nil
+	spec.DeletionFailurePolicy = ""
+	spec.ResyncInterval = nil
+	spec.TTL = nil
+	spec.DryRun = false
+	spec.CloneFrom = nil
+	spec.Username = getUsernameOrDefault(db)
+	spec.UsernameLengthPolicy = ""
+	spec.SecretName = r.getSecretNameOrDefault(db)
+	sort.Strings(spec.Privileges)
+	sort.Strings(spec.MemberOf)
+
+	// Marshalling a spec cannot fail, maps are encoded with sorted keys
+	data, _ := json.Marshal(spec)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // getSecretNameOrDefault returns the secret name from the spec, or generates a default path
-// Default format: rds/<engine>/<databaseName>
-func getSecretNameOrDefault(db *databasev1alpha1.Database) string {
+// Default format: <DefaultSecretPrefix><engine>/<databaseName>. A Database whose secret is stored
+// under another prefix keeps it, so changing the prefix does not move the secrets of existing Databases.
+func (r *DatabaseReconciler) getSecretNameOrDefault(db *databasev1alpha1.Database) string {
 	if db.Spec.SecretName != "" {
 		return db.Spec.SecretName
 	}
-	return fmt.Sprintf("rds/%s/%s", db.Spec.Engine, db.Spec.DatabaseName)
+	name := fmt.Sprintf("%s/%s", db.Spec.Engine, db.Spec.DatabaseName)
+	if strings.HasSuffix(db.Status.ActualSecretName, name) {
+		return db.Status.ActualSecretName
+	}
+	prefix := r.DefaultSecretPrefix
+	if prefix == "" {
+		prefix = secrets.DefaultSecretPrefix
+	}
+	return prefix + name
+}
+
+// validateSecretName validates spec.secretName when it is given as an ARN
+// The ARN must be well-formed and must not conflict with an explicitly configured region
+func validateSecretName(db *databasev1alpha1.Database) error {
+	if !secrets.IsSecretARN(db.Spec.SecretName) {
+		return nil
+	}
+	arn, err := secrets.ParseSecretARN(db.Spec.SecretName)
+	if err != nil {
+		return err
+	}
+	if db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Region != "" && db.Spec.AWSSecretsManager.Region != arn.Region {
+		return fmt.Errorf("secretName ARN is in region %s but awsSecretsManager.region is %s", arn.Region, db.Spec.AWSSecretsManager.Region)
+	}
+	return nil
+}
+
+// secretKeyNames returns the keys renamed by spec.secretKeyNames, nil keeps the default keys
+func secretKeyNames(db *databasev1alpha1.Database) *secrets.KeyNames {
+	return (*secrets.KeyNames)(db.Spec.SecretKeyNames)
+}
+
+// validateSecretKeyNames checks that spec.secretKeyNames is not combined with a template and
+// does not give two keys the same name
+func validateSecretKeyNames(db *databasev1alpha1.Database) error {
+	if db.Spec.SecretKeyNames == nil {
+		return nil
+	}
+	if db.Spec.SecretTemplate != "" {
+		return fmt.Errorf("spec.secretKeyNames cannot be combined with spec.secretTemplate")
+	}
+	if err := secretKeyNames(db).Validate(string(db.Spec.Engine)); err != nil {
+		return fmt.Errorf("invalid spec.secretKeyNames: %w", err)
+	}
+	return nil
+}
+
+// connectionStringOnly reports whether the secret holds the plain database URL instead of JSON
+func connectionStringOnly(db *databasev1alpha1.Database) bool {
+	return db.Spec.SecretFormat == databasev1alpha1.SecretFormatConnectionStringOnly
+}
+
+// validateSecretFormat checks that spec.secretFormat connectionStringOnly is not combined with
+// settings of the JSON format or with a private key, which the connection string cannot hold
+func validateSecretFormat(db *databasev1alpha1.Database) error {
+	if !connectionStringOnly(db) {
+		return nil
+	}
+	switch {
+	case db.Spec.SecretTemplate != "":
+		return fmt.Errorf("spec.secretFormat connectionStringOnly cannot be combined with spec.secretTemplate")
+	case db.Spec.SecretKeyNames != nil:
+		return fmt.Errorf("spec.secretFormat connectionStringOnly cannot be combined with spec.secretKeyNames")
+	case db.Spec.Snowflake != nil && db.Spec.Snowflake.KeyPair:
+		return fmt.Errorf("spec.secretFormat connectionStringOnly cannot store the private key of spec.snowflake.keyPair")
+	}
+	return nil
+}
+
+// validateSecretBinary checks that the operator can read the password back from a spec.secretBinary
+// secret rendered by spec.secretTemplate, see secrets.ValidateBinaryTemplate
+func validateSecretBinary(db *databasev1alpha1.Database) error {
+	if !db.Spec.SecretBinary || db.Spec.SecretTemplate == "" {
+		return nil
+	}
+	if err := secrets.ValidateBinaryTemplate(db.Spec.SecretTemplate, string(db.Spec.Engine)); err != nil {
+		return fmt.Errorf("invalid spec.secretTemplate for spec.secretBinary: %w", err)
+	}
+	return nil
+}
+
+// validateAWSPartitions checks that all AWS references of a Database are in the same partition
+// The operator uses a single credential chain, and AWS credentials are only valid within one partition
+func validateAWSPartitions(db *databasev1alpha1.Database) error {
+	regions := make(map[string]string)
+	if db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Region != "" {
+		regions["spec.awsSecretsManager.region"] = db.Spec.AWSSecretsManager.Region
+	}
+	if secrets.IsSecretARN(db.Spec.SecretName) {
+		if arn, err := secrets.ParseSecretARN(db.Spec.SecretName); err == nil {
+			regions["spec.secretName"] = arn.Region
+		}
+	}
+	if ref := db.Spec.ConnectionStringAWSSecretRef; ref != nil {
+		if secrets.IsSecretARN(ref.SecretName) {
+			arn, err := secrets.ParseSecretARN(ref.SecretName)
+			if err != nil {
+				return fmt.Errorf("invalid connectionStringAWSSecretRef.secretName: %w", err)
+			}
+			if ref.Region != "" && arn.Region != ref.Region {
+				return fmt.Errorf("connectionStringAWSSecretRef.secretName ARN is in region %s but connectionStringAWSSecretRef.region is %s", arn.Region, ref.Region)
+			}
+		}
+		if ref.Region != "" {
+			regions["spec.connectionStringAWSSecretRef.region"] = ref.Region
+		}
+	}
+	for _, ref := range adminConnectionAWSSecretRefs(db.Spec.AdminConnection) {
+		if ref.Region != "" {
+			regions["spec.adminConnection"] = ref.Region
+		}
+	}
+
+	// Compare in a stable order so the error message is deterministic
+	fields := make([]string, 0, len(regions))
+	for field := range regions {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for i := 1; i < len(fields); i++ {
+		first, other := regions[fields[0]], regions[fields[i]]
+		if secrets.PartitionForRegion(first) != secrets.PartitionForRegion(other) {
+			return fmt.Errorf("%s (%s, partition %s) and %s (%s, partition %s) are in different AWS partitions, which is not supported",
+				fields[0], first, secrets.PartitionForRegion(first), fields[i], other, secrets.PartitionForRegion(other))
+		}
+	}
+	return nil
+}
+
+// setSecretARN stores the ARN of the secret with the account ID and partition it belongs to, so
+// multi-account platforms can verify the secret was written into the intended account
+func setSecretARN(status *databasev1alpha1.DatabaseStatus, secretARN string) {
+	status.SecretARN = secretARN
+	status.SecretAccountID = ""
+	status.SecretPartition = ""
+	if arn, err := secrets.ParseSecretARN(secretARN); err == nil {
+		status.SecretAccountID = arn.AccountID
+		status.SecretPartition = arn.Partition
+	}
+}
+
+// resolveSecretID returns the identifier to use for AWS calls on the given secret name in the given region
+// ARNs in the spec are used as-is. Otherwise the ARN stored in status is preferred when it refers to the same
+// secret in the same region, since AWS may normalize characters in names. Falls back to the plain name.
+func resolveSecretID(db *databasev1alpha1.Database, secretName, region string) string {
+	if secrets.IsSecretARN(secretName) {
+		return secretName
+	}
+	if db.Status.SecretARN == "" {
+		return secretName
+	}
+	arn, err := secrets.ParseSecretARN(db.Status.SecretARN)
+	if err != nil || !arn.MatchesName(secretName) {
+		return secretName
+	}
+	if region != "" && arn.Region != region {
+		return secretName
+	}
+	return db.Status.SecretARN
+}
+
+// previousSecretID returns the identifier of the secret recorded in status, preferring the ARN
+func previousSecretID(db *databasev1alpha1.Database) string {
+	if db.Status.SecretARN != "" {
+		return db.Status.SecretARN
+	}
+	return db.Status.ActualSecretName
+}
+
+// syncSecretTags brings the tags of a secret in line with the spec and reports the result in the
+// TagsSynced condition. If the current tags cannot be read, the desired tags are still applied, but
+// no tag is removed: the stale tags are unknown.
+func (r *DatabaseReconciler) syncSecretTags(ctx context.Context, db *databasev1alpha1.Database, awsClient *secrets.AWSSecretsManagerClient, secretID, secretName string) error {
+	logger := log.FromContext(ctx)
+	desiredTags := r.desiredSecretTags(db)
+
+	existingTags, readErr := awsClient.GetSecretTags(ctx, secretID)
+	if readErr != nil {
+		logger.Error(readErr, "Failed to get existing secret tags, applying desired tags without removing stale tags",
+			"secretName", secretName)
+	}
+
+	// Remove unwanted tags
+	if tagsToRemove := getTagsToRemove(existingTags, desiredTags); readErr == nil && len(tagsToRemove) > 0 &&
+		!plannedChange(ctx, "remove tags %s from secret %s", sortedList(tagsToRemove), secretName) {
+		logger.Info("Removing tags from secret in AWS Secrets Manager",
+			"secretName", secretName,
+			"tagsToRemove", tagsToRemove)
+		if err := awsClient.UntagSecret(ctx, secretID, tagsToRemove); err != nil {
+			r.setTagsNotSynced(db, ReasonTagsWriteFailed, secretName, err)
+			return fmt.Errorf("failed to remove secret tags: %w", err)
+		}
+	}
+
+	// Add or update desired tags, all of them if the current tags are unknown
+	tagsToAdd := desiredTags
+	if readErr == nil {
+		tagsToAdd = getTagsToAdd(existingTags, desiredTags)
+	}
+	if len(tagsToAdd) > 0 && !plannedChange(ctx, "set tags %s on secret %s", formatTags(tagsToAdd), secretName) {
+		logger.Info("Updating secret tags in AWS Secrets Manager",
+			"secretName", secretName,
+			"tagsToAdd", tagsToAdd)
+		if err := awsClient.TagSecret(ctx, secretID, tagsToAdd); err != nil {
+			r.setTagsNotSynced(db, ReasonTagsWriteFailed, secretName, err)
+			return fmt.Errorf("failed to update secret tags: %w", err)
+		}
+	}
+
+	if readErr != nil {
+		r.setTagsNotSynced(db, ReasonTagsReadFailed, secretName, readErr)
+		return nil
+	}
+	if !tagsEqual(existingTags, desiredTags) {
+		r.recordNormal(db, EventReasonTagsSynced, "Tags of secret %s synced", secretName)
+	}
+	meta.SetStatusCondition(&db.Status.Conditions, metav1.Condition{
+		Type:               ConditionTagsSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonReconciled,
+		Message:            fmt.Sprintf("Tags of secret %s match the spec", secretName),
+		ObservedGeneration: db.Generation,
+	})
+	return nil
+}
+
+// setTagsNotSynced sets the TagsSynced condition to false and records a Warning event
+func (r *DatabaseReconciler) setTagsNotSynced(db *databasev1alpha1.Database, reason, secretName string, err error) {
+	message := normalizeErrorMessage(err.Error())
+	meta.SetStatusCondition(&db.Status.Conditions, metav1.Condition{
+		Type:               ConditionTagsSynced,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: db.Generation,
+	})
+	r.recordEvent(db, corev1.EventTypeWarning, EventReasonTagSyncFailed, "Tags of secret %s not synced: %s", secretName, message)
 }
 
 // tagsEqual compares two tag maps and returns true if they are equal
@@ -1155,46 +2356,50 @@ func getTagsToAdd(current, desired map[string]string) map[string]string {
 	return toAdd
 }
 
+// desiredSecretTags returns the tags that should be present on the AWS secret
+// The managed-by tag is added first so that user-specified tags in the spec can override it
+func (r *DatabaseReconciler) desiredSecretTags(db *databasev1alpha1.Database) map[string]string {
+	tags := make(map[string]string)
+	if r.ManagedByTagKey != "" {
+		tags[r.ManagedByTagKey] = r.ManagedByTagValue
+	}
+	if db.Spec.AWSSecretsManager != nil {
+		for k, v := range db.Spec.AWSSecretsManager.Tags {
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
+// validateRegion validates an AWS region
+// With SkipRegionValidation only the region format is checked, so regions outside of the
+// known partition patterns (e.g. a new geography) are accepted as well
+func (r *DatabaseReconciler) validateRegion(region string) error {
+	if r.SkipRegionValidation {
+		return newConfigError(secrets.ValidateRegionFormat(region))
+	}
+	return newConfigError(secrets.ValidateRegion(region))
+}
+
 // getRegion determines the AWS region from the Database spec
-// Priority: spec.awsSecretsManager.region > spec.connectionStringAWSSecretRef.region > empty (AWS SDK default)
+// Priority: spec.awsSecretsManager.region > region of spec.secretName ARN > spec.connectionStringAWSSecretRef.region >
+// region of the AWS secrets of spec.adminConnection > empty (AWS SDK default)
 func (r *DatabaseReconciler) getRegion(db *databasev1alpha1.Database) string {
 	if db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Region != "" {
 		return db.Spec.AWSSecretsManager.Region
 	}
+	if secrets.IsSecretARN(db.Spec.SecretName) {
+		if arn, err := secrets.ParseSecretARN(db.Spec.SecretName); err == nil {
+			return arn.Region
+		}
+	}
 	if db.Spec.ConnectionStringAWSSecretRef != nil && db.Spec.ConnectionStringAWSSecretRef.Region != "" {
 		return db.Spec.ConnectionStringAWSSecretRef.Region
 	}
-	return "" // Empty string means use AWS SDK default
-}
-
-// isAWSPermissionError checks if an error is an AWS permission/authorization error
-func isAWSPermissionError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	// Check error message for permission-related keywords
-	errMsg := strings.ToLower(err.Error())
-	return strings.Contains(errMsg, "accessdeniedexception") ||
-		strings.Contains(errMsg, "accessdenied") ||
-		strings.Contains(errMsg, "access denied") ||
-		strings.Contains(errMsg, "not authorized") ||
-		strings.Contains(errMsg, "insufficient permissions") ||
-		strings.Contains(errMsg, "forbidden") ||
-		strings.Contains(errMsg, "unauthorizedoperation")
-}
-
-// isAWSResourceNotFoundError checks if an error is an AWS ResourceNotFoundException
-func isAWSResourceNotFoundError(err error) bool {
-	if err == nil {
-		return false
+	if refs := adminConnectionAWSSecretRefs(db.Spec.AdminConnection); len(refs) > 0 {
+		return refs[0].Region
 	}
-
-	// Check error message for resource not found keywords
-	errMsg := strings.ToLower(err.Error())
-	return strings.Contains(errMsg, "resourcenotfoundexception") ||
-		strings.Contains(errMsg, "resource not found") ||
-		strings.Contains(errMsg, "can't find the specified secret")
+	return "" // Empty string means use AWS SDK default
 }
 
 // normalizeErrorMessage removes dynamic parts from error messages (like AWS RequestIDs)
@@ -1212,14 +2417,270 @@ func normalizeErrorMessage(errMsg string) string {
 }
 
 func (r *DatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := setupAdminSecretIndex(mgr, &databasev1alpha1.Database{}, func(obj client.Object) (*databasev1alpha1.DatabaseSpec, string) {
+		db := obj.(*databasev1alpha1.Database)
+		return &db.Spec, db.Namespace
+	}); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.Database{}, builder.WithPredicates(r.Shard.Predicate())).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.databasesForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		WithOptions(controllerOptions())
+	if r.AdminSecretWatcher != nil {
+		b = b.WatchesRawSource(r.AdminSecretWatcher.databaseSource())
+	}
+	return b.Complete(r)
+}
+
+// reconcileAccountOptions applies spec.mariadb to the user
+// The options are applied on every reconciliation, so changes made on the server are reverted
+func (r *DatabaseReconciler) reconcileAccountOptions(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, username string) error {
+	cfg := db.Spec.MariaDB
+	if cfg == nil {
+		return nil
+	}
+	if database.EngineFamily(string(db.Spec.Engine)) != "mysql" {
+		return newConfigError(fmt.Errorf("spec.mariadb requires the mysql or mariadb engine, got %s", db.Spec.Engine))
+	}
+
+	opts := database.AccountOptions{
+		Locked:                     cfg.AccountLocked,
+		PasswordExpireIntervalDays: cfg.PasswordExpireIntervalDays,
+	}
+	if err := dbClient.SetAccountOptions(ctx, username, opts); err != nil {
+		if errors.Is(err, database.ErrAccountOptionsNotSupported) {
+			return newConfigError(fmt.Errorf("spec.mariadb: %w", err))
+		}
+		return err
+	}
+	log.FromContext(ctx).V(1).Info("Applied MariaDB account options", "username", username)
+	return nil
+}
+
+// reconcileRoleSettings applies spec.postgres.roleSettings to the user and resets the parameters
+// that were removed from it since the last reconciliation
+func (r *DatabaseReconciler) reconcileRoleSettings(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, username string) error {
+	var settings map[string]string
+	if db.Spec.Postgres != nil {
+		settings = db.Spec.Postgres.RoleSettings
+	}
+	if len(settings) == 0 && len(db.Status.RoleSettings) == 0 {
+		return nil
+	}
+	if len(settings) > 0 && database.EngineFamily(string(db.Spec.Engine)) != "postgres" {
+		return newConfigError(fmt.Errorf("spec.postgres requires the postgres, postgresql or yugabyte engine, got %s", db.Spec.Engine))
+	}
+	if err := database.ValidateRoleSettings(settings); err != nil {
+		return newConfigError(err)
+	}
+
+	var reset []string
+	for _, name := range db.Status.RoleSettings {
+		if _, ok := settings[name]; !ok {
+			reset = append(reset, name)
+		}
+	}
+	if err := dbClient.SetRoleSettings(ctx, username, settings, reset); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		names = nil
+	}
+	log.FromContext(ctx).V(1).Info("Applied role settings", "username", username, "settings", names, "reset", reset)
+	db.Status.RoleSettings = names
+	return nil
+}
+
+// reconcilePrivileges grants the privileges of spec.privilegePreset to the user, or all privileges
+// without a preset, and revokes the privileges granted earlier that are no longer part of it
+func (r *DatabaseReconciler) reconcilePrivileges(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, username string) error {
+	logger := log.FromContext(ctx)
+
+	if db.Spec.PrivilegePreset == "" {
+		logger.Info("Granting privileges",
+			"database", db.Spec.DatabaseName,
+			"username", username,
+			"privileges", []string{"ALL"})
+		if err := dbClient.GrantAllPrivileges(ctx, db.Spec.DatabaseName, username); err != nil {
+			return err
+		}
+		logger.Info("Privileges granted successfully",
+			"database", db.Spec.DatabaseName,
+			"username", username)
+		db.Status.GrantedPrivileges = []string{"ALL"}
+		return nil
+	}
+
+	privileges, err := database.PresetPrivileges(string(db.Spec.Engine), string(db.Spec.PrivilegePreset))
+	if err != nil {
+		return newConfigError(fmt.Errorf("spec.privilegePreset: %w", err))
+	}
+	desired := normalizePrivileges(privileges)
+
+	// Users reconciled before the status recorded their privileges were granted ALL
+	granted := db.Status.GrantedPrivileges
+	if len(granted) == 0 && db.Status.ObservedGeneration > 0 {
+		granted = []string{"ALL"}
+	}
+	var removed []string
+	for _, privilege := range granted {
+		if !slices.Contains(desired, privilege) {
+			removed = append(removed, privilege)
+		}
+	}
+	if len(removed) > 0 {
+		if err := requireMaintenanceWindow(ctx, db, "revoking %s from %s", strings.Join(removed, ", "), username); err != nil {
+			return err
+		}
+		logger.Info("Revoking privileges",
+			"database", db.Spec.DatabaseName,
+			"username", username,
+			"privileges", removed)
+		if err := dbClient.RevokeDatabasePrivileges(ctx, db.Spec.DatabaseName, username, removed); err != nil {
+			return err
+		}
+		r.recordNormal(db, EventReasonPrivilegesRevoked, "Revoked %s on %s from %s",
+			strings.Join(removed, ", "), db.Spec.DatabaseName, username)
+	}
+
+	logger.Info("Granting privileges",
+		"database", db.Spec.DatabaseName,
+		"username", username,
+		"preset", db.Spec.PrivilegePreset,
+		"privileges", desired)
+	if err := dbClient.GrantDatabasePrivileges(ctx, db.Spec.DatabaseName, username, desired); err != nil {
+		return err
+	}
+	db.Status.GrantedPrivileges = desired
+	return nil
+}
+
+// reconcileDatabaseConnectionLimit applies spec.postgres.databaseConnectionLimit to the database
+func (r *DatabaseReconciler) reconcileDatabaseConnectionLimit(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client) error {
+	if db.Spec.Postgres == nil || db.Spec.Postgres.DatabaseConnectionLimit == nil {
+		return nil
+	}
+	if database.EngineFamily(string(db.Spec.Engine)) != "postgres" {
+		return newConfigError(fmt.Errorf("spec.postgres requires the postgres, postgresql or yugabyte engine, got %s", db.Spec.Engine))
+	}
+
+	limit := *db.Spec.Postgres.DatabaseConnectionLimit
+	if err := dbClient.SetDatabaseConnectionLimit(ctx, db.Spec.DatabaseName, limit); err != nil {
+		return err
+	}
+	log.FromContext(ctx).V(1).Info("Applied database connection limit", "database", db.Spec.DatabaseName, "limit", limit)
+	return nil
+}
+
+// reconcileSnowflake applies spec.snowflake to the user and returns the private key to store in the secret
+// privateKey is the key stored in the secret so far, it is kept as long as key-pair authentication is enabled
+func (r *DatabaseReconciler) reconcileSnowflake(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, username, privateKey string) (string, error) {
+	logger := log.FromContext(ctx)
+
+	cfg := db.Spec.Snowflake
+	if cfg == nil {
+		cfg = &databasev1alpha1.SnowflakeConfig{}
+	} else if database.EngineFamily(string(db.Spec.Engine)) != "snowflake" {
+		return "", newConfigError(fmt.Errorf("spec.snowflake requires the snowflake engine, got %s", db.Spec.Engine))
+	}
+
+	if cfg.Warehouse != "" {
+		if err := dbClient.SetDefaultWarehouse(ctx, username, cfg.Warehouse); err != nil {
+			return "", err
+		}
+	}
+
+	if !cfg.KeyPair {
+		if privateKey != "" {
+			if err := dbClient.SetPublicKey(ctx, username, ""); err != nil {
+				return "", err
+			}
+			logger.Info("Removed the public key of the disabled key-pair authentication", "username", username)
+		}
+		return "", nil
+	}
+
+	publicKey, err := database.PublicKeyFromPrivateKey(privateKey)
+	if err != nil {
+		if privateKey != "" {
+			logger.Error(err, "Private key in the secret is invalid, generating a new key pair", "username", username)
+		}
+		if privateKey, err = database.GenerateKeyPair(); err != nil {
+			return "", err
+		}
+		if publicKey, err = database.PublicKeyFromPrivateKey(privateKey); err != nil {
+			return "", err
+		}
+		logger.Info("Generated key pair", "username", username)
+	}
+	if err := dbClient.SetPublicKey(ctx, username, publicKey); err != nil {
+		return "", err
+	}
+	return privateKey, nil
+}
+
+// newDatabaseClient connects to a database server with the configured connection pool settings
+// A server with a Cloud SQL instance is connected to through the Cloud SQL Go connector, and a server
+// with Microsoft Entra authentication with an access token instead of the password
+func (r *DatabaseReconciler) newDatabaseClient(ctx context.Context, engine, connectionString string, cloudSQL *databasev1alpha1.CloudSQLConfig, azure *databasev1alpha1.AzureConfig) (database.Client, error) {
+	if err := database.CheckConnectionStringEngine(engine, connectionString); err != nil {
+		return nil, newConfigError(err)
+	}
+	// DBAs tell the sessions of the operator apart by their application name
+	connectionString, err := database.WithOperatorApplicationName(engine, connectionString)
+	if err != nil {
+		return nil, newConfigError(err)
+	}
+	// A statement stuck on a lock must not hold the reconcile worker until the reconcile timeout
+	if connectionString, err = database.WithStatementTimeout(engine, connectionString, r.ConnectionPool.StatementTimeout); err != nil {
+		return nil, newConfigError(err)
+	}
+	// The Cloud SQL connector encrypts the connection itself
+	if cloudSQL == nil {
+		if connectionString, err = r.TLSPolicy.Apply(engine, connectionString); err != nil {
+			return nil, newConfigError(err)
+		}
+	}
+	entra := azure != nil && azure.EntraAuthentication
+	if cloudSQL == nil && !entra {
+		return database.NewClientWithPool(engine, connectionString, r.ConnectionPool)
+	}
+	if cloudSQL != nil && entra {
+		return nil, newConfigError(fmt.Errorf("spec.cloudSQL and spec.azure.entraAuthentication cannot be combined"))
+	}
+	switch strings.ToLower(engine) {
+	case "postgres", "postgresql", "mysql":
+	default:
+		if entra {
+			return nil, newConfigError(fmt.Errorf("spec.azure.entraAuthentication requires the postgres, postgresql or mysql engine, got %s", engine))
+		}
+		return nil, newConfigError(fmt.Errorf("spec.cloudSQL requires the postgres, postgresql or mysql engine, got %s", engine))
+	}
+	if entra {
+		return database.NewAzureEntraClient(ctx, engine, connectionString, r.ConnectionPool)
+	}
+	return database.NewCloudSQLClient(engine, connectionString, database.CloudSQLOptions{
+		InstanceConnectionName: cloudSQL.InstanceConnectionName,
+		IPType:                 cloudSQL.IPType,
+	}, r.ConnectionPool)
+}
+
+// controllerOptions returns the options shared by the Database and ClusterDatabase controllers
+func controllerOptions() controller.Options {
 	// Configure custom rate limiter with exponential backoff: 15s, 30s, 60s
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&databasev1alpha1.Database{}).
-		WithOptions(controller.Options{
-			RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](
-				15*time.Second, // Base delay: 15 seconds
-				60*time.Second, // Max delay: 60 seconds (caps at 60s after 2 retries)
-			),
-		}).
-		Complete(r)
+	return controller.Options{
+		RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](
+			errorBackoffBase, // Base delay: 15 seconds
+			errorBackoffMax,  // Max delay: 60 seconds (caps at 60s after 2 retries)
+		),
+	}
 }
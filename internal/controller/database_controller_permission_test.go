@@ -9,9 +9,32 @@ package controller
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"testing"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
+// apiError returns an AWS API error with the given code, wrapped like the AWS client wraps errors
+func apiError(code string) error {
+	return fmt.Errorf("failed to describe secret: %w", &smithy.GenericAPIError{Code: code, Message: "test"})
+}
+
+// responseError returns an AWS response error with the given HTTP status code
+func responseError(status int) error {
+	return &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+			Err:      errors.New("test"),
+		},
+	}
+}
+
 func TestIsAWSPermissionError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -25,33 +48,33 @@ func TestIsAWSPermissionError(t *testing.T) {
 		},
 		{
 			name:     "AccessDeniedException",
-			err:      errors.New("api error AccessDeniedException: User is not authorized to perform: secretsmanager:DescribeSecret"),
+			err:      apiError("AccessDeniedException"),
 			expected: true,
 		},
 		{
-			name:     "access denied lowercase",
-			err:      errors.New("access denied to resource"),
+			name:     "UnrecognizedClientException",
+			err:      apiError("UnrecognizedClientException"),
 			expected: true,
 		},
 		{
-			name:     "not authorized",
-			err:      errors.New("User is not authorized to perform this action"),
+			name:     "UnauthorizedOperation",
+			err:      apiError("UnauthorizedOperation"),
 			expected: true,
 		},
 		{
-			name:     "insufficient permissions",
-			err:      errors.New("insufficient permissions to access resource"),
+			name:     "HTTP 403 without known code",
+			err:      responseError(http.StatusForbidden),
 			expected: true,
 		},
 		{
-			name:     "forbidden",
-			err:      errors.New("403 Forbidden"),
-			expected: true,
+			name:     "expired token is not a permission error",
+			err:      apiError("ExpiredTokenException"),
+			expected: false,
 		},
 		{
-			name:     "UnauthorizedOperation",
-			err:      errors.New("UnauthorizedOperation: You are not authorized"),
-			expected: true,
+			name:     "message mentioning access denied",
+			err:      errors.New("access denied to resource"),
+			expected: false,
 		},
 		{
 			name:     "regular error",
@@ -60,7 +83,7 @@ func TestIsAWSPermissionError(t *testing.T) {
 		},
 		{
 			name:     "not found error",
-			err:      errors.New("resource not found"),
+			err:      &types.ResourceNotFoundException{},
 			expected: false,
 		},
 	}
@@ -74,3 +97,120 @@ func TestIsAWSPermissionError(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAWSResourceNotFoundError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "typed ResourceNotFoundException", err: fmt.Errorf("failed to get secret value: %w", &types.ResourceNotFoundException{}), expected: true},
+		{name: "generic ResourceNotFoundException", err: apiError("ResourceNotFoundException"), expected: true},
+		{name: "other API error", err: apiError("InvalidRequestException"), expected: false},
+		{name: "plain error", err: errors.New("resource not found"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isAWSResourceNotFoundError(tt.err); result != tt.expected {
+				t.Errorf("isAWSResourceNotFoundError(%v) = %v, expected %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAWSThrottlingError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "ThrottlingException", err: apiError("ThrottlingException"), expected: true},
+		{name: "TooManyRequestsException", err: apiError("TooManyRequestsException"), expected: true},
+		{name: "HTTP 429", err: responseError(http.StatusTooManyRequests), expected: true},
+		{name: "LimitExceededException is a quota, not throttling", err: apiError("LimitExceededException"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isAWSThrottlingError(tt.err); result != tt.expected {
+				t.Errorf("isAWSThrottlingError(%v) = %v, expected %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAWSExpiredTokenError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "ExpiredTokenException", err: apiError("ExpiredTokenException"), expected: true},
+		{name: "RequestExpired", err: apiError("RequestExpired"), expected: true},
+		{name: "AccessDeniedException", err: apiError("AccessDeniedException"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isAWSExpiredTokenError(tt.err); result != tt.expected {
+				t.Errorf("isAWSExpiredTokenError(%v) = %v, expected %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "secretsmanager.invalid"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "DNS error", err: fmt.Errorf("failed to ping database: %w", dnsErr), expected: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, expected: true},
+		{name: "AWS request send error", err: &smithyhttp.RequestSendError{Err: dnsErr}, expected: true},
+		{name: "API error", err: apiError("InternalServiceError"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isNetworkError(tt.err); result != tt.expected {
+				t.Errorf("isNetworkError(%v) = %v, expected %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorEventAndRequeue(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantEvent   string
+		wantRequeue bool
+	}{
+		{name: "permission", err: apiError("AccessDeniedException"), wantEvent: "PermissionError", wantRequeue: true},
+		{name: "expired token", err: apiError("ExpiredTokenException"), wantEvent: "CredentialsExpired", wantRequeue: true},
+		{name: "not found", err: &types.ResourceNotFoundException{}, wantEvent: "ResourceNotFound", wantRequeue: true},
+		{name: "throttling", err: apiError("ThrottlingException"), wantEvent: "Throttled", wantRequeue: true},
+		{name: "network", err: &net.DNSError{Err: "no such host"}, wantEvent: "NetworkError", wantRequeue: false},
+		{name: "other", err: errors.New("boom"), wantEvent: "ReconciliationError", wantRequeue: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := classifyError(tt.err)
+			if event, _ := errorEvent(tt.err, reason); event != tt.wantEvent {
+				t.Errorf("errorEvent() = %v, want %v", event, tt.wantEvent)
+			}
+			if _, ok := errorRequeue(tt.err, reason); ok != tt.wantRequeue {
+				t.Errorf("errorRequeue() ok = %v, want %v", ok, tt.wantRequeue)
+			}
+		})
+	}
+}
@@ -8,12 +8,22 @@ See LICENSE file in the project root for full license information.
 package controller
 
 import (
+	"context"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestGetRegion(t *testing.T) {
@@ -51,6 +61,19 @@ func TestGetRegion(t *testing.T) {
 			},
 			want: "ap-southeast-1",
 		},
+		{
+			name: "secretName ARN region when no awsSecretsManager region",
+			db: &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					SecretName: "arn:aws-us-gov:secretsmanager:us-gov-east-1:123456789012:secret:myapp-AbC123",
+					ConnectionStringAWSSecretRef: &databasev1alpha1.AWSSecretReference{
+						SecretName: "test-secret",
+						Region:     "us-east-1",
+					},
+				},
+			},
+			want: "us-gov-east-1",
+		},
 		{
 			name: "empty string when no AWS config specified",
 			db: &databasev1alpha1.Database{
@@ -279,7 +302,7 @@ func TestValidateConnectionSource(t *testing.T) {
 				Spec: databasev1alpha1.DatabaseSpec{},
 			},
 			wantErr: true,
-			errMsg:  "neither ConnectionStringSecretRef nor ConnectionStringAWSSecretRef is specified",
+			errMsg:  "neither ConnectionStringSecretRef, ConnectionStringAWSSecretRef nor AdminConnection is specified",
 		},
 	}
 
@@ -362,6 +385,27 @@ func TestGetUsernameOrDefault(t *testing.T) {
 			},
 			want: "myapp_db",
 		},
+		{
+			name: "long username is kept without truncation",
+			db: &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:       "mysql",
+					DatabaseName: "customer_order_history_reporting_service",
+				},
+			},
+			want: "customer_order_history_reporting_service",
+		},
+		{
+			name: "long username is truncated",
+			db: &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:               "mysql",
+					DatabaseName:         "customer_order_history_reporting_service",
+					UsernameLengthPolicy: databasev1alpha1.UsernameLengthPolicyTruncate,
+				},
+			},
+			want: database.TruncateUsername("customer_order_history_reporting_service", 32),
+		},
 	}
 
 	for _, tt := range tests {
@@ -374,7 +418,25 @@ func TestGetUsernameOrDefault(t *testing.T) {
 	}
 }
 
+func TestFitUsername(t *testing.T) {
+	db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{Engine: "mysql", DatabaseName: "orders"}}
+	long := "customer_order_history_reporting_service"
+
+	if got, err := fitUsername(db, "orders_migrator"); err != nil || got != "orders_migrator" {
+		t.Errorf("fitUsername() = %q, %v, want the name unchanged", got, err)
+	}
+	if _, err := fitUsername(db, long); err == nil || !strings.Contains(err.Error(), "usernameLengthPolicy") {
+		t.Errorf("fitUsername() error = %v, want it to suggest spec.usernameLengthPolicy", err)
+	}
+	db.Spec.UsernameLengthPolicy = databasev1alpha1.UsernameLengthPolicyTruncate
+	if got, err := fitUsername(db, long); err != nil || len(got) != 32 {
+		t.Errorf("fitUsername() = %q, %v, want a name of 32 characters", got, err)
+	}
+}
+
 func TestNeedsReconciliation(t *testing.T) {
+	r := &DatabaseReconciler{}
+
 	tests := []struct {
 		name string
 		db   *databasev1alpha1.Database
@@ -428,6 +490,47 @@ func TestNeedsReconciliation(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "region migration awaiting source cleanup",
+			db: &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Status: databasev1alpha1.DatabaseStatus{
+					UserCreated:         true,
+					DatabaseCreated:     true,
+					SecretCreated:       true,
+					ObservedGeneration:  1,
+					SecretFormatVersion: "v2",
+					RegionMigration: &databasev1alpha1.RegionMigrationStatus{
+						Phase:        "Verified",
+						SourceRegion: "us-east-1",
+						TargetRegion: "eu-west-1",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "interrupted provisioning",
+			db: &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Status: databasev1alpha1.DatabaseStatus{
+					UserCreated:         true,
+					DatabaseCreated:     true,
+					SecretCreated:       true,
+					ObservedGeneration:  1,
+					SecretFormatVersion: "v2",
+					PendingOperation: &databasev1alpha1.PendingOperation{
+						Type: "Provision",
+						Step: "store-secret",
+					},
+				},
+			},
+			want: true,
+		},
 		{
 			name: "no reconciliation needed",
 			db: &databasev1alpha1.Database{
@@ -448,7 +551,7 @@ func TestNeedsReconciliation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := needsReconciliation(tt.db)
+			got := r.needsReconciliation(tt.db)
 			if got != tt.want {
 				t.Errorf("needsReconciliation() = %v, want %v", got, tt.want)
 			}
@@ -456,11 +559,127 @@ func TestNeedsReconciliation(t *testing.T) {
 	}
 }
 
+func TestSpecDigest(t *testing.T) {
+	r := &DatabaseReconciler{}
+
+	base := databasev1alpha1.Database{
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       "postgres",
+			DatabaseName: "myapp",
+			Privileges:   []string{"SELECT", "INSERT"},
+		},
+	}
+	digest := r.specDigest(&base)
+
+	tests := []struct {
+		name     string
+		mutate   func(spec *databasev1alpha1.DatabaseSpec)
+		wantSame bool
+	}{
+		{
+			name:     "resyncInterval",
+			mutate:   func(spec *databasev1alpha1.DatabaseSpec) { spec.ResyncInterval = &metav1.Duration{Duration: time.Hour} },
+			wantSame: true,
+		},
+		{
+			name:     "retainOnDelete",
+			mutate:   func(spec *databasev1alpha1.DatabaseSpec) { spec.RetainOnDelete = boolPtr(false) },
+			wantSame: true,
+		},
+		{
+			name:     "default username spelled out",
+			mutate:   func(spec *databasev1alpha1.DatabaseSpec) { spec.Username = "myapp" },
+			wantSame: true,
+		},
+		{
+			name:     "default secret name spelled out",
+			mutate:   func(spec *databasev1alpha1.DatabaseSpec) { spec.SecretName = "rds/postgres/myapp" },
+			wantSame: true,
+		},
+		{
+			name:     "privileges reordered",
+			mutate:   func(spec *databasev1alpha1.DatabaseSpec) { spec.Privileges = []string{"INSERT", "SELECT"} },
+			wantSame: true,
+		},
+		{
+			name:   "privileges changed",
+			mutate: func(spec *databasev1alpha1.DatabaseSpec) { spec.Privileges = []string{"SELECT"} },
+		},
+		{
+			name:   "username changed",
+			mutate: func(spec *databasev1alpha1.DatabaseSpec) { spec.Username = "other" },
+		},
+		{
+			name:   "secret template changed",
+			mutate: func(spec *databasev1alpha1.DatabaseSpec) { spec.SecretTemplate = `{"url": "{{ .URL }}"}` },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := base.DeepCopy()
+			tt.mutate(&db.Spec)
+			if got := r.specDigest(db) == digest; got != tt.wantSame {
+				t.Errorf("specDigest() unchanged = %v, want %v", got, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestNeedsReconciliationSpecDigest(t *testing.T) {
+	r := &DatabaseReconciler{}
+
+	applied := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec:       databasev1alpha1.DatabaseSpec{Engine: "postgres", DatabaseName: "myapp"},
+		Status: databasev1alpha1.DatabaseStatus{
+			UserCreated:         true,
+			DatabaseCreated:     true,
+			SecretCreated:       true,
+			ObservedGeneration:  1,
+			SecretFormatVersion: "v2",
+			Conditions: []metav1.Condition{
+				{Type: ConditionReady, Status: metav1.ConditionTrue, Reason: ReasonReconciled},
+			},
+		},
+	}
+	applied.Status.SpecDigest = r.specDigest(applied)
+
+	// Only resyncInterval changed
+	db := applied.DeepCopy()
+	db.Generation = 2
+	db.Spec.ResyncInterval = &metav1.Duration{Duration: time.Hour}
+	if r.needsReconciliation(db) {
+		t.Error("needsReconciliation() = true for a generation with the applied spec")
+	}
+
+	// The digest only counts for a ready Database
+	failed := db.DeepCopy()
+	failed.Status.Conditions[0].Status = metav1.ConditionFalse
+	if !r.needsReconciliation(failed) {
+		t.Error("needsReconciliation() = false for a failed Database")
+	}
+
+	// Without a digest, e.g. after an operator upgrade, every new generation is reconciled
+	noDigest := db.DeepCopy()
+	noDigest.Status.SpecDigest = ""
+	if !r.needsReconciliation(noDigest) {
+		t.Error("needsReconciliation() = false without a spec digest")
+	}
+
+	changed := db.DeepCopy()
+	changed.Spec.Privileges = []string{"SELECT"}
+	if !r.needsReconciliation(changed) {
+		t.Error("needsReconciliation() = false for a changed spec")
+	}
+}
+
 func TestGetSecretNameOrDefault(t *testing.T) {
 	tests := []struct {
-		name string
-		db   *databasev1alpha1.Database
-		want string
+		name   string
+		prefix string
+		db     *databasev1alpha1.Database
+		want   string
 	}{
 		{
 			name: "custom secret name specified",
@@ -504,11 +723,47 @@ func TestGetSecretNameOrDefault(t *testing.T) {
 			},
 			want: "rds/postgres/testdb",
 		},
+		{
+			name:   "default secret prefix",
+			prefix: "databases/prod/",
+			db: &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:       databasev1alpha1.DatabaseEnginePostgres,
+					DatabaseName: "testdb",
+				},
+			},
+			want: "databases/prod/postgres/testdb",
+		},
+		{
+			name:   "secret stored under the previous prefix",
+			prefix: "databases/prod/",
+			db: &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:       databasev1alpha1.DatabaseEnginePostgres,
+					DatabaseName: "testdb",
+				},
+				Status: databasev1alpha1.DatabaseStatus{ActualSecretName: "rds/postgres/testdb"},
+			},
+			want: "rds/postgres/testdb",
+		},
+		{
+			name:   "renamed database",
+			prefix: "databases/prod/",
+			db: &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:       databasev1alpha1.DatabaseEnginePostgres,
+					DatabaseName: "renamed",
+				},
+				Status: databasev1alpha1.DatabaseStatus{ActualSecretName: "rds/postgres/testdb"},
+			},
+			want: "databases/prod/postgres/renamed",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getSecretNameOrDefault(tt.db)
+			r := &DatabaseReconciler{DefaultSecretPrefix: tt.prefix}
+			got := r.getSecretNameOrDefault(tt.db)
 			if got != tt.want {
 				t.Errorf("getSecretNameOrDefault() = %v, want %v", got, tt.want)
 			}
@@ -759,3 +1014,1479 @@ func TestGetTagsToAdd(t *testing.T) {
 		})
 	}
 }
+
+func TestDesiredSecretTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		reconciler *DatabaseReconciler
+		specTags   map[string]string
+		want       map[string]string
+	}{
+		{
+			name: "default managed-by tag",
+			reconciler: &DatabaseReconciler{
+				ManagedByTagKey:   DefaultManagedByTagKey,
+				ManagedByTagValue: DefaultManagedByTagValue,
+			},
+			want: map[string]string{
+				"ManagedBy": "database-user-operator",
+			},
+		},
+		{
+			name: "custom managed-by tag merged with spec tags",
+			reconciler: &DatabaseReconciler{
+				ManagedByTagKey:   "owner",
+				ManagedByTagValue: "db-operator-prod",
+			},
+			specTags: map[string]string{
+				"env": "production",
+			},
+			want: map[string]string{
+				"owner": "db-operator-prod",
+				"env":   "production",
+			},
+		},
+		{
+			name:       "empty key disables managed-by tag",
+			reconciler: &DatabaseReconciler{},
+			specTags: map[string]string{
+				"env": "production",
+			},
+			want: map[string]string{
+				"env": "production",
+			},
+		},
+		{
+			name: "spec tags override managed-by value",
+			reconciler: &DatabaseReconciler{
+				ManagedByTagKey:   DefaultManagedByTagKey,
+				ManagedByTagValue: DefaultManagedByTagValue,
+			},
+			specTags: map[string]string{
+				"ManagedBy": "terraform",
+			},
+			want: map[string]string{
+				"ManagedBy": "terraform",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{}
+			if tt.specTags != nil {
+				db.Spec.AWSSecretsManager = &databasev1alpha1.AWSSecretsManagerConfig{Tags: tt.specTags}
+			}
+			got := tt.reconciler.desiredSecretTags(db)
+			if !tagsEqual(got, tt.want) {
+				t.Errorf("desiredSecretTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncSecretTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    map[string]string
+		failures    map[string]string
+		wantErr     bool
+		wantTags    map[string]string
+		wantTag     int
+		wantUntag   int
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantWarning bool
+	}{
+		{
+			name:       "in sync",
+			existing:   map[string]string{"ManagedBy": "database-user-operator", "team": "payments"},
+			wantTags:   map[string]string{"ManagedBy": "database-user-operator", "team": "payments"},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: ReasonReconciled,
+		},
+		{
+			name:       "stale tag is removed",
+			existing:   map[string]string{"ManagedBy": "database-user-operator", "team": "billing", "old": "x"},
+			wantTags:   map[string]string{"ManagedBy": "database-user-operator", "team": "payments"},
+			wantTag:    1,
+			wantUntag:  1,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: ReasonReconciled,
+		},
+		{
+			name:        "unknown tags are not removed",
+			existing:    map[string]string{"old": "x"},
+			failures:    map[string]string{"DescribeSecret": "AccessDeniedException"},
+			wantTags:    map[string]string{"ManagedBy": "database-user-operator", "team": "payments", "old": "x"},
+			wantTag:     1,
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  ReasonTagsReadFailed,
+			wantWarning: true,
+		},
+		{
+			name:        "tagging fails",
+			existing:    map[string]string{},
+			failures:    map[string]string{"TagResource": "AccessDeniedException"},
+			wantErr:     true,
+			wantTags:    map[string]string{},
+			wantTag:     1,
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  ReasonTagsWriteFailed,
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSecretsManager(t)
+			fake.tags = tt.existing
+			if tt.failures != nil {
+				fake.failures = tt.failures
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DatabaseReconciler{
+				Recorder:          recorder,
+				ManagedByTagKey:   DefaultManagedByTagKey,
+				ManagedByTagValue: DefaultManagedByTagValue,
+			}
+			db := &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{
+					AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Tags: map[string]string{"team": "payments"}},
+				},
+			}
+
+			err := r.syncSecretTags(context.Background(), db, fake.client(t), "rds/postgres/app", "rds/postgres/app")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("syncSecretTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tagsEqual(fake.tags, tt.wantTags) {
+				t.Errorf("secret tags = %v, want %v", fake.tags, tt.wantTags)
+			}
+			if got := fake.callCount("TagResource"); got != tt.wantTag {
+				t.Errorf("TagResource calls = %d, want %d", got, tt.wantTag)
+			}
+			if got := fake.callCount("UntagResource"); got != tt.wantUntag {
+				t.Errorf("UntagResource calls = %d, want %d", got, tt.wantUntag)
+			}
+
+			cond := meta.FindStatusCondition(db.Status.Conditions, ConditionTagsSynced)
+			if cond == nil {
+				t.Fatal("TagsSynced condition not set")
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("TagsSynced = %s/%s, want %s/%s", cond.Status, cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+
+			warning := false
+			for len(recorder.Events) > 0 {
+				if strings.HasPrefix(<-recorder.Events, "Warning "+EventReasonTagSyncFailed) {
+					warning = true
+				}
+			}
+			if warning != tt.wantWarning {
+				t.Errorf("TagSyncFailed event recorded = %v, want %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestResolveSecretID(t *testing.T) {
+	const storedARN = "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/myapp-AbC123"
+
+	tests := []struct {
+		name       string
+		secretName string
+		statusARN  string
+		region     string
+		want       string
+	}{
+		{
+			name:       "no stored ARN uses name",
+			secretName: "rds/postgres/myapp",
+			region:     "us-east-1",
+			want:       "rds/postgres/myapp",
+		},
+		{
+			name:       "stored ARN for same secret and region is preferred",
+			secretName: "rds/postgres/myapp",
+			statusARN:  storedARN,
+			region:     "us-east-1",
+			want:       storedARN,
+		},
+		{
+			name:       "stored ARN ignored after region change",
+			secretName: "rds/postgres/myapp",
+			statusARN:  storedARN,
+			region:     "eu-west-1",
+			want:       "rds/postgres/myapp",
+		},
+		{
+			name:       "stored ARN ignored after secret rename",
+			secretName: "rds/postgres/renamed",
+			statusARN:  storedARN,
+			region:     "us-east-1",
+			want:       "rds/postgres/renamed",
+		},
+		{
+			name:       "name ending like a suffix is not the stored secret",
+			secretName: "rds/postgres/myapp-backup",
+			statusARN:  storedARN,
+			region:     "us-east-1",
+			want:       "rds/postgres/myapp-backup",
+		},
+		{
+			name:       "stored ARN of a name ending like a suffix",
+			secretName: "rds/postgres/app-backup",
+			statusARN:  "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-backup-AbC123",
+			region:     "us-east-1",
+			want:       "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-backup-AbC123",
+		},
+		{
+			name:       "ARN in spec is used as-is",
+			secretName: "arn:aws-us-gov:secretsmanager:us-gov-west-1:123456789012:secret:myapp-x1Y2z3",
+			statusARN:  storedARN,
+			region:     "us-gov-west-1",
+			want:       "arn:aws-us-gov:secretsmanager:us-gov-west-1:123456789012:secret:myapp-x1Y2z3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{
+				Status: databasev1alpha1.DatabaseStatus{SecretARN: tt.statusARN},
+			}
+			if got := resolveSecretID(db, tt.secretName, tt.region); got != tt.want {
+				t.Errorf("resolveSecretID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetSecretARN(t *testing.T) {
+	tests := []struct {
+		name          string
+		arn           string
+		wantAccount   string
+		wantPartition string
+	}{
+		{
+			name:          "commercial",
+			arn:           "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbCdEf",
+			wantAccount:   "123456789012",
+			wantPartition: "aws",
+		},
+		{
+			name:          "govcloud",
+			arn:           "arn:aws-us-gov:secretsmanager:us-gov-west-1:210987654321:secret:app-AbCdEf",
+			wantAccount:   "210987654321",
+			wantPartition: "aws-us-gov",
+		},
+		{name: "empty"},
+		{name: "invalid", arn: "not-an-arn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &databasev1alpha1.DatabaseStatus{SecretAccountID: "999999999999", SecretPartition: "aws-cn"}
+			setSecretARN(status, tt.arn)
+			if status.SecretARN != tt.arn || status.SecretAccountID != tt.wantAccount || status.SecretPartition != tt.wantPartition {
+				t.Errorf("setSecretARN() = %q, account %q, partition %q, want account %q, partition %q",
+					status.SecretARN, status.SecretAccountID, status.SecretPartition, tt.wantAccount, tt.wantPartition)
+			}
+		})
+	}
+}
+
+func TestValidateSecretName(t *testing.T) {
+	tests := []struct {
+		name       string
+		secretName string
+		region     string
+		wantErr    bool
+	}{
+		{
+			name:       "plain name",
+			secretName: "rds/postgres/myapp",
+			region:     "us-east-1",
+		},
+		{
+			name:       "ARN matching region",
+			secretName: "arn:aws:secretsmanager:us-east-1:123456789012:secret:myapp-AbC123",
+			region:     "us-east-1",
+		},
+		{
+			name:       "ARN without explicit region",
+			secretName: "arn:aws:secretsmanager:us-east-1:123456789012:secret:myapp-AbC123",
+		},
+		{
+			name:       "ARN conflicting with region",
+			secretName: "arn:aws:secretsmanager:us-east-1:123456789012:secret:myapp-AbC123",
+			region:     "eu-west-1",
+			wantErr:    true,
+		},
+		{
+			name:       "malformed ARN",
+			secretName: "arn:aws:secretsmanager:us-east-1",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{SecretName: tt.secretName},
+			}
+			if tt.region != "" {
+				db.Spec.AWSSecretsManager = &databasev1alpha1.AWSSecretsManagerConfig{Region: tt.region}
+			}
+			err := validateSecretName(db)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSecretName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSecretBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    databasev1alpha1.DatabaseSpec
+		wantErr bool
+	}{
+		{name: "not binary", spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", SecretTemplate: "PASSWORD={{.DBPassword}}"}},
+		{name: "binary without template", spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", SecretBinary: true}},
+		{
+			name: "env file with url",
+			spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", SecretBinary: true, SecretTemplate: "DATABASE_URL={{.DatabaseURL}}\n"},
+		},
+		{
+			name:    "env file without url",
+			spec:    databasev1alpha1.DatabaseSpec{Engine: "postgres", SecretBinary: true, SecretTemplate: "PASSWORD={{.DBPassword}}\n"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretBinary(&databasev1alpha1.Database{Spec: tt.spec})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSecretBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSecretFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    databasev1alpha1.DatabaseSpec
+		wantErr bool
+	}{
+		{
+			name: "json with a template",
+			spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", SecretTemplate: `{"url": "{{ .DatabaseURL }}"}`},
+		},
+		{
+			name: "connection string only",
+			spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", SecretFormat: databasev1alpha1.SecretFormatConnectionStringOnly},
+		},
+		{
+			name: "connection string only with a template",
+			spec: databasev1alpha1.DatabaseSpec{
+				Engine:         "postgres",
+				SecretFormat:   databasev1alpha1.SecretFormatConnectionStringOnly,
+				SecretTemplate: `{"url": "{{ .DatabaseURL }}"}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection string only with renamed keys",
+			spec: databasev1alpha1.DatabaseSpec{
+				Engine:         "postgres",
+				SecretFormat:   databasev1alpha1.SecretFormatConnectionStringOnly,
+				SecretKeyNames: &databasev1alpha1.SecretKeyNames{Password: "PGPASSWORD"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection string only with a key pair",
+			spec: databasev1alpha1.DatabaseSpec{
+				Engine:       "snowflake",
+				SecretFormat: databasev1alpha1.SecretFormatConnectionStringOnly,
+				Snowflake:    &databasev1alpha1.SnowflakeConfig{KeyPair: true},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretFormat(&databasev1alpha1.Database{Spec: tt.spec})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSecretFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSecretKeyNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    databasev1alpha1.DatabaseSpec
+		wantErr bool
+	}{
+		{
+			name: "default keys",
+			spec: databasev1alpha1.DatabaseSpec{Engine: "postgres"},
+		},
+		{
+			name: "renamed keys",
+			spec: databasev1alpha1.DatabaseSpec{
+				Engine:         "postgres",
+				SecretKeyNames: &databasev1alpha1.SecretKeyNames{Host: "PGHOST", Password: "PGPASSWORD"},
+			},
+		},
+		{
+			name: "with a template",
+			spec: databasev1alpha1.DatabaseSpec{
+				Engine:         "postgres",
+				SecretTemplate: `{"password": "{{ .DBPassword }}"}`,
+				SecretKeyNames: &databasev1alpha1.SecretKeyNames{Password: "PGPASSWORD"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate keys",
+			spec: databasev1alpha1.DatabaseSpec{
+				Engine:         "postgres",
+				SecretKeyNames: &databasev1alpha1.SecretKeyNames{URL: "DB_HOST"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretKeyNames(&databasev1alpha1.Database{Spec: tt.spec})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSecretKeyNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAWSPartitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    databasev1alpha1.DatabaseSpec
+		wantErr bool
+	}{
+		{
+			name: "no AWS regions",
+			spec: databasev1alpha1.DatabaseSpec{},
+		},
+		{
+			name: "same partition",
+			spec: databasev1alpha1.DatabaseSpec{
+				AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "us-gov-west-1"},
+				ConnectionStringAWSSecretRef: &databasev1alpha1.AWSSecretReference{
+					SecretName: "arn:aws-us-gov:secretsmanager:us-gov-east-1:123456789012:secret:admin-AbC123",
+					Region:     "us-gov-east-1",
+				},
+			},
+		},
+		{
+			name: "target and admin secret in different partitions",
+			spec: databasev1alpha1.DatabaseSpec{
+				AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "cn-north-1"},
+				ConnectionStringAWSSecretRef: &databasev1alpha1.AWSSecretReference{
+					SecretName: "admin",
+					Region:     "us-east-1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "admin secret ARN region conflicts with region",
+			spec: databasev1alpha1.DatabaseSpec{
+				ConnectionStringAWSSecretRef: &databasev1alpha1.AWSSecretReference{
+					SecretName: "arn:aws:secretsmanager:us-east-1:123456789012:secret:admin-AbC123",
+					Region:     "us-west-2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "admin secret ARN with wrong partition",
+			spec: databasev1alpha1.DatabaseSpec{
+				ConnectionStringAWSSecretRef: &databasev1alpha1.AWSSecretReference{
+					SecretName: "arn:aws:secretsmanager:cn-north-1:123456789012:secret:admin-AbC123",
+					Region:     "cn-north-1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{Spec: tt.spec}
+			err := validateAWSPartitions(db)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateAWSPartitions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRegionSkip(t *testing.T) {
+	strict := &DatabaseReconciler{}
+	if err := strict.validateRegion("xx-central-1"); err == nil {
+		t.Error("validateRegion() expected error for unknown region without SkipRegionValidation")
+	}
+
+	lenient := &DatabaseReconciler{SkipRegionValidation: true}
+	if err := lenient.validateRegion("xx-central-1"); err != nil {
+		t.Errorf("validateRegion() unexpected error with SkipRegionValidation: %v", err)
+	}
+	if err := lenient.validateRegion("not a region"); err == nil {
+		t.Error("validateRegion() expected error for malformed region with SkipRegionValidation")
+	}
+}
+
+func TestStartupDelay(t *testing.T) {
+	r := &DatabaseReconciler{StartupSpread: time.Minute}
+
+	unchanged := &databasev1alpha1.Database{}
+	unchanged.UID = "unchanged"
+	unchanged.Name = "app"
+	unchanged.Generation = 2
+	unchanged.Status.ObservedGeneration = 2
+
+	first := r.startupDelay(unchanged)
+	if first < 0 || first >= time.Minute {
+		t.Errorf("startupDelay() = %v, want within [0, 1m)", first)
+	}
+	if second := r.startupDelay(unchanged); second != 0 {
+		t.Errorf("startupDelay() on second reconciliation = %v, want 0", second)
+	}
+
+	changed := &databasev1alpha1.Database{}
+	changed.UID = "changed"
+	changed.Generation = 3
+	changed.Status.ObservedGeneration = 2
+	if delay := r.startupDelay(changed); delay != 0 {
+		t.Errorf("startupDelay() with unobserved spec change = %v, want 0", delay)
+	}
+
+	disabled := &DatabaseReconciler{}
+	if delay := disabled.startupDelay(unchanged); delay != 0 {
+		t.Errorf("startupDelay() without StartupSpread = %v, want 0", delay)
+	}
+}
+
+func TestRequeueInterval(t *testing.T) {
+	db := &databasev1alpha1.Database{}
+	db.Namespace = "default"
+	db.Name = "app"
+
+	if got := (&DatabaseReconciler{}).requeueInterval(db); got != requeueAfterSuccess {
+		t.Errorf("requeueInterval() without jitter = %v, want %v", got, requeueAfterSuccess)
+	}
+
+	r := &DatabaseReconciler{RequeueJitter: 0.1}
+	got := r.requeueInterval(db)
+	if got < requeueAfterSuccess || got >= requeueAfterSuccess+requeueAfterSuccess/10 {
+		t.Errorf("requeueInterval() = %v, want within [%v, %v)", got, requeueAfterSuccess, requeueAfterSuccess+requeueAfterSuccess/10)
+	}
+	if again := r.requeueInterval(db); again != got {
+		t.Errorf("requeueInterval() not stable: %v != %v", again, got)
+	}
+}
+
+func TestRequeueIntervalResync(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator time.Duration
+		spec     *metav1.Duration
+		want     time.Duration
+	}{
+		{
+			name: "defaults",
+			want: requeueAfterSuccess,
+		},
+		{
+			name:     "operator default",
+			operator: 30 * time.Minute,
+			want:     30 * time.Minute,
+		},
+		{
+			name:     "spec overrides operator default",
+			operator: 30 * time.Minute,
+			spec:     &metav1.Duration{Duration: time.Hour},
+			want:     time.Hour,
+		},
+		{
+			name:     "zero spec uses operator default",
+			operator: 30 * time.Minute,
+			spec:     &metav1.Duration{},
+			want:     30 * time.Minute,
+		},
+		{
+			name: "spec below minimum",
+			spec: &metav1.Duration{Duration: time.Second},
+			want: minResyncInterval,
+		},
+		{
+			name:     "operator default below minimum",
+			operator: time.Second,
+			want:     minResyncInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{ResyncInterval: tt.spec}}
+			r := &DatabaseReconciler{ResyncInterval: tt.operator}
+			if got := r.requeueInterval(db); got != tt.want {
+				t.Errorf("requeueInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshLastReconcileTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		last *metav1.Time
+		want time.Time
+	}{
+		{name: "unset", last: nil, want: now},
+		{name: "recent", last: timestampPtr(now.Add(-30 * time.Second)), want: now.Add(-30 * time.Second)},
+		{name: "stale", last: timestampPtr(now.Add(-2 * time.Minute)), want: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &databasev1alpha1.DatabaseStatus{LastReconcileTime: tt.last}
+			refreshLastReconcileTime(status, now)
+			if !status.LastReconcileTime.Time.Equal(tt.want) {
+				t.Errorf("LastReconcileTime = %v, want %v", status.LastReconcileTime.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshLastCheckedTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		last        *metav1.Time
+		want        time.Time
+		wantChanged bool
+	}{
+		{name: "unset", last: nil, want: now, wantChanged: true},
+		{name: "recent", last: timestampPtr(now.Add(-30 * time.Second)), want: now.Add(-30 * time.Second)},
+		{name: "stale", last: timestampPtr(now.Add(-2 * time.Minute)), want: now, wantChanged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &databasev1alpha1.DatabaseStatus{LastCheckedTime: tt.last}
+			if changed := refreshLastCheckedTime(status, now); changed != tt.wantChanged {
+				t.Errorf("refreshLastCheckedTime() = %v, want %v", changed, tt.wantChanged)
+			}
+			if !status.LastCheckedTime.Time.Equal(tt.want) {
+				t.Errorf("LastCheckedTime = %v, want %v", status.LastCheckedTime.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileContext(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	r := &DatabaseReconciler{ReconcileTimeout: time.Minute}
+
+	ctx, cancel := r.reconcileContext(parent)
+	defer cancel()
+
+	// A shutdown cancels the manager context but must not cancel the reconciliation
+	cancelParent()
+	if err := ctx.Err(); err != nil {
+		t.Errorf("reconcile context cancelled with the manager context: %v", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("reconcile context deadline = %v, want within ReconcileTimeout", deadline)
+	}
+
+	defaultCtx, cancelDefault := (&DatabaseReconciler{}).reconcileContext(context.Background())
+	defer cancelDefault()
+	if deadline, ok := defaultCtx.Deadline(); !ok || time.Until(deadline) <= time.Minute {
+		t.Errorf("default reconcile context deadline = %v, want %v from now", deadline, defaultReconcileTimeout)
+	}
+}
+
+// accountOptionsClient is a database client that only implements SetAccountOptions
+type accountOptionsClient struct {
+	database.Client
+	err  error
+	opts *database.AccountOptions
+}
+
+func (c *accountOptionsClient) SetAccountOptions(_ context.Context, _ string, opts database.AccountOptions) error {
+	c.opts = &opts
+	return c.err
+}
+
+func TestReconcileAccountOptions(t *testing.T) {
+	locked := true
+
+	tests := []struct {
+		name       string
+		engine     databasev1alpha1.DatabaseEngine
+		mariadb    *databasev1alpha1.MariaDBConfig
+		clientErr  error
+		wantErr    bool
+		wantReason string
+		wantCall   bool
+	}{
+		{name: "not set", engine: databasev1alpha1.DatabaseEngineMariaDB},
+		{
+			name:     "applied",
+			engine:   databasev1alpha1.DatabaseEngineMySQL,
+			mariadb:  &databasev1alpha1.MariaDBConfig{AccountLocked: &locked},
+			wantCall: true,
+		},
+		{
+			name:       "postgres engine",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			mariadb:    &databasev1alpha1.MariaDBConfig{AccountLocked: &locked},
+			wantErr:    true,
+			wantReason: ReasonConfigError,
+		},
+		{
+			name:       "unsupported server",
+			engine:     databasev1alpha1.DatabaseEngineMariaDB,
+			mariadb:    &databasev1alpha1.MariaDBConfig{AccountLocked: &locked},
+			clientErr:  fmt.Errorf("%w: server version 8.0.35", database.ErrAccountOptionsNotSupported),
+			wantErr:    true,
+			wantReason: ReasonConfigError,
+			wantCall:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{}
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{Engine: tt.engine, MariaDB: tt.mariadb}}
+			dbClient := &accountOptionsClient{err: tt.clientErr}
+
+			err := r.reconcileAccountOptions(context.Background(), db, dbClient, "app")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reconcileAccountOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantReason != "" && classifyError(err) != tt.wantReason {
+				t.Errorf("reason = %s, want %s", classifyError(err), tt.wantReason)
+			}
+			if (dbClient.opts != nil) != tt.wantCall {
+				t.Errorf("SetAccountOptions called = %v, want %v", dbClient.opts != nil, tt.wantCall)
+			}
+		})
+	}
+}
+
+// roleSettingsClient is a database client that only implements SetRoleSettings
+type roleSettingsClient struct {
+	database.Client
+	settings map[string]string
+	reset    []string
+	called   bool
+}
+
+func (c *roleSettingsClient) SetRoleSettings(_ context.Context, _ string, settings map[string]string, reset []string) error {
+	c.called = true
+	c.settings = settings
+	c.reset = reset
+	return nil
+}
+
+func TestReconcileRoleSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		engine     databasev1alpha1.DatabaseEngine
+		settings   map[string]string
+		applied    []string
+		wantReason string
+		wantCall   bool
+		wantReset  []string
+		wantStatus []string
+	}{
+		{name: "none", engine: databasev1alpha1.DatabaseEnginePostgres},
+		{
+			name:       "set",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			settings:   map[string]string{"statement_timeout": "30s", "lock_timeout": "5s"},
+			wantCall:   true,
+			wantStatus: []string{"lock_timeout", "statement_timeout"},
+		},
+		{
+			name:       "removed settings are reset",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			settings:   map[string]string{"statement_timeout": "30s"},
+			applied:    []string{"search_path", "statement_timeout"},
+			wantCall:   true,
+			wantReset:  []string{"search_path"},
+			wantStatus: []string{"statement_timeout"},
+		},
+		{
+			name:      "all removed",
+			engine:    databasev1alpha1.DatabaseEnginePostgres,
+			applied:   []string{"statement_timeout"},
+			wantCall:  true,
+			wantReset: []string{"statement_timeout"},
+		},
+		{
+			name:       "mysql engine",
+			engine:     databasev1alpha1.DatabaseEngineMySQL,
+			settings:   map[string]string{"statement_timeout": "30s"},
+			wantReason: ReasonConfigError,
+		},
+		{
+			name:       "invalid name",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			settings:   map[string]string{"bad name": "1"},
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{}
+			db := &databasev1alpha1.Database{
+				Spec:   databasev1alpha1.DatabaseSpec{Engine: tt.engine, Postgres: &databasev1alpha1.PostgresConfig{RoleSettings: tt.settings}},
+				Status: databasev1alpha1.DatabaseStatus{RoleSettings: tt.applied},
+			}
+			dbClient := &roleSettingsClient{}
+
+			err := r.reconcileRoleSettings(context.Background(), db, dbClient, "app")
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("reconcileRoleSettings() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dbClient.called != tt.wantCall {
+				t.Errorf("SetRoleSettings called = %v, want %v", dbClient.called, tt.wantCall)
+			}
+			if !reflect.DeepEqual(dbClient.reset, tt.wantReset) {
+				t.Errorf("reset = %v, want %v", dbClient.reset, tt.wantReset)
+			}
+			if !reflect.DeepEqual(db.Status.RoleSettings, tt.wantStatus) {
+				t.Errorf("status.roleSettings = %v, want %v", db.Status.RoleSettings, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// connectionLimitClient is a database client that only implements SetDatabaseConnectionLimit
+type connectionLimitClient struct {
+	database.Client
+	limit *int32
+}
+
+func (c *connectionLimitClient) SetDatabaseConnectionLimit(_ context.Context, _ string, limit int32) error {
+	c.limit = &limit
+	return nil
+}
+
+func TestReconcileDatabaseConnectionLimit(t *testing.T) {
+	limit := int32(50)
+
+	tests := []struct {
+		name       string
+		engine     databasev1alpha1.DatabaseEngine
+		postgres   *databasev1alpha1.PostgresConfig
+		wantReason string
+		wantLimit  *int32
+	}{
+		{name: "not set", engine: databasev1alpha1.DatabaseEnginePostgres},
+		{name: "no limit", engine: databasev1alpha1.DatabaseEnginePostgres, postgres: &databasev1alpha1.PostgresConfig{}},
+		{
+			name:      "limit",
+			engine:    databasev1alpha1.DatabaseEnginePostgreSQL,
+			postgres:  &databasev1alpha1.PostgresConfig{DatabaseConnectionLimit: &limit},
+			wantLimit: &limit,
+		},
+		{
+			name:       "mysql engine",
+			engine:     databasev1alpha1.DatabaseEngineMySQL,
+			postgres:   &databasev1alpha1.PostgresConfig{DatabaseConnectionLimit: &limit},
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{}
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{Engine: tt.engine, DatabaseName: "app", Postgres: tt.postgres}}
+			dbClient := &connectionLimitClient{}
+
+			err := r.reconcileDatabaseConnectionLimit(context.Background(), db, dbClient)
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("reconcileDatabaseConnectionLimit() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dbClient.limit, tt.wantLimit) {
+				t.Errorf("limit = %v, want %v", dbClient.limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+// snowflakeClient is a database client that only implements SetPublicKey and SetDefaultWarehouse
+type snowflakeClient struct {
+	database.Client
+	publicKeys []string
+	warehouse  string
+}
+
+func (c *snowflakeClient) SetPublicKey(_ context.Context, _ string, publicKey string) error {
+	c.publicKeys = append(c.publicKeys, publicKey)
+	return nil
+}
+
+func (c *snowflakeClient) SetDefaultWarehouse(_ context.Context, _ string, warehouse string) error {
+	c.warehouse = warehouse
+	return nil
+}
+
+func TestReconcileSnowflake(t *testing.T) {
+	existingKey, err := database.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingPublicKey, err := database.PublicKeyFromPrivateKey(existingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		engine         databasev1alpha1.DatabaseEngine
+		snowflake      *databasev1alpha1.SnowflakeConfig
+		privateKey     string
+		wantReason     string
+		wantWarehouse  string
+		wantPrivateKey string
+		wantPublicKeys []string
+		wantNewKey     bool
+	}{
+		{name: "not set", engine: databasev1alpha1.DatabaseEngineSnowflake},
+		{name: "not set on other engine", engine: databasev1alpha1.DatabaseEnginePostgres},
+		{
+			name:          "warehouse",
+			engine:        databasev1alpha1.DatabaseEngineSnowflake,
+			snowflake:     &databasev1alpha1.SnowflakeConfig{Warehouse: "APP_WH"},
+			wantWarehouse: "APP_WH",
+		},
+		{
+			name:       "key pair generated",
+			engine:     databasev1alpha1.DatabaseEngineSnowflake,
+			snowflake:  &databasev1alpha1.SnowflakeConfig{KeyPair: true},
+			wantNewKey: true,
+		},
+		{
+			name:       "invalid key replaced",
+			engine:     databasev1alpha1.DatabaseEngineSnowflake,
+			snowflake:  &databasev1alpha1.SnowflakeConfig{KeyPair: true},
+			privateKey: "not a key",
+			wantNewKey: true,
+		},
+		{
+			name:           "existing key kept",
+			engine:         databasev1alpha1.DatabaseEngineSnowflake,
+			snowflake:      &databasev1alpha1.SnowflakeConfig{KeyPair: true},
+			privateKey:     existingKey,
+			wantPrivateKey: existingKey,
+			wantPublicKeys: []string{existingPublicKey},
+		},
+		{
+			name:           "key pair disabled",
+			engine:         databasev1alpha1.DatabaseEngineSnowflake,
+			snowflake:      &databasev1alpha1.SnowflakeConfig{},
+			privateKey:     existingKey,
+			wantPublicKeys: []string{""},
+		},
+		{
+			name:       "postgres engine",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			snowflake:  &databasev1alpha1.SnowflakeConfig{KeyPair: true},
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{}
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{Engine: tt.engine, DatabaseName: "app", Snowflake: tt.snowflake}}
+			dbClient := &snowflakeClient{}
+
+			privateKey, err := r.reconcileSnowflake(context.Background(), db, dbClient, "app_user", tt.privateKey)
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("reconcileSnowflake() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dbClient.warehouse != tt.wantWarehouse {
+				t.Errorf("warehouse = %q, want %q", dbClient.warehouse, tt.wantWarehouse)
+			}
+			if tt.wantNewKey {
+				publicKey, err := database.PublicKeyFromPrivateKey(privateKey)
+				if err != nil {
+					t.Fatalf("returned private key is invalid: %v", err)
+				}
+				if !reflect.DeepEqual(dbClient.publicKeys, []string{publicKey}) {
+					t.Errorf("public keys = %q, want %q", dbClient.publicKeys, []string{publicKey})
+				}
+				return
+			}
+			if privateKey != tt.wantPrivateKey {
+				t.Errorf("private key = %q, want %q", privateKey, tt.wantPrivateKey)
+			}
+			if !reflect.DeepEqual(dbClient.publicKeys, tt.wantPublicKeys) {
+				t.Errorf("public keys = %q, want %q", dbClient.publicKeys, tt.wantPublicKeys)
+			}
+		})
+	}
+}
+
+func TestNewDatabaseClientConfigErrors(t *testing.T) {
+	cloudSQL := &databasev1alpha1.CloudSQLConfig{InstanceConnectionName: "project:europe-west1:main"}
+	azure := &databasev1alpha1.AzureConfig{EntraAuthentication: true}
+
+	tests := []struct {
+		name             string
+		engine           string
+		connectionString string
+		cloudSQL         *databasev1alpha1.CloudSQLConfig
+		azure            *databasev1alpha1.AzureConfig
+		tlsPolicy        database.TLSPolicy
+	}{
+		{name: "cloud sql on cassandra", engine: "cassandra", connectionString: "cassandra://admin@localhost/system", cloudSQL: cloudSQL},
+		{name: "entra on mariadb", engine: "mariadb", connectionString: "mysql://admin@localhost/mysql", azure: azure},
+		{name: "cloud sql and entra", engine: "postgres", connectionString: "postgres://admin@localhost/postgres", cloudSQL: cloudSQL, azure: azure},
+		{name: "postgres connection string on mysql", engine: "mysql", connectionString: "postgres://admin@localhost/postgres"},
+		{name: "sslmode weaker than the policy", engine: "postgres", connectionString: "postgres://admin@localhost/postgres?sslmode=disable",
+			tlsPolicy: database.TLSPolicy{PostgresSSLMode: "verify-full"}},
+		{name: "tls=false with required tls", engine: "mysql", connectionString: "admin:secret@tcp(localhost:3306)/mysql?tls=false",
+			tlsPolicy: database.TLSPolicy{MySQLRequireTLS: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{TLSPolicy: tt.tlsPolicy}
+			_, err := r.newDatabaseClient(context.Background(), tt.engine, tt.connectionString, tt.cloudSQL, tt.azure)
+			if classifyError(err) != ReasonConfigError {
+				t.Errorf("newDatabaseClient() error = %v, want reason %s", err, ReasonConfigError)
+			}
+		})
+	}
+}
+
+func TestReconcileDeleteFailurePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        databasev1alpha1.DeletionFailurePolicy
+		wantErr       bool
+		wantFinalizer bool
+		wantEvent     string
+	}{
+		{
+			name:          "retry by default",
+			wantErr:       true,
+			wantFinalizer: true,
+			wantEvent:     EventReasonDeletionBlocked,
+		},
+		{
+			name:      "orphan",
+			policy:    databasev1alpha1.DeletionFailurePolicyOrphan,
+			wantEvent: EventReasonDeletionOrphaned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := newFakeSecretsManager(t)
+			now := metav1.Now()
+			// The admin secret does not exist, so the database and user cannot be dropped
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "app",
+					Namespace:         "default",
+					Finalizers:        []string{DatabaseFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:                    databasev1alpha1.DatabaseEnginePostgres,
+					DatabaseName:              "app",
+					ConnectionStringSecretRef: &databasev1alpha1.SecretKeyReference{Name: "admin"},
+					RetainOnDelete:            boolPtr(false),
+					DeletionFailurePolicy:     tt.policy,
+					AWSSecretsManager:         &databasev1alpha1.AWSSecretsManagerConfig{Region: "us-east-1"},
+				},
+			}
+			r := newClusterDatabaseTestReconciler(t, db)
+			recorder := record.NewFakeRecorder(10)
+			r.Recorder = recorder
+
+			_, err := r.reconcileDelete(ctx, db)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reconcileDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := fake.callCount("DeleteSecret"); got != 1 {
+				t.Errorf("DeleteSecret calls = %d, want 1", got)
+			}
+
+			stored := &databasev1alpha1.Database{}
+			getErr := r.Get(ctx, client.ObjectKeyFromObject(db), stored)
+			if !tt.wantFinalizer {
+				// The fake client deletes the Database once its last finalizer is removed
+				if !apierrors.IsNotFound(getErr) {
+					t.Errorf("Database still exists after removing the finalizer, error = %v", getErr)
+				}
+			} else {
+				if getErr != nil {
+					t.Fatal(getErr)
+				}
+				cond := meta.FindStatusCondition(stored.Status.Conditions, ConditionDeletionBlocked)
+				if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ReasonConnectionStringUnavailable {
+					t.Errorf("DeletionBlocked condition = %+v, want True/%s", cond, ReasonConnectionStringUnavailable)
+				}
+			}
+
+			event := ""
+			if len(recorder.Events) > 0 {
+				event = <-recorder.Events
+			}
+			if !strings.HasPrefix(event, "Warning "+tt.wantEvent) {
+				t.Errorf("event = %q, want a Warning %s", event, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestReconcileDeleteReleasesRetainedSecrets(t *testing.T) {
+	tests := []struct {
+		name          string
+		untagFailure  string
+		wantErr       bool
+		wantUntags    int
+		wantManagedBy bool
+	}{
+		{name: "retained secrets are released", wantUntags: 2},
+		{name: "release failure is retried", untagFailure: "AccessDeniedException", wantErr: true, wantUntags: 1, wantManagedBy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := newFakeSecretsManager(t)
+			fake.tags[DefaultManagedByTagKey] = DefaultManagedByTagValue
+			fake.tags["team"] = "orders"
+			if tt.untagFailure != "" {
+				fake.failures["UntagResource"] = tt.untagFailure
+			}
+			now := metav1.Now()
+			// retainOnDelete defaults to true
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "app",
+					Namespace:         "default",
+					Finalizers:        []string{DatabaseFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:            databasev1alpha1.DatabaseEnginePostgres,
+					DatabaseName:      "app",
+					AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "us-east-1"},
+				},
+				Status: databasev1alpha1.DatabaseStatus{
+					SecretCreated:    true,
+					ActualSecretName: "rds/postgres/app",
+					SecretRegion:     "us-east-1",
+					MigrationUser:    &databasev1alpha1.MigrationUserStatus{Username: "app_migrator", SecretName: "rds/postgres/app_migrator"},
+				},
+			}
+			r := newClusterDatabaseTestReconciler(t, db)
+			r.Recorder = record.NewFakeRecorder(10)
+			r.ManagedByTagKey = DefaultManagedByTagKey
+			r.ManagedByTagValue = DefaultManagedByTagValue
+
+			_, err := r.reconcileDelete(ctx, db)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reconcileDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := fake.callCount("UntagResource"); got != tt.wantUntags {
+				t.Errorf("UntagResource calls = %d, want %d", got, tt.wantUntags)
+			}
+			if got := fake.callCount("DeleteSecret"); got != 0 {
+				t.Errorf("DeleteSecret calls = %d, want the secrets retained", got)
+			}
+			// Without the managed-by tag the secret garbage collector no longer lists the secrets
+			if _, ok := fake.tags[DefaultManagedByTagKey]; ok != tt.wantManagedBy {
+				t.Errorf("managed-by tag kept = %v, want %v", ok, tt.wantManagedBy)
+			}
+			if fake.tags["team"] != "orders" {
+				t.Errorf("tags = %v, want the other tags kept", fake.tags)
+			}
+
+			stored := &databasev1alpha1.Database{}
+			getErr := r.Get(ctx, client.ObjectKeyFromObject(db), stored)
+			if !tt.wantErr {
+				if !apierrors.IsNotFound(getErr) {
+					t.Errorf("Database still exists after removing the finalizer, error = %v", getErr)
+				}
+				return
+			}
+			if getErr != nil {
+				t.Fatal(getErr)
+			}
+			cond := meta.FindStatusCondition(stored.Status.Conditions, ConditionDeletionBlocked)
+			if cond == nil || cond.Reason != ReasonSecretReleaseFailed {
+				t.Errorf("DeletionBlocked condition = %+v, want %s", cond, ReasonSecretReleaseFailed)
+			}
+		})
+	}
+}
+
+// privilegesClient is a database client that records the privileges granted and revoked
+type privilegesClient struct {
+	database.Client
+	grantedAll bool
+	granted    []string
+	revoked    []string
+}
+
+func (c *privilegesClient) GrantAllPrivileges(_ context.Context, _, _ string) error {
+	c.grantedAll = true
+	return nil
+}
+
+func (c *privilegesClient) GrantDatabasePrivileges(_ context.Context, _, _ string, privileges []string) error {
+	c.granted = privileges
+	return nil
+}
+
+func (c *privilegesClient) RevokeDatabasePrivileges(_ context.Context, _, _ string, privileges []string) error {
+	c.revoked = privileges
+	return nil
+}
+
+func TestReconcilePrivileges(t *testing.T) {
+	tests := []struct {
+		name           string
+		engine         databasev1alpha1.DatabaseEngine
+		preset         databasev1alpha1.PrivilegePreset
+		status         databasev1alpha1.DatabaseStatus
+		wantGrantedAll bool
+		wantGranted    []string
+		wantRevoked    []string
+		wantStatus     []string
+		wantReason     string
+	}{
+		{
+			name:           "no preset",
+			engine:         databasev1alpha1.DatabaseEnginePostgres,
+			wantGrantedAll: true,
+			wantStatus:     []string{"ALL"},
+		},
+		{
+			name:        "new user",
+			engine:      databasev1alpha1.DatabaseEnginePostgres,
+			preset:      databasev1alpha1.PrivilegePresetReadOnly,
+			wantGranted: []string{"CONNECT", "SELECT"},
+			wantStatus:  []string{"CONNECT", "SELECT"},
+		},
+		{
+			name:        "user reconciled before privileges were recorded",
+			engine:      databasev1alpha1.DatabaseEngineMySQL,
+			preset:      databasev1alpha1.PrivilegePresetReadOnly,
+			status:      databasev1alpha1.DatabaseStatus{ObservedGeneration: 3},
+			wantGranted: []string{"SELECT", "SHOW VIEW"},
+			wantRevoked: []string{"ALL"},
+			wantStatus:  []string{"SELECT", "SHOW VIEW"},
+		},
+		{
+			name:   "readWrite to readOnly",
+			engine: databasev1alpha1.DatabaseEngineCassandra,
+			preset: databasev1alpha1.PrivilegePresetReadOnly,
+			status: databasev1alpha1.DatabaseStatus{
+				ObservedGeneration: 3,
+				GrantedPrivileges:  []string{"MODIFY", "SELECT"},
+			},
+			wantGranted: []string{"SELECT"},
+			wantRevoked: []string{"MODIFY"},
+			wantStatus:  []string{"SELECT"},
+		},
+		{
+			name:       "unknown preset",
+			engine:     databasev1alpha1.DatabaseEnginePostgres,
+			preset:     "admin",
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{Recorder: record.NewFakeRecorder(10)}
+			db := &databasev1alpha1.Database{
+				Spec:   databasev1alpha1.DatabaseSpec{Engine: tt.engine, DatabaseName: "app", PrivilegePreset: tt.preset},
+				Status: tt.status,
+			}
+			dbClient := &privilegesClient{}
+
+			err := r.reconcilePrivileges(context.Background(), db, dbClient, "app")
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("reconcilePrivileges() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dbClient.grantedAll != tt.wantGrantedAll {
+				t.Errorf("GrantAllPrivileges called = %v, want %v", dbClient.grantedAll, tt.wantGrantedAll)
+			}
+			if !reflect.DeepEqual(dbClient.granted, tt.wantGranted) {
+				t.Errorf("granted = %v, want %v", dbClient.granted, tt.wantGranted)
+			}
+			if !reflect.DeepEqual(dbClient.revoked, tt.wantRevoked) {
+				t.Errorf("revoked = %v, want %v", dbClient.revoked, tt.wantRevoked)
+			}
+			if !reflect.DeepEqual(db.Status.GrantedPrivileges, tt.wantStatus) {
+				t.Errorf("status.grantedPrivileges = %v, want %v", db.Status.GrantedPrivileges, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestReconcileErrorObservedGeneration(t *testing.T) {
+	ctx := context.Background()
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1, Finalizers: []string{DatabaseFinalizer}},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:            databasev1alpha1.DatabaseEnginePostgres,
+			DatabaseName:      "app",
+			SecretName:        "arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/app-AbCdEf",
+			AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "us-east-1"},
+		},
+	}
+	r := newClusterDatabaseTestReconciler(t, db)
+	r.Recorder = record.NewFakeRecorder(10)
+
+	_, _ = r.reconcileObject(ctx, db, &ReconcileTrace{})
+
+	// A new generation failing with the same error is still observed
+	got := &databasev1alpha1.Database{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(db), got); err != nil {
+		t.Fatal(err)
+	}
+	got.Generation = 2
+	_, _ = r.reconcileObject(ctx, got, &ReconcileTrace{})
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(db), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != "Error" || got.Status.ObservedGeneration != 2 {
+		t.Errorf("phase = %s, observedGeneration = %d, want Error and 2", got.Status.Phase, got.Status.ObservedGeneration)
+	}
+}
+
+func TestSetSecretChecksum(t *testing.T) {
+	status := &databasev1alpha1.DatabaseStatus{}
+	secret := &secrets.DatabaseSecret{Engine: "postgres", DBHost: "db", DBPort: 5432, DBName: "orders", DBUsername: "orders", DBPassword: "a"}
+
+	if err := setSecretChecksum(status, secret, ""); err != nil {
+		t.Fatal(err)
+	}
+	first := status.SecretChecksum
+	if first == "" || status.SecretRevision != 1 {
+		t.Fatalf("first secret: checksum %q revision %d, want a checksum and revision 1", first, status.SecretRevision)
+	}
+
+	// An unchanged secret keeps its revision
+	if err := setSecretChecksum(status, secret, ""); err != nil {
+		t.Fatal(err)
+	}
+	if status.SecretRevision != 1 {
+		t.Errorf("unchanged secret: revision %d, want 1", status.SecretRevision)
+	}
+
+	secret.DBPassword = "b"
+	if err := setSecretChecksum(status, secret, ""); err != nil {
+		t.Fatal(err)
+	}
+	if status.SecretChecksum == first || status.SecretRevision != 2 {
+		t.Errorf("rotated secret: checksum %q revision %d, want a new checksum and revision 2", status.SecretChecksum, status.SecretRevision)
+	}
+
+	if err := setSecretChecksum(status, secret, "{{.DBHost"); classifyError(err) != ReasonConfigError {
+		t.Errorf("invalid template: error = %v, want reason %s", err, ReasonConfigError)
+	}
+}
+
+func TestApplicationEndpoint(t *testing.T) {
+	connInfo := &database.ConnectionInfo{Host: "writer", Port: "5432"}
+	tests := []struct {
+		name     string
+		spec     databasev1alpha1.DatabaseSpec
+		wantHost string
+		wantPort int
+	}{
+		{name: "admin endpoint", wantHost: "writer", wantPort: 5432},
+		{name: "port override", spec: databasev1alpha1.DatabaseSpec{PortOverride: 6432}, wantHost: "writer", wantPort: 6432},
+		{
+			name:     "application endpoint",
+			spec:     databasev1alpha1.DatabaseSpec{ApplicationEndpoint: &databasev1alpha1.ApplicationEndpoint{Host: "proxy", Port: 5433}},
+			wantHost: "proxy",
+			wantPort: 5433,
+		},
+		{
+			name: "application endpoint without port",
+			spec: databasev1alpha1.DatabaseSpec{
+				ApplicationEndpoint: &databasev1alpha1.ApplicationEndpoint{Host: "reader"},
+				PortOverride:        6432,
+			},
+			wantHost: "reader",
+			wantPort: 6432,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, port := applicationEndpoint(&databasev1alpha1.Database{Spec: tt.spec}, connInfo)
+			if host != tt.wantHost || port != tt.wantPort {
+				t.Errorf("applicationEndpoint() = %s:%d, want %s:%d", host, port, tt.wantHost, tt.wantPort)
+			}
+		})
+	}
+}
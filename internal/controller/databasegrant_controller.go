@@ -0,0 +1,476 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// DatabaseGrantFinalizer is the finalizer for DatabaseGrant resources
+const DatabaseGrantFinalizer = "database.opzkit.io/grant-finalizer"
+
+// DatabaseGrantReconciler reconciles a DatabaseGrant object
+// It manages the privileges of users that exist outside the operator, the users are never changed,
+// and of the Microsoft Entra users it creates for spec.entraPrincipal
+type DatabaseGrantReconciler struct {
+	*DatabaseReconciler
+}
+
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=databasegrants,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=databasegrants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=databasegrants/finalizers,verbs=update
+
+func (r *DatabaseGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := r.reconcileContext(ctx)
+	defer cancel()
+	logger := log.FromContext(ctx)
+
+	grant := &databasev1alpha1.DatabaseGrant{}
+	if err := r.Get(ctx, req.NamespacedName, grant); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !grant.DeletionTimestamp.IsZero() {
+		return r.reconcileGrantDelete(ctx, grant)
+	}
+
+	if !controllerutil.ContainsFinalizer(grant, DatabaseGrantFinalizer) {
+		controllerutil.AddFinalizer(grant, DatabaseGrantFinalizer)
+		if err := r.Update(ctx, grant); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.reconcileGrant(ctx, grant); err != nil {
+		reason := classifyError(err)
+		if reason == ReasonConflict {
+			return ctrl.Result{RequeueAfter: conflictRequeue}, nil
+		}
+
+		normalizedErrMsg := normalizeErrorMessage(err.Error())
+		statusChanged := meta.SetStatusCondition(&grant.Status.Conditions, metav1.Condition{
+			Type:               ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            normalizedErrMsg,
+			ObservedGeneration: grant.Generation,
+		})
+		if grant.Status.Phase != "Error" || grant.Status.Message != normalizedErrMsg {
+			grant.Status.Phase = "Error"
+			grant.Status.Message = normalizedErrMsg
+			grant.Status.ObservedGeneration = grant.Generation
+			statusChanged = true
+		}
+		if statusChanged {
+			if statusErr := r.updateGrantStatus(ctx, grant); statusErr != nil {
+				logger.Error(statusErr, "Failed to update error status")
+			}
+			eventReason, message := errorEvent(err, reason)
+			r.Recorder.Event(grant, corev1.EventTypeWarning, eventReason, message)
+		}
+
+		if requeueAfter, ok := errorRequeue(err, reason); ok {
+			logger.Error(err, "Reconciliation failed, retrying at a fixed interval",
+				"reason", reason,
+				"requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	grant.Status.Phase = "Ready"
+	grant.Status.Message = "Privileges are granted"
+	grant.Status.ObservedGeneration = grant.Generation
+	meta.SetStatusCondition(&grant.Status.Conditions, metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonReconciled,
+		Message:            grant.Status.Message,
+		ObservedGeneration: grant.Generation,
+	})
+	if err := r.updateGrantStatus(ctx, grant); err != nil {
+		logger.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Reconciliation successful", "username", grant.Spec.Username)
+	return ctrl.Result{RequeueAfter: r.resyncInterval()}, nil
+}
+
+// reconcileGrant revokes the privileges removed from the spec and grants the listed ones
+// The grants are applied on every reconciliation, so privileges revoked outside the operator
+// are restored
+func (r *DatabaseGrantReconciler) reconcileGrant(ctx context.Context, grant *databasev1alpha1.DatabaseGrant) error {
+	logger := log.FromContext(ctx)
+
+	if err := validateGrantUser(grant); err != nil {
+		return err
+	}
+	if len(grant.Spec.Privileges) == 0 && len(grant.Spec.Objects) == 0 {
+		return newConfigError(fmt.Errorf("spec.privileges or spec.objects is required"))
+	}
+	if err := database.ValidatePrivileges(grant.Spec.Privileges); err != nil {
+		return newConfigError(err)
+	}
+	for _, object := range grant.Spec.Objects {
+		if err := database.ValidateObjectGrant(objectGrant(object)); err != nil {
+			return newConfigError(fmt.Errorf("spec.objects: %w", err))
+		}
+	}
+
+	dbClient, err := r.grantClient(ctx, grant)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dbClient.Close(); closeErr != nil {
+			logger.Error(closeErr, "Failed to close database connection")
+		}
+	}()
+
+	userExists, err := dbClient.UserExists(ctx, grant.Spec.Username)
+	if err != nil {
+		return err
+	}
+	if !userExists {
+		if grant.Spec.EntraPrincipal == nil {
+			return fmt.Errorf("user %s does not exist, it must be created outside the operator", grant.Spec.Username)
+		}
+		if err := r.createEntraUser(ctx, grant, dbClient); err != nil {
+			return err
+		}
+	}
+	dbExists, err := dbClient.DatabaseExists(ctx, grant.Spec.DatabaseName)
+	if err != nil {
+		return err
+	}
+	if !dbExists {
+		return fmt.Errorf("database %s does not exist", grant.Spec.DatabaseName)
+	}
+
+	desired := normalizePrivileges(grant.Spec.Privileges)
+	var removed []string
+	for _, privilege := range grant.Status.GrantedPrivileges {
+		if !slices.Contains(desired, privilege) {
+			removed = append(removed, privilege)
+		}
+	}
+	if len(removed) > 0 {
+		logger.Info("Revoking privileges",
+			"username", grant.Spec.Username,
+			"database", grant.Spec.DatabaseName,
+			"privileges", removed)
+		if err := dbClient.RevokeDatabasePrivileges(ctx, grant.Spec.DatabaseName, grant.Spec.Username, removed); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(grant, corev1.EventTypeNormal, "PrivilegesRevoked", "Revoked %s on %s from %s",
+			strings.Join(removed, ", "), grant.Spec.DatabaseName, grant.Spec.Username)
+	}
+
+	if len(desired) > 0 {
+		if err := dbClient.GrantDatabasePrivileges(ctx, grant.Spec.DatabaseName, grant.Spec.Username, desired); err != nil {
+			return err
+		}
+	}
+	if len(desired) > 0 && !slices.Equal(grant.Status.GrantedPrivileges, desired) {
+		logger.Info("Granted privileges",
+			"username", grant.Spec.Username,
+			"database", grant.Spec.DatabaseName,
+			"privileges", desired)
+		r.Recorder.Eventf(grant, corev1.EventTypeNormal, "PrivilegesGranted", "Granted %s on %s to %s",
+			strings.Join(desired, ", "), grant.Spec.DatabaseName, grant.Spec.Username)
+	}
+
+	desiredObjects := normalizeGrantObjects(grant.Spec.Objects)
+	if err := r.reconcileGrantObjects(ctx, grant, dbClient, desiredObjects); err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	grant.Status.GrantedPrivileges = desired
+	grant.Status.GrantedObjects = desiredObjects
+	grant.Status.LastGrantTime = &now
+	return nil
+}
+
+// reconcileGrantObjects revokes the privileges on tables and stored routines removed from the spec and
+// grants the listed ones
+func (r *DatabaseGrantReconciler) reconcileGrantObjects(ctx context.Context, grant *databasev1alpha1.DatabaseGrant, dbClient database.Client, desired []databasev1alpha1.GrantObject) error {
+	logger := log.FromContext(ctx)
+
+	for _, object := range revokedObjectPrivileges(grant.Status.GrantedObjects, desired) {
+		logger.Info("Revoking object privileges",
+			"username", grant.Spec.Username,
+			"database", grant.Spec.DatabaseName,
+			"object", object.Type+" "+object.Name,
+			"privileges", object.Privileges)
+		if err := dbClient.RevokeObjectPrivileges(ctx, grant.Spec.DatabaseName, grant.Spec.Username, objectGrant(object)); err != nil {
+			return objectGrantError(grant, err)
+		}
+		r.Recorder.Eventf(grant, corev1.EventTypeNormal, "PrivilegesRevoked", "Revoked %s on %s %s.%s from %s",
+			strings.Join(object.Privileges, ", "), object.Type, grant.Spec.DatabaseName, object.Name, grant.Spec.Username)
+	}
+
+	for _, object := range desired {
+		if err := dbClient.GrantObjectPrivileges(ctx, grant.Spec.DatabaseName, grant.Spec.Username, objectGrant(object)); err != nil {
+			return objectGrantError(grant, err)
+		}
+		if !slices.ContainsFunc(grant.Status.GrantedObjects, func(granted databasev1alpha1.GrantObject) bool {
+			return granted.Type == object.Type && granted.Name == object.Name && slices.Equal(granted.Privileges, object.Privileges)
+		}) {
+			logger.Info("Granted object privileges",
+				"username", grant.Spec.Username,
+				"database", grant.Spec.DatabaseName,
+				"object", object.Type+" "+object.Name,
+				"privileges", object.Privileges)
+			r.Recorder.Eventf(grant, corev1.EventTypeNormal, "PrivilegesGranted", "Granted %s on %s %s.%s to %s",
+				strings.Join(object.Privileges, ", "), object.Type, grant.Spec.DatabaseName, object.Name, grant.Spec.Username)
+		}
+	}
+	return nil
+}
+
+// objectGrantError turns an engine without grants on tables and routines into a config error
+func objectGrantError(grant *databasev1alpha1.DatabaseGrant, err error) error {
+	if errors.Is(err, database.ErrObjectGrantsNotSupported) {
+		return newConfigError(fmt.Errorf("spec.objects is not supported by the %s engine", grant.Spec.Engine))
+	}
+	return err
+}
+
+// objectGrant converts a grant on a table or stored routine for the database client
+func objectGrant(object databasev1alpha1.GrantObject) database.ObjectGrant {
+	return database.ObjectGrant{Type: object.Type, Name: object.Name, Privileges: object.Privileges}
+}
+
+// normalizeGrantObjects returns the object grants sorted by type and name with normalized privileges
+// Grants on the same object are merged
+func normalizeGrantObjects(objects []databasev1alpha1.GrantObject) []databasev1alpha1.GrantObject {
+	var normalized []databasev1alpha1.GrantObject
+	for _, object := range objects {
+		i := slices.IndexFunc(normalized, func(o databasev1alpha1.GrantObject) bool {
+			return o.Type == object.Type && o.Name == object.Name
+		})
+		if i < 0 {
+			normalized = append(normalized, databasev1alpha1.GrantObject{Type: object.Type, Name: object.Name})
+			i = len(normalized) - 1
+		}
+		normalized[i].Privileges = normalizePrivileges(append(normalized[i].Privileges, object.Privileges...))
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		if normalized[i].Type != normalized[j].Type {
+			return normalized[i].Type < normalized[j].Type
+		}
+		return normalized[i].Name < normalized[j].Name
+	})
+	return normalized
+}
+
+// revokedObjectPrivileges returns the granted privileges on tables and stored routines that are not desired
+func revokedObjectPrivileges(granted, desired []databasev1alpha1.GrantObject) []databasev1alpha1.GrantObject {
+	var revoked []databasev1alpha1.GrantObject
+	for _, object := range granted {
+		var keep []string
+		if i := slices.IndexFunc(desired, func(o databasev1alpha1.GrantObject) bool {
+			return o.Type == object.Type && o.Name == object.Name
+		}); i >= 0 {
+			keep = desired[i].Privileges
+		}
+		var removed []string
+		for _, privilege := range object.Privileges {
+			if !slices.Contains(keep, privilege) {
+				removed = append(removed, privilege)
+			}
+		}
+		if len(removed) > 0 {
+			revoked = append(revoked, databasev1alpha1.GrantObject{Type: object.Type, Name: object.Name, Privileges: removed})
+		}
+	}
+	return revoked
+}
+
+// validateGrantUser checks that the user of a DatabaseGrant either exists outside the operator
+// or is a Microsoft Entra user the operator creates
+func validateGrantUser(grant *databasev1alpha1.DatabaseGrant) error {
+	if grant.Spec.EntraPrincipal == nil {
+		if !grant.Spec.ExistingUser {
+			return newConfigError(fmt.Errorf("spec.existingUser must be true, DatabaseGrant only manages privileges of users created outside the operator; use a Database to create a user"))
+		}
+		return nil
+	}
+	if grant.Spec.ExistingUser {
+		return newConfigError(fmt.Errorf("spec.existingUser must be false with spec.entraPrincipal, the operator creates the user"))
+	}
+	if grant.Spec.Azure == nil || !grant.Spec.Azure.EntraAuthentication {
+		return newConfigError(fmt.Errorf("spec.entraPrincipal requires spec.azure.entraAuthentication, only a Microsoft Entra administrator can create Microsoft Entra users"))
+	}
+	return nil
+}
+
+// createEntraUser creates the user of a DatabaseGrant mapped to its Microsoft Entra principal
+func (r *DatabaseGrantReconciler) createEntraUser(ctx context.Context, grant *databasev1alpha1.DatabaseGrant, dbClient database.Client) error {
+	principal := database.EntraPrincipal{ID: grant.Spec.EntraPrincipal.ID, Type: grant.Spec.EntraPrincipal.Type}
+	err := dbClient.CreateEntraUser(ctx, grant.Spec.Username, principal)
+	if errors.Is(err, database.ErrEntraNotSupported) {
+		return newConfigError(fmt.Errorf("spec.entraPrincipal is not supported by the %s engine", grant.Spec.Engine))
+	}
+	if err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Created Microsoft Entra user",
+		"username", grant.Spec.Username,
+		"principalID", principal.ID,
+		"principalType", principal.Type)
+	r.Recorder.Eventf(grant, corev1.EventTypeNormal, EventReasonUserCreated, "Microsoft Entra user %s created for %s %s",
+		grant.Spec.Username, principal.Type, principal.ID)
+	return nil
+}
+
+// reconcileGrantDelete revokes the granted privileges unless they are retained, and removes the finalizer
+// The user itself is left untouched
+func (r *DatabaseGrantReconciler) reconcileGrantDelete(ctx context.Context, grant *databasev1alpha1.DatabaseGrant) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(grant, DatabaseGrantFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	retainOnDelete := grant.Spec.RetainOnDelete == nil || *grant.Spec.RetainOnDelete
+	if !retainOnDelete && (len(grant.Status.GrantedPrivileges) > 0 || len(grant.Status.GrantedObjects) > 0) {
+		if err := r.revokeGrantedPrivileges(ctx, grant); err != nil {
+			r.reportDeletionBlocked(ctx, grant, &grant.Status.Conditions, cleanupReason(err, ReasonRevokeFailed), err)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Revoked privileges", "username", grant.Spec.Username,
+			"privileges", grant.Status.GrantedPrivileges, "objects", len(grant.Status.GrantedObjects))
+	}
+	if !retainOnDelete && grant.Spec.EntraPrincipal != nil {
+		if err := r.dropEntraUser(ctx, grant); err != nil {
+			r.reportDeletionBlocked(ctx, grant, &grant.Status.Conditions, cleanupReason(err, ReasonUserDropFailed), err)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Dropped Microsoft Entra user", "username", grant.Spec.Username)
+	}
+
+	controllerutil.RemoveFinalizer(grant, DatabaseGrantFinalizer)
+	return ctrl.Result{}, r.Update(ctx, grant)
+}
+
+// dropEntraUser drops the Microsoft Entra user the operator created for a DatabaseGrant
+func (r *DatabaseGrantReconciler) dropEntraUser(ctx context.Context, grant *databasev1alpha1.DatabaseGrant) error {
+	logger := log.FromContext(ctx)
+
+	dbClient, err := r.grantClient(ctx, grant)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dbClient.Close(); closeErr != nil {
+			logger.Error(closeErr, "Failed to close database connection")
+		}
+	}()
+
+	userExists, err := dbClient.UserExists(ctx, grant.Spec.Username)
+	if err != nil || !userExists {
+		return err
+	}
+	return dbClient.DropUser(ctx, grant.Spec.Username)
+}
+
+// revokeGrantedPrivileges revokes the privileges on the database and its objects recorded in the status
+// Nothing is revoked if the user or the database no longer exists
+func (r *DatabaseGrantReconciler) revokeGrantedPrivileges(ctx context.Context, grant *databasev1alpha1.DatabaseGrant) error {
+	logger := log.FromContext(ctx)
+
+	dbClient, err := r.grantClient(ctx, grant)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dbClient.Close(); closeErr != nil {
+			logger.Error(closeErr, "Failed to close database connection")
+		}
+	}()
+
+	userExists, err := dbClient.UserExists(ctx, grant.Spec.Username)
+	if err != nil || !userExists {
+		return err
+	}
+	dbExists, err := dbClient.DatabaseExists(ctx, grant.Spec.DatabaseName)
+	if err != nil || !dbExists {
+		return err
+	}
+	for _, object := range grant.Status.GrantedObjects {
+		if err := dbClient.RevokeObjectPrivileges(ctx, grant.Spec.DatabaseName, grant.Spec.Username, objectGrant(object)); err != nil {
+			return err
+		}
+	}
+	if len(grant.Status.GrantedPrivileges) == 0 {
+		return nil
+	}
+	return dbClient.RevokeDatabasePrivileges(ctx, grant.Spec.DatabaseName, grant.Spec.Username, grant.Status.GrantedPrivileges)
+}
+
+// grantClient connects to the server of a DatabaseGrant with its admin connection string
+// Errors name the cleanup step they block when the DatabaseGrant is deleted
+func (r *DatabaseGrantReconciler) grantClient(ctx context.Context, grant *databasev1alpha1.DatabaseGrant) (database.Client, error) {
+	connectionString, err := r.getConnectionString(ctx, connectionSourceView(grant, grant.Spec.Engine, grant.Spec.DatabaseName,
+		grant.Spec.ConnectionStringSecretRef, grant.Spec.ConnectionStringAWSSecretRef))
+	if err != nil {
+		return nil, newCleanupError(ReasonConnectionStringUnavailable, err)
+	}
+	dbClient, err := r.databaseClient(ctx, string(grant.Spec.Engine), connectionString, grant.Spec.CloudSQL, grant.Spec.Azure)
+	return dbClient, newCleanupError(ReasonCleanupConnectionFailed, err)
+}
+
+// normalizePrivileges returns the privileges upper-cased, sorted and without duplicates
+// ALL PRIVILEGES is recorded as ALL
+func normalizePrivileges(privileges []string) []string {
+	normalized := make([]string, 0, len(privileges))
+	for _, privilege := range privileges {
+		p := strings.ToUpper(strings.TrimSpace(privilege))
+		if p == "ALL PRIVILEGES" {
+			p = "ALL"
+		}
+		normalized = append(normalized, p)
+	}
+	sort.Strings(normalized)
+	return slices.Compact(normalized)
+}
+
+func (r *DatabaseGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.DatabaseGrant{}, builder.WithPredicates(r.Shard.Predicate())).
+		Named("databasegrant").
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// updateGrantStatus writes the status of a DatabaseGrant, retrying conflicts
+func (r *DatabaseGrantReconciler) updateGrantStatus(ctx context.Context, grant *databasev1alpha1.DatabaseGrant) error {
+	return updateStatusRetryingConflicts(ctx, r.Client, grant, func(latest *databasev1alpha1.DatabaseGrant) {
+		latest.Status = grant.Status
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestNormalizePrivileges(t *testing.T) {
+	tests := []struct {
+		name       string
+		privileges []string
+		want       []string
+	}{
+		{name: "sorted and upper-cased", privileges: []string{"update", "SELECT"}, want: []string{"SELECT", "UPDATE"}},
+		{name: "duplicates", privileges: []string{"select", "SELECT"}, want: []string{"SELECT"}},
+		{name: "all privileges", privileges: []string{"all privileges", "ALL"}, want: []string{"ALL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizePrivileges(tt.privileges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizePrivileges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeGrantObjects(t *testing.T) {
+	objects := []databasev1alpha1.GrantObject{
+		{Type: "table", Name: "orders", Privileges: []string{"select"}},
+		{Type: "procedure", Name: "refresh", Privileges: []string{"execute"}},
+		{Type: "table", Name: "orders", Privileges: []string{"INSERT", "SELECT"}},
+	}
+	want := []databasev1alpha1.GrantObject{
+		{Type: "procedure", Name: "refresh", Privileges: []string{"EXECUTE"}},
+		{Type: "table", Name: "orders", Privileges: []string{"INSERT", "SELECT"}},
+	}
+	if got := normalizeGrantObjects(objects); !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeGrantObjects() = %v, want %v", got, want)
+	}
+}
+
+func TestRevokedObjectPrivileges(t *testing.T) {
+	granted := []databasev1alpha1.GrantObject{
+		{Type: "function", Name: "tax", Privileges: []string{"EXECUTE"}},
+		{Type: "table", Name: "orders", Privileges: []string{"INSERT", "SELECT"}},
+		{Type: "table", Name: "customers", Privileges: []string{"SELECT"}},
+	}
+	desired := []databasev1alpha1.GrantObject{
+		{Type: "procedure", Name: "tax", Privileges: []string{"EXECUTE"}},
+		{Type: "table", Name: "orders", Privileges: []string{"SELECT"}},
+		{Type: "table", Name: "customers", Privileges: []string{"SELECT", "UPDATE"}},
+	}
+	want := []databasev1alpha1.GrantObject{
+		{Type: "function", Name: "tax", Privileges: []string{"EXECUTE"}},
+		{Type: "table", Name: "orders", Privileges: []string{"INSERT"}},
+	}
+	if got := revokedObjectPrivileges(granted, desired); !reflect.DeepEqual(got, want) {
+		t.Errorf("revokedObjectPrivileges() = %v, want %v", got, want)
+	}
+}
+
+func TestReconcileGrantConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec databasev1alpha1.DatabaseGrantSpec
+	}{
+		{
+			name: "existingUser false",
+			spec: databasev1alpha1.DatabaseGrantSpec{Username: "app", DatabaseName: "app", Privileges: []string{"SELECT"}},
+		},
+		{
+			name: "invalid privilege",
+			spec: databasev1alpha1.DatabaseGrantSpec{Username: "app", ExistingUser: true, DatabaseName: "app", Privileges: []string{"SELECT; DROP"}},
+		},
+		{
+			name: "no privileges or objects",
+			spec: databasev1alpha1.DatabaseGrantSpec{Username: "app", ExistingUser: true, DatabaseName: "app"},
+		},
+		{
+			name: "invalid object",
+			spec: databasev1alpha1.DatabaseGrantSpec{
+				Username: "app", ExistingUser: true, DatabaseName: "app",
+				Objects: []databasev1alpha1.GrantObject{{Type: "table", Name: "app.orders", Privileges: []string{"SELECT"}}},
+			},
+		},
+		{
+			name: "entraPrincipal with existingUser",
+			spec: databasev1alpha1.DatabaseGrantSpec{
+				Username: "app", ExistingUser: true, DatabaseName: "app", Privileges: []string{"SELECT"},
+				EntraPrincipal: &databasev1alpha1.EntraPrincipal{ID: "00000000-0000-0000-0000-000000000001", Type: "servicePrincipal"},
+				Azure:          &databasev1alpha1.AzureConfig{EntraAuthentication: true},
+			},
+		},
+		{
+			name: "entraPrincipal without entraAuthentication",
+			spec: databasev1alpha1.DatabaseGrantSpec{
+				Username: "app", DatabaseName: "app", Privileges: []string{"SELECT"},
+				EntraPrincipal: &databasev1alpha1.EntraPrincipal{ID: "00000000-0000-0000-0000-000000000001", Type: "servicePrincipal"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseGrantReconciler{DatabaseReconciler: newClusterDatabaseTestReconciler(t)}
+			grant := &databasev1alpha1.DatabaseGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "team-a"},
+				Spec:       tt.spec,
+			}
+
+			err := r.reconcileGrant(context.Background(), grant)
+			if err == nil || classifyError(err) != ReasonConfigError {
+				t.Errorf("reconcileGrant() error = %v, want config error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// DatabaseRoleFinalizer is the finalizer for DatabaseRole resources
+const DatabaseRoleFinalizer = "database.opzkit.io/role-finalizer"
+
+// DatabaseRoleReconciler reconciles a DatabaseRole object
+// It shares the configuration of the DatabaseReconciler, admin connection strings are resolved the same way
+type DatabaseRoleReconciler struct {
+	*DatabaseReconciler
+}
+
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=databaseroles,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=databaseroles/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.opzkit.io,resources=databaseroles/finalizers,verbs=update
+
+func (r *DatabaseRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := r.reconcileContext(ctx)
+	defer cancel()
+	logger := log.FromContext(ctx)
+
+	role := &databasev1alpha1.DatabaseRole{}
+	if err := r.Get(ctx, req.NamespacedName, role); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !role.DeletionTimestamp.IsZero() {
+		return r.reconcileRoleDelete(ctx, role)
+	}
+
+	if !controllerutil.ContainsFinalizer(role, DatabaseRoleFinalizer) {
+		controllerutil.AddFinalizer(role, DatabaseRoleFinalizer)
+		if err := r.Update(ctx, role); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.reconcileRole(ctx, role); err != nil {
+		reason := classifyError(err)
+		if reason == ReasonConflict {
+			return ctrl.Result{RequeueAfter: conflictRequeue}, nil
+		}
+
+		normalizedErrMsg := normalizeErrorMessage(err.Error())
+		statusChanged := meta.SetStatusCondition(&role.Status.Conditions, metav1.Condition{
+			Type:               ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            normalizedErrMsg,
+			ObservedGeneration: role.Generation,
+		})
+		if role.Status.Phase != "Error" || role.Status.Message != normalizedErrMsg {
+			role.Status.Phase = "Error"
+			role.Status.Message = normalizedErrMsg
+			role.Status.ObservedGeneration = role.Generation
+			statusChanged = true
+		}
+		if statusChanged {
+			if statusErr := r.updateRoleStatus(ctx, role); statusErr != nil {
+				logger.Error(statusErr, "Failed to update error status")
+			}
+			eventReason, message := errorEvent(err, reason)
+			r.Recorder.Event(role, corev1.EventTypeWarning, eventReason, message)
+		}
+
+		if requeueAfter, ok := errorRequeue(err, reason); ok {
+			logger.Error(err, "Reconciliation failed, retrying at a fixed interval",
+				"reason", reason,
+				"requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	role.Status.Phase = "Ready"
+	role.Status.Message = "Role is ready"
+	role.Status.ObservedGeneration = role.Generation
+	meta.SetStatusCondition(&role.Status.Conditions, metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonReconciled,
+		Message:            role.Status.Message,
+		ObservedGeneration: role.Generation,
+	})
+	if err := r.updateRoleStatus(ctx, role); err != nil {
+		logger.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Reconciliation successful", "role", role.Status.ActualRoleName)
+	return ctrl.Result{RequeueAfter: r.resyncInterval()}, nil
+}
+
+// reconcileRole creates the role and grants its privileges
+// Privileges removed from the spec are not revoked
+func (r *DatabaseRoleReconciler) reconcileRole(ctx context.Context, role *databasev1alpha1.DatabaseRole) error {
+	logger := log.FromContext(ctx)
+
+	if err := database.ValidatePrivileges(role.Spec.Privileges); err != nil {
+		return newConfigError(err)
+	}
+
+	dbClient, err := r.roleClient(ctx, role)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dbClient.Close(); closeErr != nil {
+			logger.Error(closeErr, "Failed to close database connection")
+		}
+	}()
+
+	exists, err := dbClient.RoleExists(ctx, role.Spec.RoleName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		logger.Info("Creating database role", "role", role.Spec.RoleName)
+		if err := dbClient.CreateRole(ctx, role.Spec.RoleName); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(role, corev1.EventTypeNormal, "RoleCreated", "Role %s created on %s",
+			role.Spec.RoleName, dbClient.GetConnectionInfo().Host)
+	}
+	role.Status.RoleCreated = true
+	role.Status.ActualRoleName = role.Spec.RoleName
+
+	if len(role.Spec.Privileges) == 0 {
+		return nil
+	}
+
+	dbExists, err := dbClient.DatabaseExists(ctx, role.Spec.DatabaseName)
+	if err != nil {
+		return err
+	}
+	if !dbExists {
+		return fmt.Errorf("database %s does not exist, privileges of role %s cannot be granted yet",
+			role.Spec.DatabaseName, role.Spec.RoleName)
+	}
+
+	logger.Info("Granting role privileges",
+		"role", role.Spec.RoleName,
+		"database", role.Spec.DatabaseName,
+		"privileges", role.Spec.Privileges)
+	return dbClient.GrantDatabasePrivileges(ctx, role.Spec.DatabaseName, role.Spec.RoleName, role.Spec.Privileges)
+}
+
+// reconcileRoleDelete drops the role unless it is retained, and removes the finalizer
+func (r *DatabaseRoleReconciler) reconcileRoleDelete(ctx context.Context, role *databasev1alpha1.DatabaseRole) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(role, DatabaseRoleFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	retainOnDelete := role.Spec.RetainOnDelete == nil || *role.Spec.RetainOnDelete
+	if !retainOnDelete && role.Status.RoleCreated {
+		dbClient, err := r.roleClient(ctx, role)
+		if err != nil {
+			r.reportDeletionBlocked(ctx, role, &role.Status.Conditions, cleanupReason(err, ReasonCleanupConnectionFailed), err)
+			return ctrl.Result{}, err
+		}
+		defer func() {
+			if closeErr := dbClient.Close(); closeErr != nil {
+				logger.Error(closeErr, "Failed to close database connection")
+			}
+		}()
+
+		logger.Info("Dropping database role", "role", role.Spec.RoleName)
+		if err := dbClient.DropRole(ctx, role.Spec.DatabaseName, role.Spec.RoleName); err != nil {
+			r.reportDeletionBlocked(ctx, role, &role.Status.Conditions, ReasonRoleDropFailed, err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(role, DatabaseRoleFinalizer)
+	return ctrl.Result{}, r.Update(ctx, role)
+}
+
+// roleClient connects to the server of a DatabaseRole with its admin connection string
+// Errors name the cleanup step they block when the DatabaseRole is deleted
+func (r *DatabaseRoleReconciler) roleClient(ctx context.Context, role *databasev1alpha1.DatabaseRole) (database.Client, error) {
+	connectionString, err := r.getConnectionString(ctx, connectionSourceView(role, role.Spec.Engine, role.Spec.DatabaseName,
+		role.Spec.ConnectionStringSecretRef, role.Spec.ConnectionStringAWSSecretRef))
+	if err != nil {
+		return nil, newCleanupError(ReasonConnectionStringUnavailable, err)
+	}
+	dbClient, err := r.databaseClient(ctx, string(role.Spec.Engine), connectionString, role.Spec.CloudSQL, role.Spec.Azure)
+	return dbClient, newCleanupError(ReasonCleanupConnectionFailed, err)
+}
+
+// connectionSourceView returns a Database carrying the admin connection source of another kind,
+// so the connection string is resolved like the one of a Database in the same namespace
+func connectionSourceView(obj client.Object, engine databasev1alpha1.DatabaseEngine, databaseName string,
+	secretRef *databasev1alpha1.SecretKeyReference, awsSecretRef *databasev1alpha1.AWSSecretReference) *databasev1alpha1.Database {
+	return &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:                       engine,
+			DatabaseName:                 databaseName,
+			ConnectionStringSecretRef:    secretRef,
+			ConnectionStringAWSSecretRef: awsSecretRef,
+		},
+	}
+}
+
+func (r *DatabaseRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.DatabaseRole{}, builder.WithPredicates(r.Shard.Predicate())).
+		Named("databaserole").
+		WithOptions(controllerOptions()).
+		Complete(r)
+}
+
+// reconcileMemberships grants the roles of the DatabaseRoles listed in spec.memberOf to the user
+// and revokes the roles granted earlier that are no longer listed
+func (r *DatabaseReconciler) reconcileMemberships(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, username string) error {
+	logger := log.FromContext(ctx)
+
+	if len(db.Spec.MemberOf) > 0 && !r.DatabaseRoles {
+		return newConfigError(fmt.Errorf("spec.memberOf requires the operator to run with --enable-database-roles"))
+	}
+
+	desired, err := r.memberOfRoles(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, roleName := range desired {
+		if err := dbClient.GrantRole(ctx, roleName, username); err != nil {
+			return err
+		}
+		if !slices.Contains(db.Status.MemberOf, roleName) {
+			logger.Info("Granted role to user", "role", roleName, "username", username)
+			r.recordNormal(db, EventReasonRoleGranted, "Role %s granted to user %s", roleName, username)
+		}
+	}
+	for _, roleName := range db.Status.MemberOf {
+		if slices.Contains(desired, roleName) {
+			continue
+		}
+		if err := requireMaintenanceWindow(ctx, db, "revoking role %s from %s", roleName, username); err != nil {
+			return err
+		}
+		if err := dbClient.RevokeRole(ctx, roleName, username); err != nil {
+			return err
+		}
+		logger.Info("Revoked role from user", "role", roleName, "username", username)
+		r.recordNormal(db, EventReasonRoleRevoked, "Role %s revoked from user %s", roleName, username)
+	}
+
+	db.Status.MemberOf = desired
+	return nil
+}
+
+// memberOfRoles resolves spec.memberOf to the sorted names of the ready roles
+func (r *DatabaseReconciler) memberOfRoles(ctx context.Context, db *databasev1alpha1.Database) ([]string, error) {
+	if len(db.Spec.MemberOf) == 0 {
+		return nil, nil
+	}
+
+	namespace, err := r.secretNamespace(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(db.Spec.MemberOf))
+	for _, name := range db.Spec.MemberOf {
+		role := &databasev1alpha1.DatabaseRole{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, role); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, newConfigError(fmt.Errorf("DatabaseRole %s/%s referenced in spec.memberOf not found", namespace, name))
+			}
+			return nil, err
+		}
+		if database.EngineFamily(string(role.Spec.Engine)) != database.EngineFamily(string(db.Spec.Engine)) {
+			return nil, newConfigError(fmt.Errorf("DatabaseRole %s uses engine %s, the Database uses %s",
+				name, role.Spec.Engine, db.Spec.Engine))
+		}
+		if !role.Status.RoleCreated {
+			return nil, fmt.Errorf("DatabaseRole %s is not ready yet", name)
+		}
+		roles = append(roles, role.Status.ActualRoleName)
+	}
+	sort.Strings(roles)
+	return slices.Compact(roles), nil
+}
+
+// updateRoleStatus writes the status of a DatabaseRole, retrying conflicts
+func (r *DatabaseRoleReconciler) updateRoleStatus(ctx context.Context, role *databasev1alpha1.DatabaseRole) error {
+	return updateStatusRetryingConflicts(ctx, r.Client, role, func(latest *databasev1alpha1.DatabaseRole) {
+		latest.Status = role.Status
+	})
+}
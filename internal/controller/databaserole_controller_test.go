@@ -0,0 +1,169 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func testDatabaseRole(name, roleName string, engine databasev1alpha1.DatabaseEngine, created bool) *databasev1alpha1.DatabaseRole {
+	role := &databasev1alpha1.DatabaseRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: databasev1alpha1.DatabaseRoleSpec{
+			Engine:       engine,
+			RoleName:     roleName,
+			DatabaseName: "app",
+		},
+	}
+	if created {
+		role.Status = databasev1alpha1.DatabaseRoleStatus{RoleCreated: true, ActualRoleName: roleName}
+	}
+	return role
+}
+
+func TestMemberOfRoles(t *testing.T) {
+	objs := []client.Object{
+		testDatabaseRole("readers", "app_readers", databasev1alpha1.DatabaseEnginePostgreSQL, true),
+		testDatabaseRole("writers", "app_writers", databasev1alpha1.DatabaseEnginePostgres, true),
+		testDatabaseRole("pending", "app_pending", databasev1alpha1.DatabaseEnginePostgres, false),
+		testDatabaseRole("mysql", "app_mysql", databasev1alpha1.DatabaseEngineMySQL, true),
+	}
+
+	tests := []struct {
+		name       string
+		memberOf   []string
+		want       []string
+		wantReason string
+	}{
+		{name: "none"},
+		{name: "sorted", memberOf: []string{"writers", "readers"}, want: []string{"app_readers", "app_writers"}},
+		{name: "missing", memberOf: []string{"unknown"}, wantReason: ReasonConfigError},
+		{name: "other engine family", memberOf: []string{"mysql"}, wantReason: ReasonConfigError},
+		{name: "not created yet", memberOf: []string{"pending"}, wantReason: ReasonTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newClusterDatabaseTestReconciler(t, objs...)
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:   databasev1alpha1.DatabaseEnginePostgres,
+					MemberOf: tt.memberOf,
+				},
+			}
+
+			got, err := r.memberOfRoles(context.Background(), db)
+			if tt.wantReason != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if reason := classifyError(err); reason != tt.wantReason {
+					t.Errorf("reason = %s, want %s", reason, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("memberOfRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileMembershipsRequiresFlag(t *testing.T) {
+	r := newClusterDatabaseTestReconciler(t)
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec:       databasev1alpha1.DatabaseSpec{MemberOf: []string{"readers"}},
+	}
+
+	err := r.reconcileMemberships(context.Background(), db, nil, "app")
+	if err == nil || classifyError(err) != ReasonConfigError {
+		t.Errorf("reconcileMemberships() error = %v, want config error", err)
+	}
+}
+
+func TestConnectionSourceView(t *testing.T) {
+	role := testDatabaseRole("readers", "app_readers", databasev1alpha1.DatabaseEngineMySQL, false)
+	role.Spec.ConnectionStringSecretRef = &databasev1alpha1.SecretKeyReference{Name: "admin"}
+
+	db := connectionSourceView(role, role.Spec.Engine, role.Spec.DatabaseName,
+		role.Spec.ConnectionStringSecretRef, role.Spec.ConnectionStringAWSSecretRef)
+	if db.Namespace != "team-a" || db.Spec.Engine != databasev1alpha1.DatabaseEngineMySQL {
+		t.Errorf("unexpected view %s/%s engine %s", db.Namespace, db.Name, db.Spec.Engine)
+	}
+	if db.Spec.ConnectionStringSecretRef != role.Spec.ConnectionStringSecretRef {
+		t.Error("connection string reference not carried over")
+	}
+}
+
+func TestReconcileRoleDeleteBlocked(t *testing.T) {
+	ctx := context.Background()
+	now := metav1.Now()
+	role := testDatabaseRole("readers", "readers", databasev1alpha1.DatabaseEnginePostgres, true)
+	role.Finalizers = []string{DatabaseRoleFinalizer}
+	role.DeletionTimestamp = &now
+	role.Spec.RetainOnDelete = boolPtr(false)
+	// The admin secret does not exist
+	role.Spec.ConnectionStringSecretRef = &databasev1alpha1.SecretKeyReference{Name: "admin"}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(role).
+		WithStatusSubresource(&databasev1alpha1.DatabaseRole{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &DatabaseRoleReconciler{DatabaseReconciler: &DatabaseReconciler{Client: c, Scheme: scheme, Recorder: recorder}}
+
+	if _, err := r.reconcileRoleDelete(ctx, role); err == nil {
+		t.Fatal("reconcileRoleDelete() succeeded without an admin connection string")
+	}
+
+	stored := &databasev1alpha1.DatabaseRole{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(role), stored); err != nil {
+		t.Fatalf("DatabaseRole deleted despite the failed cleanup: %v", err)
+	}
+	cond := meta.FindStatusCondition(stored.Status.Conditions, ConditionDeletionBlocked)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ReasonConnectionStringUnavailable {
+		t.Errorf("DeletionBlocked condition = %+v, want True/%s", cond, ReasonConnectionStringUnavailable)
+	}
+	if len(recorder.Events) != 1 || !strings.HasPrefix(<-recorder.Events, "Warning "+EventReasonDeletionBlocked) {
+		t.Error("DeletionBlocked event not recorded")
+	}
+
+	// An unchanged failure is not reported again
+	if _, err := r.reconcileRoleDelete(ctx, stored); err == nil {
+		t.Fatal("reconcileRoleDelete() succeeded on retry")
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("%d events recorded for an unchanged failure, want 0", len(recorder.Events))
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DebugPath is the path prefix of the debug endpoint served on the metrics server
+const DebugPath = "/debug/databases/"
+
+// Reconcile decision branches recorded in ReconcileTrace.Branch
+const (
+	BranchStartupDelay     = "startup-delay"
+	BranchRetryBackoff     = "retry-backoff"
+	BranchDeleting         = "deleting"
+	BranchFinalizerAdded   = "finalizer-added"
+	BranchUpToDate         = "up-to-date"
+	BranchFormatMigration  = "secret-format-migration"
+	BranchAllExist         = "all-exist"
+	BranchRegionChange     = "region-change-recovery"
+	BranchSecretMissing    = "secret-missing"
+	BranchCreateMissing    = "create-missing"
+	BranchResumeProvision  = "resume-provision"
+	BranchValidationFailed = "validation-failed"
+	BranchDryRun           = "dry-run"
+	BranchExpired          = "expired"
+)
+
+// ReconcileTrace is the operator's record of the last reconciliation of a Database
+// It is kept in memory only and lost on restart
+type ReconcileTrace struct {
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	Time       time.Time `json:"time"`
+	Generation int64     `json:"generation"`
+
+	// Branch is the last decision branch taken
+	Branch string `json:"branch,omitempty"`
+
+	// Existence of the resources as checked during the reconciliation, unset if not checked
+	DatabaseExists *bool `json:"databaseExists,omitempty"`
+	UserExists     *bool `json:"userExists,omitempty"`
+	SecretExists   *bool `json:"secretExists,omitempty"`
+
+	// SecretVersion is the last AWS secret version written
+	SecretVersion string `json:"secretVersion,omitempty"`
+
+	Error  string `json:"error,omitempty"`
+	Reason string `json:"reason,omitempty"`
+
+	// NextRequeue is when the Database is reconciled again, unset if it is retried with backoff
+	NextRequeue *time.Time `json:"nextRequeue,omitempty"`
+}
+
+// reconcileTraces stores the last ReconcileTrace of each Database
+type reconcileTraces struct {
+	mu     sync.RWMutex
+	traces map[types.NamespacedName]ReconcileTrace
+}
+
+// record stores the trace of a finished reconciliation
+func (t *reconcileTraces) record(trace *ReconcileTrace, result ctrl.Result, err error) {
+	if err != nil && trace.Error == "" {
+		trace.Error = err.Error()
+	}
+	if err == nil && result.RequeueAfter > 0 {
+		next := trace.Time.Add(result.RequeueAfter)
+		trace.NextRequeue = &next
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.traces == nil {
+		t.traces = make(map[types.NamespacedName]ReconcileTrace)
+	}
+	t.traces[types.NamespacedName{Namespace: trace.Namespace, Name: trace.Name}] = *trace
+}
+
+// forget removes the trace of a Database that no longer exists
+func (t *reconcileTraces) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.traces, key)
+}
+
+// get returns the trace of a Database
+func (t *reconcileTraces) get(key types.NamespacedName) (ReconcileTrace, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	trace, ok := t.traces[key]
+	return trace, ok
+}
+
+// list returns all traces sorted by namespace and name
+func (t *reconcileTraces) list() []ReconcileTrace {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	traces := make([]ReconcileTrace, 0, len(t.traces))
+	for _, trace := range t.traces {
+		traces = append(traces, trace)
+	}
+	sort.Slice(traces, func(i, j int) bool {
+		if traces[i].Namespace != traces[j].Namespace {
+			return traces[i].Namespace < traces[j].Namespace
+		}
+		return traces[i].Name < traces[j].Name
+	})
+	return traces
+}
+
+// DebugHandler serves the last ReconcileTrace of Databases as JSON
+// GET /debug/databases/ lists all traces, GET /debug/databases/<namespace>/<name> returns one
+// Traces are only recorded on the replica that reconciles, normally the leader
+func (r *DatabaseReconciler) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		path := strings.Trim(strings.TrimPrefix(req.URL.Path, DebugPath), "/")
+		if path == "" {
+			writeJSON(w, r.traces.list())
+			return
+		}
+
+		// ClusterDatabases are addressed by name only
+		parts := strings.Split(path, "/")
+		key := types.NamespacedName{Name: parts[0]}
+		switch len(parts) {
+		case 1:
+		case 2:
+			key = types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+		default:
+			http.Error(w, "expected "+DebugPath+"<namespace>/<name> or "+DebugPath+"<name>", http.StatusBadRequest)
+			return
+		}
+		trace, ok := r.traces.get(key)
+		if !ok {
+			http.Error(w, "no reconciliation recorded for "+path, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, trace)
+	})
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
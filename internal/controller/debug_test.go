@@ -0,0 +1,95 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestReconcileTracesRecord(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var traces reconcileTraces
+
+	traces.record(&ReconcileTrace{Namespace: "default", Name: "ok", Time: now, Branch: BranchAllExist}, ctrl.Result{RequeueAfter: 10 * time.Minute}, nil)
+	traces.record(&ReconcileTrace{Namespace: "default", Name: "failed", Time: now}, ctrl.Result{}, errors.New("connection refused"))
+
+	ok, found := traces.get(types.NamespacedName{Namespace: "default", Name: "ok"})
+	if !found {
+		t.Fatal("expected trace for default/ok")
+	}
+	if ok.NextRequeue == nil || !ok.NextRequeue.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("NextRequeue = %v, want %v", ok.NextRequeue, now.Add(10*time.Minute))
+	}
+
+	failed, _ := traces.get(types.NamespacedName{Namespace: "default", Name: "failed"})
+	if failed.Error != "connection refused" {
+		t.Errorf("Error = %q, want %q", failed.Error, "connection refused")
+	}
+	if failed.NextRequeue != nil {
+		t.Errorf("NextRequeue = %v, want nil for backoff", failed.NextRequeue)
+	}
+
+	list := traces.list()
+	if len(list) != 2 || list[0].Name != "failed" || list[1].Name != "ok" {
+		t.Errorf("list() = %v, want failed and ok sorted by name", list)
+	}
+
+	traces.forget(types.NamespacedName{Namespace: "default", Name: "ok"})
+	if _, found := traces.get(types.NamespacedName{Namespace: "default", Name: "ok"}); found {
+		t.Error("expected trace to be forgotten")
+	}
+}
+
+func TestDebugHandler(t *testing.T) {
+	r := &DatabaseReconciler{}
+	r.traces.record(&ReconcileTrace{Namespace: "team-a", Name: "app", Branch: BranchCreateMissing}, ctrl.Result{}, nil)
+	r.traces.record(&ReconcileTrace{Name: "platform", Branch: BranchAllExist}, ctrl.Result{}, nil)
+	handler := r.DebugHandler()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "list", method: http.MethodGet, path: DebugPath, wantStatus: http.StatusOK},
+		{name: "single", method: http.MethodGet, path: DebugPath + "team-a/app", wantStatus: http.StatusOK},
+		{name: "unknown", method: http.MethodGet, path: DebugPath + "team-a/other", wantStatus: http.StatusNotFound},
+		{name: "cluster", method: http.MethodGet, path: DebugPath + "platform", wantStatus: http.StatusOK},
+		{name: "malformed", method: http.MethodGet, path: DebugPath + "team-a/app/extra", wantStatus: http.StatusBadRequest},
+		{name: "post", method: http.MethodPost, path: DebugPath, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DebugPath+"team-a/app", nil))
+	var trace ReconcileTrace
+	if err := json.Unmarshal(rec.Body.Bytes(), &trace); err != nil {
+		t.Fatalf("failed to decode trace: %v", err)
+	}
+	if trace.Branch != BranchCreateMissing {
+		t.Errorf("Branch = %q, want %q", trace.Branch, BranchCreateMissing)
+	}
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// ConditionDryRun is the condition type reporting the outcome of the last dry run of a Database
+// It is only set while spec.dryRun or the --dry-run flag of the operator is enabled.
+const ConditionDryRun = "DryRun"
+
+// Reasons of the DryRun condition, a failed dry run is reported with the reason of the error
+const (
+	// ReasonChangesPlanned means the dry run found changes, listed in status.plannedChanges
+	ReasonChangesPlanned = "ChangesPlanned"
+	// ReasonNoChanges means the server and AWS already match the spec
+	ReasonNoChanges = "NoChanges"
+)
+
+// changePlan collects the changes a dry-run reconciliation would make instead of making them
+type changePlan struct {
+	changes []string
+}
+
+// add records a change, a change found twice in a reconciliation is recorded once
+func (p *changePlan) add(format string, args ...interface{}) {
+	change := fmt.Sprintf(format, args...)
+	if slices.Contains(p.changes, change) {
+		return
+	}
+	p.changes = append(p.changes, change)
+}
+
+type changePlanKey struct{}
+
+// withChangePlan returns a context in which the changes of a reconciliation are recorded in plan
+func withChangePlan(ctx context.Context, plan *changePlan) context.Context {
+	return context.WithValue(ctx, changePlanKey{}, plan)
+}
+
+// changePlanFrom returns the plan of the dry run ctx belongs to, or nil outside of a dry run
+func changePlanFrom(ctx context.Context) *changePlan {
+	plan, _ := ctx.Value(changePlanKey{}).(*changePlan)
+	return plan
+}
+
+// plannedChange records a change in the plan of a dry run and reports whether ctx belongs to one
+// Callers skip the change when it returns true.
+func plannedChange(ctx context.Context, format string, args ...interface{}) bool {
+	plan := changePlanFrom(ctx)
+	if plan == nil {
+		return false
+	}
+	plan.add(format, args...)
+	return true
+}
+
+// isDryRun reports whether a Database is only planned, not reconciled
+func (r *DatabaseReconciler) isDryRun(db *databasev1alpha1.Database) bool {
+	return r.DryRun || db.Spec.DryRun
+}
+
+// reconcileDryRun reconciles a copy of a Database with the changes to the database server and AWS
+// Secrets Manager recorded instead of made, and reports them in status.plannedChanges and the DryRun
+// condition. The rest of the status, including the Ready condition, is left as it is.
+func (r *DatabaseReconciler) reconcileDryRun(ctx context.Context, db *databasev1alpha1.Database, trace *ReconcileTrace) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	plan := &changePlan{}
+	err := r.reconcileDatabase(withChangePlan(ctx, plan), db.DeepCopy(), trace)
+	trace.Branch = BranchDryRun
+
+	condition := metav1.Condition{
+		Type:               ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonNoChanges,
+		Message:            "The database, user and secret match the spec",
+		ObservedGeneration: db.Generation,
+	}
+	changes := plan.changes
+	var reason string
+	if err != nil {
+		// An incomplete plan is not reported, it would hide the changes after the failed step
+		reason = classifyError(err)
+		trace.Error = err.Error()
+		trace.Reason = reason
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = normalizeErrorMessage(err.Error())
+		changes = nil
+	} else if len(changes) > 0 {
+		condition.Reason = ReasonChangesPlanned
+		condition.Message = fmt.Sprintf("%d changes planned, see status.plannedChanges", len(changes))
+	}
+
+	statusChanged := meta.SetStatusCondition(&db.Status.Conditions, condition)
+	if !slices.Equal(db.Status.PlannedChanges, changes) {
+		db.Status.PlannedChanges = changes
+		statusChanged = true
+		if len(changes) > 0 {
+			r.recordEvent(db, corev1.EventTypeNormal, EventReasonChangesPlanned, "Dry run planned %d changes", len(changes))
+		}
+	}
+	if refreshLastCheckedTime(&db.Status, time.Now()) {
+		statusChanged = true
+	}
+	if statusChanged {
+		if statusErr := r.updateStatus(ctx, db); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+	}
+
+	if err != nil {
+		if requeueAfter, ok := errorRequeue(err, reason); ok {
+			logger.Error(err, "Dry run failed, retrying at a fixed interval",
+				"reason", reason,
+				"requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	requeueAfter := r.requeueInterval(db)
+	logger.Info("Dry run finished",
+		"database", db.Spec.DatabaseName,
+		"plannedChanges", len(changes),
+		"requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// sortedList joins names in sorted order, so a plan does not change with map iteration order
+func sortedList(names []string) string {
+	sorted := slices.Clone(names)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// formatTags formats tags as key=value pairs sorted by key
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+value)
+	}
+	return sortedList(pairs)
+}
+
+// planningClient passes reads through to a database client and records its writes in a plan
+type planningClient struct {
+	database.Client
+	plan *changePlan
+}
+
+func (c *planningClient) CreateUser(_ context.Context, username, _ string) error {
+	c.plan.add("create user %s", username)
+	return nil
+}
+
+func (c *planningClient) DropUser(_ context.Context, username string) error {
+	c.plan.add("drop user %s", username)
+	return nil
+}
+
+func (c *planningClient) CreateDatabase(_ context.Context, databaseName, owner string) error {
+	c.plan.add("create database %s owned by %s", databaseName, owner)
+	return nil
+}
+
+func (c *planningClient) DropDatabase(_ context.Context, databaseName string) error {
+	c.plan.add("drop database %s", databaseName)
+	return nil
+}
+
+func (c *planningClient) GrantAllPrivileges(_ context.Context, databaseName, username string) error {
+	c.plan.add("grant all privileges on database %s to %s", databaseName, username)
+	return nil
+}
+
+func (c *planningClient) SetPassword(_ context.Context, username, _ string) error {
+	c.plan.add("set password of user %s", username)
+	return nil
+}
+
+func (c *planningClient) CreateEntraUser(_ context.Context, username string, principal database.EntraPrincipal) error {
+	c.plan.add("create Microsoft Entra user %s for %s %s", username, principal.Type, principal.ID)
+	return nil
+}
+
+func (c *planningClient) SetPublicKey(_ context.Context, username, publicKey string) error {
+	if publicKey == "" {
+		c.plan.add("remove public key of user %s", username)
+	} else {
+		c.plan.add("set public key of user %s", username)
+	}
+	return nil
+}
+
+func (c *planningClient) SetDefaultWarehouse(_ context.Context, username, warehouse string) error {
+	c.plan.add("set default warehouse of user %s to %s", username, warehouse)
+	return nil
+}
+
+func (c *planningClient) SetAccountOptions(_ context.Context, username string, opts database.AccountOptions) error {
+	c.plan.add("set account options of user %s to %+v", username, opts)
+	return nil
+}
+
+func (c *planningClient) SetRoleSettings(_ context.Context, username string, settings map[string]string, reset []string) error {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.plan.add("set %s of user %s to %s", name, username, settings[name])
+	}
+	for _, name := range reset {
+		c.plan.add("reset %s of user %s", name, username)
+	}
+	return nil
+}
+
+func (c *planningClient) SetDatabaseConnectionLimit(_ context.Context, databaseName string, limit int32) error {
+	c.plan.add("set connection limit of database %s to %d", databaseName, limit)
+	return nil
+}
+
+func (c *planningClient) CreateRole(_ context.Context, roleName string) error {
+	c.plan.add("create role %s", roleName)
+	return nil
+}
+
+func (c *planningClient) DropRole(_ context.Context, databaseName, roleName string) error {
+	c.plan.add("drop role %s on database %s", roleName, databaseName)
+	return nil
+}
+
+func (c *planningClient) CloneDatabase(_ context.Context, databaseName, source, owner string) error {
+	c.plan.add("create database %s as a copy of %s owned by %s", databaseName, source, owner)
+	return nil
+}
+
+func (c *planningClient) RenameDatabase(_ context.Context, databaseName, newName string) error {
+	c.plan.add("terminate the connections to database %s and rename it to %s", databaseName, newName)
+	return nil
+}
+
+func (c *planningClient) SetDatabaseCharset(_ context.Context, databaseName, charset, collation string) error {
+	c.plan.add("change character set of database %s to %s %s", databaseName, charset, collation)
+	return nil
+}
+
+func (c *planningClient) GrantDatabasePrivileges(_ context.Context, databaseName, grantee string, privileges []string) error {
+	c.plan.add("grant %s on database %s to %s", strings.Join(privileges, ", "), databaseName, grantee)
+	return nil
+}
+
+func (c *planningClient) RevokeDatabasePrivileges(_ context.Context, databaseName, grantee string, privileges []string) error {
+	c.plan.add("revoke %s on database %s from %s", strings.Join(privileges, ", "), databaseName, grantee)
+	return nil
+}
+
+func (c *planningClient) GrantObjectPrivileges(_ context.Context, databaseName, grantee string, object database.ObjectGrant) error {
+	c.plan.add("grant %s on %s %s.%s to %s", strings.Join(object.Privileges, ", "), object.Type, databaseName, object.Name, grantee)
+	return nil
+}
+
+func (c *planningClient) RevokeObjectPrivileges(_ context.Context, databaseName, grantee string, object database.ObjectGrant) error {
+	c.plan.add("revoke %s on %s %s.%s from %s", strings.Join(object.Privileges, ", "), object.Type, databaseName, object.Name, grantee)
+	return nil
+}
+
+func (c *planningClient) GrantOwnerDefaultPrivileges(_ context.Context, databaseName, owner, grantee string, privileges []string) error {
+	c.plan.add("grant %s on the tables of %s in database %s to %s", strings.Join(privileges, ", "), owner, databaseName, grantee)
+	return nil
+}
+
+func (c *planningClient) GrantRole(_ context.Context, roleName, username string) error {
+	c.plan.add("grant role %s to %s", roleName, username)
+	return nil
+}
+
+func (c *planningClient) RevokeRole(_ context.Context, roleName, username string) error {
+	c.plan.add("revoke role %s from %s", roleName, username)
+	return nil
+}
+
+func (c *planningClient) RevokeOwnerMembership(_ context.Context, owner string) error {
+	c.plan.add("revoke role %s from the admin user", owner)
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// existingUserClient is a database client that only implements UserExists
+type existingUserClient struct {
+	database.Client
+}
+
+func (c *existingUserClient) UserExists(_ context.Context, _ string) (bool, error) {
+	return true, nil
+}
+
+func TestPlanningClient(t *testing.T) {
+	ctx := context.Background()
+	plan := &changePlan{}
+	// Writes reaching the wrapped client would panic on its nil embedded client
+	dbClient := &planningClient{Client: &existingUserClient{}, plan: plan}
+
+	exists, err := dbClient.UserExists(ctx, "app")
+	if err != nil || !exists {
+		t.Fatalf("UserExists() = %v, %v, want the result of the wrapped client", exists, err)
+	}
+	if err := dbClient.CreateDatabase(ctx, "app", "app"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbClient.RevokeOwnerMembership(ctx, "app"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbClient.GrantDatabasePrivileges(ctx, "app", "readers", []string{"CONNECT", "TEMPORARY"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbClient.SetRoleSettings(ctx, "app", map[string]string{"work_mem": "64MB", "search_path": "app"}, []string{"statement_timeout"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"create database app owned by app",
+		"revoke role app from the admin user",
+		"grant CONNECT, TEMPORARY on database app to readers",
+		"set search_path of user app to app",
+		"set work_mem of user app to 64MB",
+		"reset statement_timeout of user app",
+	}
+	if !slices.Equal(plan.changes, want) {
+		t.Errorf("planned changes = %q, want %q", plan.changes, want)
+	}
+}
+
+func TestSyncSecretTagsDryRun(t *testing.T) {
+	fake := newFakeSecretsManager(t)
+	fake.tags = map[string]string{"old": "x", "stale": "y"}
+	r := &DatabaseReconciler{
+		Recorder:          record.NewFakeRecorder(10),
+		ManagedByTagKey:   DefaultManagedByTagKey,
+		ManagedByTagValue: DefaultManagedByTagValue,
+	}
+	db := &databasev1alpha1.Database{
+		Spec: databasev1alpha1.DatabaseSpec{
+			DryRun:            true,
+			AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Tags: map[string]string{"team": "payments"}},
+		},
+	}
+
+	plan := &changePlan{}
+	ctx := withChangePlan(context.Background(), plan)
+	if err := r.syncSecretTags(ctx, db, fake.client(t), "rds/postgres/app", "rds/postgres/app"); err != nil {
+		t.Fatalf("syncSecretTags() error = %v", err)
+	}
+
+	if got := fake.callCount("TagResource") + fake.callCount("UntagResource"); got != 0 {
+		t.Errorf("tag writes = %d, want none in a dry run", got)
+	}
+	want := []string{
+		"remove tags old, stale from secret rds/postgres/app",
+		"set tags ManagedBy=database-user-operator, team=payments on secret rds/postgres/app",
+	}
+	if !slices.Equal(plan.changes, want) {
+		t.Errorf("planned changes = %q, want %q", plan.changes, want)
+	}
+}
+
+func TestReconcileDryRunFailure(t *testing.T) {
+	ctx := context.Background()
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 2, Finalizers: []string{DatabaseFinalizer}},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       databasev1alpha1.DatabaseEnginePostgres,
+			DatabaseName: "app",
+			SecretName:   "arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/app-AbCdEf",
+			AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{
+				Region: "us-east-1",
+			},
+			DryRun: true,
+		},
+		Status: databasev1alpha1.DatabaseStatus{
+			Phase:              "Ready",
+			ObservedGeneration: 1,
+			PlannedChanges:     []string{"create user app"},
+			Conditions: []metav1.Condition{{
+				Type:   ConditionReady,
+				Status: metav1.ConditionTrue,
+				Reason: ReasonReconciled,
+			}},
+		},
+	}
+	r := newClusterDatabaseTestReconciler(t, db)
+	r.Recorder = record.NewFakeRecorder(10)
+
+	// The failure is reported in status, whether it is returned depends on its retry policy
+	_, _ = r.reconcileObject(ctx, db, &ReconcileTrace{})
+
+	got := &databasev1alpha1.Database{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(db), got); err != nil {
+		t.Fatal(err)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, ConditionDryRun)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != ReasonConfigError {
+		t.Errorf("DryRun condition = %+v, want False/%s", cond, ReasonConfigError)
+	}
+	if len(got.Status.PlannedChanges) != 0 {
+		t.Errorf("plannedChanges = %q, want none after a failed dry run", got.Status.PlannedChanges)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, ConditionReady) || got.Status.Phase != "Ready" {
+		t.Errorf("Ready condition or phase changed by a dry run: phase %s", got.Status.Phase)
+	}
+	if got.Status.ObservedGeneration != 1 {
+		t.Errorf("observedGeneration = %d, want 1, a dry run applies nothing", got.Status.ObservedGeneration)
+	}
+}
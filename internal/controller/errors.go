@@ -0,0 +1,327 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"opzkit/database-user-operator/internal/database"
+)
+
+// ConditionReady is the condition type reporting whether the database, user and secret are reconciled
+const ConditionReady = "Ready"
+
+// ConditionTagsSynced is the condition type reporting whether the tags of the secret match the spec
+// Tags are reconciled on every reconciliation that writes or verifies the secret
+const ConditionTagsSynced = "TagsSynced"
+
+// Reasons of the TagsSynced condition, it is true with ReasonReconciled
+const (
+	// ReasonTagsReadFailed means the current tags could not be read, so stale tags were not removed
+	ReasonTagsReadFailed = "TagsReadFailed"
+	// ReasonTagsWriteFailed means tagging or untagging the secret failed
+	ReasonTagsWriteFailed = "TagsWriteFailed"
+)
+
+// ConditionDeletionBlocked is the condition type reporting that the cleanup of a deleted Database
+// with retainOnDelete: false, or the release of its retained secrets, failed and its finalizer is
+// kept until a retry succeeds
+const ConditionDeletionBlocked = "DeletionBlocked"
+
+// Reasons of the DeletionBlocked condition, named after the first cleanup step that failed
+const (
+	// ReasonConnectionStringUnavailable means the admin connection string could not be read
+	ReasonConnectionStringUnavailable = "ConnectionStringUnavailable"
+	// ReasonCleanupConnectionFailed means connecting to the database server failed
+	ReasonCleanupConnectionFailed = "ConnectionFailed"
+	// ReasonDatabaseDropFailed means checking for or dropping the database failed
+	ReasonDatabaseDropFailed = "DatabaseDropFailed"
+	// ReasonUserDropFailed means checking for or dropping the user failed
+	ReasonUserDropFailed = "UserDropFailed"
+	// ReasonSecretDeleteFailed means deleting the secret from AWS Secrets Manager failed
+	ReasonSecretDeleteFailed = "SecretDeleteFailed"
+	// ReasonSecretReleaseFailed means removing the managed-by tag from a secret retained on delete failed
+	ReasonSecretReleaseFailed = "SecretReleaseFailed"
+	// ReasonRoleDropFailed means dropping the role of a DatabaseRole failed
+	ReasonRoleDropFailed = "RoleDropFailed"
+	// ReasonRevokeFailed means revoking the privileges of a DatabaseGrant failed
+	ReasonRevokeFailed = "RevokeFailed"
+	// ReasonPreDeleteHookFailed means a Job of spec.hooks.preDelete failed or could not be started
+	ReasonPreDeleteHookFailed = "PreDeleteHookFailed"
+)
+
+// Reasons of the Ready condition
+// Failed reconciliations are classified so that users and tooling can tell errors that
+// need manual intervention (ConfigError, AuthError) from errors that resolve on retry
+const (
+	// ReasonReconciled means the database, user and secret are up to date
+	ReasonReconciled = "Reconciled"
+	// ReasonConfigError means the Database spec or a resource it references is invalid or missing
+	ReasonConfigError = "ConfigError"
+	// ReasonAuthError means AWS or the database server rejected the operator's credentials or privileges
+	ReasonAuthError = "AuthError"
+	// ReasonTransient means a temporary failure that is retried with exponential backoff
+	ReasonTransient = "Transient"
+	// ReasonConflict means the Database was modified concurrently
+	ReasonConflict = "Conflict"
+	// ReasonMaintenanceWindow means an operation is deferred until spec.maintenanceWindow opens
+	ReasonMaintenanceWindow = "MaintenanceWindow"
+	// ReasonInsufficientAdminPrivileges means the admin user lacks privileges to create the database or user
+	ReasonInsufficientAdminPrivileges = "InsufficientAdminPrivileges"
+)
+
+// terminalErrorRequeue is the requeue interval for ConfigError and AuthError, which need manual
+// intervention; retrying them with exponential backoff would only produce noise
+const terminalErrorRequeue = time.Minute
+
+// conflictRequeue is the requeue interval after a conflicting update
+const conflictRequeue = time.Second
+
+// throttlingRequeue is the requeue interval after AWS throttled a request
+// The SDK already retries throttled requests, so back off further than the rate limiter would
+const throttlingRequeue = 2 * time.Minute
+
+// expiredTokenRequeue is the requeue interval after AWS rejected expired credentials
+// Web identity and instance profile credentials are refreshed shortly, so retry sooner than other auth errors
+const expiredTokenRequeue = 15 * time.Second
+
+// AWS API error codes, grouped by classification
+var (
+	awsPermissionErrorCodes = map[string]bool{
+		"AccessDenied":                true,
+		"AccessDeniedException":       true,
+		"UnauthorizedOperation":       true,
+		"UnrecognizedClientException": true,
+		"InvalidClientTokenId":        true,
+		"InvalidSignatureException":   true,
+		"SignatureDoesNotMatch":       true,
+		"AuthFailure":                 true,
+		"NotAuthorized":               true,
+	}
+	awsThrottlingErrorCodes = map[string]bool{
+		"ThrottlingException":      true,
+		"Throttling":               true,
+		"TooManyRequestsException": true,
+		"RequestLimitExceeded":     true,
+		"SlowDown":                 true,
+	}
+	awsExpiredTokenErrorCodes = map[string]bool{
+		"ExpiredToken":          true,
+		"ExpiredTokenException": true,
+		"RequestExpired":        true,
+		"TokenRefreshRequired":  true,
+	}
+)
+
+// configError marks an error caused by an invalid Database spec
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string { return e.err.Error() }
+func (e *configError) Unwrap() error { return e.err }
+
+// newConfigError marks err as a configuration error
+func newConfigError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &configError{err: err}
+}
+
+// cleanupError marks the failed step of a cleanup on deletion
+// Its reason becomes the reason of the DeletionBlocked condition.
+type cleanupError struct {
+	reason string
+	err    error
+}
+
+func (e *cleanupError) Error() string { return e.err.Error() }
+func (e *cleanupError) Unwrap() error { return e.err }
+
+// newCleanupError marks err as a failure of the cleanup step reason
+func newCleanupError(reason string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &cleanupError{reason: reason, err: err}
+}
+
+// cleanupReason returns the reason of the cleanup step err failed in, or fallback if it is not marked
+func cleanupReason(err error, fallback string) string {
+	var cleanupErr *cleanupError
+	if errors.As(err, &cleanupErr) {
+		return cleanupErr.reason
+	}
+	return fallback
+}
+
+// awsErrorCode returns the AWS API error code of err, or an empty string if err is not an AWS API error
+func awsErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+// awsHTTPStatusCode returns the HTTP status code of an AWS response error, or 0 if there was no response
+func awsHTTPStatusCode(err error) int {
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		return respErr.HTTPStatusCode()
+	}
+	return 0
+}
+
+// isAWSPermissionError checks if an error is an AWS permission/authorization error
+func isAWSPermissionError(err error) bool {
+	if err == nil || isAWSExpiredTokenError(err) {
+		return false
+	}
+	return awsPermissionErrorCodes[awsErrorCode(err)] || awsHTTPStatusCode(err) == http.StatusForbidden
+}
+
+// isAWSResourceNotFoundError checks if an error is an AWS ResourceNotFoundException
+func isAWSResourceNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return awsErrorCode(err) == "ResourceNotFoundException"
+}
+
+// isAWSThrottlingError checks if AWS rejected a request because of rate limits
+func isAWSThrottlingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return awsThrottlingErrorCodes[awsErrorCode(err)] || awsHTTPStatusCode(err) == http.StatusTooManyRequests
+}
+
+// isAWSExpiredTokenError checks if AWS rejected a request because the credentials have expired
+func isAWSExpiredTokenError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return awsExpiredTokenErrorCodes[awsErrorCode(err)]
+}
+
+// isNetworkError checks if a request failed before a response was received,
+// e.g. because of DNS resolution failures, refused connections or timeouts
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sendErr *smithyhttp.RequestSendError
+	if errors.As(err, &sendErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// connectionFailureMessages are the event messages of the failures to reach a database server,
+// formatted with its host:port
+var connectionFailureMessages = map[string]string{
+	database.ConnectionFailureDNS: "Cannot resolve database host %s from the operator pod. " +
+		"Check the host of the admin connection and the DNS of the cluster.",
+	database.ConnectionFailureRefused: "Database host %s refused the connection from the operator pod. " +
+		"Check the port of the admin connection and that the server is running.",
+	database.ConnectionFailureUnreachable: "Cannot reach database host %s from the operator pod. " +
+		"Check security groups/network policies.",
+	database.ConnectionFailureTLS: "TLS handshake with database host %s failed. " +
+		"Check the sslmode of the admin connection and the certificate of the server.",
+}
+
+// errorEvent returns the reason and message of the Warning event recorded for a reconciliation error
+func errorEvent(err error, reason string) (string, string) {
+	// Failures to reach the database server are named after their cause instead of a generic ping failure
+	if kind, address := database.ConnectionFailure(err); kind != "" && reason == ReasonTransient {
+		return kind, fmt.Sprintf(connectionFailureMessages[kind], address) + " " + err.Error()
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return "ConfigurationError", err.Error()
+	case isAWSExpiredTokenError(err):
+		return "CredentialsExpired",
+			"AWS credentials have expired. Verify that IRSA/instance profile credentials are being refreshed."
+	case isAWSPermissionError(err):
+		return "PermissionError",
+			"AWS permission denied. Ensure the operator has IAM permissions for Secrets Manager. " +
+				"Grant secretsmanager:* on the secret ARN, or configure IRSA/instance profile."
+	case isAWSResourceNotFoundError(err):
+		return "ResourceNotFound",
+			"AWS resource not found. Verify the secret exists in AWS Secrets Manager and the name/region are correct in the Database spec."
+	case isAWSThrottlingError(err):
+		return "Throttled", "AWS throttled the request, retrying later: " + err.Error()
+	case reason == ReasonMaintenanceWindow:
+		return "OperationDeferred", err.Error()
+	case reason == ReasonInsufficientAdminPrivileges:
+		return ReasonInsufficientAdminPrivileges, err.Error()
+	case reason == ReasonConfigError:
+		return "ConfigurationError", err.Error()
+	case reason == ReasonAuthError:
+		return "AuthenticationError", err.Error()
+	case isNetworkError(err):
+		return "NetworkError", err.Error()
+	default:
+		return "ReconciliationError", err.Error()
+	}
+}
+
+// errorRequeue returns the fixed requeue interval for a reconciliation error
+// ok is false for errors that are retried with the rate limiter's exponential backoff
+func errorRequeue(err error, reason string) (requeueAfter time.Duration, ok bool) {
+	switch {
+	case reason == ReasonMaintenanceWindow:
+		return maintenanceWindowRequeue(err)
+	case isAWSThrottlingError(err):
+		return throttlingRequeue, true
+	case isAWSExpiredTokenError(err):
+		return expiredTokenRequeue, true
+	case reason == ReasonConfigError, reason == ReasonAuthError, reason == ReasonInsufficientAdminPrivileges:
+		return terminalErrorRequeue, true
+	default:
+		return 0, false
+	}
+}
+
+// classifyError returns the Ready condition reason for a reconciliation error
+func classifyError(err error) string {
+	var cfgErr *configError
+	var windowErr *maintenanceWindowError
+	switch {
+	case apierrors.IsConflict(err):
+		return ReasonConflict
+	case errors.As(err, &windowErr):
+		return ReasonMaintenanceWindow
+	case isAdminPrivilegesError(err):
+		return ReasonInsufficientAdminPrivileges
+	case errors.As(err, &cfgErr):
+		return ReasonConfigError
+	case apierrors.IsNotFound(err):
+		// A referenced Kubernetes secret does not exist
+		return ReasonConfigError
+	case isAWSPermissionError(err), isAWSExpiredTokenError(err), database.IsAuthError(err):
+		return ReasonAuthError
+	case isAWSResourceNotFoundError(err):
+		return ReasonConfigError
+	default:
+		return ReasonTransient
+	}
+}
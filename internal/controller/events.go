@@ -0,0 +1,230 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// Lifecycle event reasons recorded on Database resources
+const (
+	EventReasonUserCreated                = "UserCreated"
+	EventReasonDatabaseCreated            = "DatabaseCreated"
+	EventReasonSecretCreated              = "SecretCreated"
+	EventReasonSecretRotated              = "SecretRotated"
+	EventReasonSecretMigrated             = "SecretMigrated"
+	EventReasonSecretRestored             = "SecretRestored"
+	EventReasonPasswordSynced             = "PasswordSynced"
+	EventReasonTagsSynced                 = "TagsSynced"
+	EventReasonTagSyncFailed              = "TagSyncFailed"
+	EventReasonRoleGranted                = "RoleGranted"
+	EventReasonRoleRevoked                = "RoleRevoked"
+	EventReasonPrivilegesRevoked          = "PrivilegesRevoked"
+	EventReasonDeleted                    = "Deleted"
+	EventReasonDeletionBlocked            = "DeletionBlocked"
+	EventReasonDeletionOrphaned           = "DeletionOrphaned"
+	EventReasonCleanupDeferred            = "CleanupDeferred"
+	EventReasonChangesPlanned             = "ChangesPlanned"
+	EventReasonCharsetChanged             = "CharsetChanged"
+	EventReasonDatabaseRenamed            = "DatabaseRenamed"
+	EventReasonCloneStarted               = "CloneStarted"
+	EventReasonCloneCompleted             = "CloneCompleted"
+	EventReasonHookStarted                = "HookStarted"
+	EventReasonHookCompleted              = "HookCompleted"
+	EventReasonWorkloadRestarted          = "WorkloadRestarted"
+	EventReasonRestartTargetNotFound      = "RestartTargetNotFound"
+	EventReasonExpired                    = "Expired"
+	EventReasonPasswordLogged             = "PasswordLogged"
+	EventReasonSecretProviderClassCreated = "SecretProviderClassCreated"
+	EventReasonReconciled                 = "Reconciled"
+	EventReasonEventsSuppressed           = "EventsSuppressed"
+)
+
+// eventBudgetWindow is the window of the Warning event budget of each Database
+const eventBudgetWindow = time.Hour
+
+// DefaultWarningEventBudget is the default number of Warning events recorded per Database within eventBudgetWindow
+const DefaultWarningEventBudget = 30
+
+// eventDeduplicator suppresses identical events for the same object within a time window
+// The Kubernetes event recorder only aggregates identical events into a count, so periodic
+// reconciliations would otherwise keep refreshing the same event
+type eventDeduplicator struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+	now  func() time.Time
+}
+
+// allow reports whether an event with the given key may be recorded and remembers it
+// A window of zero or less disables deduplication
+func (d *eventDeduplicator) allow(key string, window time.Duration) bool {
+	if window <= 0 {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if d.now != nil {
+		now = d.now()
+	}
+	if d.last == nil {
+		d.last = make(map[string]time.Time)
+	}
+
+	if last, ok := d.last[key]; ok && now.Sub(last) < window {
+		return false
+	}
+	d.last[key] = now
+
+	// Drop expired entries so the map does not grow with deleted objects
+	for k, t := range d.last {
+		if now.Sub(t) >= window {
+			delete(d.last, k)
+		}
+	}
+	return true
+}
+
+// eventBudget limits the Warning events recorded per object within a fixed window, so a
+// persistently failing object does not flood etcd with events that differ in their message
+type eventBudget struct {
+	mu      sync.Mutex
+	windows map[string]budgetWindow
+	now     func() time.Time
+}
+
+// budgetWindow counts the events of an object since the start of its window
+type budgetWindow struct {
+	start time.Time
+	used  int
+}
+
+func (b *eventBudget) timeNow() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+// take consumes an event of the budget of key and returns the events left in the window
+// It reports false without consuming anything when the budget is exhausted.
+func (b *eventBudget) take(key string, limit int, window time.Duration) (int, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.timeNow()
+	if b.windows == nil {
+		b.windows = make(map[string]budgetWindow)
+	}
+	// Drop expired windows so the map does not grow with deleted objects
+	for k, w := range b.windows {
+		if now.Sub(w.start) >= window {
+			delete(b.windows, k)
+		}
+	}
+
+	w, ok := b.windows[key]
+	if !ok {
+		w = budgetWindow{start: now}
+	}
+	if w.used >= limit {
+		return 0, false
+	}
+	w.used++
+	b.windows[key] = w
+	return limit - w.used, true
+}
+
+// remaining returns the events left in the window of key without consuming any
+func (b *eventBudget) remaining(key string, limit int, window time.Duration) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	w, ok := b.windows[key]
+	if !ok || b.timeNow().Sub(w.start) >= window {
+		return limit
+	}
+	return max(limit-w.used, 0)
+}
+
+// eventBudgetKey identifies the event budget of a Database
+func eventBudgetKey(db *databasev1alpha1.Database) string {
+	return string(db.UID) + "/" + db.Namespace + "/" + db.Name
+}
+
+// recordEvent records an event on a Database unless an identical event was recorded
+// within EventDedupWindow. The emit-events annotation of the Database overrides both.
+// Warning events are limited to WarningEventBudget per Database and hour in either case.
+func (r *DatabaseReconciler) recordEvent(db *databasev1alpha1.Database, eventType, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	switch eventVerbosity(db) {
+	case EmitEventsNone:
+		if eventType != corev1.EventTypeWarning {
+			return
+		}
+	case EmitEventsAll:
+		r.emitEvent(db, eventType, reason, message)
+		return
+	}
+	key := eventBudgetKey(db) + "/" + eventType + "/" + reason + "/" + message
+	if !r.events.allow(key, r.EventDedupWindow) {
+		return
+	}
+	r.emitEvent(db, eventType, reason, message)
+}
+
+// emitEvent records an event on a Database within its Warning event budget
+// The last Warning of the budget is followed by an EventsSuppressed warning.
+func (r *DatabaseReconciler) emitEvent(db *databasev1alpha1.Database, eventType, reason, message string) {
+	if eventType != corev1.EventTypeWarning || r.WarningEventBudget <= 0 {
+		r.Recorder.Event(eventObject(db), eventType, reason, message)
+		r.notify(db, eventType, reason, message)
+		return
+	}
+
+	remaining, ok := r.budget.take(eventBudgetKey(db), r.WarningEventBudget, eventBudgetWindow)
+	DatabaseUserErrorBudget.WithLabelValues(db.Namespace, db.Name).Set(float64(remaining))
+	if !ok {
+		DatabaseUserEventsSuppressed.WithLabelValues(db.Namespace, db.Name).Inc()
+		return
+	}
+	r.Recorder.Event(eventObject(db), eventType, reason, message)
+	r.notify(db, eventType, reason, message)
+	if remaining == 0 {
+		r.Recorder.Eventf(eventObject(db), corev1.EventTypeWarning, EventReasonEventsSuppressed,
+			"%d warnings recorded within %s, further warnings are suppressed until the window ends",
+			r.WarningEventBudget, eventBudgetWindow)
+	}
+}
+
+// refreshErrorBudget updates the error budget metric of a Database, whose window may have ended
+// since its last Warning event
+func (r *DatabaseReconciler) refreshErrorBudget(db *databasev1alpha1.Database) {
+	if r.WarningEventBudget <= 0 {
+		return
+	}
+	remaining := r.budget.remaining(eventBudgetKey(db), r.WarningEventBudget, eventBudgetWindow)
+	DatabaseUserErrorBudget.WithLabelValues(db.Namespace, db.Name).Set(float64(remaining))
+}
+
+// recordNormal records a Normal lifecycle event on a Database
+// Dry runs make no changes, so they record no lifecycle events.
+func (r *DatabaseReconciler) recordNormal(db *databasev1alpha1.Database, reason, messageFmt string, args ...interface{}) {
+	if r.isDryRun(db) {
+		return
+	}
+	r.recordEvent(db, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestEventDeduplicatorAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := &eventDeduplicator{now: func() time.Time { return now }}
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		key     string
+		window  time.Duration
+		want    bool
+	}{
+		{name: "first event", key: "a", window: time.Minute, want: true},
+		{name: "repeated within window", advance: 30 * time.Second, key: "a", window: time.Minute, want: false},
+		{name: "different key", key: "b", window: time.Minute, want: true},
+		{name: "window expired", advance: 31 * time.Second, key: "a", window: time.Minute, want: true},
+		{name: "zero window disables deduplication", key: "a", window: 0, want: true},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if got := d.allow(step.key, step.window); got != step.want {
+			t.Errorf("%s: allow(%q) = %v, want %v", step.name, step.key, got, step.want)
+		}
+	}
+}
+
+func TestEventDeduplicatorPrunesExpired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := &eventDeduplicator{now: func() time.Time { return now }}
+
+	d.allow("a", time.Minute)
+	now = now.Add(2 * time.Minute)
+	d.allow("b", time.Minute)
+
+	if _, ok := d.last["a"]; ok {
+		t.Errorf("expected expired key to be pruned, got %v", d.last)
+	}
+}
+
+func TestRecordEventDeduplicates(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &DatabaseReconciler{Recorder: recorder, EventDedupWindow: time.Minute}
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"}}
+	other := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "uid-2"}}
+
+	r.recordNormal(db, EventReasonSecretRotated, "Secret %s updated", "app-secret")
+	r.recordNormal(db, EventReasonSecretRotated, "Secret %s updated", "app-secret")
+	r.recordNormal(other, EventReasonSecretRotated, "Secret %s updated", "app-secret")
+	r.recordEvent(db, corev1.EventTypeWarning, "PermissionError", "%s", "access denied")
+
+	want := []string{
+		"Normal SecretRotated Secret app-secret updated",
+		"Normal SecretRotated Secret app-secret updated",
+		"Warning PermissionError access denied",
+	}
+	if len(recorder.Events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(recorder.Events), len(want))
+	}
+	for _, w := range want {
+		if got := <-recorder.Events; got != w {
+			t.Errorf("got event %q, want %q", got, w)
+		}
+	}
+}
+
+func TestEventBudgetTake(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &eventBudget{now: func() time.Time { return now }}
+
+	steps := []struct {
+		name          string
+		advance       time.Duration
+		key           string
+		wantRemaining int
+		wantOK        bool
+	}{
+		{name: "first warning", key: "a", wantRemaining: 1, wantOK: true},
+		{name: "last warning of the budget", advance: time.Minute, key: "a", wantRemaining: 0, wantOK: true},
+		{name: "exhausted", advance: time.Minute, key: "a", wantRemaining: 0, wantOK: false},
+		{name: "other object", key: "b", wantRemaining: 1, wantOK: true},
+		{name: "window ended", advance: time.Hour, key: "a", wantRemaining: 1, wantOK: true},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		remaining, ok := b.take(step.key, 2, time.Hour)
+		if remaining != step.wantRemaining || ok != step.wantOK {
+			t.Errorf("%s: take(%q) = %d, %v, want %d, %v", step.name, step.key, remaining, ok, step.wantRemaining, step.wantOK)
+		}
+	}
+	if _, ok := b.windows["b"]; ok {
+		t.Errorf("expected the ended window of b to be pruned, got %v", b.windows)
+	}
+	if got := b.remaining("a", 2, time.Hour); got != 1 {
+		t.Errorf("remaining(a) = %d, want 1", got)
+	}
+	if got := b.remaining("c", 2, time.Hour); got != 2 {
+		t.Errorf("remaining(c) = %d, want the whole budget", got)
+	}
+}
+
+func TestRecordEventBudget(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &DatabaseReconciler{Recorder: recorder, EventDedupWindow: time.Minute, WarningEventBudget: 2}
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"}}
+
+	r.recordEvent(db, corev1.EventTypeWarning, "ConnectionFailed", "%s", "dial tcp 10.0.0.1:5432: i/o timeout")
+	r.recordEvent(db, corev1.EventTypeWarning, "ConnectionFailed", "%s", "dial tcp 10.0.0.2:5432: i/o timeout")
+	r.recordEvent(db, corev1.EventTypeWarning, "ConnectionFailed", "%s", "dial tcp 10.0.0.3:5432: i/o timeout")
+	r.recordNormal(db, EventReasonSecretRotated, "Secret %s updated", "app-secret")
+
+	want := []string{
+		"Warning ConnectionFailed dial tcp 10.0.0.1:5432: i/o timeout",
+		"Warning ConnectionFailed dial tcp 10.0.0.2:5432: i/o timeout",
+		"Warning EventsSuppressed 2 warnings recorded within 1h0m0s, further warnings are suppressed until the window ends",
+		"Normal SecretRotated Secret app-secret updated",
+	}
+	if len(recorder.Events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(recorder.Events), len(want))
+	}
+	for _, w := range want {
+		if got := <-recorder.Events; got != w {
+			t.Errorf("got event %q, want %q", got, w)
+		}
+	}
+}
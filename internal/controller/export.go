@@ -0,0 +1,89 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// ExportDatabases returns a snapshot of the given Database resources suitable for re-applying
+// to a rebuilt cluster after a disaster recovery event.
+// Server-populated metadata is removed and the status is kept for reference.
+// With pinResolved, the username, secret name and region the operator resolved are written
+// into the spec, so the re-created resources adopt the same user and secret even if
+// defaults differ in the new cluster.
+func ExportDatabases(dbs []databasev1alpha1.Database, pinResolved bool) *databasev1alpha1.DatabaseList {
+	list := &databasev1alpha1.DatabaseList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: databasev1alpha1.GroupVersion.String(),
+			Kind:       "DatabaseList",
+		},
+	}
+
+	for i := range dbs {
+		src := &dbs[i]
+		db := databasev1alpha1.Database{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: databasev1alpha1.GroupVersion.String(),
+				Kind:       "Database",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        src.Name,
+				Namespace:   src.Namespace,
+				Labels:      src.Labels,
+				Annotations: exportAnnotations(src.Annotations),
+			},
+			Spec:   *src.Spec.DeepCopy(),
+			Status: *src.Status.DeepCopy(),
+		}
+
+		if pinResolved {
+			pinResolvedSpec(&db)
+		}
+		list.Items = append(list.Items, db)
+	}
+
+	return list
+}
+
+// exportAnnotations drops annotations that must not be carried over to a new cluster
+func exportAnnotations(annotations map[string]string) map[string]string {
+	const lastApplied = "kubectl.kubernetes.io/last-applied-configuration"
+
+	result := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k == lastApplied {
+			continue
+		}
+		result[k] = v
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// pinResolvedSpec writes the username, secret name and region from the status into unset spec fields
+func pinResolvedSpec(db *databasev1alpha1.Database) {
+	if db.Spec.Username == "" && db.Status.ActualUsername != "" {
+		db.Spec.Username = db.Status.ActualUsername
+	}
+	if db.Spec.SecretName == "" && db.Status.ActualSecretName != "" {
+		db.Spec.SecretName = db.Status.ActualSecretName
+	}
+	if db.Status.SecretRegion != "" {
+		if db.Spec.AWSSecretsManager == nil {
+			db.Spec.AWSSecretsManager = &databasev1alpha1.AWSSecretsManagerConfig{}
+		}
+		if db.Spec.AWSSecretsManager.Region == "" {
+			db.Spec.AWSSecretsManager.Region = db.Status.SecretRegion
+		}
+	}
+}
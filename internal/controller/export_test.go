@@ -0,0 +1,91 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestExportDatabases(t *testing.T) {
+	src := databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "app",
+			Namespace:       "prod",
+			UID:             "1234",
+			ResourceVersion: "42",
+			Generation:      3,
+			Finalizers:      []string{DatabaseFinalizer},
+			Labels:          map[string]string{"team": "payments"},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       "postgres",
+			DatabaseName: "app",
+		},
+		Status: databasev1alpha1.DatabaseStatus{
+			ActualUsername:   "app",
+			ActualSecretName: "rds/postgres/app",
+			SecretRegion:     "eu-west-1",
+			SecretARN:        "arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/app-AbC123",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		pinResolved    bool
+		wantUsername   string
+		wantSecretName string
+		wantRegion     bool
+	}{
+		{name: "as-is", pinResolved: false},
+		{name: "pin resolved", pinResolved: true, wantUsername: "app", wantSecretName: "rds/postgres/app", wantRegion: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := ExportDatabases([]databasev1alpha1.Database{src}, tt.pinResolved)
+			if len(list.Items) != 1 {
+				t.Fatalf("ExportDatabases() returned %d items, want 1", len(list.Items))
+			}
+			db := list.Items[0]
+
+			if db.Kind != "Database" || db.APIVersion != databasev1alpha1.GroupVersion.String() {
+				t.Errorf("unexpected TypeMeta %+v", db.TypeMeta)
+			}
+			if db.UID != "" || db.ResourceVersion != "" || db.Generation != 0 || len(db.Finalizers) != 0 {
+				t.Errorf("server-populated metadata not removed: %+v", db.ObjectMeta)
+			}
+			if db.Annotations != nil {
+				t.Errorf("annotations = %v, want last-applied-configuration removed", db.Annotations)
+			}
+			if db.Labels["team"] != "payments" {
+				t.Errorf("labels = %v, want labels kept", db.Labels)
+			}
+			if db.Status.SecretARN != src.Status.SecretARN {
+				t.Errorf("status not kept: %+v", db.Status)
+			}
+			if db.Spec.Username != tt.wantUsername || db.Spec.SecretName != tt.wantSecretName {
+				t.Errorf("spec username/secretName = %q/%q, want %q/%q",
+					db.Spec.Username, db.Spec.SecretName, tt.wantUsername, tt.wantSecretName)
+			}
+			if gotRegion := db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Region == "eu-west-1"; gotRegion != tt.wantRegion {
+				t.Errorf("spec region pinned = %v, want %v", gotRegion, tt.wantRegion)
+			}
+		})
+	}
+
+	if src.Spec.Username != "" || src.Spec.AWSSecretsManager != nil {
+		t.Error("ExportDatabases() modified its input")
+	}
+}
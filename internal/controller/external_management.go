@@ -0,0 +1,170 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// externalSecret holds the AWS secret of a Database with spec.externalManagement.secret, as read by
+// the reconciliation
+type externalSecret struct {
+	name      string
+	arn       string
+	region    string
+	versionID string
+}
+
+// externalSecretManaged reports whether the AWS secret of a Database is owned by another tool
+func externalSecretManaged(db *databasev1alpha1.Database) bool {
+	return db.Spec.ExternalManagement != nil && db.Spec.ExternalManagement.Secret
+}
+
+// validateExternalManagement rejects settings that only apply to secrets the operator writes
+func validateExternalManagement(db *databasev1alpha1.Database) error {
+	if !externalSecretManaged(db) {
+		return nil
+	}
+	var fields []string
+	if db.Spec.SecretTemplate != "" {
+		fields = append(fields, "spec.secretTemplate")
+	}
+	if db.Spec.SecretBinary {
+		fields = append(fields, "spec.secretBinary")
+	}
+	if config := db.Spec.AWSSecretsManager; config != nil {
+		if len(config.Tags) > 0 {
+			fields = append(fields, "spec.awsSecretsManager.tags")
+		}
+		if config.Description != "" {
+			fields = append(fields, "spec.awsSecretsManager.description")
+		}
+	}
+	if db.Spec.Snowflake != nil && db.Spec.Snowflake.KeyPair {
+		fields = append(fields, "spec.snowflake.keyPair")
+	}
+	if len(fields) > 0 {
+		return fmt.Errorf("%s cannot be combined with spec.externalManagement.secret, the operator does not write the secret",
+			strings.Join(fields, ", "))
+	}
+	return nil
+}
+
+// readExternalSecret reads the password and private key from the externally managed secret of a
+// Database. A missing secret is retried, the tool owning it may not have created it yet.
+func (r *DatabaseReconciler) readExternalSecret(ctx context.Context, db *databasev1alpha1.Database,
+	awsClient *secrets.AWSSecretsManagerClient, exists bool, secretID, secretName string) (*externalSecret, string, string, error) {
+	if !exists {
+		return nil, "", "", fmt.Errorf("secret %s is managed externally (spec.externalManagement.secret) and does not exist in %s, "+
+			"the operator waits for it to be created", secretName, awsClient.GetRegion())
+	}
+	scheduled, err := awsClient.SecretScheduledForDeletion(ctx, secretID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to check if secret is scheduled for deletion: %w", err)
+	}
+	if scheduled {
+		return nil, "", "", newConfigError(fmt.Errorf("secret %s is managed externally (spec.externalManagement.secret) "+
+			"and scheduled for deletion, restore it or remove the Database", secretName))
+	}
+
+	// The version is read before the value, a version stored in between is applied again on the
+	// next reconciliation instead of being recorded with the previous password
+	versionID, err := awsClient.GetSecretVersionID(ctx, secretID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	arn, err := awsClient.GetSecretARN(ctx, secretID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	existing, err := awsClient.GetSecretWithKeyNames(ctx, secretID, secretKeyNames(db))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to read externally managed secret: %w", err)
+	}
+	if existing.DBPassword == "" {
+		return nil, "", "", newConfigError(fmt.Errorf("externally managed secret %s has no password, "+
+			"set spec.secretKeyNames if it uses other keys", secretName))
+	}
+
+	external := &externalSecret{
+		name:      secretName,
+		arn:       arn,
+		region:    awsClient.GetRegion(),
+		versionID: versionID,
+	}
+	return external, existing.DBPassword, existing.DBPrivateKey, nil
+}
+
+// syncExternalPassword sets the password of an existing user to the password of the externally
+// managed secret when the secret has a version the operator has not applied yet, e.g. after the
+// tool owning it rotated the password
+func (r *DatabaseReconciler) syncExternalPassword(ctx context.Context, db *databasev1alpha1.Database,
+	dbClient database.Client, external *externalSecret, username, password string) error {
+	if external.versionID == db.Status.SecretVersion {
+		return nil
+	}
+	log.FromContext(ctx).Info("Setting password of user from new version of externally managed secret",
+		"username", username,
+		"secretName", external.name,
+		"versionID", external.versionID)
+	if err := dbClient.SetPassword(ctx, username, password); err != nil {
+		return err
+	}
+	r.warnPasswordLogging(ctx, db, dbClient)
+	if changePlanFrom(ctx) == nil {
+		r.recordNormal(db, EventReasonPasswordSynced, "Password of user %s set from version %s of secret %s",
+			username, external.versionID, external.name)
+	}
+	return nil
+}
+
+// recordExternalSecret records the externally managed secret in the status in place of storing the
+// credentials in AWS
+func recordExternalSecret(db *databasev1alpha1.Database, external *externalSecret, username, host string, port int) {
+	if db.Status.SecretVersion != external.versionID || db.Status.SecretLastSyncedAt == nil {
+		db.Status.SecretLastSyncedAt = timestampPtr(time.Now())
+	}
+	db.Status.SecretCreated = true
+	db.Status.ActualSecretName = external.name
+	db.Status.SecretRegion = external.region
+	setSecretARN(&db.Status, external.arn)
+	db.Status.SecretVersion = external.versionID
+	db.Status.SecretFormatVersion = currentSecretFormatVersion
+	db.Status.ConnectionInfo = databasev1alpha1.ConnectionInfo{
+		Host:     host,
+		Port:     port,
+		Database: db.Spec.DatabaseName,
+		Username: username,
+		Engine:   string(db.Spec.Engine),
+	}
+}
+
+// externalSecretChanged reports whether the externally managed secret of an up-to-date Database has
+// a new version, which is only noticed by comparing it with status.secretVersion. Errors are reported
+// as a change, so the full reconciliation surfaces them.
+func (r *DatabaseReconciler) externalSecretChanged(ctx context.Context, db *databasev1alpha1.Database) bool {
+	if !externalSecretManaged(db) {
+		return false
+	}
+	awsClient, err := r.awsClient(ctx, db.Status.SecretRegion)
+	if err != nil {
+		return true
+	}
+	secretID := resolveSecretID(db, db.Status.ActualSecretName, awsClient.GetRegion())
+	versionID, err := awsClient.GetSecretVersionID(ctx, secretID)
+	return err != nil || versionID != db.Status.SecretVersion
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestValidateExternalManagement(t *testing.T) {
+	external := &databasev1alpha1.ExternalManagement{Secret: true}
+	tests := []struct {
+		name    string
+		spec    databasev1alpha1.DatabaseSpec
+		wantErr string
+	}{
+		{name: "not managed externally", spec: databasev1alpha1.DatabaseSpec{SecretTemplate: "{{ .Password }}"}},
+		{name: "managed externally", spec: databasev1alpha1.DatabaseSpec{ExternalManagement: external}},
+		{
+			name:    "secret template",
+			spec:    databasev1alpha1.DatabaseSpec{ExternalManagement: external, SecretTemplate: "{{ .Password }}"},
+			wantErr: "spec.secretTemplate cannot be combined",
+		},
+		{
+			name: "tags and description",
+			spec: databasev1alpha1.DatabaseSpec{
+				ExternalManagement: external,
+				AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{
+					Tags:        map[string]string{"team": "payments"},
+					Description: "orders",
+				},
+			},
+			wantErr: "spec.awsSecretsManager.tags, spec.awsSecretsManager.description cannot be combined",
+		},
+		{
+			name: "snowflake key pair",
+			spec: databasev1alpha1.DatabaseSpec{
+				ExternalManagement: external,
+				Snowflake:          &databasev1alpha1.SnowflakeConfig{KeyPair: true},
+			},
+			wantErr: "spec.snowflake.keyPair",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExternalManagement(&databasev1alpha1.Database{Spec: tt.spec})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateExternalManagement() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateExternalManagement() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadExternalSecret(t *testing.T) {
+	const arn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbC123"
+	tests := []struct {
+		name         string
+		exists       bool
+		deleted      bool
+		value        string
+		wantPassword string
+		wantErr      string
+		wantConfig   bool
+	}{
+		{name: "ok", exists: true, value: `{"DB_PASSWORD":"s3cret"}`, wantPassword: "s3cret"},
+		{name: "not created yet", wantErr: "waits for it to be created"},
+		{name: "scheduled for deletion", exists: true, deleted: true, wantErr: "scheduled for deletion", wantConfig: true},
+		{name: "no password", exists: true, value: `{"DB_USER":"app"}`, wantErr: "has no password", wantConfig: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSecretsManager(t)
+			fake.deleted = tt.deleted
+			fake.arn = arn
+			fake.versionID = "v2"
+			fake.values["rds/postgres/app"] = tt.value
+			r := &DatabaseReconciler{}
+			db := &databasev1alpha1.Database{}
+
+			external, password, _, err := r.readExternalSecret(context.Background(), db, fake.client(t),
+				tt.exists, "rds/postgres/app", "rds/postgres/app")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readExternalSecret() error = %v, want %q", err, tt.wantErr)
+				}
+				if got := classifyError(err) == ReasonConfigError; got != tt.wantConfig {
+					t.Errorf("classifyError() = %s, want config error %t", classifyError(err), tt.wantConfig)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readExternalSecret() error = %v", err)
+			}
+			if password != tt.wantPassword {
+				t.Errorf("password = %q, want %q", password, tt.wantPassword)
+			}
+			if external.arn != arn || external.versionID != "v2" || external.region != "us-east-1" {
+				t.Errorf("external secret = %+v", external)
+			}
+		})
+	}
+}
+
+func TestSyncExternalPassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusVersion string
+		wantPasswords int
+	}{
+		{name: "new version", statusVersion: "v1", wantPasswords: 1},
+		{name: "first reconciliation", wantPasswords: 1},
+		{name: "version applied", statusVersion: "v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &DatabaseReconciler{Recorder: recorder}
+			db := &databasev1alpha1.Database{Status: databasev1alpha1.DatabaseStatus{SecretVersion: tt.statusVersion}}
+			client := &passwordClient{}
+			external := &externalSecret{name: "rds/postgres/app", versionID: "v2"}
+
+			if err := r.syncExternalPassword(context.Background(), db, client, external, "app", "s3cret"); err != nil {
+				t.Fatalf("syncExternalPassword() error = %v", err)
+			}
+			if len(client.passwords) != tt.wantPasswords {
+				t.Fatalf("SetPassword called %d times, want %d", len(client.passwords), tt.wantPasswords)
+			}
+			select {
+			case event := <-recorder.Events:
+				if tt.wantPasswords == 0 || !strings.Contains(event, EventReasonPasswordSynced) {
+					t.Errorf("event = %q", event)
+				}
+			default:
+				if tt.wantPasswords > 0 {
+					t.Errorf("no event recorded, want %s", EventReasonPasswordSynced)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordExternalSecret(t *testing.T) {
+	db := &databasev1alpha1.Database{
+		Spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", DatabaseName: "app"},
+	}
+	external := &externalSecret{
+		name:      "rds/postgres/app",
+		arn:       "arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/app-AbC123",
+		region:    "eu-west-1",
+		versionID: "v2",
+	}
+
+	recordExternalSecret(db, external, "app", "db.example.com", 5432)
+	if !db.Status.SecretCreated || db.Status.ActualSecretName != external.name || db.Status.SecretRegion != "eu-west-1" ||
+		db.Status.SecretVersion != "v2" || db.Status.SecretAccountID != "123456789012" || db.Status.SecretLastSyncedAt == nil {
+		t.Errorf("status = %+v", db.Status)
+	}
+	if db.Status.ConnectionInfo.Host != "db.example.com" || db.Status.ConnectionInfo.Username != "app" {
+		t.Errorf("connectionInfo = %+v", db.Status.ConnectionInfo)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// DefaultStatusHistoryLength is the default number of entries kept in status.history
+const DefaultStatusHistoryLength = 10
+
+// Results recorded in status.history
+const (
+	historyResultSuccess = "Success"
+	historyResultError   = "Error"
+)
+
+// recordHistory records the outcome of a reconciliation that started at start in status.history and
+// keeps the last length entries. reason is the class of the error, empty on success.
+// An outcome equal to the last entry is not recorded, a length of zero removes the history.
+// It reports whether the history changed.
+func recordHistory(status *databasev1alpha1.DatabaseStatus, length int, start, now time.Time, reason string, generation int64) bool {
+	if length <= 0 {
+		changed := status.History != nil
+		status.History = nil
+		return changed
+	}
+
+	result := historyResultSuccess
+	if reason != "" {
+		result = historyResultError
+	}
+	if n := len(status.History); n > 0 {
+		last := status.History[n-1]
+		if last.Result == result && last.Reason == reason && last.ObservedGeneration == generation {
+			return false
+		}
+	}
+
+	status.History = append(status.History, databasev1alpha1.ReconcileHistoryEntry{
+		Time:               metav1.NewTime(start),
+		Duration:           metav1.Duration{Duration: now.Sub(start).Round(time.Millisecond)},
+		Result:             result,
+		Reason:             reason,
+		ObservedGeneration: generation,
+	})
+	if n := len(status.History); n > length {
+		status.History = slices.Clone(status.History[n-length:])
+	}
+	return true
+}
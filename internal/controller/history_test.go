@@ -0,0 +1,59 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestRecordHistory(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	status := &databasev1alpha1.DatabaseStatus{}
+
+	steps := []struct {
+		name        string
+		reason      string
+		generation  int64
+		wantChanged bool
+		wantLen     int
+	}{
+		{name: "first success", generation: 1, wantChanged: true, wantLen: 1},
+		{name: "periodic success", generation: 1, wantChanged: false, wantLen: 1},
+		{name: "error", reason: ReasonTransient, generation: 1, wantChanged: true, wantLen: 2},
+		{name: "same error", reason: ReasonTransient, generation: 1, wantChanged: false, wantLen: 2},
+		{name: "other error", reason: ReasonConfigError, generation: 1, wantChanged: true, wantLen: 3},
+		{name: "recovered", generation: 1, wantChanged: true, wantLen: 3},
+		{name: "new generation", generation: 2, wantChanged: true, wantLen: 3},
+	}
+
+	for i, step := range steps {
+		now := start.Add(time.Duration(i)*time.Minute + 1500*time.Millisecond)
+		changed := recordHistory(status, 3, start.Add(time.Duration(i)*time.Minute), now, step.reason, step.generation)
+		if changed != step.wantChanged {
+			t.Errorf("%s: changed = %v, want %v", step.name, changed, step.wantChanged)
+		}
+		if len(status.History) != step.wantLen {
+			t.Fatalf("%s: history = %+v, want %d entries", step.name, status.History, step.wantLen)
+		}
+	}
+
+	last := status.History[len(status.History)-1]
+	if last.Result != historyResultSuccess || last.ObservedGeneration != 2 || last.Duration.Duration != 1500*time.Millisecond {
+		t.Errorf("last entry = %+v, want success of generation 2 taking 1.5s", last)
+	}
+	if first := status.History[0]; first.Result != historyResultError || first.Reason != ReasonConfigError {
+		t.Errorf("oldest entry = %+v, want the ConfigError after trimming", first)
+	}
+
+	if !recordHistory(status, 0, start, start, "", 2) || status.History != nil {
+		t.Errorf("disabled history = %+v, want it removed", status.History)
+	}
+}
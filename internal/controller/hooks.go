@@ -0,0 +1,266 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// Hooks of spec.hooks, their names prefix the names of their Jobs
+const (
+	hookPostCreate = "post-create"
+	hookPreDelete  = "pre-delete"
+)
+
+// preDeleteHooksDone is the last step of the PreDeleteHooks operation, recorded once all Jobs
+// succeeded so the cleanup of a deleted Database does not run them again
+const preDeleteHooksDone = "cleanup"
+
+// credentialsLoader returns the credentials passed to the Jobs of a hook
+// It is only called when a Job is started.
+type credentialsLoader func(ctx context.Context) (*secrets.DatabaseSecret, error)
+
+// hookSteps returns the steps of a hook operation: the names of its Jobs, followed by the final steps
+func hookSteps(jobs []databasev1alpha1.HookJob, final ...string) []string {
+	steps := make([]string, 0, len(jobs)+len(final))
+	for _, job := range jobs {
+		steps = append(steps, job.Name)
+	}
+	return append(steps, final...)
+}
+
+// postCreateHooks returns the Jobs of spec.hooks.postCreate
+func postCreateHooks(db *databasev1alpha1.Database) []databasev1alpha1.HookJob {
+	if db.Spec.Hooks == nil {
+		return nil
+	}
+	return db.Spec.Hooks.PostCreate
+}
+
+// preDeleteHooks returns the Jobs of spec.hooks.preDelete
+func preDeleteHooks(db *databasev1alpha1.Database) []databasev1alpha1.HookJob {
+	if db.Spec.Hooks == nil {
+		return nil
+	}
+	return db.Spec.Hooks.PreDelete
+}
+
+// startPostCreateHooks records the PostCreateHooks operation at the first Job of spec.hooks.postCreate
+func startPostCreateHooks(db *databasev1alpha1.Database) {
+	jobs := postCreateHooks(db)
+	if len(jobs) == 0 {
+		return
+	}
+	setPendingOperation(&db.Status, PendingOperationPostCreateHooks, hookSteps(jobs), jobs[0].Name, time.Now())
+}
+
+// reconcilePostCreateHooks runs the Jobs of spec.hooks.postCreate one after the other while
+// status.pendingOperation records the PostCreateHooks operation. Like the clone Job they run
+// after the secret was stored, so the Database is Ready while they run.
+func (r *DatabaseReconciler) reconcilePostCreateHooks(ctx context.Context, db *databasev1alpha1.Database, credentials credentialsLoader) error {
+	if !pendingOperationIs(&db.Status, PendingOperationPostCreateHooks) {
+		return nil
+	}
+	jobs := postCreateHooks(db)
+	for {
+		i := slices.IndexFunc(jobs, func(job databasev1alpha1.HookJob) bool {
+			return job.Name == db.Status.PendingOperation.Step
+		})
+		if i < 0 {
+			log.FromContext(ctx).Info("Job of the post-create hook was removed from spec.hooks, the hook is finished",
+				"job", db.Status.PendingOperation.Step)
+			clearPendingOperation(&db.Status, PendingOperationPostCreateHooks)
+			return nil
+		}
+		done, err := r.runHookJob(ctx, db, hookPostCreate, jobs[i], credentials)
+		if err != nil || !done {
+			return err
+		}
+		if i == len(jobs)-1 {
+			clearPendingOperation(&db.Status, PendingOperationPostCreateHooks)
+			return nil
+		}
+		setPendingOperation(&db.Status, PendingOperationPostCreateHooks, hookSteps(jobs), jobs[i+1].Name, time.Now())
+	}
+}
+
+// reconcilePreDeleteHooks runs the Jobs of spec.hooks.preDelete of a deleted Database one after the
+// other and reports whether all of them succeeded. Each step is checkpointed, the status of a deleted
+// Database is not written otherwise. The Jobs read the credentials from the secret in AWS Secrets Manager.
+// Removing a Job from spec.hooks.preDelete skips it, in a terminating namespace the hook is skipped.
+func (r *DatabaseReconciler) reconcilePreDeleteHooks(ctx context.Context, db *databasev1alpha1.Database) (bool, error) {
+	jobs := preDeleteHooks(db)
+	if len(jobs) == 0 || r.isDryRun(db) {
+		return true, nil
+	}
+	steps := hookSteps(jobs, preDeleteHooksDone)
+	if !pendingOperationIs(&db.Status, PendingOperationPreDeleteHooks) {
+		if err := r.checkpoint(ctx, db, PendingOperationPreDeleteHooks, steps, jobs[0].Name); err != nil {
+			return false, err
+		}
+	}
+
+	for {
+		step := db.Status.PendingOperation.Step
+		if step == preDeleteHooksDone {
+			return true, nil
+		}
+		i := slices.IndexFunc(jobs, func(job databasev1alpha1.HookJob) bool { return job.Name == step })
+		if i < 0 {
+			log.FromContext(ctx).Info("Job of the pre-delete hook was removed from spec.hooks, skipping it", "job", step)
+			return true, r.checkpoint(ctx, db, PendingOperationPreDeleteHooks, steps, preDeleteHooksDone)
+		}
+		done, err := r.runHookJob(ctx, db, hookPreDelete, jobs[i], r.storedCredentials(db))
+		if apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+			log.FromContext(ctx).Info("Namespace is terminating, skipping the pre-delete hook")
+			return true, nil
+		}
+		if err != nil || !done {
+			return false, err
+		}
+		next := preDeleteHooksDone
+		if i+1 < len(jobs) {
+			next = jobs[i+1].Name
+		}
+		if err := r.checkpoint(ctx, db, PendingOperationPreDeleteHooks, steps, next); err != nil {
+			return false, err
+		}
+	}
+}
+
+// storedCredentials returns a loader of the credentials stored in the secret of a Database
+func (r *DatabaseReconciler) storedCredentials(db *databasev1alpha1.Database) credentialsLoader {
+	return func(ctx context.Context) (*secrets.DatabaseSecret, error) {
+		awsClient, err := r.awsClient(ctx, r.getRegion(db))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		secretName := db.Status.ActualSecretName
+		if secretName == "" {
+			secretName = r.getSecretNameOrDefault(db)
+		}
+		credentials, err := awsClient.GetSecretWithKeyNames(ctx, resolveSecretID(db, secretName, awsClient.GetRegion()), secretKeyNames(db))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret %s for the hook Job: %w", secretName, err)
+		}
+		return credentials, nil
+	}
+}
+
+// runHookJob runs a Job of a hook and reports whether it succeeded
+// The credentials are passed in a secret named like the Job, which is deleted once the Job succeeded.
+// A failed Job is kept for its logs and is a configuration error until it is deleted, which starts a new Job.
+func (r *DatabaseReconciler) runHookJob(ctx context.Context, db *databasev1alpha1.Database, hook string, hookJob databasev1alpha1.HookJob, credentials credentialsLoader) (bool, error) {
+	key, err := r.jobKey(ctx, db, hook+"-"+hookJob.Name)
+	if err != nil {
+		return false, err
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, key, job)
+	if apierrors.IsForbidden(err) {
+		return false, newConfigError(fmt.Errorf("spec.hooks requires RBAC for Jobs, granted by the chart value hookJobs.enabled: %w", err))
+	}
+	if apierrors.IsNotFound(err) {
+		if plannedChange(ctx, "start %s hook Job %s", hook, key) {
+			return false, nil
+		}
+		values, err := credentials(ctx)
+		if err != nil {
+			return false, err
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       key.Namespace,
+				Name:            key.Name,
+				OwnerReferences: []metav1.OwnerReference{ownerReference(db)},
+			},
+			Data: hookSecretData(values),
+		}
+		if err := r.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create secret of hook Job %s: %w", key, err)
+		}
+		if err := r.Create(ctx, newHookJob(db, key, hookJob)); err != nil {
+			return false, fmt.Errorf("failed to create hook Job %s: %w", key, err)
+		}
+		log.FromContext(ctx).Info("Hook Job started", "hook", hook, "job", key.String())
+		r.recordNormal(db, EventReasonHookStarted, "Job %s of the %s hook started", key, hook)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get hook Job %s: %w", key, err)
+	}
+	if !isOwnedBy(job, db) {
+		return false, newConfigError(fmt.Errorf("hook Job %s already exists and is not owned by this resource", key))
+	}
+
+	switch {
+	case jobConditionTrue(job, batchv1.JobFailed):
+		return false, newConfigError(fmt.Errorf("%s hook Job %s failed, see its logs and delete it to retry", hook, key))
+	case jobConditionTrue(job, batchv1.JobComplete):
+		if plannedChange(ctx, "delete secret %s of the completed hook Job", key) {
+			return false, nil
+		}
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete secret of hook Job %s: %w", key, err)
+		}
+		log.FromContext(ctx).Info("Hook Job completed", "hook", hook, "job", key.String())
+		r.recordNormal(db, EventReasonHookCompleted, "Job %s of the %s hook completed", key, hook)
+		return true, nil
+	}
+	return false, nil
+}
+
+// newHookJob returns the Job of a hook with the secret of key in the environment of all its containers
+func newHookJob(db *databasev1alpha1.Database, key client.ObjectKey, hookJob databasev1alpha1.HookJob) *batchv1.Job {
+	spec := hookJob.Template.DeepCopy()
+	if spec.Template.Spec.RestartPolicy == "" {
+		spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	envFrom := corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: key.Name}}}
+	for i := range spec.Template.Spec.InitContainers {
+		spec.Template.Spec.InitContainers[i].EnvFrom = append(spec.Template.Spec.InitContainers[i].EnvFrom, envFrom)
+	}
+	for i := range spec.Template.Spec.Containers {
+		spec.Template.Spec.Containers[i].EnvFrom = append(spec.Template.Spec.Containers[i].EnvFrom, envFrom)
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       key.Namespace,
+			Name:            key.Name,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(db)},
+		},
+		Spec: *spec,
+	}
+}
+
+// hookSecretData returns the environment passed to the Jobs of hooks
+func hookSecretData(credentials *secrets.DatabaseSecret) map[string][]byte {
+	return map[string][]byte{
+		"DB_HOST":     []byte(credentials.DBHost),
+		"DB_PORT":     []byte(strconv.Itoa(credentials.DBPort)),
+		"DB_NAME":     []byte(credentials.DBName),
+		"DB_USERNAME": []byte(credentials.DBUsername),
+		"DB_PASSWORD": []byte(credentials.DBPassword),
+	}
+}
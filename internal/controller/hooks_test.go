@@ -0,0 +1,201 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+func newHookTestReconciler(t *testing.T, objs ...client.Object) *DatabaseReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&databasev1alpha1.Database{}, &batchv1.Job{}).
+		Build()
+	return &DatabaseReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(20)}
+}
+
+func testHookJob(name string) databasev1alpha1.HookJob {
+	return databasev1alpha1.HookJob{
+		Name: name,
+		Template: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: name, Image: "busybox"}}},
+			},
+		},
+	}
+}
+
+// completeJob marks the Job of key as succeeded
+func completeJob(t *testing.T, r *DatabaseReconciler, key client.ObjectKey) {
+	t.Helper()
+	ctx := context.Background()
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, key, job); err != nil {
+		t.Fatal(err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := r.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReconcilePostCreateHooks(t *testing.T) {
+	ctx := context.Background()
+	r := newHookTestReconciler(t)
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders", UID: "uid-1"},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       databasev1alpha1.DatabaseEnginePostgres,
+			DatabaseName: "orders",
+			Hooks: &databasev1alpha1.DatabaseHooks{
+				PostCreate: []databasev1alpha1.HookJob{testHookJob("migrate"), testHookJob("seed")},
+			},
+		},
+	}
+	credentials := func(context.Context) (*secrets.DatabaseSecret, error) {
+		return &secrets.DatabaseSecret{DBHost: "db.example.com", DBPort: 5432, DBName: "orders", DBUsername: "orders", DBPassword: "s3cret"}, nil
+	}
+
+	if err := r.reconcilePostCreateHooks(ctx, db, credentials); err != nil {
+		t.Fatal(err)
+	}
+	migrate := client.ObjectKey{Namespace: "shop", Name: "orders-post-create-migrate"}
+	if err := r.Get(ctx, migrate, &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Job without a pending hook: error = %v, want NotFound", err)
+	}
+
+	startPostCreateHooks(db)
+	if err := r.reconcilePostCreateHooks(ctx, db, credentials); err != nil {
+		t.Fatal(err)
+	}
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, migrate, job); err != nil {
+		t.Fatal(err)
+	}
+	if !isOwnedBy(job, db) {
+		t.Errorf("owner references = %v, want controller uid-1", job.OwnerReferences)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("restartPolicy = %s, want Never", job.Spec.Template.Spec.RestartPolicy)
+	}
+	envFrom := job.Spec.Template.Spec.Containers[0].EnvFrom
+	if len(envFrom) != 1 || envFrom[0].SecretRef == nil || envFrom[0].SecretRef.Name != migrate.Name {
+		t.Errorf("envFrom = %v, want the secret %s", envFrom, migrate.Name)
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, migrate, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["DB_PASSWORD"]) != "s3cret" || string(secret.Data["DB_PORT"]) != "5432" {
+		t.Errorf("secret data = %v, want the credentials of the Database", secret.Data)
+	}
+
+	// The second Job starts once the first one succeeded
+	completeJob(t, r, migrate)
+	if err := r.reconcilePostCreateHooks(ctx, db, credentials); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, migrate, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("secret of the completed Job: error = %v, want NotFound", err)
+	}
+	if step := db.Status.PendingOperation.Step; step != "seed" {
+		t.Fatalf("step = %s, want seed", step)
+	}
+	seed := client.ObjectKey{Namespace: "shop", Name: "orders-post-create-seed"}
+	completeJob(t, r, seed)
+	if err := r.reconcilePostCreateHooks(ctx, db, credentials); err != nil {
+		t.Fatal(err)
+	}
+	if pendingOperationIs(&db.Status, PendingOperationPostCreateHooks) {
+		t.Error("pending hooks not cleared after all Jobs completed")
+	}
+}
+
+func TestReconcilePreDeleteHooks(t *testing.T) {
+	ctx := context.Background()
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders", UID: "uid-1"},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       databasev1alpha1.DatabaseEnginePostgres,
+			DatabaseName: "orders",
+			Hooks: &databasev1alpha1.DatabaseHooks{
+				PreDelete: []databasev1alpha1.HookJob{testHookJob("dump")},
+			},
+		},
+	}
+	// A Job started by an earlier reconciliation, the credentials are not read again
+	job := newHookJob(db, client.ObjectKey{Namespace: "shop", Name: "orders-pre-delete-dump"}, db.Spec.Hooks.PreDelete[0])
+	r := newHookTestReconciler(t, db.DeepCopy(), job)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(db), db); err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := r.reconcilePreDeleteHooks(ctx, db)
+	if err != nil || done {
+		t.Fatalf("running Job: reconcilePreDeleteHooks() = %v, %v, want false", done, err)
+	}
+	stored := &databasev1alpha1.Database{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(db), stored); err != nil {
+		t.Fatal(err)
+	}
+	if !pendingOperationIs(&stored.Status, PendingOperationPreDeleteHooks) {
+		t.Errorf("stored pending operation = %v, want %s", stored.Status.PendingOperation, PendingOperationPreDeleteHooks)
+	}
+
+	completeJob(t, r, client.ObjectKeyFromObject(job))
+	done, err = r.reconcilePreDeleteHooks(ctx, db)
+	if err != nil || !done {
+		t.Fatalf("completed Job: reconcilePreDeleteHooks() = %v, %v, want true", done, err)
+	}
+	if step := db.Status.PendingOperation.Step; step != preDeleteHooksDone {
+		t.Errorf("step = %s, want %s", step, preDeleteHooksDone)
+	}
+
+	// Once done, the Jobs are not run again
+	if err := r.Delete(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if done, err := r.reconcilePreDeleteHooks(ctx, db); err != nil || !done {
+		t.Errorf("after completion: reconcilePreDeleteHooks() = %v, %v, want true", done, err)
+	}
+}
+
+func TestJobKey(t *testing.T) {
+	r := &DatabaseReconciler{}
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders"}}
+	key, err := r.jobKey(context.Background(), db, hookPreDelete+"-dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Name != "orders-pre-delete-dump" {
+		t.Errorf("name = %s, want orders-pre-delete-dump", key.Name)
+	}
+}
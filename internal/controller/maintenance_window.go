@@ -0,0 +1,124 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// maxMaintenanceWindowDuration is the longest maintenance window, a week
+const maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// maintenanceWindowError defers an operation until the maintenance window of the Database opens
+type maintenanceWindowError struct {
+	operation string
+	opensAt   time.Time
+}
+
+func (e *maintenanceWindowError) Error() string {
+	return fmt.Sprintf("%s deferred until the maintenance window opens at %s",
+		e.operation, e.opensAt.UTC().Format(time.RFC3339))
+}
+
+// validateMaintenanceWindow checks the parts of spec.maintenanceWindow the CRD schema cannot
+func validateMaintenanceWindow(window *databasev1alpha1.MaintenanceWindow) error {
+	if window == nil {
+		return nil
+	}
+	_, _, err := maintenanceWindowOpen(window, time.Now())
+	return err
+}
+
+// maintenanceWindowOpen reports whether the window is open at now, and otherwise when it opens next
+func maintenanceWindowOpen(window *databasev1alpha1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	location, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("spec.maintenanceWindow.timeZone: %w", err)
+	}
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("spec.maintenanceWindow.start must be HH:MM: %w", err)
+	}
+	duration := window.Duration.Duration
+	if duration <= 0 || duration > maxMaintenanceWindowDuration {
+		return false, time.Time{}, fmt.Errorf("spec.maintenanceWindow.duration must be positive and at most %s", maxMaintenanceWindowDuration)
+	}
+
+	// A window that opened on one of the previous days may still be open
+	local := now.In(location)
+	var opensAt time.Time
+	for day := -7; day <= 7; day++ {
+		date := local.AddDate(0, 0, day)
+		if len(window.Days) > 0 && !slices.Contains(window.Days, date.Weekday().String()[:3]) {
+			continue
+		}
+		opens := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, location)
+		if !now.Before(opens) && now.Before(opens.Add(duration)) {
+			return true, opens, nil
+		}
+		if opens.After(now) && (opensAt.IsZero() || opens.Before(opensAt)) {
+			opensAt = opens
+		}
+	}
+	return false, opensAt, nil
+}
+
+// requireMaintenanceWindow returns a maintenanceWindowError for the operation while the maintenance
+// window of the Database is closed, and nil without a window. Dry runs are not restricted, they
+// report the operation as a planned change.
+func requireMaintenanceWindow(ctx context.Context, db *databasev1alpha1.Database, format string, args ...any) error {
+	window := db.Spec.MaintenanceWindow
+	if window == nil || changePlanFrom(ctx) != nil {
+		return nil
+	}
+	open, opensAt, err := maintenanceWindowOpen(window, time.Now())
+	if err != nil {
+		return newConfigError(err)
+	}
+	if open {
+		return nil
+	}
+	return &maintenanceWindowError{operation: fmt.Sprintf(format, args...), opensAt: opensAt}
+}
+
+// maintenanceWindowRequeue returns the time until the maintenance window of a deferred operation opens
+func maintenanceWindowRequeue(err error) (time.Duration, bool) {
+	var windowErr *maintenanceWindowError
+	if !errors.As(err, &windowErr) {
+		return 0, false
+	}
+	return max(time.Until(windowErr.opensAt), time.Second), true
+}
+
+// deferDeletion keeps the finalizer of a deleted Database whose cleanup waits for the maintenance
+// window, reports it in the DeletionBlocked condition and requeues the Database when the window opens
+func (r *DatabaseReconciler) deferDeletion(ctx context.Context, db *databasev1alpha1.Database, err error) (ctrl.Result, error) {
+	requeueAfter, ok := maintenanceWindowRequeue(err)
+	if !ok {
+		r.setDeletionBlocked(ctx, db, ReasonConfigError, err)
+		return ctrl.Result{}, err
+	}
+	log.FromContext(ctx).Info("Cleanup deferred until the maintenance window opens", "requeueAfter", requeueAfter)
+	message, changed := setDeletionBlockedCondition(&db.Status.Conditions, db.Generation, ReasonMaintenanceWindow, err)
+	if changed {
+		if statusErr := r.updateStatus(ctx, db); statusErr != nil {
+			log.FromContext(ctx).Error(statusErr, "Failed to update deletion status")
+		}
+		r.recordNormal(db, EventReasonCleanupDeferred, "%s", message)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
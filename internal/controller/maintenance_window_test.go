@@ -0,0 +1,204 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+func TestMaintenanceWindowOpen(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Saturday 2025-06-07
+	saturday := func(hour, minute int) time.Time { return time.Date(2025, 6, 7, hour, minute, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name        string
+		window      databasev1alpha1.MaintenanceWindow
+		now         time.Time
+		wantOpen    bool
+		wantOpensAt time.Time
+		wantErr     bool
+	}{
+		{
+			name:     "every day, open",
+			window:   databasev1alpha1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			now:      saturday(3, 0),
+			wantOpen: true,
+		},
+		{
+			name:        "every day, closed",
+			window:      databasev1alpha1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			now:         saturday(6, 0),
+			wantOpensAt: time.Date(2025, 6, 8, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "opened the day before",
+			window:   databasev1alpha1.MaintenanceWindow{Days: []string{"Fri"}, Start: "22:00", Duration: metav1.Duration{Duration: 6 * time.Hour}},
+			now:      saturday(1, 0),
+			wantOpen: true,
+		},
+		{
+			name:        "weekdays only",
+			window:      databasev1alpha1.MaintenanceWindow{Days: []string{"Mon", "Wed"}, Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}},
+			now:         saturday(2, 30),
+			wantOpensAt: time.Date(2025, 6, 9, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			window: databasev1alpha1.MaintenanceWindow{
+				Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Europe/Stockholm",
+			},
+			now:         saturday(1, 30),
+			wantOpensAt: time.Date(2025, 6, 8, 2, 0, 0, 0, stockholm),
+		},
+		{
+			name:    "unknown time zone",
+			window:  databasev1alpha1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Europe/Atlantis"},
+			now:     saturday(2, 0),
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			window:  databasev1alpha1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 200 * time.Hour}},
+			now:     saturday(2, 0),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, opensAt, err := maintenanceWindowOpen(&tt.window, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("maintenanceWindowOpen() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if open != tt.wantOpen {
+				t.Errorf("open = %v, want %v", open, tt.wantOpen)
+			}
+			if !tt.wantOpen && !opensAt.Equal(tt.wantOpensAt) {
+				t.Errorf("opensAt = %v, want %v", opensAt, tt.wantOpensAt)
+			}
+		})
+	}
+}
+
+func TestRequireMaintenanceWindow(t *testing.T) {
+	// A one minute window that opened an hour ago is closed
+	closed := &databasev1alpha1.MaintenanceWindow{
+		Start:    time.Now().UTC().Add(-time.Hour).Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Minute},
+	}
+	db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{MaintenanceWindow: closed}}
+
+	err := requireMaintenanceWindow(context.Background(), db, "update of secret %s", "rds/postgres/app")
+	if classifyError(err) != ReasonMaintenanceWindow {
+		t.Fatalf("closed window: error = %v, want reason %s", err, ReasonMaintenanceWindow)
+	}
+	requeueAfter, ok := errorRequeue(err, ReasonMaintenanceWindow)
+	if !ok || requeueAfter <= 0 || requeueAfter > 24*time.Hour {
+		t.Errorf("errorRequeue() = %s, %v, want the time until the window opens", requeueAfter, ok)
+	}
+
+	if err := requireMaintenanceWindow(withChangePlan(context.Background(), &changePlan{}), db, "update"); err != nil {
+		t.Errorf("dry run: error = %v, want nil", err)
+	}
+	if err := requireMaintenanceWindow(context.Background(), &databasev1alpha1.Database{}, "update"); err != nil {
+		t.Errorf("no window: error = %v, want nil", err)
+	}
+
+	db.Spec.MaintenanceWindow = &databasev1alpha1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 24 * time.Hour}}
+	if err := requireMaintenanceWindow(context.Background(), db, "update"); err != nil {
+		t.Errorf("window open all day: error = %v, want nil", err)
+	}
+}
+
+func TestStoreCredentialsOutsideMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		wantErr     bool
+		wantVersion string
+	}{
+		// The secret is only compared, the version recorded when it was written stays
+		{name: "unchanged secret", password: "s3cret", wantVersion: "v1"},
+		{name: "changed secret is deferred", password: "rotated", wantErr: true, wantVersion: "v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: databasev1alpha1.DatabaseSpec{
+					Engine:            databasev1alpha1.DatabaseEnginePostgres,
+					DatabaseName:      "app",
+					SecretName:        "rds/postgres/app",
+					AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "us-east-1"},
+					// A one minute window that opened an hour ago is closed
+					MaintenanceWindow: &databasev1alpha1.MaintenanceWindow{
+						Start:    time.Now().UTC().Add(-time.Hour).Format("15:04"),
+						Duration: metav1.Duration{Duration: time.Minute},
+					},
+				},
+				Status: databasev1alpha1.DatabaseStatus{
+					SecretCreated:    true,
+					ActualSecretName: "rds/postgres/app",
+					SecretRegion:     "us-east-1",
+					SecretVersion:    "v1",
+				},
+			}
+			current := &secrets.DatabaseSecret{
+				DBHost:      "db.example.com",
+				DBPort:      5432,
+				DBName:      "app",
+				DBUsername:  "app",
+				DBPassword:  "s3cret",
+				DatabaseURL: databaseURL(db, "app", "s3cret", "db.example.com", 5432),
+				Engine:      "postgres",
+			}
+			value, err := current.ToJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			fake := newFakeSecretsManager(t)
+			fake.arn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbC123"
+			fake.values["rds/postgres/app"] = string(value)
+			r := &DatabaseReconciler{Recorder: record.NewFakeRecorder(10)}
+			// UpdateSecret then only writes a new value
+			if fake.description, err = r.secretDescription(db); err != nil {
+				t.Fatal(err)
+			}
+
+			err = r.storeCredentialsInAWS(context.Background(), db, "app", tt.password, "", "db.example.com", 5432, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("storeCredentialsInAWS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && classifyError(err) != ReasonMaintenanceWindow {
+				t.Errorf("storeCredentialsInAWS() error = %v, want reason %s", err, ReasonMaintenanceWindow)
+			}
+			if got := fake.callCount("UpdateSecret"); got != 0 {
+				t.Errorf("secret written %d times outside the window", got)
+			}
+			if db.Status.SecretVersion != tt.wantVersion {
+				t.Errorf("status.secretVersion = %q, want %q", db.Status.SecretVersion, tt.wantVersion)
+			}
+		})
+	}
+}
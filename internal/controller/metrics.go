@@ -10,6 +10,9 @@ package controller
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
 )
 
 var (
@@ -58,8 +61,132 @@ var (
 		},
 		[]string{"namespace", "name", "condition"},
 	)
+
+	// DatabaseUserErrorBudget tracks the Warning events a Database may still record in the current window
+	DatabaseUserErrorBudget = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "databaseuser_reconcile_error_budget",
+			Help: "Warning events a DatabaseUser may still record in the current hour before further warnings are suppressed",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// DatabaseUserEventsSuppressed tracks the Warning events dropped because the budget was exhausted
+	DatabaseUserEventsSuppressed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "databaseuser_events_suppressed_total",
+			Help: "Total number of Warning events of a DatabaseUser suppressed by the event budget",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// DatabaseUserOrphanedResources tracks managed users and databases without a Database resource
+	DatabaseUserOrphanedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "databaseuser_orphaned_resources",
+			Help: "Users and databases carrying the operator's managed marker without a corresponding Database resource (1 = orphaned)",
+		},
+		[]string{"server", "kind", "name"},
+	)
+
+	// DatabaseUserStaleSecrets tracks managed AWS secrets not referenced by any Database resource
+	DatabaseUserStaleSecrets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "databaseuser_stale_secrets",
+			Help: "AWS secrets carrying the managed-by tag that are not referenced by any Database resource (1 = stale)",
+		},
+		[]string{"region", "secret"},
+	)
+
+	// DatabaseUserStaleSecretsDeleted tracks stale secrets deleted by the garbage collector
+	DatabaseUserStaleSecretsDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "databaseuser_stale_secrets_deleted_total",
+			Help: "Total number of stale AWS secrets deleted by the secret garbage collector",
+		},
+		[]string{"region"},
+	)
+
+	// DatabaseUserAdoptionCandidates tracks unmanaged AWS secrets following the naming convention
+	DatabaseUserAdoptionCandidates = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "databaseuser_adoption_candidates",
+			Help: "AWS secrets following the naming convention of the operator that no Database manages, listed in the AdoptionReport",
+		},
+		[]string{"region"},
+	)
+
+	// DatabaseUserServerUp tracks the result of the health checks of the admin endpoints
+	DatabaseUserServerUp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "databaseuser_server_up",
+			Help: "Whether the last health check of a database server admin endpoint succeeded (1 = up, 0 = down)",
+		},
+		[]string{"server"},
+	)
+
+	// DatabaseUserServerLatency tracks how long the health checks of the admin endpoints took
+	DatabaseUserServerLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "databaseuser_server_latency_seconds",
+			Help: "Time the last health check of a database server admin endpoint took to connect and run a query",
+		},
+		[]string{"server"},
+	)
+
+	// DatabaseUserNotifications tracks the notifications of lifecycle events per sink
+	DatabaseUserNotifications = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "databaseuser_notifications_total",
+			Help: "Total number of lifecycle event notifications per sink and result (sent, failed, dropped)",
+		},
+		[]string{"sink", "result"},
+	)
+
+	// DatabaseUserAWSAPICalls tracks the attempts of AWS API calls, including retries
+	DatabaseUserAWSAPICalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "databaseuser_aws_api_calls_total",
+			Help: "Total number of attempts of AWS API calls per service, operation, region and result (success, throttled, error)",
+		},
+		[]string{"service", "operation", "region", "result"},
+	)
+
+	// DatabaseUserAWSRateLimitWait tracks how long AWS API calls waited for the operator-wide rate limit
+	DatabaseUserAWSRateLimitWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "databaseuser_aws_rate_limit_wait_seconds",
+			Help:    "Time attempts of AWS API calls waited for the operator-wide rate limit",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10},
+		},
+		[]string{"service"},
+	)
+
+	// DatabaseUserStatementTimeouts tracks statements canceled by the statement timeout watchdog
+	DatabaseUserStatementTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "databaseuser_db_statement_timeouts_total",
+			Help: "Total number of database statements canceled by the operator after --db-statement-timeout per engine",
+		},
+		[]string{"engine"},
+	)
 )
 
+// observeAWSAPICall records an attempt of an AWS API call in the metrics
+func observeAWSAPICall(call secrets.APICall) {
+	result := "success"
+	switch {
+	case call.Throttled:
+		result = "throttled"
+	case call.Failed:
+		result = "error"
+	}
+	DatabaseUserAWSAPICalls.WithLabelValues(call.Service, call.Operation, call.Region, result).Inc()
+	if call.Wait > 0 {
+		DatabaseUserAWSRateLimitWait.WithLabelValues(call.Service).Observe(call.Wait.Seconds())
+	}
+}
+
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(
@@ -68,5 +195,21 @@ func init() {
 		DatabaseUserInfo,
 		DatabaseUserValidationErrors,
 		DatabaseUserConditions,
+		DatabaseUserErrorBudget,
+		DatabaseUserEventsSuppressed,
+		DatabaseUserOrphanedResources,
+		DatabaseUserStaleSecrets,
+		DatabaseUserStaleSecretsDeleted,
+		DatabaseUserAdoptionCandidates,
+		DatabaseUserServerUp,
+		DatabaseUserServerLatency,
+		DatabaseUserNotifications,
+		DatabaseUserAWSAPICalls,
+		DatabaseUserAWSRateLimitWait,
+		DatabaseUserStatementTimeouts,
 	)
+	secrets.SetAPICallObserver(observeAWSAPICall)
+	database.SetStatementTimeoutObserver(func(engine string) {
+		DatabaseUserStatementTimeouts.WithLabelValues(engine).Inc()
+	})
 }
@@ -0,0 +1,335 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// defaultMigrationUserSuffix is appended to the username and secret name without spec.migrationUser.suffix
+const defaultMigrationUserSuffix = "_migrator"
+
+// maxMigrationUsernameLength is the longest username of a migration user, the PostgreSQL identifier limit
+const maxMigrationUsernameLength = 63
+
+// migrationUserSuffix returns the suffix of the migration user of a Database
+func migrationUserSuffix(db *databasev1alpha1.Database) string {
+	if db.Spec.MigrationUser != nil && db.Spec.MigrationUser.Suffix != "" {
+		return db.Spec.MigrationUser.Suffix
+	}
+	return defaultMigrationUserSuffix
+}
+
+// migrationSecretName returns the name of the secret of the migration user
+// The default appends the suffix to the secret name of the Database, which is not possible for an ARN.
+func (r *DatabaseReconciler) migrationSecretName(db *databasev1alpha1.Database) (string, error) {
+	if db.Spec.MigrationUser != nil && db.Spec.MigrationUser.SecretName != "" {
+		return db.Spec.MigrationUser.SecretName, nil
+	}
+	if secrets.IsSecretARN(db.Spec.SecretName) {
+		return "", fmt.Errorf("spec.migrationUser.secretName is required when spec.secretName is an ARN")
+	}
+	return r.getSecretNameOrDefault(db) + migrationUserSuffix(db), nil
+}
+
+// migrationUserPrivileges returns the normalized privileges of the migration user
+func migrationUserPrivileges(db *databasev1alpha1.Database) ([]string, error) {
+	privileges := db.Spec.MigrationUser.Privileges
+	if len(privileges) == 0 {
+		var err error
+		privileges, err = database.PresetPrivileges(string(db.Spec.Engine), database.PresetMigrationRunner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := database.ValidatePrivileges(privileges); err != nil {
+		return nil, fmt.Errorf("spec.migrationUser.privileges: %w", err)
+	}
+	return normalizePrivileges(privileges), nil
+}
+
+// checkMigrationSecret returns an error unless the existing secret of the migration user may be reused:
+// an existing user must have been created for spec.migrationUser, and the secret must carry the
+// managed-by tag, or have been recorded for the Database if the tag is disabled
+func (r *DatabaseReconciler) checkMigrationSecret(ctx context.Context, awsClient *secrets.AWSSecretsManagerClient, migrator, secretName string, userExists, owned bool) error {
+	if userExists && !owned {
+		return newConfigError(fmt.Errorf("user %s already exists but was not created for spec.migrationUser", migrator))
+	}
+	if r.ManagedByTagKey == "" {
+		if !owned {
+			return newConfigError(fmt.Errorf("secret %s of the migration user already exists but was not created for spec.migrationUser", secretName))
+		}
+		return nil
+	}
+	tags, err := awsClient.GetSecretTags(ctx, secretName)
+	if err != nil {
+		return fmt.Errorf("failed to get tags of secret %s of migration user: %w", secretName, err)
+	}
+	if value, ok := tags[r.ManagedByTagKey]; !ok || value != r.ManagedByTagValue {
+		return newConfigError(fmt.Errorf("secret %s of the migration user already exists without tag %s=%s, it is not managed by the operator",
+			secretName, r.ManagedByTagKey, r.ManagedByTagValue))
+	}
+	return nil
+}
+
+// reconcileMigrationUser provisions the migration user of spec.migrationUser next to the user of the
+// Database and stores its credentials in a secret of its own, in the region of the Database secret.
+// The user of the Database is granted its privileges on the tables the migration user creates.
+// A user of the same name that was not created for the Database is never taken over.
+func (r *DatabaseReconciler) reconcileMigrationUser(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client, connInfo *database.ConnectionInfo, host string, port int, username string) error {
+	cfg := db.Spec.MigrationUser
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	migrator, err := fitUsername(db, username+migrationUserSuffix(db))
+	if err != nil {
+		return newConfigError(fmt.Errorf("migration user: %w", err))
+	}
+	if len(migrator) > maxMigrationUsernameLength {
+		return newConfigError(fmt.Errorf("migration username %s is longer than %d characters, shorten spec.migrationUser.suffix",
+			migrator, maxMigrationUsernameLength))
+	}
+	secretName, err := r.migrationSecretName(db)
+	if err != nil {
+		return newConfigError(err)
+	}
+	desired, err := migrationUserPrivileges(db)
+	if err != nil {
+		return newConfigError(err)
+	}
+	description, err := r.userSecretDescription(db, migrator)
+	if err != nil {
+		return err
+	}
+
+	region := r.getRegion(db)
+	if err := r.validateRegion(region); err != nil {
+		return fmt.Errorf("invalid AWS region: %w", err)
+	}
+	awsClient, err := r.awsClient(ctx, region)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+	region = awsClient.GetRegion()
+
+	secretExists, err := awsClient.SecretExists(ctx, secretName)
+	if err != nil {
+		return fmt.Errorf("failed to check if secret exists: %w", err)
+	}
+	userExists, err := dbClient.UserExists(ctx, migrator)
+	if err != nil {
+		return fmt.Errorf("failed to check if user exists: %w", err)
+	}
+	status := db.Status.MigrationUser
+	owned := status != nil && status.Username == migrator
+
+	var password string
+	switch {
+	case secretExists:
+		if err := r.checkMigrationSecret(ctx, awsClient, migrator, secretName, userExists, owned); err != nil {
+			return err
+		}
+		existing, err := awsClient.GetSecretWithKeyNames(ctx, secretName, secretKeyNames(db))
+		if err != nil {
+			return fmt.Errorf("failed to retrieve secret of migration user: %w", err)
+		}
+		password = existing.DBPassword
+		if password == "" {
+			return fmt.Errorf("could not extract password from secret %s of migration user", secretName)
+		}
+		if !userExists {
+			logger.Info("Creating migration user from its existing secret", "username", migrator, "secretName", secretName)
+			if err := dbClient.CreateUser(ctx, migrator, password); err != nil {
+				return err
+			}
+			r.warnPasswordLogging(ctx, db, dbClient)
+			r.recordNormal(db, EventReasonUserCreated, "Migration user %s created on %s", migrator, connInfo.Host)
+		}
+	case userExists && !owned:
+		return newConfigError(fmt.Errorf("user %s already exists but was not created for spec.migrationUser, and secret %s does not exist",
+			migrator, secretName))
+	default:
+		password, err = database.GeneratePassword(32)
+		if err != nil {
+			return err
+		}
+		if userExists {
+			logger.Info("Resetting password of migration user without secret", "username", migrator)
+			if err := dbClient.SetPassword(ctx, migrator, password); err != nil {
+				return err
+			}
+			r.warnPasswordLogging(ctx, db, dbClient)
+		} else {
+			logger.Info("Creating migration user", "username", migrator, "host", connInfo.Host)
+			if err := dbClient.CreateUser(ctx, migrator, password); err != nil {
+				return err
+			}
+			r.warnPasswordLogging(ctx, db, dbClient)
+			r.recordNormal(db, EventReasonUserCreated, "Migration user %s created on %s", migrator, connInfo.Host)
+		}
+	}
+
+	if !owned {
+		// Record the user before its secret is written, so a failed write is retried with a new password
+		db.Status.MigrationUser = &databasev1alpha1.MigrationUserStatus{Username: migrator, SecretName: secretName}
+	}
+
+	if owned {
+		var removed []string
+		for _, privilege := range status.GrantedPrivileges {
+			if !slices.Contains(desired, privilege) {
+				removed = append(removed, privilege)
+			}
+		}
+		if len(removed) > 0 {
+			if err := requireMaintenanceWindow(ctx, db, "revoking %s from %s", strings.Join(removed, ", "), migrator); err != nil {
+				return err
+			}
+			logger.Info("Revoking privileges of migration user", "username", migrator, "privileges", removed)
+			if err := dbClient.RevokeDatabasePrivileges(ctx, db.Spec.DatabaseName, migrator, removed); err != nil {
+				return err
+			}
+			r.recordNormal(db, EventReasonPrivilegesRevoked, "Revoked %s on %s from %s",
+				strings.Join(removed, ", "), db.Spec.DatabaseName, migrator)
+		}
+	}
+	if err := dbClient.GrantDatabasePrivileges(ctx, db.Spec.DatabaseName, migrator, desired); err != nil {
+		return err
+	}
+	if err := dbClient.GrantOwnerDefaultPrivileges(ctx, db.Spec.DatabaseName, migrator, username, db.Status.GrantedPrivileges); err != nil {
+		return err
+	}
+
+	secretValue := &secrets.DatabaseSecret{
+		DBHost:      host,
+		DBPort:      port,
+		DBName:      db.Spec.DatabaseName,
+		DBUsername:  migrator,
+		DBPassword:  password,
+		DatabaseURL: databaseURL(db, migrator, password, host, port),
+		Engine:      string(db.Spec.Engine),
+		KeyNames:    secretKeyNames(db),
+
+		ConnectionStringOnly: connectionStringOnly(db),
+		Binary:               db.Spec.SecretBinary,
+	}
+
+	var secretARN string
+	if !secretExists {
+		if plannedChange(ctx, "create secret %s in %s", secretName, region) {
+			return nil
+		}
+		var versionID string
+		secretARN, versionID, err = awsClient.CreateSecretWithTemplate(ctx, secretName, description, secretValue, r.desiredSecretTags(db), db.Spec.SecretTemplate)
+		if err != nil {
+			return err
+		}
+		logger.Info("Secret of migration user created", "secretName", secretName, "secretARN", secretARN, "region", region)
+		r.recordNormal(db, EventReasonSecretCreated, "Secret %s created in %s", secretName, region)
+		r.publishSecretEvent(db, EventReasonSecretCreated, NotificationSecret{Name: secretName, ARN: secretARN, Region: region, VersionID: versionID},
+			"Secret %s of the migration user created in %s", secretName, region)
+	} else {
+		if changePlanFrom(ctx) != nil {
+			changed, err := awsClient.SecretContentChanged(ctx, secretName, secretValue, db.Spec.SecretTemplate)
+			if err != nil {
+				return err
+			}
+			if changed {
+				plannedChange(ctx, "update secret %s", secretName)
+			}
+		} else if deferErr := requireMaintenanceWindow(ctx, db, "update of secret %s", secretName); deferErr != nil {
+			changed, err := awsClient.SecretContentChanged(ctx, secretName, secretValue, db.Spec.SecretTemplate)
+			if err != nil {
+				return err
+			}
+			if changed {
+				return deferErr
+			}
+		} else if versionID, updated, err := awsClient.UpdateSecretIfChanged(ctx, secretName, secretValue, db.Spec.SecretTemplate); err != nil {
+			return err
+		} else if updated {
+			r.recordNormal(db, EventReasonSecretRotated, "Secret %s updated", secretName)
+			r.publishSecretEvent(db, EventReasonSecretRotated, NotificationSecret{Name: secretName, ARN: db.Status.MigrationUser.SecretARN, Region: region, VersionID: versionID},
+				"Secret %s of the migration user updated", secretName)
+		}
+
+		// A failure to read the tags of the Database secret must stay visible in TagsSynced
+		if !meta.IsStatusConditionFalse(db.Status.Conditions, ConditionTagsSynced) {
+			if err := r.syncSecretTags(ctx, db, awsClient, secretName, secretName); err != nil {
+				return err
+			}
+		}
+		if err := r.syncSecretDescription(ctx, awsClient, secretName, secretName, description); err != nil {
+			return err
+		}
+		secretARN, _ = awsClient.GetSecretARN(ctx, secretName)
+	}
+
+	if !owned {
+		logger.Info("Migration user provisioned", "username", migrator, "secretName", secretName)
+	}
+	db.Status.MigrationUser = &databasev1alpha1.MigrationUserStatus{
+		Username:          migrator,
+		SecretName:        secretName,
+		SecretARN:         secretARN,
+		GrantedPrivileges: desired,
+	}
+	return nil
+}
+
+// dropMigrationUser drops the migration user recorded in the status, if it exists
+func (r *DatabaseReconciler) dropMigrationUser(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client) error {
+	if db.Status.MigrationUser == nil || db.Status.MigrationUser.Username == "" {
+		return nil
+	}
+	username := db.Status.MigrationUser.Username
+
+	exists, err := dbClient.UserExists(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to check existence of migration user %s: %w", username, err)
+	}
+	if !exists {
+		return nil
+	}
+	log.FromContext(ctx).Info("Dropping migration user", "username", username)
+	if err := dbClient.DropUser(ctx, username); err != nil {
+		return fmt.Errorf("failed to drop migration user %s: %w", username, err)
+	}
+	return nil
+}
+
+// deleteMigrationSecret deletes the secret of the migration user recorded in the status
+func (r *DatabaseReconciler) deleteMigrationSecret(ctx context.Context, db *databasev1alpha1.Database, awsClient *secrets.AWSSecretsManagerClient) error {
+	if db.Status.MigrationUser == nil || db.Status.MigrationUser.SecretName == "" {
+		return nil
+	}
+	secretName := db.Status.MigrationUser.SecretName
+
+	log.FromContext(ctx).Info("Deleting secret of migration user", "secretName", secretName)
+	if err := awsClient.DeleteSecret(ctx, secretName, true); err != nil {
+		if isAWSResourceNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete secret %s: %w", secretName, err)
+	}
+	r.publishSecretEvent(db, SecretEventDeleted, NotificationSecret{Name: secretName, ARN: db.Status.MigrationUser.SecretARN, Region: awsClient.GetRegion()},
+		"Secret %s of the migration user deleted", secretName)
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestMigrationSecretName(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    databasev1alpha1.DatabaseSpec
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			spec: databasev1alpha1.DatabaseSpec{Engine: "postgres", DatabaseName: "orders", MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true}},
+			want: "rds/postgres/orders_migrator",
+		},
+		{
+			name: "suffix",
+			spec: databasev1alpha1.DatabaseSpec{
+				SecretName:    "prod/orders",
+				MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true, Suffix: "_ddl"},
+			},
+			want: "prod/orders_ddl",
+		},
+		{
+			name: "explicit secret name",
+			spec: databasev1alpha1.DatabaseSpec{
+				SecretName:    "arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/orders-AbCdEf",
+				MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true, SecretName: "prod/orders-migrations"},
+			},
+			want: "prod/orders-migrations",
+		},
+		{
+			name: "database secret is an ARN",
+			spec: databasev1alpha1.DatabaseSpec{
+				SecretName:    "arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/orders-AbCdEf",
+				MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&DatabaseReconciler{}).migrationSecretName(&databasev1alpha1.Database{Spec: tt.spec})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrationSecretName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("migrationSecretName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrationUserPrivileges(t *testing.T) {
+	tests := []struct {
+		name       string
+		engine     databasev1alpha1.DatabaseEngine
+		privileges []string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:   "migrationRunner preset",
+			engine: "postgres",
+			want:   []string{"ALL"},
+		},
+		{
+			name:       "explicit privileges",
+			engine:     "postgres",
+			privileges: []string{"create", "CONNECT", "create"},
+			want:       []string{"CONNECT", "CREATE"},
+		},
+		{
+			name:       "invalid privilege",
+			engine:     "postgres",
+			privileges: []string{"SELECT; DROP TABLE users"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{
+				Engine:        tt.engine,
+				MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true, Privileges: tt.privileges},
+			}}
+			got, err := migrationUserPrivileges(db)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrationUserPrivileges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("migrationUserPrivileges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMigrationSecret(t *testing.T) {
+	managed := map[string]string{DefaultManagedByTagKey: DefaultManagedByTagValue}
+	tests := []struct {
+		name       string
+		tagKey     string
+		tags       map[string]string
+		userExists bool
+		owned      bool
+		wantErr    string
+	}{
+		{name: "managed secret", tagKey: DefaultManagedByTagKey, tags: managed},
+		{name: "managed secret of owned user", tagKey: DefaultManagedByTagKey, tags: managed, userExists: true, owned: true},
+		{name: "user not created for the Database", tagKey: DefaultManagedByTagKey, tags: managed, userExists: true, wantErr: "already exists but was not created"},
+		{name: "secret without tag", tagKey: DefaultManagedByTagKey, tags: map[string]string{"team": "orders"}, wantErr: "not managed by the operator"},
+		{name: "secret of another operator", tagKey: DefaultManagedByTagKey, tags: map[string]string{DefaultManagedByTagKey: "terraform"}, wantErr: "not managed by the operator"},
+		{name: "tag disabled and owned", owned: true},
+		{name: "tag disabled and not owned", wantErr: "was not created for spec.migrationUser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSecretsManager(t)
+			fake.tags = tt.tags
+			r := &DatabaseReconciler{ManagedByTagKey: tt.tagKey, ManagedByTagValue: DefaultManagedByTagValue}
+
+			err := r.checkMigrationSecret(context.Background(), fake.client(t), "app_migrator", "rds/postgres/app_migrator", tt.userExists, tt.owned)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkMigrationSecret() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkMigrationSecret() error = %v, want %q", err, tt.wantErr)
+			}
+			if classifyError(err) != ReasonConfigError {
+				t.Errorf("classifyError() = %s, want %s", classifyError(err), ReasonConfigError)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// DefaultNotificationReasons are the event reasons sent to the notification sinks by default
+// NotificationReasonWarning matches every Warning event, e.g. a failed reconciliation.
+var DefaultNotificationReasons = []string{
+	"Created",
+	EventReasonDeleted,
+	EventReasonExpired,
+	EventReasonDeletionOrphaned,
+	EventReasonSecretRotated,
+	EventReasonDatabaseRenamed,
+	NotificationReasonWarning,
+}
+
+// NotificationReasonWarning selects all Warning events for notifications
+const NotificationReasonWarning = "Warning"
+
+// notificationQueueSize is the number of notifications buffered for the sinks, further ones are dropped
+const notificationQueueSize = 256
+
+// notificationTimeout bounds the delivery of a notification to one sink
+const notificationTimeout = 10 * time.Second
+
+// Notification is a lifecycle event of a Database sent to the notification sinks
+type Notification struct {
+	Cluster   string    `json:"cluster,omitempty"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Time      time.Time `json:"time"`
+	// Secret is the AWS secret of a secret lifecycle notification
+	Secret *NotificationSecret `json:"secret,omitempty"`
+}
+
+// NotificationSecret identifies the AWS secret of a secret lifecycle notification
+type NotificationSecret struct {
+	Name      string `json:"name"`
+	ARN       string `json:"arn,omitempty"`
+	Region    string `json:"region,omitempty"`
+	VersionID string `json:"versionId,omitempty"`
+}
+
+// resource returns the kind and name of the resource of a notification, e.g. Database shop/orders
+func (n Notification) resource() string {
+	if n.Namespace == "" {
+		return n.Kind + " " + n.Name
+	}
+	return n.Kind + " " + n.Namespace + "/" + n.Name
+}
+
+// NotificationSink delivers notifications to an external system
+type NotificationSink interface {
+	// Name identifies the sink in logs and metrics
+	Name() string
+	Send(ctx context.Context, notification Notification) error
+}
+
+// Notifier sends the events of selected reasons to the notification sinks, for SREs who do not
+// watch Kubernetes events. Notifications are queued and delivered by Start, so a slow sink never
+// delays a reconciliation; they are dropped when the queue is full and not retried.
+type Notifier struct {
+	sinks   []NotificationSink
+	reasons []string
+	queue   chan Notification
+}
+
+// NewNotifier returns a notifier sending the events of the given reasons to the sinks
+func NewNotifier(sinks []NotificationSink, reasons []string) *Notifier {
+	return &Notifier{
+		sinks:   sinks,
+		reasons: reasons,
+		queue:   make(chan Notification, notificationQueueSize),
+	}
+}
+
+// selects reports whether events of the type and reason are sent
+func (n *Notifier) selects(eventType, reason string) bool {
+	return slices.Contains(n.reasons, reason) ||
+		(eventType == corev1.EventTypeWarning && slices.Contains(n.reasons, NotificationReasonWarning))
+}
+
+// enqueue queues a notification without blocking
+func (n *Notifier) enqueue(notification Notification) {
+	select {
+	case n.queue <- notification:
+	default:
+		for _, sink := range n.sinks {
+			DatabaseUserNotifications.WithLabelValues(sink.Name(), "dropped").Inc()
+		}
+	}
+}
+
+// Start delivers the queued notifications until the context is cancelled
+// It implements manager.Runnable and only runs on the elected leader, like the reconcilers.
+func (n *Notifier) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("notifier")
+	logger.Info("Starting notifier", "sinks", len(n.sinks), "reasons", n.reasons)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-n.queue:
+			n.send(ctx, notification)
+		}
+	}
+}
+
+// send delivers a notification to every sink, a failed sink does not affect the others
+func (n *Notifier) send(ctx context.Context, notification Notification) {
+	for _, sink := range n.sinks {
+		sendCtx, cancel := context.WithTimeout(ctx, notificationTimeout)
+		err := sink.Send(sendCtx, notification)
+		cancel()
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send notification",
+				"sink", sink.Name(), "reason", notification.Reason, "resource", notification.resource())
+			DatabaseUserNotifications.WithLabelValues(sink.Name(), "failed").Inc()
+			continue
+		}
+		DatabaseUserNotifications.WithLabelValues(sink.Name(), "sent").Inc()
+	}
+}
+
+// notify queues a notification for an event recorded on a Database, if the Notifier selects its reason
+func (r *DatabaseReconciler) notify(db *databasev1alpha1.Database, eventType, reason, message string) {
+	if r.Notifier == nil || !r.Notifier.selects(eventType, reason) {
+		return
+	}
+	r.Notifier.enqueue(r.newNotification(db, eventType, reason, message))
+}
+
+// newNotification returns the notification of an event of a Database
+func (r *DatabaseReconciler) newNotification(db *databasev1alpha1.Database, eventType, reason, message string) Notification {
+	kind := "Database"
+	if isClusterView(db) {
+		kind = "ClusterDatabase"
+	}
+	return Notification{
+		Cluster:   r.ClusterName,
+		Kind:      kind,
+		Namespace: db.Namespace,
+		Name:      db.Name,
+		Type:      eventType,
+		Reason:    reason,
+		Message:   message,
+		Time:      time.Now().UTC(),
+	}
+}
+
+// WebhookSink posts notifications as JSON to a URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Name implements NotificationSink
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Send implements NotificationSink
+func (s *WebhookSink) Send(ctx context.Context, notification Notification) error {
+	return postJSON(ctx, s.Client, s.URL, notification)
+}
+
+// SlackSink posts notifications as messages to a Slack incoming webhook
+type SlackSink struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// Name implements NotificationSink
+func (s *SlackSink) Name() string {
+	return "slack"
+}
+
+// Send implements NotificationSink
+func (s *SlackSink) Send(ctx context.Context, notification Notification) error {
+	return postJSON(ctx, s.Client, s.WebhookURL, map[string]string{"text": slackText(notification)})
+}
+
+// slackText returns the Slack message of a notification
+func slackText(notification Notification) string {
+	icon := ":information_source:"
+	if notification.Type == corev1.EventTypeWarning {
+		icon = ":warning:"
+	}
+	text := fmt.Sprintf("%s *%s* %s: %s", icon, notification.Reason, notification.resource(), notification.Message)
+	if notification.Cluster != "" {
+		text += fmt.Sprintf(" (cluster %s)", notification.Cluster)
+	}
+	return text
+}
+
+// SNSSink publishes notifications as JSON to an SNS topic
+type SNSSink struct {
+	Publisher *secrets.SNSPublisher
+}
+
+// Name implements NotificationSink
+func (s *SNSSink) Name() string {
+	return "sns"
+}
+
+// Send implements NotificationSink
+func (s *SNSSink) Send(ctx context.Context, notification Notification) error {
+	message, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return s.Publisher.Publish(ctx, notification.Reason+": "+notification.resource(), string(message))
+}
+
+// postJSON posts a value as JSON and fails on responses other than 2xx
+func postJSON(ctx context.Context, httpClient *http.Client, target string, value any) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// The URL of a Slack webhook is a credential, it is left out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("notification request failed: %w", urlErr.Err)
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification rejected with status %s", resp.Status)
+	}
+	return nil
+}
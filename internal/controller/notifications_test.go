@@ -0,0 +1,158 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// fakeSink records the notifications sent to it
+type fakeSink struct {
+	sent []Notification
+	err  error
+}
+
+func (s *fakeSink) Name() string { return "fake" }
+
+func (s *fakeSink) Send(_ context.Context, notification Notification) error {
+	s.sent = append(s.sent, notification)
+	return s.err
+}
+
+func TestNotifierSelects(t *testing.T) {
+	n := NewNotifier(nil, DefaultNotificationReasons)
+	tests := []struct {
+		eventType string
+		reason    string
+		want      bool
+	}{
+		{corev1.EventTypeNormal, "Created", true},
+		{corev1.EventTypeNormal, EventReasonDeleted, true},
+		{corev1.EventTypeNormal, EventReasonTagsSynced, false},
+		{corev1.EventTypeWarning, "AuthenticationError", true},
+	}
+	for _, tt := range tests {
+		if got := n.selects(tt.eventType, tt.reason); got != tt.want {
+			t.Errorf("selects(%s, %s) = %v, want %v", tt.eventType, tt.reason, got, tt.want)
+		}
+	}
+
+	n = NewNotifier(nil, []string{EventReasonDeleted})
+	if n.selects(corev1.EventTypeWarning, "AuthenticationError") {
+		t.Error("Warning event selected without the Warning reason")
+	}
+}
+
+func TestRecordEventNotifies(t *testing.T) {
+	sink := &fakeSink{}
+	r := &DatabaseReconciler{
+		Recorder:         record.NewFakeRecorder(10),
+		EventDedupWindow: time.Minute,
+		ClusterName:      "prod-eu",
+		Notifier:         NewNotifier([]NotificationSink{sink}, DefaultNotificationReasons),
+	}
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders", UID: "uid-1"}}
+
+	r.recordNormal(db, "Created", "Database created")
+	r.recordNormal(db, EventReasonTagsSynced, "Tags synced")
+	r.recordEvent(db, corev1.EventTypeWarning, "AuthenticationError", "access denied")
+	// Deduplicated events are not sent again
+	r.recordEvent(db, corev1.EventTypeWarning, "AuthenticationError", "access denied")
+
+	close(r.Notifier.queue)
+	for notification := range r.Notifier.queue {
+		r.Notifier.send(context.Background(), notification)
+	}
+	if len(sink.sent) != 2 {
+		t.Fatalf("sent %d notifications, want 2: %v", len(sink.sent), sink.sent)
+	}
+	got := sink.sent[1]
+	if got.Reason != "AuthenticationError" || got.Type != corev1.EventTypeWarning || got.Kind != "Database" ||
+		got.Namespace != "shop" || got.Name != "orders" || got.Cluster != "prod-eu" {
+		t.Errorf("notification = %+v, want the Warning of Database shop/orders", got)
+	}
+}
+
+func TestNotifierEnqueueDropsWhenFull(t *testing.T) {
+	sink := &fakeSink{}
+	n := NewNotifier([]NotificationSink{sink}, DefaultNotificationReasons)
+	for range notificationQueueSize + 5 {
+		n.enqueue(Notification{Reason: "Created"})
+	}
+	if len(n.queue) != notificationQueueSize {
+		t.Errorf("queued %d notifications, want %d", len(n.queue), notificationQueueSize)
+	}
+}
+
+func TestNotifierSendContinuesAfterFailure(t *testing.T) {
+	failing := &fakeSink{err: errors.New("unavailable")}
+	working := &fakeSink{}
+	n := NewNotifier([]NotificationSink{failing, working}, DefaultNotificationReasons)
+	n.send(context.Background(), Notification{Reason: "Created"})
+	if len(working.sent) != 1 {
+		t.Errorf("second sink received %d notifications, want 1", len(working.sent))
+	}
+}
+
+func TestHTTPSinks(t *testing.T) {
+	var body map[string]any
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body = nil
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	notification := Notification{
+		Kind: "Database", Namespace: "shop", Name: "orders",
+		Type: corev1.EventTypeWarning, Reason: "AuthenticationError", Message: "access denied",
+	}
+
+	if err := (&WebhookSink{URL: server.URL}).Send(ctx, notification); err != nil {
+		t.Fatal(err)
+	}
+	if body["reason"] != "AuthenticationError" || body["namespace"] != "shop" {
+		t.Errorf("webhook body = %v, want the notification", body)
+	}
+
+	if err := (&SlackSink{WebhookURL: server.URL}).Send(ctx, notification); err != nil {
+		t.Fatal(err)
+	}
+	if text, _ := body["text"].(string); !strings.Contains(text, "*AuthenticationError* Database shop/orders: access denied") {
+		t.Errorf("slack text = %q, want the reason, resource and message", text)
+	}
+
+	status = http.StatusForbidden
+	if err := (&WebhookSink{URL: server.URL}).Send(ctx, notification); err == nil {
+		t.Error("rejected notification: error = nil, want an error")
+	}
+
+	// The URL of a Slack webhook is a credential and must not be logged
+	secretURL := "http://127.0.0.1:1/services/T000/B000/s3cret"
+	err := (&SlackSink{WebhookURL: secretURL}).Send(ctx, notification)
+	if err == nil || strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("unreachable webhook: error = %v, want an error without the URL", err)
+	}
+}
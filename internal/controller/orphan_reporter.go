@@ -0,0 +1,249 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// OrphanReporter periodically lists users and databases carrying the operator's managed marker
+// on every server referenced by a Database resource or listed in Servers, and reports those without
+// a corresponding CR. Orphans are only logged and exported via the databaseuser_orphaned_resources
+// metric: the Database resources sharing a server may belong to other tenants, who must not see
+// the names. It only reports; cleanup of orphaned objects is left to the administrator.
+type OrphanReporter struct {
+	Reconciler *DatabaseReconciler
+	Interval   time.Duration
+
+	// Servers are scanned even when no Database resource uses them anymore, e.g. after the
+	// namespace of their last Database was deleted
+	Servers []OrphanReportServer
+}
+
+// OrphanReportServer is a database server scanned by the orphan report, whose admin connection
+// string is read from a Kubernetes Secret
+type OrphanReportServer struct {
+	Engine    databasev1alpha1.DatabaseEngine
+	Namespace string
+	Name      string
+	// Key defaults to "connectionString"
+	Key string
+}
+
+// ParseOrphanReportServers parses the comma-separated servers of --orphan-report-servers, each
+// <engine>=<namespace>/<secret>[:<key>]
+func ParseOrphanReportServers(value string) ([]OrphanReportServer, error) {
+	var servers []OrphanReportServer
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		engine, ref, ok := strings.Cut(item, "=")
+		if !ok || !adoptionEngines[databasev1alpha1.DatabaseEngine(engine)] {
+			return nil, fmt.Errorf("%q is not <engine>=<namespace>/<secret>[:<key>] with a supported engine", item)
+		}
+		ref, key, _ := strings.Cut(ref, ":")
+		namespace, name, ok := strings.Cut(ref, "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, fmt.Errorf("%q does not reference a Secret as <namespace>/<secret>", item)
+		}
+		servers = append(servers, OrphanReportServer{
+			Engine:    databasev1alpha1.DatabaseEngine(engine),
+			Namespace: namespace,
+			Name:      name,
+			Key:       key,
+		})
+	}
+	return servers, nil
+}
+
+// database returns a Database using the server, to read its connection string like a Database would
+func (s OrphanReportServer) database() *databasev1alpha1.Database {
+	return &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: s.Namespace},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:                    s.Engine,
+			ConnectionStringSecretRef: &databasev1alpha1.SecretKeyReference{Name: s.Name, Key: s.Key},
+		},
+	}
+}
+
+// orphanServer collects the Database resources that share a database server
+type orphanServer struct {
+	engine           string
+	connectionString string
+	cloudSQL         *databasev1alpha1.CloudSQLConfig
+	azure            *databasev1alpha1.AzureConfig
+	users            map[string]bool
+	databases        map[string]bool
+}
+
+// Start runs the reporter until the context is cancelled
+// It implements manager.Runnable and only runs on the elected leader
+func (o *OrphanReporter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphan-reporter")
+	ctx = log.IntoContext(ctx, logger)
+
+	logger.Info("Starting orphan reporter", "interval", o.Interval)
+	ticker := time.NewTicker(o.Interval)
+	defer ticker.Stop()
+
+	for {
+		o.report(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// report performs a single orphan scan across all servers
+func (o *OrphanReporter) report(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	dbs, err := o.Reconciler.listDatabases(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list databases")
+		return
+	}
+
+	servers := make(map[string]*orphanServer)
+	// addServer returns the server of a Database, keyed like the server health checks
+	addServer := func(db *databasev1alpha1.Database) (*orphanServer, error) {
+		connectionString, err := o.Reconciler.getConnectionString(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		connInfo, err := database.ParseConnectionInfo(string(db.Spec.Engine), connectionString)
+		if err != nil {
+			return nil, err
+		}
+
+		key := serverKey(db, connInfo)
+		srv, ok := servers[key]
+		if !ok {
+			srv = &orphanServer{
+				engine:           string(db.Spec.Engine),
+				connectionString: connectionString,
+				cloudSQL:         db.Spec.CloudSQL,
+				azure:            db.Spec.Azure,
+				users:            make(map[string]bool),
+				databases:        make(map[string]bool),
+			}
+			servers[key] = srv
+		}
+		return srv, nil
+	}
+
+	for i := range dbs {
+		db := &dbs[i]
+		srv, err := addServer(db)
+		if err != nil {
+			logger.V(1).Info("Skipping Database without usable connection string",
+				"namespace", db.Namespace, "name", db.Name, "error", err.Error())
+			continue
+		}
+		for _, username := range databaseUsers(db) {
+			srv.users[username] = true
+		}
+		srv.databases[db.Spec.DatabaseName] = true
+	}
+	// On a configured server without Database resources, every managed object is orphaned
+	for _, server := range o.Servers {
+		if _, err := addServer(server.database()); err != nil {
+			logger.Error(err, "Skipping configured server without usable connection string",
+				"namespace", server.Namespace, "secret", server.Name)
+		}
+	}
+
+	DatabaseUserOrphanedResources.Reset()
+	for key, srv := range servers {
+		o.reportServer(ctx, key, srv)
+	}
+}
+
+// reportServer connects to one server and reports its orphaned users and databases
+func (o *OrphanReporter) reportServer(ctx context.Context, server string, srv *orphanServer) {
+	logger := log.FromContext(ctx).WithValues("server", server)
+
+	dbClient, err := o.Reconciler.newDatabaseClient(ctx, srv.engine, srv.connectionString, srv.cloudSQL, srv.azure)
+	if err != nil {
+		logger.Error(err, "Failed to connect to server for orphan report")
+		return
+	}
+	defer func() {
+		if closeErr := dbClient.Close(); closeErr != nil {
+			logger.Error(closeErr, "Failed to close database connection")
+		}
+	}()
+
+	managedUsers, err := dbClient.ListManagedUsers(ctx)
+	if errors.Is(err, database.ErrManagedMarkerNotSupported) {
+		logger.V(1).Info("Engine does not support managed markers, skipping orphan report", "engine", srv.engine)
+		return
+	}
+	if err != nil {
+		logger.Error(err, "Failed to list managed users")
+		return
+	}
+
+	managedDatabases, err := dbClient.ListManagedDatabases(ctx)
+	if err != nil && !errors.Is(err, database.ErrManagedMarkerNotSupported) {
+		logger.Error(err, "Failed to list managed databases")
+		return
+	}
+
+	for _, username := range findOrphans(managedUsers, srv.users) {
+		logger.Info("Found orphaned user", "username", username)
+		DatabaseUserOrphanedResources.WithLabelValues(server, "user", username).Set(1)
+	}
+
+	for _, dbName := range findOrphans(managedDatabases, srv.databases) {
+		logger.Info("Found orphaned database", "database", dbName)
+		DatabaseUserOrphanedResources.WithLabelValues(server, "database", dbName).Set(1)
+	}
+}
+
+// databaseUsers returns the users the operator created for a Database, which carry the managed marker
+func databaseUsers(db *databasev1alpha1.Database) []string {
+	username := db.Status.ActualUsername
+	if username == "" {
+		username = getUsernameOrDefault(db)
+	}
+	users := []string{username}
+	if db.Status.MigrationUser != nil && db.Status.MigrationUser.Username != "" {
+		users = append(users, db.Status.MigrationUser.Username)
+	}
+	return users
+}
+
+// findOrphans returns the managed names that are not expected, sorted
+func findOrphans(managed []string, expected map[string]bool) []string {
+	var orphans []string
+	for _, name := range managed {
+		if !expected[name] {
+			orphans = append(orphans, name)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestFindOrphans(t *testing.T) {
+	tests := []struct {
+		name     string
+		managed  []string
+		expected map[string]bool
+		want     []string
+	}{
+		{
+			name:     "no managed objects",
+			managed:  nil,
+			expected: map[string]bool{"app": true},
+			want:     nil,
+		},
+		{
+			name:     "all managed objects have a CR",
+			managed:  []string{"app", "billing"},
+			expected: map[string]bool{"app": true, "billing": true},
+			want:     nil,
+		},
+		{
+			name:     "orphans are returned sorted",
+			managed:  []string{"zeta", "app", "alpha"},
+			expected: map[string]bool{"app": true},
+			want:     []string{"alpha", "zeta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findOrphans(tt.managed, tt.expected)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findOrphans() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabaseUsers(t *testing.T) {
+	tests := []struct {
+		name string
+		db   databasev1alpha1.Database
+		want []string
+	}{
+		{
+			name: "default username",
+			db:   databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{DatabaseName: "app"}},
+			want: []string{"app"},
+		},
+		{
+			name: "migration user",
+			db: databasev1alpha1.Database{
+				Spec: databasev1alpha1.DatabaseSpec{DatabaseName: "app"},
+				Status: databasev1alpha1.DatabaseStatus{
+					ActualUsername: "app_user",
+					MigrationUser:  &databasev1alpha1.MigrationUserStatus{Username: "app_user_migrator"},
+				},
+			},
+			want: []string{"app_user", "app_user_migrator"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := databaseUsers(&tt.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("databaseUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOrphanReportServers(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []OrphanReportServer
+		wantErr bool
+	}{
+		{name: "empty"},
+		{
+			name:  "default key",
+			value: "postgres=db-system/rds-admin",
+			want:  []OrphanReportServer{{Engine: "postgres", Namespace: "db-system", Name: "rds-admin"}},
+		},
+		{
+			name:  "key and several servers",
+			value: "postgres=db-system/rds-admin:url, mysql=db-system/shop-admin",
+			want: []OrphanReportServer{
+				{Engine: "postgres", Namespace: "db-system", Name: "rds-admin", Key: "url"},
+				{Engine: "mysql", Namespace: "db-system", Name: "shop-admin"},
+			},
+		},
+		{name: "unknown engine", value: "oracle=db-system/admin", wantErr: true},
+		{name: "no namespace", value: "postgres=rds-admin", wantErr: true},
+		{name: "no engine", value: "db-system/rds-admin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOrphanReportServers(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrphanReportServers() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOrphanReportServers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// scramClient hashes passwords into SCRAM-SHA-256 verifiers before they are sent to a PostgreSQL server,
+// so plaintext passwords never appear in the statements the server logs, e.g. with log_statement=all.
+// Servers older than PostgreSQL 10 cannot store verifiers and get the plaintext password.
+type scramClient struct {
+	database.Client
+}
+
+func (c *scramClient) CreateUser(ctx context.Context, username, password string) error {
+	password, err := c.hashPassword(ctx, password)
+	if err != nil {
+		return err
+	}
+	return c.Client.CreateUser(ctx, username, password)
+}
+
+func (c *scramClient) SetPassword(ctx context.Context, username, password string) error {
+	password, err := c.hashPassword(ctx, password)
+	if err != nil {
+		return err
+	}
+	return c.Client.SetPassword(ctx, username, password)
+}
+
+// PasswordLoggingRisk returns an empty string if the server supports SCRAM-SHA-256, it only ever logs verifiers
+func (c *scramClient) PasswordLoggingRisk(ctx context.Context) (string, error) {
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	if version.HasCapability("postgres", database.CapabilityScram) {
+		return "", nil
+	}
+	return c.Client.PasswordLoggingRisk(ctx)
+}
+
+// hashPassword returns the SCRAM-SHA-256 verifier of a password if the server supports it
+// A password that cannot be hashed client-side fails the reconciliation rather than being sent in plaintext.
+func (c *scramClient) hashPassword(ctx context.Context, password string) (string, error) {
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !version.HasCapability("postgres", database.CapabilityScram) {
+		return password, nil
+	}
+	verifier, err := database.ScramSHA256Verifier(password)
+	if err != nil {
+		return "", newConfigError(err)
+	}
+	return verifier, nil
+}
+
+// warnPasswordLogging records a warning on a Database after a password was sent to a server that logs
+// the statement with the password literal. The check never fails the reconciliation.
+func (r *DatabaseReconciler) warnPasswordLogging(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client) {
+	risk, err := dbClient.PasswordLoggingRisk(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to check whether the server logs passwords")
+		return
+	}
+	if risk == "" {
+		return
+	}
+	r.recordEvent(db, corev1.EventTypeWarning, EventReasonPasswordLogged,
+		"The server logs statements setting passwords with %s, passwords may appear in its log", risk)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// passwordClient is a database client that records the passwords it is given
+type passwordClient struct {
+	database.Client
+	version   string
+	risk      string
+	passwords []string
+}
+
+func (c *passwordClient) PasswordLoggingRisk(_ context.Context) (string, error) {
+	return c.risk, nil
+}
+
+func (c *passwordClient) ServerVersion(_ context.Context) (database.ServerVersion, error) {
+	return database.ParseServerVersion(c.version), nil
+}
+
+func (c *passwordClient) CreateUser(_ context.Context, _, password string) error {
+	c.passwords = append(c.passwords, password)
+	return nil
+}
+
+func (c *passwordClient) SetPassword(_ context.Context, _, password string) error {
+	c.passwords = append(c.passwords, password)
+	return nil
+}
+
+func TestScramClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		password   string
+		wantHashed bool
+		wantReason string
+	}{
+		{name: "postgres 16", version: "PostgreSQL 16.2", password: "s3cret", wantHashed: true},
+		{name: "postgres 9.6", version: "PostgreSQL 9.6.24", password: "s3cret"},
+		{name: "non-ASCII password", version: "PostgreSQL 16.2", password: "pässword", wantReason: ReasonConfigError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &passwordClient{version: tt.version}
+			c := &scramClient{Client: recorder}
+
+			err := c.CreateUser(context.Background(), "app", tt.password)
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason || len(recorder.passwords) != 0 {
+					t.Fatalf("CreateUser() error = %v, passwords sent = %d, want reason %s and none sent", err, len(recorder.passwords), tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.SetPassword(context.Background(), "app", tt.password); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, sent := range recorder.passwords {
+				if hashed := strings.HasPrefix(sent, "SCRAM-SHA-256$"); hashed != tt.wantHashed {
+					t.Errorf("password sent = %q, want hashed %v", sent, tt.wantHashed)
+				}
+			}
+		})
+	}
+}
+
+func TestWarnPasswordLogging(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      database.Client
+		wantWarning bool
+	}{
+		{name: "not logged", client: &passwordClient{version: "PostgreSQL 16.2"}},
+		{name: "logged", client: &passwordClient{version: "PostgreSQL 16.2", risk: "log_statement = all"}, wantWarning: true},
+		{
+			name:   "logged but hashed",
+			client: &scramClient{Client: &passwordClient{version: "PostgreSQL 16.2", risk: "log_statement = all"}},
+		},
+		{
+			name:        "logged and too old to hash",
+			client:      &scramClient{Client: &passwordClient{version: "PostgreSQL 9.6.24", risk: "log_statement = all"}},
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &DatabaseReconciler{Recorder: recorder}
+
+			r.warnPasswordLogging(context.Background(), &databasev1alpha1.Database{}, tt.client)
+			select {
+			case event := <-recorder.Events:
+				if !tt.wantWarning || !strings.Contains(event, EventReasonPasswordLogged) {
+					t.Errorf("event = %q, want warning %v", event, tt.wantWarning)
+				}
+			default:
+				if tt.wantWarning {
+					t.Error("no event recorded, want PasswordLogged warning")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// preflightDialTimeout bounds the TCP connection attempt to each database host
+const preflightDialTimeout = 5 * time.Second
+
+// Preflight check names
+const (
+	PreflightCheckCRD            = "crd"
+	PreflightCheckAWSCredentials = "aws-credentials"
+	PreflightCheckRegions        = "regions"
+	PreflightCheckDatabaseHosts  = "database-hosts"
+)
+
+// PreflightCheck is the result of a single preflight check
+type PreflightCheck struct {
+	Name    string   `json:"name"`
+	Passed  bool     `json:"passed"`
+	Message string   `json:"message,omitempty"`
+	Details []string `json:"details,omitempty"`
+}
+
+// PreflightReport is the structured result of all preflight checks
+type PreflightReport struct {
+	Passed bool             `json:"passed"`
+	Checks []PreflightCheck `json:"checks"`
+}
+
+// add appends a check to the report and updates the overall result
+func (p *PreflightReport) add(check PreflightCheck) {
+	p.Checks = append(p.Checks, check)
+	p.Passed = p.Passed && check.Passed
+}
+
+// Preflight validates that the operator can run in the current environment: the Database CRD
+// is installed, AWS credentials are accepted, the AWS regions of existing Database resources
+// are valid and the referenced database hosts are reachable.
+// It is meant to run before the manager starts, so r.Client should be an uncached client.
+func (r *DatabaseReconciler) Preflight(ctx context.Context, awsRegion string) *PreflightReport {
+	report := &PreflightReport{Passed: true}
+
+	dbList := &databasev1alpha1.DatabaseList{}
+	if err := r.List(ctx, dbList); err != nil {
+		check := PreflightCheck{Name: PreflightCheckCRD, Message: fmt.Sprintf("failed to list Database resources: %v", err)}
+		if meta.IsNoMatchError(err) {
+			check.Message = "Database CRD (databases.database.opzkit.io) is not installed"
+		}
+		report.add(check)
+		// Without the CRD there are no Database resources to check further
+		report.add(r.preflightAWSCredentials(ctx, awsRegion))
+		return report
+	}
+	report.add(PreflightCheck{
+		Name:    PreflightCheckCRD,
+		Passed:  true,
+		Message: fmt.Sprintf("Database CRD installed, %d Database resources found", len(dbList.Items)),
+	})
+
+	report.add(r.preflightAWSCredentials(ctx, awsRegion))
+	report.add(r.preflightRegions(dbList.Items))
+	report.add(r.preflightDatabaseHosts(ctx, dbList.Items))
+
+	return report
+}
+
+// preflightAWSCredentials verifies that AWS credentials are accepted by AWS
+func (r *DatabaseReconciler) preflightAWSCredentials(ctx context.Context, region string) PreflightCheck {
+	check := PreflightCheck{Name: PreflightCheckAWSCredentials}
+
+	arn, err := secrets.CallerIdentity(ctx, region)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+
+	check.Passed = true
+	check.Message = fmt.Sprintf("authenticated as %s", arn)
+	return check
+}
+
+// preflightRegions validates the AWS regions, partitions and secret ARNs of all Database resources
+func (r *DatabaseReconciler) preflightRegions(dbs []databasev1alpha1.Database) PreflightCheck {
+	check := PreflightCheck{Name: PreflightCheckRegions}
+
+	for i := range dbs {
+		db := &dbs[i]
+		if err := r.validateDatabaseRegions(db); err != nil {
+			check.Details = append(check.Details, fmt.Sprintf("%s/%s: %v", db.Namespace, db.Name, err))
+		}
+	}
+
+	if len(check.Details) > 0 {
+		check.Message = fmt.Sprintf("%d Database resources have invalid AWS region configuration", len(check.Details))
+		return check
+	}
+
+	check.Passed = true
+	check.Message = "AWS region configuration of all Database resources is valid"
+	return check
+}
+
+// validateDatabaseRegions validates all AWS region settings of a Database
+func (r *DatabaseReconciler) validateDatabaseRegions(db *databasev1alpha1.Database) error {
+	if err := r.validateRegion(r.getRegion(db)); err != nil {
+		return err
+	}
+	if ref := db.Spec.ConnectionStringAWSSecretRef; ref != nil {
+		if err := r.validateRegion(ref.Region); err != nil {
+			return fmt.Errorf("connectionStringAWSSecretRef: %w", err)
+		}
+	}
+	for _, ref := range adminConnectionAWSSecretRefs(db.Spec.AdminConnection) {
+		if err := r.validateRegion(ref.Region); err != nil {
+			return fmt.Errorf("adminConnection: %w", err)
+		}
+	}
+	if err := validateSecretName(db); err != nil {
+		return err
+	}
+	return validateAWSPartitions(db)
+}
+
+// preflightDatabaseHosts checks that every database server referenced by a Database resource
+// accepts TCP connections. Credentials are not verified.
+func (r *DatabaseReconciler) preflightDatabaseHosts(ctx context.Context, dbs []databasev1alpha1.Database) PreflightCheck {
+	check := PreflightCheck{Name: PreflightCheckDatabaseHosts}
+
+	servers := r.referencedServers(ctx, dbs)
+	keys := make([]string, 0, len(servers))
+	for key := range servers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		srv := servers[key]
+		if err := checkHostReachable(ctx, srv.host, srv.port); err != nil {
+			check.Details = append(check.Details, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+
+	if len(check.Details) > 0 {
+		check.Message = fmt.Sprintf("%d of %d database servers are unreachable", len(check.Details), len(servers))
+		return check
+	}
+
+	check.Passed = true
+	check.Message = fmt.Sprintf("%d database servers reachable", len(servers))
+	return check
+}
+
+// checkHostReachable opens and closes a TCP connection to the given host and port
+func checkHostReachable(ctx context.Context, host, port string) error {
+	dialer := &net.Dialer{Timeout: preflightDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(strings.Trim(host, "[]"), port))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
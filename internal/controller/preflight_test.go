@@ -0,0 +1,83 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestPreflightReportAdd(t *testing.T) {
+	report := &PreflightReport{Passed: true}
+
+	report.add(PreflightCheck{Name: PreflightCheckCRD, Passed: true})
+	if !report.Passed {
+		t.Error("report should pass when all checks passed")
+	}
+
+	report.add(PreflightCheck{Name: PreflightCheckAWSCredentials, Passed: false})
+	report.add(PreflightCheck{Name: PreflightCheckRegions, Passed: true})
+	if report.Passed {
+		t.Error("report should fail when any check failed")
+	}
+	if len(report.Checks) != 3 {
+		t.Errorf("report has %d checks, want 3", len(report.Checks))
+	}
+}
+
+func TestPreflightRegions(t *testing.T) {
+	r := &DatabaseReconciler{}
+	dbs := []databasev1alpha1.Database{
+		{
+			Spec: databasev1alpha1.DatabaseSpec{
+				AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "eu-west-1"},
+			},
+		},
+		{
+			Spec: databasev1alpha1.DatabaseSpec{
+				AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Region: "xx-central-1"},
+			},
+		},
+	}
+	dbs[1].Namespace = "default"
+	dbs[1].Name = "invalid"
+
+	check := r.preflightRegions(dbs)
+	if check.Passed {
+		t.Error("preflightRegions() passed with an invalid region")
+	}
+	if len(check.Details) != 1 {
+		t.Errorf("preflightRegions() details = %v, want 1 entry", check.Details)
+	}
+
+	check = r.preflightRegions(dbs[:1])
+	if !check.Passed {
+		t.Errorf("preflightRegions() failed for valid regions: %v", check.Details)
+	}
+}
+
+func TestCheckHostReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	if err := checkHostReachable(context.Background(), host, port); err != nil {
+		t.Errorf("checkHostReachable() unexpected error: %v", err)
+	}
+
+	// Closing the listener frees the port, so the next connection is refused
+	_ = listener.Close()
+	if err := checkHostReachable(context.Background(), host, port); err == nil {
+		t.Error("checkHostReachable() expected error for closed port")
+	}
+}
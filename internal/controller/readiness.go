@@ -0,0 +1,227 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// errReadinessNotChecked is reported until the first readiness check has completed
+var errReadinessNotChecked = errors.New("readiness checks have not completed yet")
+
+// ReadinessChecker periodically verifies that the operator can reach its dependencies
+// and serves the last result as the readyz check.
+// Checks run in the background on every replica, so a slow AWS or database endpoint
+// never blocks the probe handler.
+type ReadinessChecker struct {
+	Reconciler *DatabaseReconciler
+	Interval   time.Duration
+
+	// CheckAWS verifies AWS credentials with sts:GetCallerIdentity
+	CheckAWS bool
+	// AWSRegion is the region used for the AWS check, empty uses the AWS SDK default
+	AWSRegion string
+
+	// CheckDatabases connects to every server referenced by a Database resource
+	// The check fails only if none of the servers is reachable
+	CheckDatabases bool
+
+	mu      sync.RWMutex
+	checked bool
+	lastErr error
+}
+
+// Start runs the readiness checks until the context is cancelled
+// It implements manager.Runnable
+func (c *ReadinessChecker) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("readiness")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := c.check(ctx)
+		if err != nil {
+			logger.Info("Readiness check failed", "error", err.Error())
+		}
+		c.setResult(err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+// Readiness is checked on every replica, not only on the leader
+func (c *ReadinessChecker) NeedLeaderElection() bool {
+	return false
+}
+
+// Check returns the result of the last readiness check
+// It implements healthz.Checker
+func (c *ReadinessChecker) Check(_ *http.Request) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.checked {
+		return errReadinessNotChecked
+	}
+	return c.lastErr
+}
+
+// setResult stores the result of a readiness check
+func (c *ReadinessChecker) setResult(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checked = true
+	c.lastErr = err
+}
+
+// check performs all enabled readiness checks
+func (c *ReadinessChecker) check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Interval)
+	defer cancel()
+
+	if c.CheckAWS {
+		arn, err := secrets.CallerIdentity(ctx, c.AWSRegion)
+		if err != nil {
+			return fmt.Errorf("AWS credentials check failed: %w", err)
+		}
+		log.FromContext(ctx).V(1).Info("AWS credentials check succeeded", "callerARN", arn)
+	}
+
+	if c.CheckDatabases {
+		if err := c.checkDatabaseServers(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkDatabaseServers connects to each distinct server referenced by a Database resource
+func (c *ReadinessChecker) checkDatabaseServers(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	dbs, err := c.Reconciler.listDatabases(ctx)
+	if err != nil {
+		return err
+	}
+
+	servers := c.Reconciler.referencedServers(ctx, dbs)
+
+	failures := make(map[string]error)
+	for key, srv := range servers {
+		dbClient, err := c.Reconciler.newDatabaseClient(ctx, srv.engine, srv.connectionString, srv.cloudSQL, srv.azure)
+		if err != nil {
+			failures[key] = err
+			continue
+		}
+		if err := dbClient.Close(); err != nil {
+			logger.Error(err, "Failed to close database connection", "server", key)
+		}
+	}
+
+	return serverReadiness(len(servers), failures)
+}
+
+// databaseServer is a database server referenced by one or more Database resources
+type databaseServer struct {
+	engine           string
+	connectionString string
+	cloudSQL         *databasev1alpha1.CloudSQLConfig
+	azure            *databasev1alpha1.AzureConfig
+	host             string
+	port             string
+}
+
+// referencedServers resolves the admin connection strings of the given Database resources
+// and returns the distinct servers keyed by engine family, host and port
+// Database resources whose connection string cannot be resolved or parsed are skipped
+func (r *DatabaseReconciler) referencedServers(ctx context.Context, dbs []databasev1alpha1.Database) map[string]databaseServer {
+	logger := log.FromContext(ctx)
+
+	servers := make(map[string]databaseServer)
+	for i := range dbs {
+		db := &dbs[i]
+
+		connectionString, err := r.getConnectionString(ctx, db)
+		if err != nil {
+			logger.V(1).Info("Skipping Database without usable connection string",
+				"namespace", db.Namespace, "name", db.Name, "error", err.Error())
+			continue
+		}
+		connInfo, err := database.ParseConnectionInfo(string(db.Spec.Engine), connectionString)
+		if err != nil {
+			logger.V(1).Info("Skipping Database with unparsable connection string",
+				"namespace", db.Namespace, "name", db.Name, "error", err.Error())
+			continue
+		}
+
+		key := serverKey(db, connInfo)
+		if _, ok := servers[key]; !ok {
+			servers[key] = databaseServer{
+				engine:           string(db.Spec.Engine),
+				connectionString: connectionString,
+				cloudSQL:         db.Spec.CloudSQL,
+				azure:            db.Spec.Azure,
+				host:             connInfo.Host,
+				port:             connInfo.Port,
+			}
+		}
+	}
+	return servers
+}
+
+// serverKey identifies the server of a Database resource by engine family, host and port,
+// or by the Cloud SQL instance it is connected to through the connector
+func serverKey(db *databasev1alpha1.Database, connInfo *database.ConnectionInfo) string {
+	family := database.EngineFamily(string(db.Spec.Engine))
+	if db.Spec.CloudSQL != nil {
+		return fmt.Sprintf("%s://cloudsql/%s", family, db.Spec.CloudSQL.InstanceConnectionName)
+	}
+	return fmt.Sprintf("%s://%s:%s", family, connInfo.Host, connInfo.Port)
+}
+
+// serverReadiness returns an error if none of the checked servers was reachable
+// No configured servers, or at least one reachable server, counts as ready
+func serverReadiness(total int, failures map[string]error) error {
+	if total == 0 || len(failures) < total {
+		return nil
+	}
+
+	keys := make([]string, 0, len(failures))
+	for key := range failures {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	msgs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", key, failures[key]))
+	}
+	return fmt.Errorf("no database server reachable: %s", strings.Join(msgs, "; "))
+}
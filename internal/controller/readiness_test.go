@@ -0,0 +1,110 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+func TestReadinessCheckerCheck(t *testing.T) {
+	c := &ReadinessChecker{}
+
+	if err := c.Check(nil); !errors.Is(err, errReadinessNotChecked) {
+		t.Errorf("Check() before first run = %v, want %v", err, errReadinessNotChecked)
+	}
+
+	checkErr := errors.New("AWS credentials check failed")
+	c.setResult(checkErr)
+	if err := c.Check(nil); !errors.Is(err, checkErr) {
+		t.Errorf("Check() after failed run = %v, want %v", err, checkErr)
+	}
+
+	c.setResult(nil)
+	if err := c.Check(nil); err != nil {
+		t.Errorf("Check() after successful run = %v, want nil", err)
+	}
+}
+
+func TestServerReadiness(t *testing.T) {
+	connErr := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		total    int
+		failures map[string]error
+		wantErr  bool
+	}{
+		{
+			name:    "no servers",
+			total:   0,
+			wantErr: false,
+		},
+		{
+			name:     "all reachable",
+			total:    2,
+			failures: map[string]error{},
+			wantErr:  false,
+		},
+		{
+			name:     "some unreachable",
+			total:    2,
+			failures: map[string]error{"postgres://db1:5432": connErr},
+			wantErr:  false,
+		},
+		{
+			name:  "none reachable",
+			total: 2,
+			failures: map[string]error{
+				"postgres://db1:5432": connErr,
+				"mysql://db2:3306":    connErr,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := serverReadiness(tt.total, tt.failures)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serverReadiness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServerKey(t *testing.T) {
+	connInfo := &database.ConnectionInfo{Host: "10.0.0.5", Port: "5432"}
+
+	tests := []struct {
+		name     string
+		engine   databasev1alpha1.DatabaseEngine
+		cloudSQL *databasev1alpha1.CloudSQLConfig
+		want     string
+	}{
+		{name: "host and port", engine: databasev1alpha1.DatabaseEnginePostgreSQL, want: "postgres://10.0.0.5:5432"},
+		{
+			name:     "cloud sql instance",
+			engine:   databasev1alpha1.DatabaseEnginePostgres,
+			cloudSQL: &databasev1alpha1.CloudSQLConfig{InstanceConnectionName: "project:europe-west1:main"},
+			want:     "postgres://cloudsql/project:europe-west1:main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{Engine: tt.engine, CloudSQL: tt.cloudSQL}}
+			if got := serverKey(db, connInfo); got != tt.want {
+				t.Errorf("serverKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
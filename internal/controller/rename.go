@@ -0,0 +1,75 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// renameAllowed reports whether the allow-rename annotation is set on a Database
+func renameAllowed(db *databasev1alpha1.Database) bool {
+	return db.Annotations[databasev1alpha1.AnnotationAllowRename] == "true"
+}
+
+// reconcileDatabaseRename handles a change of spec.databaseName after the database was created
+// status.connectionInfo.database holds the name of the created database. Without the allow-rename
+// annotation the change is a configuration error, since creating a database of the new name would
+// abandon the existing one. With it, the existing database is renamed.
+// A database of the previous name that no longer exists was already renamed, by a reconciliation
+// that failed afterwards, or was dropped outside the operator: the database of the new name is then
+// checked and created by the normal flow.
+func (r *DatabaseReconciler) reconcileDatabaseRename(ctx context.Context, db *databasev1alpha1.Database, dbClient database.Client) error {
+	previous := db.Status.ConnectionInfo.Database
+	if !db.Status.DatabaseCreated || previous == "" || previous == db.Spec.DatabaseName {
+		return nil
+	}
+	if !renameAllowed(db) {
+		return newConfigError(fmt.Errorf("spec.databaseName changed from %s to %s, which would create a second database: "+
+			"restore the previous name, or set the annotation %s=true to rename the database",
+			previous, db.Spec.DatabaseName, databasev1alpha1.AnnotationAllowRename))
+	}
+
+	exists, err := dbClient.DatabaseExists(ctx, previous)
+	if err != nil {
+		return fmt.Errorf("failed to check if database exists: %w", err)
+	}
+	if !exists {
+		log.FromContext(ctx).Info("Database to rename does not exist, assuming it was already renamed",
+			"database", previous, "newName", db.Spec.DatabaseName)
+		return nil
+	}
+	targetExists, err := dbClient.DatabaseExists(ctx, db.Spec.DatabaseName)
+	if err != nil {
+		return fmt.Errorf("failed to check if database exists: %w", err)
+	}
+	if targetExists {
+		return newConfigError(fmt.Errorf("cannot rename database %s to %s, a database named %s already exists",
+			previous, db.Spec.DatabaseName, db.Spec.DatabaseName))
+	}
+
+	if err := requireMaintenanceWindow(ctx, db, "renaming database %s to %s", previous, db.Spec.DatabaseName); err != nil {
+		return err
+	}
+	if err := dbClient.RenameDatabase(ctx, previous, db.Spec.DatabaseName); err != nil {
+		if errors.Is(err, database.ErrRenameNotSupported) {
+			return newConfigError(fmt.Errorf("cannot rename database %s to %s: %w", previous, db.Spec.DatabaseName, err))
+		}
+		return err
+	}
+	log.FromContext(ctx).Info("Database renamed", "database", previous, "newName", db.Spec.DatabaseName)
+	r.recordNormal(db, EventReasonDatabaseRenamed, "Database %s renamed to %s, its connections were terminated",
+		previous, db.Spec.DatabaseName)
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// renameClient is a database client that only implements DatabaseExists and RenameDatabase
+type renameClient struct {
+	database.Client
+	databases map[string]bool
+	renameErr error
+	renamed   []string
+}
+
+func (c *renameClient) DatabaseExists(_ context.Context, name string) (bool, error) {
+	return c.databases[name], nil
+}
+
+func (c *renameClient) RenameDatabase(_ context.Context, name, newName string) error {
+	if c.renameErr != nil {
+		return c.renameErr
+	}
+	c.renamed = []string{name, newName}
+	return nil
+}
+
+func TestReconcileDatabaseRename(t *testing.T) {
+	allowRename := map[string]string{databasev1alpha1.AnnotationAllowRename: "true"}
+
+	tests := []struct {
+		name        string
+		created     string
+		annotations map[string]string
+		databases   map[string]bool
+		renameErr   error
+		wantReason  string
+		wantRenamed bool
+	}{
+		{name: "unchanged", created: "sales", databases: map[string]bool{"sales": true}},
+		{name: "not created yet", databases: map[string]bool{}},
+		{name: "changed without annotation", created: "orders", databases: map[string]bool{"orders": true}, wantReason: ReasonConfigError},
+		{name: "renamed", created: "orders", annotations: allowRename, databases: map[string]bool{"orders": true}, wantRenamed: true},
+		{name: "already renamed", created: "orders", annotations: allowRename, databases: map[string]bool{"sales": true}},
+		{
+			name:        "target exists",
+			created:     "orders",
+			annotations: allowRename,
+			databases:   map[string]bool{"orders": true, "sales": true},
+			wantReason:  ReasonConfigError,
+		},
+		{
+			name:        "engine cannot rename",
+			created:     "orders",
+			annotations: allowRename,
+			databases:   map[string]bool{"orders": true},
+			renameErr:   database.ErrRenameNotSupported,
+			wantReason:  ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DatabaseReconciler{Recorder: record.NewFakeRecorder(10)}
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "shop", Annotations: tt.annotations},
+				Spec:       databasev1alpha1.DatabaseSpec{Engine: databasev1alpha1.DatabaseEnginePostgres, DatabaseName: "sales"},
+			}
+			if tt.created != "" {
+				db.Status.DatabaseCreated = true
+				db.Status.ConnectionInfo.Database = tt.created
+			}
+			dbClient := &renameClient{databases: tt.databases, renameErr: tt.renameErr}
+
+			err := r.reconcileDatabaseRename(context.Background(), db, dbClient)
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("reconcileDatabaseRename() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (dbClient.renamed != nil) != tt.wantRenamed {
+				t.Errorf("RenameDatabase called = %v, want %v", dbClient.renamed != nil, tt.wantRenamed)
+			}
+		})
+	}
+
+	plan := &changePlan{}
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Annotations: allowRename},
+		Spec:       databasev1alpha1.DatabaseSpec{Engine: databasev1alpha1.DatabaseEnginePostgres, DatabaseName: "sales"},
+		Status:     databasev1alpha1.DatabaseStatus{DatabaseCreated: true, ConnectionInfo: databasev1alpha1.ConnectionInfo{Database: "orders"}},
+	}
+	dbClient := &planningClient{Client: &renameClient{databases: map[string]bool{"orders": true}}, plan: plan}
+	r := &DatabaseReconciler{Recorder: record.NewFakeRecorder(10)}
+	if err := r.reconcileDatabaseRename(withChangePlan(context.Background(), plan), db, dbClient); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.changes) != 1 {
+		t.Errorf("dry run: planned changes = %q, want the rename", plan.changes)
+	}
+}
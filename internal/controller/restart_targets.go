@@ -0,0 +1,113 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// CredentialsChecksumAnnotation is set on the pod templates of spec.restartTargets to a checksum of
+// the secret version, changing it rolls the pods of the workload
+const CredentialsChecksumAnnotation = "database.opzkit.io/credentials-checksum"
+
+// credentialsChecksum returns the checksum of the current secret version of a Database, empty
+// before the secret was stored
+func credentialsChecksum(db *databasev1alpha1.Database) string {
+	if db.Status.SecretVersion == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(db.Status.ActualSecretName + "/" + db.Status.SecretVersion))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// reconcileRestartTargets sets the credentials checksum annotation on the pod templates of
+// spec.restartTargets, so their pods are restarted once the secret changed. A workload that does
+// not exist yet is skipped with a warning, it reads the current secret when it starts.
+func (r *DatabaseReconciler) reconcileRestartTargets(ctx context.Context, db *databasev1alpha1.Database) error {
+	checksum := credentialsChecksum(db)
+	if len(db.Spec.RestartTargets) == 0 || checksum == "" {
+		return nil
+	}
+	namespace, err := r.secretNamespace(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range db.Spec.RestartTargets {
+		workload, template, err := restartTargetObject(target)
+		if err != nil {
+			return newConfigError(err)
+		}
+		key := client.ObjectKey{Namespace: namespace, Name: target.Name}
+		err = r.Get(ctx, key, workload)
+		if apierrors.IsForbidden(err) {
+			return newConfigError(fmt.Errorf("spec.restartTargets requires RBAC for %ss, granted by the chart value restartTargets.enabled: %w", target.Kind, err))
+		}
+		if apierrors.IsNotFound(err) {
+			r.recordEvent(db, corev1.EventTypeWarning, EventReasonRestartTargetNotFound,
+				"%s %s of spec.restartTargets does not exist", target.Kind, key)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s %s: %w", target.Kind, key, err)
+		}
+		if template.Annotations[CredentialsChecksumAnnotation] == checksum {
+			continue
+		}
+		if plannedChange(ctx, "restart %s %s for the changed secret", target.Kind, key) {
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]any{
+			"spec": map[string]any{
+				"template": map[string]any{
+					"metadata": map[string]any{
+						"annotations": map[string]string{CredentialsChecksumAnnotation: checksum},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if err := r.Patch(ctx, workload, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return fmt.Errorf("failed to restart %s %s: %w", target.Kind, key, err)
+		}
+		log.FromContext(ctx).Info("Restarted workload for the changed secret", "kind", target.Kind, "workload", key.String())
+		r.recordNormal(db, EventReasonWorkloadRestarted, "%s %s restarted for secret version %s", target.Kind, key, db.Status.SecretVersion)
+	}
+	return nil
+}
+
+// restartTargetObject returns an empty object of the kind of a restart target and its pod template
+func restartTargetObject(target databasev1alpha1.RestartTarget) (client.Object, *metav1.ObjectMeta, error) {
+	switch target.Kind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		return deployment, &deployment.Spec.Template.ObjectMeta, nil
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		return statefulSet, &statefulSet.Spec.Template.ObjectMeta, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported kind %q of spec.restartTargets, must be Deployment or StatefulSet", target.Kind)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestCredentialsChecksum(t *testing.T) {
+	db := &databasev1alpha1.Database{}
+	if got := credentialsChecksum(db); got != "" {
+		t.Errorf("checksum without a secret version = %q, want empty", got)
+	}
+	db.Status.ActualSecretName = "rds/shop/orders"
+	db.Status.SecretVersion = "v1"
+	first := credentialsChecksum(db)
+	if len(first) != 16 {
+		t.Errorf("checksum = %q, want 16 hex characters", first)
+	}
+	db.Status.SecretVersion = "v2"
+	if credentialsChecksum(db) == first {
+		t.Error("checksum did not change with the secret version")
+	}
+}
+
+func TestReconcileRestartTargets(t *testing.T) {
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api"}}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "worker"}}
+	r := newHookTestReconciler(t, deployment, statefulSet)
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders", UID: "uid-1"},
+		Spec: databasev1alpha1.DatabaseSpec{RestartTargets: []databasev1alpha1.RestartTarget{
+			{Kind: "Deployment", Name: "api"},
+			{Kind: "StatefulSet", Name: "worker"},
+			{Kind: "Deployment", Name: "missing"},
+		}},
+		Status: databasev1alpha1.DatabaseStatus{ActualSecretName: "rds/shop/orders", SecretVersion: "v1"},
+	}
+	checksum := credentialsChecksum(db)
+
+	plan := &changePlan{}
+	if err := r.reconcileRestartTargets(withChangePlan(ctx, plan), db); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.changes) != 2 {
+		t.Fatalf("dry run: planned changes = %q, want 2", plan.changes)
+	}
+
+	if err := r.reconcileRestartTargets(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	gotDeployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), gotDeployment); err != nil {
+		t.Fatal(err)
+	}
+	if got := gotDeployment.Spec.Template.Annotations[CredentialsChecksumAnnotation]; got != checksum {
+		t.Errorf("Deployment annotation = %q, want %q", got, checksum)
+	}
+	gotStatefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(statefulSet), gotStatefulSet); err != nil {
+		t.Fatal(err)
+	}
+	if got := gotStatefulSet.Spec.Template.Annotations[CredentialsChecksumAnnotation]; got != checksum {
+		t.Errorf("StatefulSet annotation = %q, want %q", got, checksum)
+	}
+
+	// An unchanged secret does not restart the workloads again
+	plan = &changePlan{}
+	if err := r.reconcileRestartTargets(withChangePlan(ctx, plan), db); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.changes) != 0 {
+		t.Errorf("unchanged secret: planned changes = %q, want none", plan.changes)
+	}
+
+	recorder := r.Recorder.(*record.FakeRecorder)
+	var restarted, notFound int
+	for len(recorder.Events) > 0 {
+		event := <-recorder.Events
+		switch {
+		case strings.HasPrefix(event, "Normal "+EventReasonWorkloadRestarted):
+			restarted++
+		case strings.HasPrefix(event, "Warning "+EventReasonRestartTargetNotFound):
+			notFound++
+		}
+	}
+	if restarted != 2 || notFound == 0 {
+		t.Errorf("got %d %s and %d %s events, want 2 and at least 1",
+			restarted, EventReasonWorkloadRestarted, notFound, EventReasonRestartTargetNotFound)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// defaultDescriptionTemplate is the description of secrets without spec.awsSecretsManager.description
+const defaultDescriptionTemplate = "Database credentials for {{ .DatabaseName }}"
+
+// secretDescription renders spec.awsSecretsManager.description for the secret of a Database
+// The kind and UID of the Database are appended, so the secret identifies its owner in the AWS console.
+func (r *DatabaseReconciler) secretDescription(db *databasev1alpha1.Database) (string, error) {
+	return r.userSecretDescription(db, getUsernameOrDefault(db))
+}
+
+// userSecretDescription renders spec.awsSecretsManager.description for the secret of a user of a Database
+func (r *DatabaseReconciler) userSecretDescription(db *databasev1alpha1.Database, username string) (string, error) {
+	tmpl := defaultDescriptionTemplate
+	if db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Description != "" {
+		tmpl = db.Spec.AWSSecretsManager.Description
+	}
+	kind := "Database"
+	if isClusterView(db) {
+		kind = "ClusterDatabase"
+	}
+
+	description, err := secrets.RenderDescription(tmpl, secrets.DescriptionData{
+		Kind:         kind,
+		Namespace:    db.Namespace,
+		Name:         db.Name,
+		UID:          string(db.UID),
+		Cluster:      r.ClusterName,
+		DatabaseName: db.Spec.DatabaseName,
+		Engine:       string(db.Spec.Engine),
+		Username:     username,
+	})
+	if err != nil {
+		return "", newConfigError(fmt.Errorf("invalid spec.awsSecretsManager.description: %w", err))
+	}
+	return description, nil
+}
+
+// syncSecretDescription updates the description of an existing secret if it differs from description
+// A description that cannot be read is left as it is, it does not affect the credentials.
+func (r *DatabaseReconciler) syncSecretDescription(ctx context.Context, awsClient *secrets.AWSSecretsManagerClient, secretID, secretName, description string) error {
+	logger := log.FromContext(ctx)
+
+	current, err := awsClient.GetSecretDescription(ctx, secretID)
+	if err != nil {
+		logger.Error(err, "Failed to get secret description, leaving it unchanged", "secretName", secretName)
+		return nil
+	}
+	if current == description || plannedChange(ctx, "set description of secret %s to %q", secretName, description) {
+		return nil
+	}
+
+	logger.Info("Updating secret description in AWS Secrets Manager",
+		"secretName", secretName,
+		"description", description)
+	if err := awsClient.UpdateSecretMetadata(ctx, secretID, description); err != nil {
+		return fmt.Errorf("failed to update secret description: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestSecretDescription(t *testing.T) {
+	r := &DatabaseReconciler{ClusterName: "prod-eu"}
+
+	tests := []struct {
+		name       string
+		db         *databasev1alpha1.Database
+		want       string
+		wantReason string
+	}{
+		{
+			name: "default",
+			db: &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "orders", UID: "uid-1"},
+				Spec:       databasev1alpha1.DatabaseSpec{DatabaseName: "orders"},
+			},
+			want: "Database credentials for orders [Database uid-1]",
+		},
+		{
+			name: "template",
+			db: &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "orders", UID: "uid-1"},
+				Spec: databasev1alpha1.DatabaseSpec{
+					DatabaseName:      "orders",
+					AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Description: "{{ .Namespace }}/{{ .Name }} on {{ .Cluster }}"},
+				},
+			},
+			want: "payments/orders on prod-eu [Database uid-1]",
+		},
+		{
+			name: "cluster database",
+			db: &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Name: "platform", UID: "uid-2"},
+				Spec: databasev1alpha1.DatabaseSpec{
+					DatabaseName:      "platform",
+					AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Description: "{{ .Kind }} {{ .Name }}"},
+				},
+			},
+			want: "ClusterDatabase platform [ClusterDatabase uid-2]",
+		},
+		{
+			name: "invalid template",
+			db: &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "orders"},
+				Spec: databasev1alpha1.DatabaseSpec{
+					AWSSecretsManager: &databasev1alpha1.AWSSecretsManagerConfig{Description: "{{ .Team }}"},
+				},
+			},
+			wantReason: ReasonConfigError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.secretDescription(tt.db)
+			if tt.wantReason != "" {
+				if classifyError(err) != tt.wantReason {
+					t.Fatalf("secretDescription() error = %v, want reason %s", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("secretDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncSecretDescription(t *testing.T) {
+	fake := newFakeSecretsManager(t)
+	fake.description = "Database credentials for orders"
+	r := &DatabaseReconciler{}
+	want := "Database credentials for orders [Database uid-1]"
+
+	plan := &changePlan{}
+	if err := r.syncSecretDescription(withChangePlan(context.Background(), plan), fake.client(t), "rds/postgres/orders", "rds/postgres/orders", want); err != nil {
+		t.Fatal(err)
+	}
+	if fake.callCount("UpdateSecret") != 0 || len(plan.changes) != 1 {
+		t.Errorf("dry run: UpdateSecret calls = %d, planned changes = %q, want 0 calls and 1 change", fake.callCount("UpdateSecret"), plan.changes)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := r.syncSecretDescription(context.Background(), fake.client(t), "rds/postgres/orders", "rds/postgres/orders", want); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fake.description != want {
+		t.Errorf("description = %q, want %q", fake.description, want)
+	}
+	if got := fake.callCount("UpdateSecret"); got != 1 {
+		t.Errorf("UpdateSecret calls = %d, want 1, an unchanged description is not written", got)
+	}
+}
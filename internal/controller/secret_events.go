@@ -0,0 +1,69 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// SecretEventDeleted is the reason of the notification of a deleted secret, which records no event of its own
+const SecretEventDeleted = "SecretDeleted"
+
+// SecretEventReasons are the reasons of secret lifecycle notifications
+var SecretEventReasons = []string{
+	EventReasonSecretCreated,
+	EventReasonSecretRotated,
+	EventReasonSecretMigrated,
+	SecretEventDeleted,
+}
+
+// secretEventDetailTypes are the EventBridge detail types of the secret lifecycle notifications
+// A migration to the current secret format changes the value like a rotation.
+var secretEventDetailTypes = map[string]string{
+	EventReasonSecretCreated:  "Secret Created",
+	EventReasonSecretRotated:  "Secret Rotated",
+	EventReasonSecretMigrated: "Secret Rotated",
+	SecretEventDeleted:        "Secret Deleted",
+}
+
+// publishSecretEvent queues a secret lifecycle notification for the SecretEvents notifier
+// Dry runs change no secrets and publish nothing.
+func (r *DatabaseReconciler) publishSecretEvent(db *databasev1alpha1.Database, reason string, secret NotificationSecret, messageFmt string, args ...any) {
+	if r.SecretEvents == nil || r.isDryRun(db) || !r.SecretEvents.selects(corev1.EventTypeNormal, reason) {
+		return
+	}
+	notification := r.newNotification(db, corev1.EventTypeNormal, reason, fmt.Sprintf(messageFmt, args...))
+	notification.Secret = &secret
+	r.SecretEvents.enqueue(notification)
+}
+
+// EventBridgeSink puts secret lifecycle notifications on an EventBridge bus, so AWS automation can
+// react to credential changes, e.g. restart the ECS services reading a rotated secret
+type EventBridgeSink struct {
+	Publisher *secrets.EventBridgePublisher
+}
+
+// Name implements NotificationSink
+func (s *EventBridgeSink) Name() string {
+	return "eventbridge"
+}
+
+// Send implements NotificationSink
+func (s *EventBridgeSink) Send(ctx context.Context, notification Notification) error {
+	detailType, ok := secretEventDetailTypes[notification.Reason]
+	if !ok {
+		detailType = notification.Reason
+	}
+	return s.Publisher.Put(ctx, detailType, notification)
+}
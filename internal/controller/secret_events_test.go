@@ -0,0 +1,50 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestPublishSecretEvent(t *testing.T) {
+	r := &DatabaseReconciler{
+		ClusterName:  "prod-eu",
+		SecretEvents: NewNotifier(nil, SecretEventReasons),
+	}
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders"}}
+	secret := NotificationSecret{
+		Name:   "rds/postgres/orders",
+		ARN:    "arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/orders-AbCdEf",
+		Region: "eu-west-1",
+	}
+
+	r.publishSecretEvent(db, EventReasonSecretRotated, secret, "Secret %s updated", secret.Name)
+	r.publishSecretEvent(db, EventReasonTagsSynced, secret, "Tags synced")
+
+	dryRun := db.DeepCopy()
+	dryRun.Spec.DryRun = true
+	r.publishSecretEvent(dryRun, SecretEventDeleted, secret, "Secret %s deleted", secret.Name)
+
+	if len(r.SecretEvents.queue) != 1 {
+		t.Fatalf("queued %d secret events, want 1", len(r.SecretEvents.queue))
+	}
+	got := <-r.SecretEvents.queue
+	if got.Reason != EventReasonSecretRotated || got.Secret == nil || got.Secret.ARN != secret.ARN || got.Cluster != "prod-eu" {
+		t.Errorf("secret event = %+v, want the rotation of %s", got, secret.ARN)
+	}
+	if detailType := secretEventDetailTypes[got.Reason]; detailType != "Secret Rotated" {
+		t.Errorf("detail type = %q, want Secret Rotated", detailType)
+	}
+
+	// Without a bus nothing is published
+	(&DatabaseReconciler{}).publishSecretEvent(db, EventReasonSecretCreated, secret, "Secret %s created", secret.Name)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// secretGCMinAge is the minimum age of a secret before it is considered by the garbage collector
+// This protects secrets that were just created but whose Database status has not been persisted yet
+const secretGCMinAge = time.Hour
+
+// SecretGarbageCollector periodically lists AWS secrets carrying the operator's managed-by tag and
+// flags, or deletes, those not referenced by the status of any Database resource.
+// Typical leftovers are secrets from region migrations or secrets whose name was changed in the spec.
+type SecretGarbageCollector struct {
+	Reconciler *DatabaseReconciler
+	Interval   time.Duration
+
+	// Regions are scanned in addition to the regions referenced by Database resources
+	Regions []string
+
+	// DryRun only reports stale secrets without deleting them
+	DryRun bool
+}
+
+// Validate rejects deleting stale secrets while the managed-by tag has its default value
+// Every installation in an AWS account tags its secrets the same way then, and each one only knows
+// the Databases of its own cluster, so the installations would delete each other's secrets.
+func (g *SecretGarbageCollector) Validate() error {
+	if !g.DryRun && g.Reconciler.ManagedByTagKey != "" && g.Reconciler.ManagedByTagValue == DefaultManagedByTagValue {
+		return fmt.Errorf("deleting stale secrets requires a --managed-by-tag-value unique to this installation, "+
+			"the default %s is shared by every installation in the AWS account", DefaultManagedByTagValue)
+	}
+	return nil
+}
+
+// Start runs the garbage collector until the context is cancelled
+// It implements manager.Runnable and only runs on the elected leader
+func (g *SecretGarbageCollector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("secret-gc")
+	ctx = log.IntoContext(ctx, logger)
+
+	if g.Reconciler.ManagedByTagKey == "" {
+		logger.Info("Managed-by tag is disabled, secret garbage collection cannot identify managed secrets and will not run")
+		return nil
+	}
+
+	logger.Info("Starting secret garbage collector", "interval", g.Interval, "dryRun", g.DryRun)
+	ticker := time.NewTicker(g.Interval)
+	defer ticker.Stop()
+
+	for {
+		g.collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect performs a single garbage collection pass across all regions
+func (g *SecretGarbageCollector) collect(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	dbs, err := g.Reconciler.listDatabases(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list databases")
+		return
+	}
+
+	referenced := referencedSecrets(dbs)
+	regions := secretRegions(g.Reconciler, g.Regions, dbs)
+
+	DatabaseUserStaleSecrets.Reset()
+	for _, region := range regions {
+		g.collectRegion(ctx, region, referenced)
+	}
+}
+
+// collectRegion lists managed secrets in one region and handles the unreferenced ones
+func (g *SecretGarbageCollector) collectRegion(ctx context.Context, region string, referenced map[string]bool) {
+	logger := log.FromContext(ctx).WithValues("region", region)
+
+	awsClient, err := secrets.NewAWSSecretsManagerClient(ctx, region)
+	if err != nil {
+		logger.Error(err, "Failed to create AWS client for secret garbage collection")
+		return
+	}
+	region = awsClient.GetRegion()
+
+	managed, err := awsClient.ListSecretsByTag(ctx, g.Reconciler.ManagedByTagKey, g.Reconciler.ManagedByTagValue)
+	if err != nil {
+		logger.Error(err, "Failed to list managed secrets")
+		return
+	}
+
+	for _, secret := range findStaleSecrets(managed, referenced, region, time.Now()) {
+		DatabaseUserStaleSecrets.WithLabelValues(region, secret.Name).Set(1)
+
+		if g.DryRun {
+			logger.Info("Found stale secret (dry-run, not deleting)", "secretName", secret.Name, "secretARN", secret.ARN)
+			continue
+		}
+
+		logger.Info("Deleting stale secret with recovery window", "secretName", secret.Name, "secretARN", secret.ARN)
+		if err := awsClient.DeleteSecret(ctx, secret.ARN, false); err != nil {
+			logger.Error(err, "Failed to delete stale secret", "secretName", secret.Name)
+			continue
+		}
+		DatabaseUserStaleSecretsDeleted.WithLabelValues(region).Inc()
+	}
+}
+
+// secretRegions returns the sorted set of the regions of the Database resources and the given regions
+// The AWS SDK default region of Databases without a region is returned as an empty string.
+func secretRegions(r *DatabaseReconciler, extra []string, dbs []databasev1alpha1.Database) []string {
+	set := make(map[string]bool)
+	for _, region := range extra {
+		set[region] = true
+	}
+	for i := range dbs {
+		if dbs[i].Status.SecretRegion != "" {
+			set[dbs[i].Status.SecretRegion] = true
+		}
+		set[r.getRegion(&dbs[i])] = true
+	}
+
+	regions := make([]string, 0, len(set))
+	for region := range set {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// referencedSecrets returns the set of secret identifiers referenced by Database statuses
+// Both ARNs and region-qualified names are included, so secrets are matched either way
+func referencedSecrets(dbs []databasev1alpha1.Database) map[string]bool {
+	referenced := make(map[string]bool)
+	for i := range dbs {
+		status := dbs[i].Status
+		if status.SecretARN != "" {
+			referenced[status.SecretARN] = true
+		}
+		if status.ActualSecretName != "" {
+			referenced[status.SecretRegion+"/"+status.ActualSecretName] = true
+		}
+		// The secret of the migration user is written to the region of the Database secret
+		if migrator := status.MigrationUser; migrator != nil {
+			if migrator.SecretARN != "" {
+				referenced[migrator.SecretARN] = true
+			}
+			if migrator.SecretName != "" {
+				referenced[status.SecretRegion+"/"+migrator.SecretName] = true
+			}
+		}
+	}
+	return referenced
+}
+
+// findStaleSecrets returns the managed secrets in the region that are not referenced
+// and are older than secretGCMinAge
+func findStaleSecrets(managed []secrets.SecretSummary, referenced map[string]bool, region string, now time.Time) []secrets.SecretSummary {
+	var stale []secrets.SecretSummary
+	for _, secret := range managed {
+		if referenced[secret.ARN] || referenced[region+"/"+secret.Name] {
+			continue
+		}
+		if now.Sub(secret.CreatedDate) < secretGCMinAge {
+			continue
+		}
+		stale = append(stale, secret)
+	}
+	return stale
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+func TestFindStaleSecrets(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-24 * time.Hour)
+
+	dbs := []databasev1alpha1.Database{
+		{
+			Status: databasev1alpha1.DatabaseStatus{
+				SecretARN:        "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbC123",
+				ActualSecretName: "rds/postgres/app",
+				SecretRegion:     "us-east-1",
+			},
+		},
+		{
+			Status: databasev1alpha1.DatabaseStatus{
+				ActualSecretName: "rds/mysql/shop",
+				SecretRegion:     "us-east-1",
+			},
+		},
+		{
+			Spec: databasev1alpha1.DatabaseSpec{
+				MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true},
+			},
+			Status: databasev1alpha1.DatabaseStatus{
+				ActualSecretName: "rds/postgres/orders",
+				SecretRegion:     "us-east-1",
+				MigrationUser: &databasev1alpha1.MigrationUserStatus{
+					Username:   "orders_migrator",
+					SecretName: "rds/postgres/orders_migrator",
+					SecretARN:  "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/orders_migrator-UiO345",
+				},
+			},
+		},
+		{
+			Spec: databasev1alpha1.DatabaseSpec{
+				MigrationUser: &databasev1alpha1.MigrationUserConfig{Enabled: true},
+			},
+			// The secret of the migration user was written, its ARN not recorded yet
+			Status: databasev1alpha1.DatabaseStatus{
+				ActualSecretName: "rds/postgres/billing",
+				SecretRegion:     "us-east-1",
+				MigrationUser: &databasev1alpha1.MigrationUserStatus{
+					Username:   "billing_migrator",
+					SecretName: "rds/postgres/billing_migrator",
+				},
+			},
+		},
+	}
+	referenced := referencedSecrets(dbs)
+
+	managed := []secrets.SecretSummary{
+		{Name: "rds/postgres/app", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbC123", CreatedDate: old},
+		{Name: "rds/mysql/shop", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/mysql/shop-XyZ789", CreatedDate: old},
+		{Name: "rds/postgres/renamed", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/renamed-QwE456", CreatedDate: old},
+		{Name: "rds/postgres/orders_migrator", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/orders_migrator-UiO345", CreatedDate: old},
+		{Name: "rds/postgres/billing_migrator", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/billing_migrator-PaS678", CreatedDate: old},
+		{Name: "rds/postgres/new", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/new-RtY012", CreatedDate: now.Add(-time.Minute)},
+	}
+
+	stale := findStaleSecrets(managed, referenced, "us-east-1", now)
+	if len(stale) != 1 || stale[0].Name != "rds/postgres/renamed" {
+		t.Errorf("findStaleSecrets() = %v, want only rds/postgres/renamed", stale)
+	}
+
+	// The same name in another region is not referenced
+	staleOtherRegion := findStaleSecrets([]secrets.SecretSummary{
+		{Name: "rds/mysql/shop", ARN: "arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/mysql/shop-XyZ789", CreatedDate: old},
+	}, referenced, "eu-west-1", now)
+	if len(staleOtherRegion) != 1 {
+		t.Errorf("findStaleSecrets() in old region = %v, want 1 stale secret", staleOtherRegion)
+	}
+}
+
+func TestSecretGarbageCollectorValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		dryRun   bool
+		tagKey   string
+		tagValue string
+		wantErr  bool
+	}{
+		{name: "dry run with the default tag", dryRun: true, tagKey: DefaultManagedByTagKey, tagValue: DefaultManagedByTagValue},
+		{name: "deleting with the default tag", tagKey: DefaultManagedByTagKey, tagValue: DefaultManagedByTagValue, wantErr: true},
+		{name: "deleting with a tag of the installation", tagKey: DefaultManagedByTagKey, tagValue: "database-user-operator-prod-eu"},
+		{name: "tag disabled", tagValue: DefaultManagedByTagValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &SecretGarbageCollector{
+				Reconciler: &DatabaseReconciler{ManagedByTagKey: tt.tagKey, ManagedByTagValue: tt.tagValue},
+				DryRun:     tt.dryRun,
+			}
+			if err := g.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// Secret migration outcomes reported by MigrateDatabaseSecret
+const (
+	SecretMigrationMigrated     = "migrated"
+	SecretMigrationWouldMigrate = "would migrate"
+	SecretMigrationSkipped      = "skipped"
+)
+
+// MigrateDatabaseSecret migrates the secret of a Database from the v1 to the v2 format
+// without connecting to the database server, and records the new format in the status.
+// With dryRun the secret is only read and the outcome reported.
+// It returns the outcome and, for skipped Databases, the reason.
+func (r *DatabaseReconciler) MigrateDatabaseSecret(ctx context.Context, db *databasev1alpha1.Database, dryRun bool) (string, string, error) {
+	if !db.Status.SecretCreated || db.Status.ActualSecretName == "" {
+		return SecretMigrationSkipped, "secret not created yet", nil
+	}
+	if externalSecretManaged(db) {
+		return SecretMigrationSkipped, "secret is managed externally", nil
+	}
+	if db.Status.SecretFormatVersion == currentSecretFormatVersion {
+		return SecretMigrationSkipped, "already " + currentSecretFormatVersion, nil
+	}
+
+	region := db.Status.SecretRegion
+	if region == "" {
+		region = r.getRegion(db)
+	}
+	if err := r.validateRegion(region); err != nil {
+		return "", "", fmt.Errorf("invalid AWS region: %w", err)
+	}
+
+	awsClient, err := r.awsClient(ctx, region)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	secretID := previousSecretID(db)
+	secretString, err := awsClient.GetSecretString(ctx, secretID)
+	if err != nil {
+		return "", "", err
+	}
+
+	converted, legacy, err := secrets.ConvertLegacySecret(secretString)
+	if err != nil {
+		return "", "", err
+	}
+
+	if dryRun {
+		return SecretMigrationWouldMigrate, "", nil
+	}
+
+	// A secret already in v2 format only needs its status updated
+	if legacy {
+		migrated := legacySecretForDatabase(db, converted)
+		versionID, err := awsClient.UpdateSecretWithTemplate(ctx, secretID, migrated, db.Spec.SecretTemplate)
+		if err != nil {
+			return "", "", err
+		}
+		db.Status.SecretVersion = versionID
+		if err := setSecretChecksum(&db.Status, migrated, db.Spec.SecretTemplate); err != nil {
+			return "", "", err
+		}
+	}
+
+	db.Status.SecretFormatVersion = currentSecretFormatVersion
+	if err := r.updateStatus(ctx, db); err != nil {
+		return "", "", fmt.Errorf("secret migrated but failed to update status: %w", err)
+	}
+
+	return SecretMigrationMigrated, "", nil
+}
+
+// legacySecretForDatabase completes a converted v1 secret with the values the operator
+// would write for the Database, so the migrated secret matches a reconciled one
+func legacySecretForDatabase(db *databasev1alpha1.Database, secret *secrets.DatabaseSecret) *secrets.DatabaseSecret {
+	migrated := *secret
+	migrated.Engine = string(db.Spec.Engine)
+	migrated.KeyNames = secretKeyNames(db)
+	migrated.ConnectionStringOnly = connectionStringOnly(db)
+	migrated.Binary = db.Spec.SecretBinary
+	migrated.DBName = db.Spec.DatabaseName
+	if db.Status.ActualUsername != "" {
+		migrated.DBUsername = db.Status.ActualUsername
+	}
+	if db.Status.ConnectionInfo.Host != "" {
+		migrated.DBHost = db.Status.ConnectionInfo.Host
+	}
+	if db.Status.ConnectionInfo.Port != 0 {
+		migrated.DBPort = db.Status.ConnectionInfo.Port
+	}
+	migrated.DatabaseURL = databaseURL(db, migrated.DBUsername, migrated.DBPassword, migrated.DBHost, migrated.DBPort)
+	return &migrated
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+func TestMigrateDatabaseSecretSkipped(t *testing.T) {
+	r := &DatabaseReconciler{}
+
+	tests := []struct {
+		name   string
+		status databasev1alpha1.DatabaseStatus
+	}{
+		{
+			name:   "secret not created",
+			status: databasev1alpha1.DatabaseStatus{},
+		},
+		{
+			name: "already migrated",
+			status: databasev1alpha1.DatabaseStatus{
+				SecretCreated:       true,
+				ActualSecretName:    "rds/postgres/app",
+				SecretFormatVersion: currentSecretFormatVersion,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{Status: tt.status}
+			outcome, reason, err := r.MigrateDatabaseSecret(context.Background(), db, false)
+			if err != nil {
+				t.Fatalf("MigrateDatabaseSecret() unexpected error: %v", err)
+			}
+			if outcome != SecretMigrationSkipped || reason == "" {
+				t.Errorf("MigrateDatabaseSecret() = %q (%q), want %q with reason", outcome, reason, SecretMigrationSkipped)
+			}
+		})
+	}
+}
+
+func TestLegacySecretForDatabase(t *testing.T) {
+	db := &databasev1alpha1.Database{
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:       "postgres",
+			DatabaseName: "app",
+		},
+		Status: databasev1alpha1.DatabaseStatus{
+			ActualUsername: "app_user",
+			ConnectionInfo: databasev1alpha1.ConnectionInfo{Host: "db.internal", Port: 5433},
+		},
+	}
+	converted := &secrets.DatabaseSecret{
+		DBHost:     "old-host",
+		DBPort:     5432,
+		DBName:     "old",
+		DBUsername: "old_user",
+		DBPassword: "pw",
+	}
+
+	got := legacySecretForDatabase(db, converted)
+	want := secrets.DatabaseSecret{
+		DBHost:      "db.internal",
+		DBPort:      5433,
+		DBName:      "app",
+		DBUsername:  "app_user",
+		DBPassword:  "pw",
+		Engine:      "postgres",
+		DatabaseURL: "postgresql://app_user:pw@db.internal:5433/app",
+	}
+	if *got != want {
+		t.Errorf("legacySecretForDatabase() = %+v, want %+v", got, want)
+	}
+	if converted.DBHost != "old-host" {
+		t.Error("legacySecretForDatabase() modified its input")
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// secretProviderClassGVK is the SecretProviderClass of the Secrets Store CSI Driver
+// The driver is optional, so its types are not imported and the object is handled as unstructured.
+var secretProviderClassGVK = schema.GroupVersionKind{
+	Group:   "secrets-store.csi.x-k8s.io",
+	Version: "v1",
+	Kind:    "SecretProviderClass",
+}
+
+// secretProviderClassFile is the name of the file the whole JSON secret is mounted as
+const secretProviderClassFile = "credentials.json"
+
+// jmesPathIdentifier matches the keys that can be used as a JMESPath expression without quotes
+var jmesPathIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// secretProviderClassKeys returns the keys of the secret mounted as files of their own
+// The default secret format stores the port as a number, which the AWS provider cannot mount.
+// Only JSON in SecretString has keys by default.
+func secretProviderClassKeys(db *databasev1alpha1.Database) []string {
+	if keys := db.Spec.SecretProviderClass.Keys; len(keys) > 0 || db.Spec.SecretTemplate != "" || connectionStringOnly(db) || db.Spec.SecretBinary {
+		return keys
+	}
+	engine := string(db.Spec.Engine)
+	names := secretKeyNames(db).Keys(engine)
+	return []string{names["DB_HOST"], names["DB_NAME"], names["DB_USERNAME"], names["DB_PASSWORD"], names[secrets.URLFieldName(engine)]}
+}
+
+// secretProviderClassSpec returns the spec of the SecretProviderClass that mounts a secret
+// The objects parameter of the AWS provider is a YAML document embedded in a string.
+func secretProviderClassSpec(secretID, region string, keys []string) (map[string]interface{}, error) {
+	object := map[string]interface{}{
+		"objectName":  secretID,
+		"objectType":  "secretsmanager",
+		"objectAlias": secretProviderClassFile,
+	}
+	if len(keys) > 0 {
+		paths := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			path := key
+			if !jmesPathIdentifier.MatchString(key) {
+				path = fmt.Sprintf("%q", key)
+			}
+			paths = append(paths, map[string]interface{}{"path": path, "objectAlias": key})
+		}
+		object["jmesPath"] = paths
+	}
+	objects, err := yaml.Marshal([]interface{}{object})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"provider": "aws",
+		"parameters": map[string]interface{}{
+			"objects": string(objects),
+			"region":  region,
+		},
+	}, nil
+}
+
+// secretProviderClassKey returns the namespace and name of the SecretProviderClass of a Database
+func secretProviderClassKey(db *databasev1alpha1.Database) (client.ObjectKey, error) {
+	cfg := db.Spec.SecretProviderClass
+	key := client.ObjectKey{Namespace: db.Namespace, Name: cfg.Name}
+	if key.Name == "" {
+		key.Name = db.Name
+	}
+	if isClusterView(db) {
+		if cfg.Namespace == "" {
+			return key, fmt.Errorf("spec.secretProviderClass.namespace is required for ClusterDatabases")
+		}
+		key.Namespace = cfg.Namespace
+	}
+	return key, nil
+}
+
+// reconcileSecretProviderClass creates or updates the SecretProviderClass of spec.secretProviderClass
+// It is owned by the Database, so Kubernetes deletes it with the Database. A SecretProviderClass of the
+// same name that is not owned by the Database is never taken over.
+func (r *DatabaseReconciler) reconcileSecretProviderClass(ctx context.Context, db *databasev1alpha1.Database) error {
+	cfg := db.Spec.SecretProviderClass
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	key, err := secretProviderClassKey(db)
+	if err != nil {
+		return newConfigError(err)
+	}
+	secretID := db.Status.SecretARN
+	if secretID == "" {
+		secretID = r.getSecretNameOrDefault(db)
+	}
+	spec, err := secretProviderClassSpec(secretID, db.Status.SecretRegion, secretProviderClassKeys(db))
+	if err != nil {
+		return fmt.Errorf("failed to build SecretProviderClass: %w", err)
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(secretProviderClassGVK)
+	err = r.Get(ctx, key, existing)
+	switch {
+	case meta.IsNoMatchError(err):
+		return newConfigError(fmt.Errorf("spec.secretProviderClass requires the Secrets Store CSI Driver, SecretProviderClass is not installed"))
+	case apierrors.IsNotFound(err):
+		if plannedChange(ctx, "create SecretProviderClass %s", key) {
+			return nil
+		}
+		desired := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		desired.SetGroupVersionKind(secretProviderClassGVK)
+		desired.SetNamespace(key.Namespace)
+		desired.SetName(key.Name)
+		desired.SetOwnerReferences([]metav1.OwnerReference{ownerReference(db)})
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create SecretProviderClass %s: %w", key, err)
+		}
+		log.FromContext(ctx).Info("SecretProviderClass created", "secretProviderClass", key.String())
+		r.recordNormal(db, EventReasonSecretProviderClassCreated, "SecretProviderClass %s created", key)
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get SecretProviderClass %s: %w", key, err)
+	}
+
+	if !isOwnedBy(existing, db) {
+		return newConfigError(fmt.Errorf("SecretProviderClass %s already exists and is not owned by this resource", key))
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], spec) {
+		return nil
+	}
+	if plannedChange(ctx, "update SecretProviderClass %s", key) {
+		return nil
+	}
+	existing.Object["spec"] = spec
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update SecretProviderClass %s: %w", key, err)
+	}
+	log.FromContext(ctx).Info("SecretProviderClass updated", "secretProviderClass", key.String())
+	return nil
+}
+
+// ownerReference returns the controller reference to a Database, or to the ClusterDatabase it is a view of
+func ownerReference(db *databasev1alpha1.Database) metav1.OwnerReference {
+	kind := "Database"
+	if isClusterView(db) {
+		kind = "ClusterDatabase"
+	}
+	controller := true
+	return metav1.OwnerReference{
+		APIVersion: databasev1alpha1.GroupVersion.String(),
+		Kind:       kind,
+		Name:       db.Name,
+		UID:        db.UID,
+		Controller: &controller,
+	}
+}
+
+// isOwnedBy reports whether obj is controlled by a Database
+func isOwnedBy(obj metav1.Object, db *databasev1alpha1.Database) bool {
+	owner := metav1.GetControllerOf(obj)
+	return owner != nil && owner.UID == db.UID
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestSecretProviderClassSpec(t *testing.T) {
+	spec, err := secretProviderClassSpec("arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/orders-AbCdEf",
+		"eu-west-1", []string{"DB_PASSWORD", "db-url"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parameters := spec["parameters"].(map[string]interface{})
+	if parameters["region"] != "eu-west-1" {
+		t.Errorf("region = %v, want eu-west-1", parameters["region"])
+	}
+	want := `- jmesPath:
+  - objectAlias: DB_PASSWORD
+    path: DB_PASSWORD
+  - objectAlias: db-url
+    path: '"db-url"'
+  objectAlias: credentials.json
+  objectName: arn:aws:secretsmanager:eu-west-1:123456789012:secret:rds/postgres/orders-AbCdEf
+  objectType: secretsmanager
+`
+	if got := parameters["objects"]; got != want {
+		t.Errorf("objects =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSecretProviderClassKeys(t *testing.T) {
+	db := &databasev1alpha1.Database{Spec: databasev1alpha1.DatabaseSpec{
+		Engine:              databasev1alpha1.DatabaseEngineMySQL,
+		SecretProviderClass: &databasev1alpha1.SecretProviderClassConfig{Enabled: true},
+	}}
+	if got := strings.Join(secretProviderClassKeys(db), ","); got != "DB_HOST,DB_NAME,DB_USERNAME,DB_PASSWORD,MYSQL_URL" {
+		t.Errorf("default keys = %s", got)
+	}
+
+	db.Spec.SecretKeyNames = &databasev1alpha1.SecretKeyNames{Password: "MYSQL_PWD", URL: "DATABASE_URL"}
+	if got := strings.Join(secretProviderClassKeys(db), ","); got != "DB_HOST,DB_NAME,DB_USERNAME,MYSQL_PWD,DATABASE_URL" {
+		t.Errorf("renamed keys = %s", got)
+	}
+	db.Spec.SecretKeyNames = nil
+
+	db.Spec.SecretBinary = true
+	if got := secretProviderClassKeys(db); len(got) != 0 {
+		t.Errorf("keys of a binary secret = %v, want none", got)
+	}
+	db.Spec.SecretBinary = false
+
+	db.Spec.SecretTemplate = `{"password": "{{ .DBPassword }}"}`
+	if got := secretProviderClassKeys(db); len(got) != 0 {
+		t.Errorf("keys with secret template = %v, want none", got)
+	}
+	db.Spec.SecretProviderClass.Keys = []string{"password"}
+	if got := strings.Join(secretProviderClassKeys(db), ","); got != "password" {
+		t.Errorf("keys = %s, want password", got)
+	}
+}
+
+func TestReconcileSecretProviderClass(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := databasev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	scheme.AddKnownTypeWithName(secretProviderClassGVK, &unstructured.Unstructured{})
+
+	foreign := &unstructured.Unstructured{}
+	foreign.SetGroupVersionKind(secretProviderClassGVK)
+	foreign.SetNamespace("shop")
+	foreign.SetName("taken")
+	r := &DatabaseReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(foreign).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	db := &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders", UID: "uid-1"},
+		Spec: databasev1alpha1.DatabaseSpec{
+			Engine:              databasev1alpha1.DatabaseEnginePostgres,
+			DatabaseName:        "orders",
+			SecretProviderClass: &databasev1alpha1.SecretProviderClassConfig{Enabled: true},
+		},
+		Status: databasev1alpha1.DatabaseStatus{SecretARN: "arn:aws:secretsmanager:eu-west-1:123456789012:secret:orders", SecretRegion: "eu-west-1"},
+	}
+
+	plan := &changePlan{}
+	if err := r.reconcileSecretProviderClass(withChangePlan(ctx, plan), db); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.changes) != 1 {
+		t.Fatalf("dry run: planned changes = %q, want 1", plan.changes)
+	}
+
+	if err := r.reconcileSecretProviderClass(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	spc := &unstructured.Unstructured{}
+	spc.SetGroupVersionKind(secretProviderClassGVK)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "orders"}, spc); err != nil {
+		t.Fatal(err)
+	}
+	if !isOwnedBy(spc, db) {
+		t.Errorf("owner references = %v, want controller uid-1", spc.GetOwnerReferences())
+	}
+
+	db.Status.SecretRegion = "eu-central-1"
+	if err := r.reconcileSecretProviderClass(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "orders"}, spc); err != nil {
+		t.Fatal(err)
+	}
+	if region, _, _ := unstructured.NestedString(spc.Object, "spec", "parameters", "region"); region != "eu-central-1" {
+		t.Errorf("region = %s, want eu-central-1 after update", region)
+	}
+
+	db.Spec.SecretProviderClass.Name = "taken"
+	if err := r.reconcileSecretProviderClass(ctx, db); classifyError(err) != ReasonConfigError {
+		t.Errorf("SecretProviderClass not owned by the Database: error = %v, want reason %s", err, ReasonConfigError)
+	}
+
+	cluster := db.DeepCopy()
+	cluster.Namespace = ""
+	if err := r.reconcileSecretProviderClass(ctx, cluster); classifyError(err) != ReasonConfigError {
+		t.Errorf("ClusterDatabase without namespace: error = %v, want reason %s", err, ReasonConfigError)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// restoreScheduledSecret restores the secret of a Database if it is scheduled for deletion, e.g. by a
+// deleted Database of the same name or by hand, so it is reused with its ARN and version history
+// instead of failing to be read, updated and created. With SkipSecretRestore the reconciliation fails
+// until the secret is restored or its deletion completes.
+func (r *DatabaseReconciler) restoreScheduledSecret(ctx context.Context, db *databasev1alpha1.Database,
+	awsClient *secrets.AWSSecretsManagerClient, secretID, secretName string) error {
+	scheduled, err := awsClient.SecretScheduledForDeletion(ctx, secretID)
+	if err != nil {
+		return fmt.Errorf("failed to check if secret is scheduled for deletion: %w", err)
+	}
+	if !scheduled {
+		return nil
+	}
+	if r.SkipSecretRestore {
+		return newConfigError(fmt.Errorf("secret %s is scheduled for deletion in AWS Secrets Manager, restore it "+
+			"or wait for the deletion to complete, the operator runs with --skip-secret-restore", secretName))
+	}
+	if plannedChange(ctx, "restore secret %s scheduled for deletion", secretName) {
+		return nil
+	}
+
+	if err := awsClient.RestoreSecret(ctx, secretID); err != nil {
+		return fmt.Errorf("failed to restore secret scheduled for deletion: %w", err)
+	}
+	log.FromContext(ctx).Info("Restored secret scheduled for deletion",
+		"secretName", secretName,
+		"region", awsClient.GetRegion())
+	r.recordNormal(db, EventReasonSecretRestored, "Secret %s was scheduled for deletion and has been restored", secretName)
+	return nil
+}
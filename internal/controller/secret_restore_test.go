@@ -0,0 +1,68 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestRestoreScheduledSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleted     bool
+		skip        bool
+		wantErr     string
+		wantRestore int
+		wantEvent   string
+	}{
+		{name: "not scheduled for deletion"},
+		{name: "restored", deleted: true, wantRestore: 1, wantEvent: EventReasonSecretRestored},
+		{name: "restore skipped", deleted: true, skip: true, wantErr: "--skip-secret-restore"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSecretsManager(t)
+			fake.deleted = tt.deleted
+			recorder := record.NewFakeRecorder(10)
+			r := &DatabaseReconciler{Recorder: recorder, SkipSecretRestore: tt.skip}
+			db := &databasev1alpha1.Database{}
+
+			err := r.restoreScheduledSecret(context.Background(), db, fake.client(t), "rds/postgres/app", "rds/postgres/app")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("restoreScheduledSecret() error = %v, want %q", err, tt.wantErr)
+				}
+				if classifyError(err) != ReasonConfigError {
+					t.Errorf("classifyError() = %s, want %s", classifyError(err), ReasonConfigError)
+				}
+			} else if err != nil {
+				t.Fatalf("restoreScheduledSecret() error = %v", err)
+			}
+			if got := fake.callCount("RestoreSecret"); got != tt.wantRestore {
+				t.Errorf("RestoreSecret called %d times, want %d", got, tt.wantRestore)
+			}
+			select {
+			case event := <-recorder.Events:
+				if tt.wantEvent == "" || !strings.Contains(event, tt.wantEvent) {
+					t.Errorf("event = %q, want %q", event, tt.wantEvent)
+				}
+			default:
+				if tt.wantEvent != "" {
+					t.Errorf("no event recorded, want %s", tt.wantEvent)
+				}
+			}
+		})
+	}
+}
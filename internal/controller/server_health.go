@@ -0,0 +1,161 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+)
+
+// serverHealthResolution limits how often an unchanged health result is written to the status
+// Every write is a status change that triggers another reconciliation
+const serverHealthResolution = 5 * time.Minute
+
+// ServerHealthChecker periodically connects to the admin endpoint of every server referenced by
+// a Database resource and runs a query. The result is exported via the databaseuser_server_up and
+// databaseuser_server_latency_seconds metrics and recorded in status.serverHealthy of each Database,
+// so a database that is down can be told apart from a broken operator.
+type ServerHealthChecker struct {
+	Reconciler *DatabaseReconciler
+	Interval   time.Duration
+}
+
+// serverHealth is the result of a health check of one server
+type serverHealth struct {
+	healthy bool
+	latency time.Duration
+	message string
+}
+
+// Start runs the health checks until the context is cancelled
+// It implements manager.Runnable and only runs on the elected leader
+func (c *ServerHealthChecker) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("server-health")
+	ctx = log.IntoContext(ctx, logger)
+
+	logger.Info("Starting server health checks", "interval", c.Interval)
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// check checks every server once and records the results on the Database resources
+func (c *ServerHealthChecker) check(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	r := c.Reconciler
+
+	dbs, err := r.listDatabases(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list databases")
+		return
+	}
+
+	results := make(map[string]serverHealth)
+	DatabaseUserServerUp.Reset()
+	DatabaseUserServerLatency.Reset()
+	for i := range dbs {
+		db := &dbs[i]
+		if !db.DeletionTimestamp.IsZero() || !r.Shard.Owns(db.Namespace, db.Name) {
+			continue
+		}
+
+		health := c.checkDatabase(ctx, db, results)
+		if next, ok := nextServerHealthStatus(db.Status, health, time.Now()); ok {
+			db.Status = next
+			if err := r.updateStatus(ctx, db); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to update server health", "namespace", db.Namespace, "name", db.Name)
+			}
+		}
+	}
+}
+
+// checkDatabase returns the health of the server of a Database, checking each server only once
+// per round. A Database whose connection string cannot be resolved is reported unhealthy.
+func (c *ServerHealthChecker) checkDatabase(ctx context.Context, db *databasev1alpha1.Database, results map[string]serverHealth) serverHealth {
+	connectionString, err := c.Reconciler.getConnectionString(ctx, db)
+	if err != nil {
+		return serverHealth{message: normalizeErrorMessage(err.Error())}
+	}
+	connInfo, err := database.ParseConnectionInfo(string(db.Spec.Engine), connectionString)
+	if err != nil {
+		return serverHealth{message: normalizeErrorMessage(err.Error())}
+	}
+
+	key := serverKey(db, connInfo)
+	if health, ok := results[key]; ok {
+		return health
+	}
+	health := c.checkServer(ctx, db, connectionString)
+	results[key] = health
+
+	up := 0.0
+	if health.healthy {
+		up = 1
+	}
+	DatabaseUserServerUp.WithLabelValues(key).Set(up)
+	DatabaseUserServerLatency.WithLabelValues(key).Set(health.latency.Seconds())
+	if !health.healthy {
+		log.FromContext(ctx).Info("Server health check failed", "server", key, "error", health.message)
+	}
+	return health
+}
+
+// checkServer connects to the admin endpoint of a Database and queries the server version
+// The connection is opened for every check, cached server versions are never used.
+func (c *ServerHealthChecker) checkServer(ctx context.Context, db *databasev1alpha1.Database, connectionString string) serverHealth {
+	ctx, cancel := context.WithTimeout(ctx, c.Interval)
+	defer cancel()
+
+	start := time.Now()
+	dbClient, err := c.Reconciler.newDatabaseClient(ctx, string(db.Spec.Engine), connectionString, db.Spec.CloudSQL, db.Spec.Azure)
+	if err != nil {
+		return serverHealth{latency: time.Since(start), message: normalizeErrorMessage(err.Error())}
+	}
+	defer func() {
+		if closeErr := dbClient.Close(); closeErr != nil {
+			log.FromContext(ctx).Error(closeErr, "Failed to close database connection")
+		}
+	}()
+
+	if _, err := dbClient.ServerVersion(ctx); err != nil {
+		return serverHealth{latency: time.Since(start), message: normalizeErrorMessage(err.Error())}
+	}
+	return serverHealth{healthy: true, latency: time.Since(start)}
+}
+
+// nextServerHealthStatus returns the status with the health check result applied and whether it
+// needs to be written. Unchanged results are written at most every serverHealthResolution.
+func nextServerHealthStatus(status databasev1alpha1.DatabaseStatus, health serverHealth, now time.Time) (databasev1alpha1.DatabaseStatus, bool) {
+	changed := status.ServerHealthy == nil || *status.ServerHealthy != health.healthy ||
+		status.ServerHealthMessage != health.message
+	if !changed && status.ServerHealthCheckedAt != nil && now.Sub(status.ServerHealthCheckedAt.Time) < serverHealthResolution {
+		return status, false
+	}
+
+	healthy := health.healthy
+	status.ServerHealthy = &healthy
+	status.ServerLatencyMilliseconds = health.latency.Milliseconds()
+	status.ServerHealthMessage = health.message
+	status.ServerHealthCheckedAt = timestampPtr(now)
+	return status, true
+}
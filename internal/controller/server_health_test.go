@@ -0,0 +1,84 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestNextServerHealthStatus(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	healthy, unhealthy := true, false
+	recent := timestampPtr(now.Add(-time.Minute))
+	old := timestampPtr(now.Add(-serverHealthResolution))
+
+	tests := []struct {
+		name      string
+		status    databasev1alpha1.DatabaseStatus
+		health    serverHealth
+		wantWrite bool
+	}{
+		{
+			name:      "first check",
+			health:    serverHealth{healthy: true, latency: 12 * time.Millisecond},
+			wantWrite: true,
+		},
+		{
+			name:   "unchanged and recent",
+			status: databasev1alpha1.DatabaseStatus{ServerHealthy: &healthy, ServerHealthCheckedAt: recent},
+			health: serverHealth{healthy: true, latency: 30 * time.Millisecond},
+		},
+		{
+			name:      "unchanged but old",
+			status:    databasev1alpha1.DatabaseStatus{ServerHealthy: &healthy, ServerHealthCheckedAt: old},
+			health:    serverHealth{healthy: true},
+			wantWrite: true,
+		},
+		{
+			name:      "server went down",
+			status:    databasev1alpha1.DatabaseStatus{ServerHealthy: &healthy, ServerHealthCheckedAt: recent},
+			health:    serverHealth{message: "connection refused"},
+			wantWrite: true,
+		},
+		{
+			name: "error message changed",
+			status: databasev1alpha1.DatabaseStatus{
+				ServerHealthy: &unhealthy, ServerHealthMessage: "connection refused", ServerHealthCheckedAt: recent,
+			},
+			health:    serverHealth{message: "authentication failed"},
+			wantWrite: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, write := nextServerHealthStatus(tt.status, tt.health, now)
+			if write != tt.wantWrite {
+				t.Fatalf("nextServerHealthStatus() write = %v, want %v", write, tt.wantWrite)
+			}
+			if !write {
+				return
+			}
+			if got.ServerHealthy == nil || *got.ServerHealthy != tt.health.healthy {
+				t.Errorf("serverHealthy = %v, want %v", got.ServerHealthy, tt.health.healthy)
+			}
+			if got.ServerHealthMessage != tt.health.message {
+				t.Errorf("serverHealthMessage = %q, want %q", got.ServerHealthMessage, tt.health.message)
+			}
+			if got.ServerLatencyMilliseconds != tt.health.latency.Milliseconds() {
+				t.Errorf("serverLatencyMilliseconds = %d, want %d", got.ServerLatencyMilliseconds, tt.health.latency.Milliseconds())
+			}
+			if got.ServerHealthCheckedAt == nil || !got.ServerHealthCheckedAt.Time.Equal(now) {
+				t.Errorf("serverHealthCheckedAt = %v, want %v", got.ServerHealthCheckedAt, now)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Shard selects the resources reconciled by one operator deployment when the work is split
+// across several of them. Resources are assigned by a hash of namespace/name, so every
+// deployment started with the same Count agrees on the owner of a resource.
+// The zero value owns every resource.
+type Shard struct {
+	// Index of this shard, from 0 to Count-1
+	Index int
+	// Count of shards, 0 or 1 disables sharding
+	Count int
+}
+
+// Validate checks that Index is within Count
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must not be negative, got %d", s.Count)
+	}
+	if s.Count > 1 && (s.Index < 0 || s.Index >= s.Count) {
+		return fmt.Errorf("shard index must be between 0 and %d, got %d", s.Count-1, s.Index)
+	}
+	return nil
+}
+
+// Enabled reports whether the resources are split across more than one shard
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns reports whether the resource namespace/name belongs to this shard
+// Cluster-scoped resources have an empty namespace.
+func (s Shard) Owns(namespace, name string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return shardOf(namespace, name, s.Count) == s.Index
+}
+
+// Predicate filters the events of resources of other shards
+func (s Shard) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return s.Owns(obj.GetNamespace(), obj.GetName())
+	})
+}
+
+// filterRequests drops the requests of resources of other shards
+func (s Shard) filterRequests(requests []reconcile.Request) []reconcile.Request {
+	if !s.Enabled() {
+		return requests
+	}
+	owned := requests[:0]
+	for _, req := range requests {
+		if s.Owns(req.Namespace, req.Name) {
+			owned = append(owned, req)
+		}
+	}
+	return owned
+}
+
+// shardOf returns the shard of the resource namespace/name among count shards
+func shardOf(namespace, name string, count int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace + "/" + name))
+	return int(h.Sum32() % uint32(count))
+}
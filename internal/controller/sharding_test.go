@@ -0,0 +1,93 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestShardValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		shard   Shard
+		wantErr bool
+	}{
+		{name: "disabled", shard: Shard{}},
+		{name: "single shard ignores index", shard: Shard{Count: 1, Index: 3}},
+		{name: "first shard", shard: Shard{Count: 4, Index: 0}},
+		{name: "last shard", shard: Shard{Count: 4, Index: 3}},
+		{name: "index out of range", shard: Shard{Count: 4, Index: 4}, wantErr: true},
+		{name: "negative index", shard: Shard{Count: 4, Index: -1}, wantErr: true},
+		{name: "negative count", shard: Shard{Count: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.shard.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShardOwns(t *testing.T) {
+	const count = 4
+	perShard := make([]int, count)
+	for i := 0; i < 1000; i++ {
+		namespace, name := fmt.Sprintf("team-%d", i%10), fmt.Sprintf("db-%d", i)
+		if !(Shard{}).Owns(namespace, name) {
+			t.Fatalf("disabled sharding does not own %s/%s", namespace, name)
+		}
+
+		owners := 0
+		for index := 0; index < count; index++ {
+			if (Shard{Index: index, Count: count}).Owns(namespace, name) {
+				owners++
+				perShard[index]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("%s/%s owned by %d shards, want 1", namespace, name, owners)
+		}
+	}
+
+	for index, n := range perShard {
+		if n < 150 {
+			t.Errorf("shard %d owns %d of 1000 resources, want a roughly even split", index, n)
+		}
+	}
+}
+
+func TestShardFilterRequests(t *testing.T) {
+	var requests []reconcile.Request
+	for i := 0; i < 20; i++ {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("db-%d", i)}})
+	}
+
+	if got := (Shard{}).filterRequests(append([]reconcile.Request(nil), requests...)); len(got) != len(requests) {
+		t.Errorf("disabled sharding kept %d of %d requests", len(got), len(requests))
+	}
+
+	total := 0
+	for index := 0; index < 2; index++ {
+		shard := Shard{Index: index, Count: 2}
+		for _, req := range shard.filterRequests(append([]reconcile.Request(nil), requests...)) {
+			if !shard.Owns(req.Namespace, req.Name) {
+				t.Errorf("shard %d kept request of %s", index, req.NamespacedName)
+			}
+			total++
+		}
+	}
+	if total != len(requests) {
+		t.Errorf("shards kept %d requests in total, want %d", total, len(requests))
+	}
+}
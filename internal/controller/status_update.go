@@ -0,0 +1,40 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateStatusRetryingConflicts writes the status of obj. When the write conflicts with a concurrent
+// update, e.g. of the spec or the finalizers, the latest version is read and only the status, set on
+// it by setStatus, is written again, so a reconciliation is not redone for a stale resourceVersion.
+// obj takes the resourceVersion of the written object.
+func updateStatusRetryingConflicts[T client.Object](ctx context.Context, c client.Client, obj T, setStatus func(latest T)) error {
+	err := c.Status().Update(ctx, obj)
+	if !apierrors.IsConflict(err) {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(T)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return err
+		}
+		setStatus(latest)
+		if err := c.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+		obj.SetResourceVersion(latest.GetResourceVersion())
+		return nil
+	})
+}
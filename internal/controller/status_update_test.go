@@ -0,0 +1,58 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestUpdateStatusRetryingConflicts(t *testing.T) {
+	ctx := context.Background()
+	r := newHookTestReconciler(t, &databasev1alpha1.Database{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "orders"},
+		Spec:       databasev1alpha1.DatabaseSpec{DatabaseName: "orders"},
+	})
+
+	db := &databasev1alpha1.Database{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "orders"}, db); err != nil {
+		t.Fatal(err)
+	}
+
+	// A concurrent update makes the resourceVersion of db stale
+	concurrent := db.DeepCopy()
+	concurrent.Labels = map[string]string{"team": "payments"}
+	if err := r.Update(ctx, concurrent); err != nil {
+		t.Fatal(err)
+	}
+
+	db.Status.ActualUsername = "orders"
+	db.Status.ObservedGeneration = 3
+	if err := r.updateStatus(ctx, db); err != nil {
+		t.Fatalf("updateStatus() with a stale resourceVersion: %v", err)
+	}
+
+	got := &databasev1alpha1.Database{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(db), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.ActualUsername != "orders" || got.Status.ObservedGeneration != 3 {
+		t.Errorf("status = %+v, want the status written after the conflict", got.Status)
+	}
+	if got.Labels["team"] != "payments" {
+		t.Errorf("labels = %v, want the concurrent update kept", got.Labels)
+	}
+	if db.ResourceVersion != got.ResourceVersion {
+		t.Errorf("resourceVersion = %s, want the written version %s", db.ResourceVersion, got.ResourceVersion)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+// expiryTime returns the time spec.ttl of a Database elapses, nil without a ttl
+func expiryTime(db *databasev1alpha1.Database) *metav1.Time {
+	if db.Spec.TTL == nil || db.Spec.TTL.Duration <= 0 {
+		return nil
+	}
+	expiresAt := metav1.NewTime(db.CreationTimestamp.Add(db.Spec.TTL.Duration))
+	return &expiresAt
+}
+
+// refreshExpiresAt sets status.expiresAt from spec.ttl and reports whether it changed
+func refreshExpiresAt(db *databasev1alpha1.Database) bool {
+	expiresAt := expiryTime(db)
+	if expiresAt.Equal(db.Status.ExpiresAt) {
+		return false
+	}
+	db.Status.ExpiresAt = expiresAt
+	return true
+}
+
+// reconcileExpiry deletes a Database whose spec.ttl elapsed and reports whether it did
+// The deletion is handled by the finalizer like any other, so retainOnDelete decides whether the
+// database, user and secret are removed. A Database in dry run is not deleted.
+func (r *DatabaseReconciler) reconcileExpiry(ctx context.Context, db *databasev1alpha1.Database) (bool, error) {
+	expiresAt := expiryTime(db)
+	if expiresAt == nil || time.Now().Before(expiresAt.Time) {
+		return false, nil
+	}
+	if r.isDryRun(db) {
+		log.FromContext(ctx).Info("spec.ttl elapsed, the Database is not deleted in dry run", "expiresAt", expiresAt)
+		return false, nil
+	}
+
+	if err := r.deleteObject(ctx, db); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete expired Database: %w", err)
+	}
+	log.FromContext(ctx).Info("Deleted expired Database", "ttl", db.Spec.TTL.Duration, "expiresAt", expiresAt)
+	r.recordEvent(db, corev1.EventTypeNormal, EventReasonExpired, "Deleting the Database, its ttl of %s elapsed at %s",
+		db.Spec.TTL.Duration, expiresAt.UTC().Format(time.RFC3339))
+	return true, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestRefreshExpiresAt(t *testing.T) {
+	created := metav1.NewTime(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}}
+
+	if refreshExpiresAt(db) || db.Status.ExpiresAt != nil {
+		t.Fatalf("without ttl: expiresAt = %v, want unset", db.Status.ExpiresAt)
+	}
+
+	db.Spec.TTL = &metav1.Duration{Duration: 72 * time.Hour}
+	if !refreshExpiresAt(db) {
+		t.Fatal("refreshExpiresAt() = false after setting the ttl, want true")
+	}
+	if want := created.Add(72 * time.Hour); !db.Status.ExpiresAt.Time.Equal(want) {
+		t.Errorf("expiresAt = %v, want %v", db.Status.ExpiresAt, want)
+	}
+	if refreshExpiresAt(db) {
+		t.Error("refreshExpiresAt() = true for an unchanged ttl, want false")
+	}
+
+	db.Spec.TTL = nil
+	if !refreshExpiresAt(db) || db.Status.ExpiresAt != nil {
+		t.Errorf("ttl removed: expiresAt = %v, want unset", db.Status.ExpiresAt)
+	}
+}
+
+func TestReconcileExpiry(t *testing.T) {
+	ctx := context.Background()
+	expired := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	ttl := &metav1.Duration{Duration: time.Hour}
+
+	tests := []struct {
+		name        string
+		ttl         *metav1.Duration
+		dryRun      bool
+		wantExpired bool
+	}{
+		{name: "no ttl"},
+		{name: "not expired", ttl: &metav1.Duration{Duration: 72 * time.Hour}},
+		{name: "expired", ttl: ttl, wantExpired: true},
+		{name: "expired in dry run", ttl: ttl, dryRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &databasev1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "previews", Name: "pr-42", UID: "uid-1", CreationTimestamp: expired},
+				Spec:       databasev1alpha1.DatabaseSpec{Engine: databasev1alpha1.DatabaseEnginePostgres, TTL: tt.ttl, DryRun: tt.dryRun},
+			}
+			r := newClusterDatabaseTestReconciler(t, db.DeepCopy())
+			r.Recorder = record.NewFakeRecorder(10)
+
+			gotExpired, err := r.reconcileExpiry(ctx, db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotExpired != tt.wantExpired {
+				t.Errorf("reconcileExpiry() = %v, want %v", gotExpired, tt.wantExpired)
+			}
+			err = r.Get(ctx, client.ObjectKeyFromObject(db), &databasev1alpha1.Database{})
+			if deleted := apierrors.IsNotFound(err); deleted != tt.wantExpired {
+				t.Errorf("Database deleted = %v (error %v), want %v", deleted, err, tt.wantExpired)
+			}
+		})
+	}
+
+	t.Run("cluster database", func(t *testing.T) {
+		cdb := testClusterDatabase()
+		cdb.UID = "uid-2"
+		cdb.CreationTimestamp = expired
+		cdb.Spec.TTL = ttl
+		r := newClusterDatabaseTestReconciler(t, cdb)
+		r.Recorder = record.NewFakeRecorder(10)
+
+		gotExpired, err := r.reconcileExpiry(ctx, databaseView(cdb))
+		if err != nil || !gotExpired {
+			t.Fatalf("reconcileExpiry() = %v, %v, want true", gotExpired, err)
+		}
+		err = r.Get(ctx, client.ObjectKey{Name: cdb.Name}, &databasev1alpha1.ClusterDatabase{})
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("ClusterDatabase: error = %v, want NotFound", err)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+const (
+	// AnnotationLogLevel raises the log verbosity of the reconciliations of a single Database
+	// The value is "debug" or a verbosity level like "2", logged whatever the level of the operator.
+	AnnotationLogLevel = "database.opzkit.io/log-level"
+
+	// AnnotationEmitEvents controls the events recorded on a single Database, see eventVerbosity
+	AnnotationEmitEvents = "database.opzkit.io/emit-events"
+)
+
+// Values of the emit-events annotation
+const (
+	// EmitEventsNone records Warning events only
+	EmitEventsNone = "none"
+	// EmitEventsDefault records lifecycle and Warning events, deduplicated within the event dedup window
+	EmitEventsDefault = "default"
+	// EmitEventsAll records every event without deduplication and a Reconciled event per reconciliation
+	EmitEventsAll = "all"
+)
+
+// maxObjectLogLevel is the highest verbosity the log-level annotation can select
+const maxObjectLogLevel = 5
+
+// objectLogLevel returns the verbosity selected by the log-level annotation of a Database, 0 if unset
+func objectLogLevel(db *databasev1alpha1.Database) (int, error) {
+	value, ok := db.Annotations[AnnotationLogLevel]
+	if !ok || value == "" || value == "info" {
+		return 0, nil
+	}
+	if value == "debug" {
+		return 1, nil
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 0 || level > maxObjectLogLevel {
+		return 0, fmt.Errorf("annotation %s must be info, debug or a level from 0 to %d, got %q",
+			AnnotationLogLevel, maxObjectLogLevel, value)
+	}
+	return level, nil
+}
+
+// eventVerbosity returns the value of the emit-events annotation of a Database
+// Unknown values select the default.
+func eventVerbosity(db *databasev1alpha1.Database) string {
+	switch value := db.Annotations[AnnotationEmitEvents]; value {
+	case EmitEventsNone, EmitEventsAll:
+		return value
+	default:
+		return EmitEventsDefault
+	}
+}
+
+// withObjectLogLevel returns a context whose logger logs the verbosity selected by the log-level
+// annotation of a Database. An invalid annotation is logged and ignored.
+func withObjectLogLevel(ctx context.Context, db *databasev1alpha1.Database) context.Context {
+	logger := log.FromContext(ctx)
+	level, err := objectLogLevel(db)
+	if err != nil {
+		logger.Error(err, "Ignoring invalid annotation")
+		return ctx
+	}
+	if level == 0 {
+		return ctx
+	}
+	return log.IntoContext(ctx, logr.New(newVerboseSink(logger.GetSink(), level)))
+}
+
+// verboseSink logs the messages up to a verbosity level that the operator's log level drops
+// They are logged at the info level with their verbosity in the "v" field.
+type verboseSink struct {
+	logr.LogSink
+	level int
+}
+
+// newVerboseSink wraps a sink, accounting for the extra frame in the caller of its messages
+func newVerboseSink(sink logr.LogSink, level int) *verboseSink {
+	if withDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = withDepth.WithCallDepth(1)
+	}
+	return &verboseSink{LogSink: sink, level: level}
+}
+
+func (s *verboseSink) Enabled(level int) bool {
+	return level <= s.level || s.LogSink.Enabled(level)
+}
+
+func (s *verboseSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if level > 0 && level <= s.level && !s.LogSink.Enabled(level) {
+		s.LogSink.Info(0, msg, append(keysAndValues, "v", level)...)
+		return
+	}
+	s.LogSink.Info(level, msg, keysAndValues...)
+}
+
+func (s *verboseSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &verboseSink{LogSink: s.LogSink.WithValues(keysAndValues...), level: s.level}
+}
+
+func (s *verboseSink) WithName(name string) logr.LogSink {
+	return &verboseSink{LogSink: s.LogSink.WithName(name), level: s.level}
+}
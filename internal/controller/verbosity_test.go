@@ -0,0 +1,131 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+)
+
+func TestObjectLogLevel(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "info", want: 0},
+		{value: "debug", want: 1},
+		{value: "2", want: 2},
+		{value: "-1", wantErr: true},
+		{value: "6", wantErr: true},
+		{value: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{AnnotationLogLevel: tt.value},
+			}}
+			got, err := objectLogLevel(db)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("objectLogLevel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("objectLogLevel() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithObjectLogLevel(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{Verbosity: 0})
+
+	for _, level := range []string{"", "2"} {
+		db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationLogLevel: level},
+		}}
+		ctx := withObjectLogLevel(log.IntoContext(context.Background(), logger), db)
+		l := log.FromContext(ctx).WithValues("database", "app")
+		l.V(2).Info("details")
+		l.V(3).Info("too verbose")
+	}
+
+	if len(lines) != 1 || !strings.Contains(lines[0], `"details"`) || !strings.Contains(lines[0], `"v"=2`) {
+		t.Errorf("logged %q, want only the V(2) message of the annotated Database", lines)
+	}
+}
+
+func TestVerboseSinkKeepsLevels(t *testing.T) {
+	var lines []string
+	sink := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{Verbosity: 1}).GetSink()
+	logger := logr.New(newVerboseSink(sink, 2))
+
+	logger.V(1).Info("enabled by the operator")
+	if len(lines) != 1 || strings.Contains(lines[0], `"v"=`) {
+		t.Errorf("logged %q, want the message without a v field", lines)
+	}
+}
+
+func TestRecordEventVerbosity(t *testing.T) {
+	tests := []struct {
+		emitEvents string
+		want       []string
+	}{
+		{
+			emitEvents: EmitEventsDefault,
+			want:       []string{"Normal SecretRotated Secret updated", "Warning PermissionError access denied"},
+		},
+		{
+			emitEvents: EmitEventsNone,
+			want:       []string{"Warning PermissionError access denied"},
+		},
+		{
+			emitEvents: EmitEventsAll,
+			want: []string{"Normal SecretRotated Secret updated", "Warning PermissionError access denied",
+				"Normal SecretRotated Secret updated", "Warning PermissionError access denied"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.emitEvents, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &DatabaseReconciler{Recorder: recorder, EventDedupWindow: time.Minute}
+			db := &databasev1alpha1.Database{ObjectMeta: metav1.ObjectMeta{
+				Name: "app", Namespace: "default", UID: "uid-1",
+				Annotations: map[string]string{AnnotationEmitEvents: tt.emitEvents},
+			}}
+
+			for i := 0; i < 2; i++ {
+				r.recordNormal(db, EventReasonSecretRotated, "Secret updated")
+				r.recordEvent(db, corev1.EventTypeWarning, "PermissionError", "access denied")
+			}
+
+			var got []string
+			for len(recorder.Events) > 0 {
+				got = append(got, <-recorder.Events)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("events = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAccountOptionsNotSupported is returned when the server cannot apply the account options
+var ErrAccountOptionsNotSupported = errors.New("account options are not supported by this database server")
+
+// AccountOptions are settings of a user account besides its password
+// Nil fields leave the setting on the server unchanged
+type AccountOptions struct {
+	// Locked locks or unlocks the account
+	Locked *bool
+
+	// PasswordExpireIntervalDays expires the password after this number of days, 0 never expires it
+	PasswordExpireIntervalDays *int32
+}
+
+// IsZero reports whether no option is set
+func (o AccountOptions) IsZero() bool {
+	return o.Locked == nil && o.PasswordExpireIntervalDays == nil
+}
+
+// mariaDBAccountStatement returns the ALTER USER statement applying the account options on MariaDB
+func mariaDBAccountStatement(username string, opts AccountOptions) string {
+	clauses := []string{fmt.Sprintf("ALTER USER %s@'%%'", quoteMySQLIdentifier(username))}
+	if days := opts.PasswordExpireIntervalDays; days != nil {
+		if *days == 0 {
+			clauses = append(clauses, "PASSWORD EXPIRE NEVER")
+		} else {
+			clauses = append(clauses, fmt.Sprintf("PASSWORD EXPIRE INTERVAL %d DAY", *days))
+		}
+	}
+	if locked := opts.Locked; locked != nil {
+		if *locked {
+			clauses = append(clauses, "ACCOUNT LOCK")
+		} else {
+			clauses = append(clauses, "ACCOUNT UNLOCK")
+		}
+	}
+	return strings.Join(clauses, " ")
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import "testing"
+
+func TestMariaDBAccountStatement(t *testing.T) {
+	locked, unlocked := true, false
+	days, never := int32(90), int32(0)
+
+	tests := []struct {
+		name string
+		opts AccountOptions
+		want string
+	}{
+		{name: "lock", opts: AccountOptions{Locked: &locked}, want: "ALTER USER `app`@'%' ACCOUNT LOCK"},
+		{name: "unlock", opts: AccountOptions{Locked: &unlocked}, want: "ALTER USER `app`@'%' ACCOUNT UNLOCK"},
+		{name: "expire interval", opts: AccountOptions{PasswordExpireIntervalDays: &days}, want: "ALTER USER `app`@'%' PASSWORD EXPIRE INTERVAL 90 DAY"},
+		{
+			name: "never expire and unlock",
+			opts: AccountOptions{Locked: &unlocked, PasswordExpireIntervalDays: &never},
+			want: "ALTER USER `app`@'%' PASSWORD EXPIRE NEVER ACCOUNT UNLOCK",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mariaDBAccountStatement("app", tt.opts); got != tt.want {
+				t.Errorf("mariaDBAccountStatement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
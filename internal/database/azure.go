@@ -0,0 +1,167 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrEntraNotSupported is returned when an engine cannot map users to Microsoft Entra principals
+var ErrEntraNotSupported = errors.New("engine does not support Microsoft Entra users")
+
+// azureDatabaseScope is the scope of access tokens for Azure Database for PostgreSQL and MySQL
+const azureDatabaseScope = "https://ossrdbms-aad.database.windows.net/.default"
+
+// Microsoft Entra principal types
+const (
+	EntraPrincipalUser             = "user"
+	EntraPrincipalGroup            = "group"
+	EntraPrincipalServicePrincipal = "servicePrincipal"
+)
+
+// EntraPrincipal is the Microsoft Entra principal a database user is mapped to
+type EntraPrincipal struct {
+	// ID is the object ID of the principal; on MySQL the client ID of a service principal
+	ID string
+	// Type is user, group or servicePrincipal
+	Type string
+}
+
+var (
+	azureCredentialMu sync.Mutex
+	azureCredential   azcore.TokenCredential
+)
+
+// sharedAzureCredential returns the Azure credential of the process, creating it on first use
+// The default credential chain covers AKS workload identity, managed identities and the
+// AZURE_CLIENT_ID, AZURE_TENANT_ID and AZURE_CLIENT_SECRET environment variables.
+func sharedAzureCredential() (azcore.TokenCredential, error) {
+	azureCredentialMu.Lock()
+	defer azureCredentialMu.Unlock()
+
+	if azureCredential == nil {
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure credential: %w", err)
+		}
+		azureCredential = cred
+	}
+	return azureCredential, nil
+}
+
+// entraAccessToken returns an access token for Azure Database of the operator's identity
+func entraAccessToken(ctx context.Context) (string, error) {
+	cred, err := sharedAzureCredential()
+	if err != nil {
+		return "", err
+	}
+	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{azureDatabaseScope}})
+	if err != nil {
+		return "", fmt.Errorf("failed to get Microsoft Entra access token: %w", err)
+	}
+	return token.Token, nil
+}
+
+// NewAzureEntraClient creates a client for an Azure Database flexible server that logs the user of the
+// connection string in with a Microsoft Entra access token instead of its password
+// The token is requested when the client is created, it outlives the short-lived clients of the operator.
+func NewAzureEntraClient(ctx context.Context, engine, connectionString string, pool PoolConfig) (Client, error) {
+	switch strings.ToLower(engine) {
+	case "postgres", "postgresql":
+		token, err := entraAccessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		connectionString, err = withPostgresPassword(connectionString, token)
+		if err != nil {
+			return nil, err
+		}
+		return NewPostgresClient(connectionString, pool)
+	case "mysql":
+		token, err := entraAccessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dsn, err := entraMySQLDSN(connectionString, token)
+		if err != nil {
+			return nil, err
+		}
+		return NewMySQLClient(dsn, pool)
+	default:
+		return nil, fmt.Errorf("engine %s does not support Microsoft Entra authentication", engine)
+	}
+}
+
+// withPostgresPassword returns the connection string with its password replaced
+func withPostgresPassword(connectionString, password string) (string, error) {
+	if !strings.HasPrefix(connectionString, "postgres://") && !strings.HasPrefix(connectionString, "postgresql://") {
+		return connectionString + " password=" + quoteDSNValue(password), nil
+	}
+	u, err := parseURL(connectionString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	if u.User == nil {
+		return "", fmt.Errorf("connection string has no user")
+	}
+	u.User = url.UserPassword(u.User.Username(), password)
+	return u.String(), nil
+}
+
+// quoteDSNValue quotes a value of a key=value PostgreSQL connection string
+func quoteDSNValue(value string) string {
+	escaped := strings.ReplaceAll(value, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, "'", `\'`)
+	return "'" + escaped + "'"
+}
+
+// entraMySQLDSN returns the DSN of a MySQL connection string with the access token as password
+// MySQL receives the token with the cleartext plugin, so the connection must use TLS.
+func entraMySQLDSN(connectionString, token string) (string, error) {
+	dsn := connectionString
+	if strings.HasPrefix(connectionString, "mysql://") {
+		var err error
+		if dsn, err = convertMySQLURLToDSN(connectionString); err != nil {
+			return "", err
+		}
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	if cfg.TLS == nil {
+		return "", fmt.Errorf("TLS is required for Microsoft Entra authentication, add tls=true to the connection string")
+	}
+	cfg.Passwd = token
+	cfg.AllowCleartextPasswords = true
+	return cfg.FormatDSN(), nil
+}
+
+// entraPostgresPrincipalType returns the principal type of pgaadauth_create_principal_with_oid
+func entraPostgresPrincipalType(principalType string) (string, error) {
+	switch principalType {
+	case EntraPrincipalUser:
+		return "user", nil
+	case EntraPrincipalGroup:
+		return "group", nil
+	case EntraPrincipalServicePrincipal:
+		return "service", nil
+	default:
+		return "", fmt.Errorf("unsupported Microsoft Entra principal type: %s", principalType)
+	}
+}
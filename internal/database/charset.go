@@ -0,0 +1,38 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Character set and collation of the MySQL databases the operator creates
+const (
+	DefaultCharset   = "utf8mb4"
+	DefaultCollation = "utf8mb4_unicode_ci"
+)
+
+// ErrCharsetNotSupported is returned when an engine has no per-database character set that can be changed
+var ErrCharsetNotSupported = errors.New("engine does not support changing the database character set")
+
+// charsetNamePattern matches MySQL character set and collation names
+// The names are written into ALTER DATABASE statements, so anything else is rejected
+var charsetNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ValidateCharset checks the character set and the optional collation
+func ValidateCharset(charset, collation string) error {
+	if !charsetNamePattern.MatchString(charset) {
+		return fmt.Errorf("invalid character set %q: must be a character set name such as utf8mb4", charset)
+	}
+	if collation != "" && !charsetNamePattern.MatchString(collation) {
+		return fmt.Errorf("invalid collation %q: must be a collation name such as utf8mb4_unicode_ci", collation)
+	}
+	return nil
+}
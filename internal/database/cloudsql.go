@@ -0,0 +1,137 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/cloudsqlconn"
+)
+
+// CloudSQLOptions selects the Google Cloud SQL instance a client connects to through the Cloud SQL Go connector
+type CloudSQLOptions struct {
+	// InstanceConnectionName is the connection name of the instance, project:region:instance
+	InstanceConnectionName string
+	// IPType is the address of the instance the connector dials: public (default), private or psc
+	IPType string
+}
+
+var (
+	cloudSQLDialerMu sync.Mutex
+	cloudSQLDialer   *cloudsqlconn.Dialer
+)
+
+// sharedCloudSQLDialer returns the Cloud SQL dialer of the process, creating it on first use
+// The dialer authenticates with Application Default Credentials and logs users in with IAM
+// database authentication. Certificates are refreshed when a connection is dialed, as the operator
+// connects too rarely to keep them refreshed in the background.
+func sharedCloudSQLDialer() (*cloudsqlconn.Dialer, error) {
+	cloudSQLDialerMu.Lock()
+	defer cloudSQLDialerMu.Unlock()
+
+	if cloudSQLDialer == nil {
+		d, err := cloudsqlconn.NewDialer(context.Background(), cloudsqlconn.WithIAMAuthN(), cloudsqlconn.WithLazyRefresh())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud SQL dialer: %w", err)
+		}
+		cloudSQLDialer = d
+	}
+	return cloudSQLDialer, nil
+}
+
+// dialOptions returns the connector options for the IP type
+func (o CloudSQLOptions) dialOptions() ([]cloudsqlconn.DialOption, error) {
+	switch strings.ToLower(o.IPType) {
+	case "", "public":
+		return []cloudsqlconn.DialOption{cloudsqlconn.WithPublicIP()}, nil
+	case "private":
+		return []cloudsqlconn.DialOption{cloudsqlconn.WithPrivateIP()}, nil
+	case "psc":
+		return []cloudsqlconn.DialOption{cloudsqlconn.WithPSC()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Cloud SQL IP type: %s", o.IPType)
+	}
+}
+
+// dial connects to the instance, the network and address chosen by the driver are ignored
+func (o CloudSQLOptions) dial(ctx context.Context, _, _ string) (net.Conn, error) {
+	opts, err := o.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	d, err := sharedCloudSQLDialer()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := d.Dial(ctx, o.InstanceConnectionName, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial Cloud SQL instance %s: %w", o.InstanceConnectionName, err)
+	}
+	return conn, nil
+}
+
+// cloudSQLPQDialer dials Cloud SQL for the PostgreSQL driver
+type cloudSQLPQDialer struct {
+	opts CloudSQLOptions
+}
+
+// Dial implements pq.Dialer
+func (d cloudSQLPQDialer) Dial(network, address string) (net.Conn, error) {
+	return d.opts.dial(context.Background(), network, address)
+}
+
+// DialTimeout implements pq.Dialer
+func (d cloudSQLPQDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.opts.dial(ctx, network, address)
+}
+
+// DialContext implements pq.DialerContext
+func (d cloudSQLPQDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d.opts.dial(ctx, network, address)
+}
+
+// NewCloudSQLClient creates a client connecting to a Cloud SQL instance through the Cloud SQL Go connector
+// The user of the connection string is logged in with IAM database authentication, its password is ignored.
+// The host of the connection string is not dialed.
+func NewCloudSQLClient(engine, connectionString string, opts CloudSQLOptions, pool PoolConfig) (Client, error) {
+	if _, err := opts.dialOptions(); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(engine) {
+	case "postgres", "postgresql":
+		return newPostgresClient(connectionString, pool, cloudSQLPQDialer{opts: opts})
+	case "mysql":
+		return newMySQLClient(connectionString, pool, opts.dial)
+	default:
+		return nil, fmt.Errorf("engine %s is not supported on Cloud SQL", engine)
+	}
+}
+
+// disablePostgresSSL returns the connection string with sslmode=disable
+// The connector encrypts the connection itself, the driver must not negotiate TLS inside it
+func disablePostgresSSL(connectionString string) (string, error) {
+	if !strings.HasPrefix(connectionString, "postgres://") && !strings.HasPrefix(connectionString, "postgresql://") {
+		return connectionString + " sslmode=disable", nil
+	}
+	u, err := parseURL(connectionString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	q := u.Query()
+	q.Set("sslmode", "disable")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import "testing"
+
+func TestDisablePostgresSSL(t *testing.T) {
+	tests := []struct {
+		name             string
+		connectionString string
+		want             string
+	}{
+		{
+			name:             "url without sslmode",
+			connectionString: "postgres://admin%40project.iam@localhost:5432/postgres",
+			want:             "postgres://admin%40project.iam@localhost:5432/postgres?sslmode=disable",
+		},
+		{
+			name:             "url with sslmode",
+			connectionString: "postgresql://admin@localhost/postgres?sslmode=require&connect_timeout=5",
+			want:             "postgresql://admin@localhost/postgres?connect_timeout=5&sslmode=disable",
+		},
+		{
+			name:             "key value",
+			connectionString: "host=localhost user=admin sslmode=require",
+			want:             "host=localhost user=admin sslmode=require sslmode=disable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := disablePostgresSSL(tt.connectionString)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("disablePostgresSSL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCloudSQLClientUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine string
+		opts   CloudSQLOptions
+	}{
+		{name: "engine", engine: "mariadb", opts: CloudSQLOptions{InstanceConnectionName: "project:region:instance"}},
+		{name: "ip type", engine: "postgres", opts: CloudSQLOptions{InstanceConnectionName: "project:region:instance", IPType: "ipv6"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCloudSQLClient(tt.engine, "postgres://admin@localhost/postgres", tt.opts, DefaultPoolConfig()); err == nil {
+				t.Error("NewCloudSQLClient() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// OperatorApplicationName identifies the sessions of the operator on database servers, as
+// application_name on PostgreSQL and program_name connection attribute on MySQL
+const OperatorApplicationName = "database-user-operator"
+
+// reservedConnectionParams select the server, database and user of a connection, they are taken from
+// the connection string and the generated credentials only
+var reservedConnectionParams = map[string]bool{
+	"user":     true,
+	"password": true,
+	"host":     true,
+	"hostaddr": true,
+	"port":     true,
+	"dbname":   true,
+	"passfile": true,
+	"service":  true,
+}
+
+// ValidateConnectionParams checks that the connection parameters are supported by an engine
+// Only the URLs of PostgreSQL and MySQL take parameters of the driver or the server.
+func ValidateConnectionParams(engine string, params map[string]string) error {
+	if len(params) == 0 {
+		return nil
+	}
+	switch EngineFamily(engine) {
+	case "postgres", "mysql":
+	default:
+		return fmt.Errorf("connection parameters are not supported for engine %s", engine)
+	}
+	for _, key := range sortedParamKeys(params) {
+		if key == "" || strings.ContainsAny(key, "&=?# ") {
+			return fmt.Errorf("invalid connection parameter name %q", key)
+		}
+		if reservedConnectionParams[strings.ToLower(key)] {
+			return fmt.Errorf("connection parameter %s cannot be set, it is part of the connection string", key)
+		}
+	}
+	return nil
+}
+
+// WithConnectionParams returns a connection string of an engine with the parameters set, replacing
+// parameters of the same name
+func WithConnectionParams(engine, connectionString string, params map[string]string) (string, error) {
+	if len(params) == 0 {
+		return connectionString, nil
+	}
+	return setConnectionParams(engine, connectionString, params, true)
+}
+
+// WithOperatorApplicationName returns a connection string of an engine that identifies the sessions
+// as OperatorApplicationName, unless the connection string names them itself
+func WithOperatorApplicationName(engine, connectionString string) (string, error) {
+	switch EngineFamily(engine) {
+	case "postgres":
+		return setConnectionParams(engine, connectionString, map[string]string{"application_name": OperatorApplicationName}, false)
+	case "mysql":
+		return setConnectionParams(engine, connectionString,
+			map[string]string{"connectionAttributes": "program_name:" + OperatorApplicationName}, false)
+	default:
+		return connectionString, nil
+	}
+}
+
+// setConnectionParams sets the parameters of a PostgreSQL URL or a MySQL URL or DSN, parameters that
+// are already set are only replaced with override
+func setConnectionParams(engine, connectionString string, params map[string]string, override bool) (string, error) {
+	switch EngineFamily(engine) {
+	case "postgres":
+		return setURLParams(connectionString, params, override)
+	case "mysql":
+		if strings.HasPrefix(connectionString, "mysql://") {
+			return setURLParams(connectionString, params, override)
+		}
+		slash := strings.LastIndex(connectionString, ")/")
+		if slash < 0 {
+			return "", fmt.Errorf("unsupported MySQL connection string format")
+		}
+		base, rawParams, _ := strings.Cut(connectionString[slash:], "?")
+		query, err := url.ParseQuery(rawParams)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse the parameters of the connection string: %w", err)
+		}
+		setParams(query, params, override)
+		return connectionString[:slash] + base + "?" + query.Encode(), nil
+	default:
+		return "", fmt.Errorf("connection parameters are not supported for engine %s", engine)
+	}
+}
+
+// setURLParams sets the parameters of a connection URL
+func setURLParams(connectionString string, params map[string]string, override bool) (string, error) {
+	u, err := parseURL(connectionString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse connection URL: %w", err)
+	}
+	query := u.Query()
+	setParams(query, params, override)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func setParams(query url.Values, params map[string]string, override bool) {
+	for key, value := range params {
+		if override || query.Get(key) == "" {
+			query.Set(key, value)
+		}
+	}
+}
+
+// URLWithParams returns a connection URL with the parameters appended in the order of their names
+func URLWithParams(connectionURL string, params map[string]string) string {
+	if len(params) == 0 {
+		return connectionURL
+	}
+	query := url.Values{}
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	separator := "?"
+	if strings.Contains(connectionURL, "?") {
+		separator = "&"
+	}
+	return connectionURL + separator + query.Encode()
+}
+
+func sortedParamKeys(params map[string]string) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import "testing"
+
+func TestValidateConnectionParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  string
+		params  map[string]string
+		wantErr bool
+	}{
+		{name: "no params on cassandra", engine: "cassandra"},
+		{name: "postgres", engine: "postgres", params: map[string]string{"connect_timeout": "10", "options": "-c search_path=app"}},
+		{name: "mariadb", engine: "mariadb", params: map[string]string{"timeout": "5s"}},
+		{name: "snowflake", engine: "snowflake", params: map[string]string{"warehouse": "wh"}, wantErr: true},
+		{name: "password", engine: "postgres", params: map[string]string{"Password": "x"}, wantErr: true},
+		{name: "invalid name", engine: "mysql", params: map[string]string{"a=b": "c"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateConnectionParams(tt.engine, tt.params); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConnectionParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithConnectionParams(t *testing.T) {
+	tests := []struct {
+		name             string
+		engine           string
+		connectionString string
+		params           map[string]string
+		want             string
+	}{
+		{
+			name:             "no params",
+			engine:           "postgres",
+			connectionString: "postgres://admin@db/postgres?sslmode=disable",
+			want:             "postgres://admin@db/postgres?sslmode=disable",
+		},
+		{
+			name:             "postgres",
+			engine:           "postgres",
+			connectionString: "postgres://admin:secret@db/postgres?connect_timeout=30&sslmode=require",
+			params:           map[string]string{"connect_timeout": "5", "options": "-c search_path=app"},
+			want:             "postgres://admin:secret@db/postgres?connect_timeout=5&options=-c+search_path%3Dapp&sslmode=require",
+		},
+		{
+			name:             "mysql url",
+			engine:           "mysql",
+			connectionString: "mysql://admin:secret@db:3306/mysql",
+			params:           map[string]string{"timeout": "5s"},
+			want:             "mysql://admin:secret@db:3306/mysql?timeout=5s",
+		},
+		{
+			name:             "mysql dsn",
+			engine:           "mariadb",
+			connectionString: "admin:p?ss@tcp(db:3306)/mysql?tls=true",
+			params:           map[string]string{"timeout": "5s"},
+			want:             "admin:p?ss@tcp(db:3306)/mysql?timeout=5s&tls=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithConnectionParams(tt.engine, tt.connectionString, tt.params)
+			if err != nil {
+				t.Fatalf("WithConnectionParams() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("WithConnectionParams() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithOperatorApplicationName(t *testing.T) {
+	tests := []struct {
+		name             string
+		engine           string
+		connectionString string
+		want             string
+	}{
+		{
+			name:             "postgres",
+			engine:           "postgres",
+			connectionString: "postgres://admin@db/postgres",
+			want:             "postgres://admin@db/postgres?application_name=database-user-operator",
+		},
+		{
+			name:             "postgres with an application name",
+			engine:           "yugabyte",
+			connectionString: "postgresql://admin@db/yugabyte?application_name=platform",
+			want:             "postgresql://admin@db/yugabyte?application_name=platform",
+		},
+		{
+			name:             "mysql dsn",
+			engine:           "mysql",
+			connectionString: "admin:secret@tcp(db:3306)/mysql",
+			want:             "admin:secret@tcp(db:3306)/mysql?connectionAttributes=program_name%3Adatabase-user-operator",
+		},
+		{
+			name:             "cassandra",
+			engine:           "cassandra",
+			connectionString: "cassandra://admin@db:9042/system",
+			want:             "cassandra://admin@db:9042/system",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithOperatorApplicationName(tt.engine, tt.connectionString)
+			if err != nil {
+				t.Fatalf("WithOperatorApplicationName() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("WithOperatorApplicationName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLWithParams(t *testing.T) {
+	got := URLWithParams("postgresql://app:secret@db:5432/app", map[string]string{"sslmode": "require", "connect_timeout": "5"})
+	if want := "postgresql://app:secret@db:5432/app?connect_timeout=5&sslmode=require"; got != want {
+		t.Errorf("URLWithParams() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/go-sql-driver/mysql"
+	"github.com/gocql/gocql"
+	"github.com/lib/pq"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// postgresAuthErrorCodes are the SQLSTATE codes for failed authentication or missing privileges
+var postgresAuthErrorCodes = map[pq.ErrorCode]bool{
+	"28000": true, // invalid_authorization_specification
+	"28P01": true, // invalid_password
+	"42501": true, // insufficient_privilege
+}
+
+// mysqlAuthErrorNumbers are the MySQL/MariaDB error numbers for failed authentication or missing privileges
+var mysqlAuthErrorNumbers = map[uint16]bool{
+	1044: true, // ER_DBACCESS_DENIED_ERROR
+	1045: true, // ER_ACCESS_DENIED_ERROR
+	1142: true, // ER_TABLEACCESS_DENIED_ERROR
+	1227: true, // ER_SPECIFIC_ACCESS_DENIED_ERROR
+}
+
+// cassandraAuthErrorCodes are the Cassandra error codes for failed authentication or missing permissions
+var cassandraAuthErrorCodes = map[int]bool{
+	gocql.ErrCodeCredentials:  true,
+	gocql.ErrCodeUnauthorized: true,
+}
+
+// snowflakeAuthErrorNumbers are the Snowflake error numbers for failed authentication or missing privileges
+var snowflakeAuthErrorNumbers = map[int]bool{
+	3001:   true, // insufficient privileges
+	390100: true, // incorrect username or password
+	390144: true, // invalid JWT token of key-pair authentication
+}
+
+// mysqlErrNonexistingGrant is ER_NONEXISTING_GRANT, returned when revoking a privilege that was not granted
+const mysqlErrNonexistingGrant uint16 = 1141
+
+// mysqlNonexistingObjectGrantNumbers are the errors returned when revoking a privilege on a table or a
+// stored routine that was not granted
+var mysqlNonexistingObjectGrantNumbers = map[uint16]bool{
+	1141: true, // ER_NONEXISTING_GRANT
+	1147: true, // ER_NONEXISTING_TABLE_GRANT
+	1403: true, // ER_NONEXISTING_PROC_GRANT
+}
+
+// postgresErrInsufficientPrivilege is the SQLSTATE of insufficient_privilege
+const postgresErrInsufficientPrivilege pq.ErrorCode = "42501"
+
+// postgresErrObjectInUse is the SQLSTATE of object_in_use, e.g. of a template database with other sessions
+const postgresErrObjectInUse pq.ErrorCode = "55006"
+
+// isPostgresError reports whether err is a PostgreSQL server error with the given SQLSTATE
+func isPostgresError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
+
+// isMySQLError reports whether err is a MySQL/MariaDB server error with the given number
+func isMySQLError(err error, number uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == number
+}
+
+// isMySQLNonexistingObjectGrant reports whether err is returned for revoking a privilege on a table or a
+// stored routine that was not granted
+func isMySQLNonexistingObjectGrant(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlNonexistingObjectGrantNumbers[mysqlErr.Number]
+}
+
+// IsAuthError reports whether err is a database server error caused by invalid admin
+// credentials or missing privileges, which requires manual intervention to resolve
+func IsAuthError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return postgresAuthErrorCodes[pqErr.Code]
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlAuthErrorNumbers[mysqlErr.Number]
+	}
+
+	var snowflakeErr *gosnowflake.SnowflakeError
+	if errors.As(err, &snowflakeErr) {
+		return snowflakeAuthErrorNumbers[snowflakeErr.Number]
+	}
+
+	var cassandraErr gocql.RequestError
+	if errors.As(err, &cassandraErr) {
+		return cassandraAuthErrorCodes[cassandraErr.Code()]
+	}
+
+	// Microsoft Entra rejected the operator's identity, e.g. a missing federated credential
+	var entraErr *azidentity.AuthenticationFailedError
+	if errors.As(err, &entraErr) {
+		return true
+	}
+
+	return false
+}
+
+// Kinds of failures to reach a database server, returned by ConnectionFailure
+const (
+	// ConnectionFailureDNS means the host of the server could not be resolved
+	ConnectionFailureDNS = "DNSResolutionFailed"
+	// ConnectionFailureRefused means nothing accepts connections on the port of the server
+	ConnectionFailureRefused = "ConnectionRefused"
+	// ConnectionFailureUnreachable means the connection timed out or the network has no route to the server
+	ConnectionFailureUnreachable = "HostUnreachable"
+	// ConnectionFailureTLS means the TLS handshake with the server failed, e.g. on an untrusted certificate
+	ConnectionFailureTLS = "TLSHandshakeFailed"
+)
+
+// connectionError is a failed first connection to the server at address
+type connectionError struct {
+	address string
+	err     error
+}
+
+func (e *connectionError) Error() string { return e.err.Error() }
+func (e *connectionError) Unwrap() error { return e.err }
+
+// newConnectionError marks err as a failure to connect to the server of connInfo
+func newConnectionError(connInfo *ConnectionInfo, err error) error {
+	return &connectionError{address: net.JoinHostPort(connInfo.Host, connInfo.Port), err: err}
+}
+
+// ConnectionFailure returns the kind of a failure to reach the database server and the host:port
+// of the server, or empty strings when err is not one, e.g. rejected credentials
+func ConnectionFailure(err error) (kind, address string) {
+	var connErr *connectionError
+	if !errors.As(err, &connErr) {
+		return "", ""
+	}
+
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		kind = ConnectionFailureDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		kind = ConnectionFailureRefused
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr),
+		errors.Is(err, pq.ErrSSLNotSupported):
+		kind = ConnectionFailureTLS
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH),
+		errors.As(err, &netErr) && netErr.Timeout():
+		kind = ConnectionFailureUnreachable
+	default:
+		return "", ""
+	}
+	return kind, connErr.address
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gocql/gocql"
+	"github.com/lib/pq"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// cassandraRequestError is a gocql.RequestError with the given error code
+type cassandraRequestError int
+
+func (e cassandraRequestError) Code() int       { return int(e) }
+func (e cassandraRequestError) Message() string { return "request error" }
+func (e cassandraRequestError) Error() string   { return fmt.Sprintf("request error %#x", int(e)) }
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "plain error", err: errors.New("connection refused"), want: false},
+		{name: "postgres invalid password", err: &pq.Error{Code: "28P01"}, want: true},
+		{name: "postgres insufficient privilege wrapped", err: fmt.Errorf("failed to create user: %w", &pq.Error{Code: "42501"}), want: true},
+		{name: "postgres duplicate object", err: &pq.Error{Code: "42710"}, want: false},
+		{name: "mysql access denied", err: &mysql.MySQLError{Number: 1045}, want: true},
+		{name: "mysql access denied wrapped", err: fmt.Errorf("failed to ping database: %w", &mysql.MySQLError{Number: 1227}), want: true},
+		{name: "mysql unknown database", err: &mysql.MySQLError{Number: 1049}, want: false},
+		{name: "snowflake incorrect password", err: fmt.Errorf("failed to ping database: %w", &gosnowflake.SnowflakeError{Number: 390100}), want: true},
+		{name: "snowflake object does not exist", err: &gosnowflake.SnowflakeError{Number: 2003}, want: false},
+		{name: "cassandra bad credentials", err: fmt.Errorf("failed to connect to cassandra: %w", cassandraRequestError(gocql.ErrCodeCredentials)), want: true},
+		{name: "cassandra unauthorized", err: cassandraRequestError(gocql.ErrCodeUnauthorized), want: true},
+		{name: "cassandra invalid query", err: cassandraRequestError(gocql.ErrCodeInvalid), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAuthError(tt.err); got != tt.want {
+				t.Errorf("IsAuthError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectionFailure(t *testing.T) {
+	connInfo := &ConnectionInfo{Host: "db.example.com", Port: "5432"}
+	dial := func(err error) error {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: err}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantKind string
+	}{
+		{
+			name:     "DNS",
+			err:      dial(&net.DNSError{Err: "no such host", Name: "db.example.com", IsNotFound: true}),
+			wantKind: ConnectionFailureDNS,
+		},
+		{
+			name:     "refused",
+			err:      dial(&os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}),
+			wantKind: ConnectionFailureRefused,
+		},
+		{
+			name:     "timeout",
+			err:      dial(os.ErrDeadlineExceeded),
+			wantKind: ConnectionFailureUnreachable,
+		},
+		{
+			name:     "no route",
+			err:      dial(&os.SyscallError{Syscall: "connect", Err: syscall.EHOSTUNREACH}),
+			wantKind: ConnectionFailureUnreachable,
+		},
+		{
+			name:     "untrusted certificate",
+			err:      &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}},
+			wantKind: ConnectionFailureTLS,
+		},
+		{
+			name:     "SSL not enabled",
+			err:      pq.ErrSSLNotSupported,
+			wantKind: ConnectionFailureTLS,
+		},
+		{
+			name: "authentication",
+			err:  &pq.Error{Code: "28P01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newConnectionError(connInfo, fmt.Errorf("failed to ping database: %w", tt.err))
+			kind, address := ConnectionFailure(err)
+			if kind != tt.wantKind {
+				t.Errorf("ConnectionFailure() kind = %q, want %q", kind, tt.wantKind)
+			}
+			if tt.wantKind != "" && address != "db.example.com:5432" {
+				t.Errorf("ConnectionFailure() address = %q, want db.example.com:5432", address)
+			}
+		})
+	}
+
+	// Only the errors of connecting to the server are classified, not those of later statements
+	if kind, _ := ConnectionFailure(dial(&os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED})); kind != "" {
+		t.Errorf("ConnectionFailure() of an unmarked error = %q, want empty", kind)
+	}
+}
@@ -8,21 +8,141 @@ See LICENSE file in the project root for full license information.
 package database
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
-// NewClient creates a new database client based on the engine type
+// NewClient creates a new database client based on the engine type with the default pool settings
 func NewClient(engine, connectionString string) (Client, error) {
+	return NewClientWithPool(engine, connectionString, DefaultPoolConfig())
+}
+
+// NewClientWithPool creates a new database client whose connections are bounded by pool
+func NewClientWithPool(engine, connectionString string, pool PoolConfig) (Client, error) {
 	// Normalize engine name
 	normalizedEngine := strings.ToLower(engine)
 
 	switch normalizedEngine {
 	case "postgres", "postgresql":
-		return NewPostgresClient(connectionString)
+		return NewPostgresClient(connectionString, pool)
+	case "yugabyte":
+		return NewYugabyteClient(connectionString, pool)
+	case "mysql", "mariadb":
+		return NewMySQLClient(connectionString, pool)
+	case "cassandra":
+		return NewCassandraClient(connectionString, pool)
+	case "snowflake":
+		return NewSnowflakeClient(connectionString, pool)
+	default:
+		return nil, fmt.Errorf("unsupported database engine: %s", engine)
+	}
+}
+
+// EngineFamily returns the canonical engine family for an engine name
+// postgres, postgresql and yugabyte map to "postgres"; mysql and mariadb map to "mysql";
+// other engines such as cassandra and snowflake are their own family
+func EngineFamily(engine string) string {
+	switch strings.ToLower(engine) {
+	case "postgres", "postgresql", "yugabyte":
+		return "postgres"
 	case "mysql", "mariadb":
-		return NewMySQLClient(connectionString)
+		return "mysql"
+	default:
+		return strings.ToLower(engine)
+	}
+}
+
+// ParseConnectionInfo parses a connection string for the given engine without connecting
+func ParseConnectionInfo(engine, connectionString string) (*ConnectionInfo, error) {
+	switch EngineFamily(engine) {
+	case "postgres":
+		return ParseConnectionString(connectionString)
+	case "mysql":
+		return ParseMySQLConnectionString(connectionString)
+	case "cassandra":
+		return ParseCassandraConnectionString(connectionString)
+	case "snowflake":
+		return ParseSnowflakeConnectionString(connectionString)
 	default:
 		return nil, fmt.Errorf("unsupported database engine: %s", engine)
 	}
 }
+
+// ConnectionStringEngine returns the engine family of the scheme of a connection string, e.g. postgres
+// for postgresql://, or an empty string when the scheme is not one of a supported engine
+func ConnectionStringEngine(connectionString string) string {
+	scheme, _, found := strings.Cut(connectionString, "://")
+	if !found {
+		// MySQL DSN format: user:pass@tcp(host:port)/database
+		if strings.Contains(connectionString, "@tcp(") {
+			return "mysql"
+		}
+		return ""
+	}
+	switch family := EngineFamily(scheme); family {
+	case "postgres", "mysql", "cassandra", "snowflake":
+		return family
+	default:
+		return ""
+	}
+}
+
+// CheckConnectionStringEngine returns an error when the scheme of a connection string belongs to
+// another engine family than engine, e.g. a postgres:// connection string of a mysql Database, which
+// would otherwise fail with an error of the driver. Unknown schemes are left to the parser of the engine.
+func CheckConnectionStringEngine(engine, connectionString string) error {
+	found := ConnectionStringEngine(connectionString)
+	if found == "" || found == EngineFamily(engine) {
+		return nil
+	}
+	return fmt.Errorf("the admin connection string is a %s connection string but the engine is %s, check spec.engine", found, engine)
+}
+
+// BuildConnectionString returns the connection string of an engine from its parts
+// A port of 0 selects the default port of the engine. sslMode is only supported by the engines
+// whose connection string has an sslmode parameter.
+func BuildConnectionString(engine, host string, port int, username, password, dbName, sslMode string) (string, error) {
+	u := &url.URL{
+		User: url.UserPassword(username, password),
+		Host: host,
+		Path: "/" + dbName,
+	}
+	if port != 0 {
+		u.Host = fmt.Sprintf("%s:%d", host, port)
+	}
+
+	switch family := EngineFamily(engine); family {
+	case "postgres", "cassandra":
+		u.Scheme = family
+		if sslMode != "" {
+			u.RawQuery = url.Values{"sslmode": {sslMode}}.Encode()
+		}
+	case "mysql", "snowflake":
+		u.Scheme = family
+		if sslMode != "" {
+			return "", fmt.Errorf("sslmode is not supported for engine %s", engine)
+		}
+	default:
+		return "", fmt.Errorf("unsupported database engine: %s", engine)
+	}
+	return u.String(), nil
+}
+
+// SanitizeConnectionString removes the whitespace around a connection string read from a secret,
+// such as the trailing newline of a secret created from a file
+func SanitizeConnectionString(connectionString string) string {
+	return strings.TrimSpace(connectionString)
+}
+
+// parseURL parses a connection URL
+// The errors of url.Parse quote the URL and its password, so only the reason is returned.
+func parseURL(connectionString string) (*url.URL, error) {
+	u, err := url.Parse(connectionString)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return nil, urlErr.Err
+	}
+	return u, err
+}
@@ -7,7 +7,28 @@ See LICENSE file in the project root for full license information.
 
 package database
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ManagedMarker is the comment the operator attaches to users and databases it creates
+const ManagedMarker = "Managed by database-user-operator"
+
+// ErrManagedMarkerNotSupported is returned when an engine cannot mark objects as operator-managed
+var ErrManagedMarkerNotSupported = errors.New("engine does not support the managed marker")
+
+// ErrConnectionLimitNotSupported is returned when an engine cannot limit the connections to a database
+var ErrConnectionLimitNotSupported = errors.New("engine does not support database connection limits")
+
+// ErrWarehouseNotSupported is returned when an engine has no warehouses
+var ErrWarehouseNotSupported = errors.New("engine does not support warehouses")
+
+// ErrRenameNotSupported is returned when an engine cannot rename a database
+var ErrRenameNotSupported = errors.New("engine does not support renaming databases")
+
+// ErrCloneNotSupported is returned when an engine cannot copy a database on the server
+var ErrCloneNotSupported = errors.New("engine does not support cloning databases")
 
 // Client defines the interface for database operations
 type Client interface {
@@ -24,21 +45,124 @@ type Client interface {
 	DropUser(ctx context.Context, username string) error
 
 	// CreateDatabase creates a new database
-	// For PostgreSQL, owner is required; for MySQL, it's ignored
+	// For PostgreSQL, owner is required; for MySQL and Cassandra, it's ignored
 	CreateDatabase(ctx context.Context, databaseName string, owner string) error
 
+	// CloneDatabase creates a database as a copy of the source database on the same server
+	// Returns ErrCloneNotSupported if the engine cannot copy a database on the server
+	CloneDatabase(ctx context.Context, databaseName, source, owner string) error
+
 	// DatabaseExists checks if a database exists
 	DatabaseExists(ctx context.Context, databaseName string) (bool, error)
 
 	// DropDatabase drops a database
 	DropDatabase(ctx context.Context, databaseName string) error
 
+	// RenameDatabase renames a database, terminating the connections to it first
+	// Returns ErrRenameNotSupported if the engine cannot rename a database
+	RenameDatabase(ctx context.Context, databaseName, newName string) error
+
 	// GrantAllPrivileges grants all privileges on a database to a user
 	GrantAllPrivileges(ctx context.Context, databaseName, username string) error
 
 	// SetPassword sets/updates the password for a user
 	SetPassword(ctx context.Context, username, password string) error
 
+	// CreateEntraUser creates a user that logs in as a Microsoft Entra principal, if it does not exist
+	// Returns ErrEntraNotSupported if the engine has no Microsoft Entra users
+	CreateEntraUser(ctx context.Context, username string, principal EntraPrincipal) error
+
+	// SetPublicKey sets the public key a user authenticates with, an empty key removes it
+	// Returns ErrKeyPairNotSupported if the engine has no key-pair authentication
+	SetPublicKey(ctx context.Context, username, publicKey string) error
+
+	// SetDefaultWarehouse allows a user to use a warehouse and makes it the user's default
+	// Returns ErrWarehouseNotSupported if the engine has no warehouses
+	SetDefaultWarehouse(ctx context.Context, username, warehouse string) error
+
+	// SetAccountOptions applies the account options to a user
+	// Returns ErrAccountOptionsNotSupported if the server cannot apply them
+	SetAccountOptions(ctx context.Context, username string, opts AccountOptions) error
+
+	// SetRoleSettings sets configuration parameters applied to every session of a user and
+	// resets the parameters listed in reset
+	// Returns ErrRoleSettingsNotSupported if the engine has no per-role parameters
+	SetRoleSettings(ctx context.Context, username string, settings map[string]string, reset []string) error
+
+	// SetDatabaseConnectionLimit limits the concurrent connections to a database, -1 removes the limit
+	// Returns ErrConnectionLimitNotSupported if the engine has no per-database limit
+	SetDatabaseConnectionLimit(ctx context.Context, databaseName string, limit int32) error
+
+	// DatabaseCharset returns the default character set and collation of a database
+	// Returns ErrCharsetNotSupported if the engine has no per-database character set that can be changed
+	DatabaseCharset(ctx context.Context, databaseName string) (charset, collation string, err error)
+
+	// SetDatabaseCharset changes the default character set and collation of a database, used by tables
+	// created afterwards. An empty collation selects the default collation of the character set.
+	// Returns ErrCharsetNotSupported if the engine has no per-database character set that can be changed
+	SetDatabaseCharset(ctx context.Context, databaseName, charset, collation string) error
+
+	// PasswordLoggingRisk returns the server setting that makes it log the statements setting passwords,
+	// including the password literal, or an empty string if they are not logged
+	PasswordLoggingRisk(ctx context.Context) (string, error)
+
+	// MissingAdminPrivileges returns the privileges the admin user lacks to create databases and users,
+	// empty if it holds them all or the engine cannot tell
+	MissingAdminPrivileges(ctx context.Context) ([]string, error)
+
+	// RevokeOwnerMembership removes the admin user from the role owning a database it created
+	// Engines whose admin does not need membership to create databases for other users do nothing
+	RevokeOwnerMembership(ctx context.Context, owner string) error
+
+	// CreateRole creates a role that cannot log in and groups privileges for its members
+	// Returns ErrRolesNotSupported if the server does not support roles
+	CreateRole(ctx context.Context, roleName string) error
+
+	// RoleExists checks if a role exists
+	RoleExists(ctx context.Context, roleName string) (bool, error)
+
+	// DropRole removes the privileges of a role on a database and drops the role
+	DropRole(ctx context.Context, databaseName, roleName string) error
+
+	// GrantDatabasePrivileges grants privileges on a database to a role or an existing user
+	GrantDatabasePrivileges(ctx context.Context, databaseName, grantee string, privileges []string) error
+
+	// RevokeDatabasePrivileges revokes privileges on a database from a role or an existing user
+	// Privileges the grantee does not hold are ignored
+	RevokeDatabasePrivileges(ctx context.Context, databaseName, grantee string, privileges []string) error
+
+	// GrantObjectPrivileges grants privileges on a table or stored routine of a database to a role or an existing user
+	// Returns ErrObjectGrantsNotSupported if the engine only grants on whole databases
+	GrantObjectPrivileges(ctx context.Context, databaseName, grantee string, object ObjectGrant) error
+
+	// RevokeObjectPrivileges revokes privileges on a table or stored routine of a database
+	// Privileges the grantee does not hold are ignored
+	// Returns ErrObjectGrantsNotSupported if the engine only grants on whole databases
+	RevokeObjectPrivileges(ctx context.Context, databaseName, grantee string, object ObjectGrant) error
+
+	// GrantOwnerDefaultPrivileges grants the table privileges among privileges on the current and future
+	// tables owner creates in a database to grantee
+	// Engines whose grants on a database already cover the tables of every owner do nothing
+	GrantOwnerDefaultPrivileges(ctx context.Context, databaseName, owner, grantee string, privileges []string) error
+
+	// GrantRole makes a user a member of a role
+	GrantRole(ctx context.Context, roleName, username string) error
+
+	// RevokeRole removes a user from a role
+	RevokeRole(ctx context.Context, roleName, username string) error
+
 	// GetConnectionInfo returns the parsed connection information
 	GetConnectionInfo() *ConnectionInfo
+
+	// ServerVersion returns the version of the database server
+	// The result is cached for the lifetime of the client
+	ServerVersion(ctx context.Context) (ServerVersion, error)
+
+	// ListManagedUsers returns the users carrying the operator's managed marker
+	// Returns ErrManagedMarkerNotSupported if the engine cannot mark users
+	ListManagedUsers(ctx context.Context) ([]string, error)
+
+	// ListManagedDatabases returns the databases carrying the operator's managed marker
+	// Returns ErrManagedMarkerNotSupported if the engine cannot mark databases
+	ListManagedDatabases(ctx context.Context) ([]string, error)
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ErrKeyPairNotSupported is returned when the engine cannot authenticate users with a key pair
+var ErrKeyPairNotSupported = errors.New("engine does not support key-pair authentication")
+
+// keyPairBits is the size of generated RSA keys, the minimum Snowflake accepts
+const keyPairBits = 2048
+
+// GenerateKeyPair generates an RSA private key for key-pair authentication
+// The key is returned PEM encoded in PKCS#8, the format Snowflake drivers read
+func GenerateKeyPair() (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyPairBits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// PublicKeyFromPrivateKey returns the base64 encoded public key of a PEM encoded PKCS#8 RSA private key,
+// without the PEM header and footer
+func PublicKeyFromPrivateKey(privateKeyPEM string) (string, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return "", fmt.Errorf("private key is not PEM encoded")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("private key is not an RSA key")
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Object types privileges can be granted on, see ObjectGrant
+const (
+	ObjectTable     = "table"
+	ObjectProcedure = "procedure"
+	ObjectFunction  = "function"
+)
+
+// ErrObjectGrantsNotSupported is returned when an engine only grants privileges on whole databases
+var ErrObjectGrantsNotSupported = errors.New("engine does not support grants on tables and routines")
+
+// objectNamePattern matches the unquoted name of a table or stored routine
+var objectNamePattern = regexp.MustCompile(`^[A-Za-z0-9_$]{1,64}$`)
+
+// ObjectGrant is a grant of privileges on a single table or stored routine of a database
+type ObjectGrant struct {
+	// Type is ObjectTable, ObjectProcedure or ObjectFunction
+	Type string
+	// Name is the name of the object in the database
+	Name string
+	// Privileges are the privileges granted on the object
+	Privileges []string
+}
+
+// ValidateObjectGrant checks the type, name and privileges of an object grant
+func ValidateObjectGrant(object ObjectGrant) error {
+	switch object.Type {
+	case ObjectTable, ObjectProcedure, ObjectFunction:
+	default:
+		return fmt.Errorf("invalid object type %q: must be %s, %s or %s", object.Type, ObjectTable, ObjectProcedure, ObjectFunction)
+	}
+	if !objectNamePattern.MatchString(object.Name) {
+		return fmt.Errorf("invalid %s name %q: must be 1 to 64 letters, digits, underscores or dollar signs", object.Type, object.Name)
+	}
+	if len(object.Privileges) == 0 {
+		return fmt.Errorf("%s %s: at least one privilege is required", object.Type, object.Name)
+	}
+	return ValidatePrivileges(object.Privileges)
+}
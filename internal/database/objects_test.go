@@ -0,0 +1,34 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import "testing"
+
+func TestValidateObjectGrant(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  ObjectGrant
+		wantErr bool
+	}{
+		{name: "table", object: ObjectGrant{Type: ObjectTable, Name: "orders", Privileges: []string{"SELECT"}}},
+		{name: "routine with dollar sign", object: ObjectGrant{Type: ObjectFunction, Name: "calc$tax", Privileges: []string{"EXECUTE"}}},
+		{name: "unknown type", object: ObjectGrant{Type: "view", Name: "orders", Privileges: []string{"SELECT"}}, wantErr: true},
+		{name: "qualified name", object: ObjectGrant{Type: ObjectTable, Name: "app.orders", Privileges: []string{"SELECT"}}, wantErr: true},
+		{name: "backtick in name", object: ObjectGrant{Type: ObjectTable, Name: "orders`; DROP", Privileges: []string{"SELECT"}}, wantErr: true},
+		{name: "no privileges", object: ObjectGrant{Type: ObjectProcedure, Name: "refresh"}, wantErr: true},
+		{name: "invalid privilege", object: ObjectGrant{Type: ObjectTable, Name: "orders", Privileges: []string{"SELECT;"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateObjectGrant(tt.object); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateObjectGrant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+)
+
+// PoolConfig bounds the connections a client opens to the database server
+// Zero values keep the database/sql defaults, which do not limit open connections
+type PoolConfig struct {
+	// MaxOpenConns is the maximum number of open connections of a client
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of idle connections kept by a client
+	MaxIdleConns int
+	// ConnMaxLifetime is the maximum time a connection is reused
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is the maximum time a connection stays idle before it is closed
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout bounds a statement on PostgreSQL and MySQL connections, see WithStatementTimeout
+	// and the watchdog of open. Zero disables both, the default: poolers such as PgBouncer reject
+	// statement_timeout as a startup parameter.
+	StatementTimeout time.Duration
+}
+
+// DefaultPoolConfig returns the pool settings used by the operator unless configured otherwise
+// A client runs its statements one after another, so two connections cover the admin connection
+// and a connection to the target database
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    2,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: time.Minute,
+	}
+}
+
+// apply configures the connection pool of db
+func (p PoolConfig) apply(db *sql.DB) {
+	if p.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if p.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	}
+	if p.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+	}
+}
+
+// open opens a connection pool whose statements are canceled by the watchdog once they run longer
+// than StatementTimeout
+func (p PoolConfig) open(engine string, connector driver.Connector) *sql.DB {
+	db := sql.OpenDB(newWatchdogConnector(engine, connector, p.StatementTimeout))
+	p.apply(db)
+	return db
+}
@@ -11,18 +11,43 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/base64"
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq"
 )
 
 // PostgresClient provides PostgreSQL database operations
 type PostgresClient struct {
 	db       *sql.DB
 	connInfo *ConnectionInfo
+	version  *ServerVersion
+	pool     PoolConfig
+
+	// passwordEncryption is the password_encryption setting of the server, read once
+	passwordEncryption string
+
+	// suppressLogging is whether the admin may turn off statement logging, probed once
+	suppressLogging *bool
+
+	// dialer connects to the server instead of the host of the connection string, if set
+	dialer pq.Dialer
+
+	// sessionParams are the parameters of the connection string other than sslmode, such as
+	// application_name and statement_timeout, which connections to other databases keep
+	sessionParams url.Values
+
+	// yugabyte skips the statements YugabyteDB does not support, see NewYugabyteClient
+	yugabyte bool
+
+	// targets are the connection pools to other databases on the server, opened on first use by
+	// targetDatabase and closed with the client
+	targetsMu sync.Mutex
+	targets   map[string]*sql.DB
 }
 
 // ConnectionInfo contains parsed connection information
@@ -36,14 +61,19 @@ type ConnectionInfo struct {
 }
 
 // NewPostgresClient creates a new PostgreSQL client
-func NewPostgresClient(connectionString string) (*PostgresClient, error) {
+func NewPostgresClient(connectionString string, pool PoolConfig) (*PostgresClient, error) {
+	return newPostgresClient(connectionString, pool, nil)
+}
+
+// newPostgresClient creates a PostgreSQL client whose connections are opened with dialer, if set
+func newPostgresClient(connectionString string, pool PoolConfig, dialer pq.Dialer) (*PostgresClient, error) {
 	// Parse connection info first
 	connInfo, err := ParseConnectionString(connectionString)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := sql.Open("postgres", connectionString)
+	db, err := openPostgres(connectionString, pool, dialer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -51,17 +81,70 @@ func NewPostgresClient(connectionString string) (*PostgresClient, error) {
 	// Test the connection
 	if err := db.Ping(); err != nil {
 		_ = db.Close() // Ignore error on cleanup path
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, newConnectionError(connInfo, fmt.Errorf("failed to ping database: %w", err))
 	}
 
 	return &PostgresClient{
-		db:       db,
-		connInfo: connInfo,
+		db:            db,
+		connInfo:      connInfo,
+		pool:          pool,
+		dialer:        dialer,
+		sessionParams: postgresSessionParams(connectionString),
 	}, nil
 }
 
-// Close closes the database connection
+// postgresSessionParams returns the parameters of a PostgreSQL URL other than sslmode
+func postgresSessionParams(connectionString string) url.Values {
+	u, err := parseURL(connectionString)
+	if err != nil {
+		return nil
+	}
+	params := u.Query()
+	params.Del("sslmode")
+	return params
+}
+
+// openPostgres opens a connection pool, connections are opened with dialer if set
+func openPostgres(connectionString string, pool PoolConfig, dialer pq.Dialer) (*sql.DB, error) {
+	if dialer != nil {
+		var err error
+		if connectionString, err = disablePostgresSSL(connectionString); err != nil {
+			return nil, err
+		}
+	}
+	connector, err := pq.NewConnector(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	if dialer != nil {
+		connector.Dialer(dialer)
+	}
+	return pool.open("postgres", connector), nil
+}
+
+// NewYugabyteClient creates a client for the YSQL API of YugabyteDB
+// YugabyteDB speaks the PostgreSQL protocol, the client differs from a PostgreSQL client in that it
+//   - does not run ALTER DEFAULT PRIVILEGES, which YugabyteDB rejects; tables created later are
+//     covered by the GRANT ... ON ALL TABLES of the next reconciliation
+//   - does not terminate the connections to a database before dropping it, pg_terminate_backend only
+//     reaches the backends of the node the operator is connected to
+func NewYugabyteClient(connectionString string, pool PoolConfig) (*PostgresClient, error) {
+	c, err := NewPostgresClient(connectionString, pool)
+	if err != nil {
+		return nil, err
+	}
+	c.yugabyte = true
+	return c, nil
+}
+
+// Close closes the database connection and the connections to target databases
 func (c *PostgresClient) Close() error {
+	c.targetsMu.Lock()
+	for dbName, targetDB := range c.targets {
+		_ = targetDB.Close() // Ignore error on cleanup
+		delete(c.targets, dbName)
+	}
+	c.targetsMu.Unlock()
 	if c.db != nil {
 		return c.db.Close()
 	}
@@ -72,7 +155,7 @@ func (c *PostgresClient) Close() error {
 func ParseConnectionString(connectionString string) (*ConnectionInfo, error) {
 	// Handle both URL and DSN formats
 	if strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://") {
-		u, err := url.Parse(connectionString)
+		u, err := parseURL(connectionString)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse connection URL: %w", err)
 		}
@@ -115,6 +198,43 @@ func (c *PostgresClient) DatabaseExists(ctx context.Context, dbName string) (boo
 
 // CreateDatabase creates a new database
 func (c *PostgresClient) CreateDatabase(ctx context.Context, dbName string, owner string) error {
+	return c.createDatabase(ctx, dbName, owner, "")
+}
+
+// CloneDatabase creates a database from the source database as template
+// The objects of the source keep their owners. PostgreSQL refuses to copy a database other sessions
+// are connected to, the connections are not terminated since the source is usually in use.
+// YugabyteDB cannot use a database other than template0 and template1 as template.
+func (c *PostgresClient) CloneDatabase(ctx context.Context, dbName, source, owner string) error {
+	if c.yugabyte {
+		return ErrCloneNotSupported
+	}
+	return c.createDatabase(ctx, dbName, owner, source)
+}
+
+// execWithoutStatementTimeout runs a statement exempt from the statement timeout of the operator on a
+// connection of its own, which is discarded afterwards instead of returning to the pool with
+// statement_timeout disabled
+func (c *PostgresClient) execWithoutStatementTimeout(ctx context.Context, query string) error {
+	ctx = withoutStatementTimeout(ctx)
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, query)
+	return err
+}
+
+// createDatabase creates a database, as a copy of template if it is set
+func (c *PostgresClient) createDatabase(ctx context.Context, dbName, owner, template string) error {
 	// Check if database already exists
 	exists, err := c.DatabaseExists(ctx, dbName)
 	if err != nil {
@@ -124,9 +244,24 @@ func (c *PostgresClient) CreateDatabase(ctx context.Context, dbName string, owne
 		return nil // Database already exists, nothing to do
 	}
 
+	if err := c.grantOwnerMembership(ctx, owner); err != nil {
+		return err
+	}
+
 	// Create database
 	query := fmt.Sprintf("CREATE DATABASE %s OWNER %s", quoteIdentifier(dbName), quoteIdentifier(owner))
-	_, err = c.db.ExecContext(ctx, query)
+	if template != "" {
+		query += " TEMPLATE " + quoteIdentifier(template)
+	}
+	if template != "" {
+		// Copying the template takes as long as its data, the statement timeout does not apply
+		err = c.execWithoutStatementTimeout(ctx, query)
+	} else {
+		_, err = c.db.ExecContext(ctx, query)
+	}
+	if template != "" && isPostgresError(err, postgresErrObjectInUse) {
+		return fmt.Errorf("failed to create database: other sessions are connected to source database %s: %w", template, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create database: %w", err)
 	}
@@ -134,31 +269,96 @@ func (c *PostgresClient) CreateDatabase(ctx context.Context, dbName string, owne
 	// Add comment to database indicating it's managed by the operator
 	commentQuery := fmt.Sprintf("COMMENT ON DATABASE %s IS %s",
 		quoteIdentifier(dbName),
-		quoteLiteral("Managed by database-user-operator"))
+		quoteLiteral(ManagedMarker))
 	_, err = c.db.ExecContext(ctx, commentQuery)
 	if err != nil {
 		return fmt.Errorf("failed to add comment to database: %w", err)
 	}
 
+	// Before PostgreSQL 15 every role may create objects in the public schema of a new database
+	// Apply the PostgreSQL 15 default so only the owner and explicitly granted users can
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if !version.HasCapability("postgres", CapabilitySecurePublicSchema) {
+		if err := c.revokePublicSchemaCreate(ctx, dbName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grantOwnerMembership makes the admin user a member of owner unless it already acts as owner
+// CREATE DATABASE ... OWNER requires it on servers without a superuser, e.g. the master user of RDS.
+// Users created by the operator already are, users created elsewhere and PostgreSQL 16, which
+// makes the creator of a role a member that does not inherit its privileges, are not.
+func (c *PostgresClient) grantOwnerMembership(ctx context.Context, owner string) error {
+	var member bool
+	err := c.db.QueryRowContext(ctx, "SELECT pg_has_role(current_user, $1, 'USAGE')", owner).Scan(&member)
+	if err != nil {
+		return fmt.Errorf("failed to check membership of the admin user in role %s: %w", owner, err)
+	}
+	if member {
+		return nil
+	}
+	query := fmt.Sprintf("GRANT %s TO CURRENT_USER", quoteIdentifier(owner))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to grant role %s to the admin user: %w", owner, err)
+	}
+	return nil
+}
+
+// RevokeOwnerMembership removes the admin user from owner, which it was made a member of to create
+// the databases of owner. It is a no-op if the admin is owner itself.
+func (c *PostgresClient) RevokeOwnerMembership(ctx context.Context, owner string) error {
+	var self bool
+	if err := c.db.QueryRowContext(ctx, "SELECT current_user = $1", owner).Scan(&self); err != nil {
+		return fmt.Errorf("failed to read the admin user: %w", err)
+	}
+	if self {
+		return nil
+	}
+	query := fmt.Sprintf("REVOKE %s FROM CURRENT_USER", quoteIdentifier(owner))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to revoke role %s from the admin user: %w", owner, err)
+	}
+	return nil
+}
+
+// revokePublicSchemaCreate revokes the CREATE privilege on the public schema from PUBLIC
+func (c *PostgresClient) revokePublicSchemaCreate(ctx context.Context, dbName string) error {
+	targetDB, err := c.targetDatabase(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if _, err := targetDB.ExecContext(ctx, "REVOKE CREATE ON SCHEMA public FROM PUBLIC"); err != nil {
+		return fmt.Errorf("failed to revoke public schema privileges: %w", err)
+	}
 	return nil
 }
 
 // DropDatabase drops a database
 func (c *PostgresClient) DropDatabase(ctx context.Context, dbName string) error {
+	c.closeTargetDatabase(dbName)
+
 	// Terminate existing connections to the database
-	terminateQuery := `
+	if !c.yugabyte {
+		terminateQuery := `
 		SELECT pg_terminate_backend(pid)
 		FROM pg_stat_activity
 		WHERE datname = $1 AND pid <> pg_backend_pid()
 	`
-	_, err := c.db.ExecContext(ctx, terminateQuery, dbName)
-	if err != nil {
-		return fmt.Errorf("failed to terminate connections: %w", err)
+		_, err := c.db.ExecContext(ctx, terminateQuery, dbName)
+		if err != nil {
+			return fmt.Errorf("failed to terminate connections: %w", err)
+		}
 	}
 
 	// Drop database
 	query := fmt.Sprintf("DROP DATABASE IF EXISTS %s", quoteIdentifier(dbName))
-	_, err = c.db.ExecContext(ctx, query)
+	_, err := c.db.ExecContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("failed to drop database: %w", err)
 	}
@@ -166,6 +366,30 @@ func (c *PostgresClient) DropDatabase(ctx context.Context, dbName string) error
 	return nil
 }
 
+// RenameDatabase renames a database
+// PostgreSQL refuses to rename a database with open connections, so they are terminated first.
+// A client reconnecting between the termination and the rename makes it fail, it is retried.
+func (c *PostgresClient) RenameDatabase(ctx context.Context, dbName, newName string) error {
+	c.closeTargetDatabase(dbName)
+
+	if !c.yugabyte {
+		terminateQuery := `
+		SELECT pg_terminate_backend(pid)
+		FROM pg_stat_activity
+		WHERE datname = $1 AND pid <> pg_backend_pid()
+	`
+		if _, err := c.db.ExecContext(ctx, terminateQuery, dbName); err != nil {
+			return fmt.Errorf("failed to terminate connections: %w", err)
+		}
+	}
+
+	query := fmt.Sprintf("ALTER DATABASE %s RENAME TO %s", quoteIdentifier(dbName), quoteIdentifier(newName))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to rename database: %w", err)
+	}
+	return nil
+}
+
 // UserExists checks if a user exists
 func (c *PostgresClient) UserExists(ctx context.Context, username string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)`
@@ -188,15 +412,13 @@ func (c *PostgresClient) CreateUser(ctx context.Context, username, password stri
 	if exists {
 		// User exists, update password
 		query := fmt.Sprintf("ALTER USER %s WITH PASSWORD %s", quoteIdentifier(username), quoteLiteral(password))
-		_, err = c.db.ExecContext(ctx, query)
-		if err != nil {
+		if err := c.execPasswordStatement(ctx, query); err != nil {
 			return fmt.Errorf("failed to update user password: %w", err)
 		}
 	} else {
 		// Create new user
 		query := fmt.Sprintf("CREATE USER %s WITH PASSWORD %s", quoteIdentifier(username), quoteLiteral(password))
-		_, err = c.db.ExecContext(ctx, query)
-		if err != nil {
+		if err := c.execPasswordStatement(ctx, query); err != nil {
 			return fmt.Errorf("failed to create user: %w", err)
 		}
 
@@ -212,7 +434,7 @@ func (c *PostgresClient) CreateUser(ctx context.Context, username, password stri
 	// Add comment to role indicating it's managed by the operator
 	commentQuery := fmt.Sprintf("COMMENT ON ROLE %s IS %s",
 		quoteIdentifier(username),
-		quoteLiteral("Managed by database-user-operator"))
+		quoteLiteral(ManagedMarker))
 	_, err = c.db.ExecContext(ctx, commentQuery)
 	if err != nil {
 		return fmt.Errorf("failed to add comment to role: %w", err)
@@ -238,84 +460,521 @@ func (c *PostgresClient) DropUser(ctx context.Context, username string) error {
 }
 
 // GrantPrivileges grants privileges to a user on a database
+// Like GrantDatabasePrivileges, database privileges are granted on the database and all others on
+// the public schema and its current and future tables and sequences
 func (c *PostgresClient) GrantPrivileges(ctx context.Context, username, dbName string, privileges []string) error {
-	// Connect to the target database to grant privileges
+	return c.execPrivilegeStatements(ctx, dbName, username, privileges, false)
+}
+
+// targetDatabase returns the connection pool to another database on the same server, opened with the
+// admin credentials on first use and reused until the client is closed
+func (c *PostgresClient) targetDatabase(ctx context.Context, dbName string) (*sql.DB, error) {
+	c.targetsMu.Lock()
+	defer c.targetsMu.Unlock()
+	if targetDB, ok := c.targets[dbName]; ok {
+		return targetDB, nil
+	}
+
 	connInfo, err := c.getConnectionInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create connection string for the target database
-	targetConnStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+	params := url.Values{"sslmode": {connInfo.SSLMode}}
+	for key, values := range c.sessionParams {
+		params[key] = values
+	}
+	targetConnStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?%s",
 		url.QueryEscape(connInfo.Username),
 		url.QueryEscape(connInfo.Password),
 		connInfo.Host,
 		connInfo.Port,
 		dbName,
-		connInfo.SSLMode,
+		params.Encode(),
 	)
 
-	targetDB, err := sql.Open("postgres", targetConnStr)
+	targetDB, err := openPostgres(targetConnStr, c.pool, c.dialer)
 	if err != nil {
-		return fmt.Errorf("failed to connect to target database: %w", err)
+		return nil, fmt.Errorf("failed to connect to target database: %w", err)
 	}
-	defer func() {
+
+	if err := targetDB.PingContext(ctx); err != nil {
+		_ = targetDB.Close() // Ignore error on cleanup path
+		return nil, newConnectionError(connInfo, fmt.Errorf("failed to ping target database: %w", err))
+	}
+
+	if c.targets == nil {
+		c.targets = make(map[string]*sql.DB)
+	}
+	c.targets[dbName] = targetDB
+	return targetDB, nil
+}
+
+// closeTargetDatabase closes the connection pool to a database, before it is dropped or renamed
+func (c *PostgresClient) closeTargetDatabase(dbName string) {
+	c.targetsMu.Lock()
+	defer c.targetsMu.Unlock()
+	if targetDB, ok := c.targets[dbName]; ok {
 		_ = targetDB.Close() // Ignore error on cleanup
+		delete(c.targets, dbName)
+	}
+}
+
+// GrantAllPrivileges grants all privileges on a database to a user
+func (c *PostgresClient) GrantAllPrivileges(ctx context.Context, databaseName, username string) error {
+	return c.GrantPrivileges(ctx, username, databaseName, []string{"ALL"})
+}
+
+// SetPassword sets/updates the password for a user
+func (c *PostgresClient) SetPassword(ctx context.Context, username, password string) error {
+	query := fmt.Sprintf("ALTER USER %s WITH PASSWORD %s", quoteIdentifier(username), quoteLiteral(password))
+	if err := c.execPasswordStatement(ctx, query); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+	return nil
+}
+
+// passwordLoggingSettings keep a statement out of the server log, including when it fails
+// All of them can only be set by superusers and roles granted SET on them.
+var passwordLoggingSettings = []string{
+	"SET LOCAL log_statement = 'none'",
+	"SET LOCAL log_min_duration_statement = -1",
+	"SET LOCAL log_min_error_statement = 'panic'",
+}
+
+// execPasswordStatement runs a CREATE USER or ALTER USER statement setting a password
+// The statement runs in a transaction with statement logging turned off if the admin may do so,
+// and with password_encryption set to SCRAM-SHA-256 if the server supports it but defaults to md5.
+func (c *PostgresClient) execPasswordStatement(ctx context.Context, query string) error {
+	suppressLogging, err := c.canSuppressLogging(ctx)
+	if err != nil {
+		return err
+	}
+	forceScram, err := c.forceScram(ctx)
+	if err != nil {
+		return err
+	}
+	if !suppressLogging && !forceScram {
+		_, err := c.db.ExecContext(ctx, query)
+		return err
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error after commit
 	}()
+	if suppressLogging {
+		for _, setting := range passwordLoggingSettings {
+			if _, err := tx.ExecContext(ctx, setting); err != nil {
+				return fmt.Errorf("failed to turn off statement logging: %w", err)
+			}
+		}
+	}
+	if forceScram {
+		if _, err := tx.ExecContext(ctx, "SET LOCAL password_encryption = 'scram-sha-256'"); err != nil {
+			return fmt.Errorf("failed to set password_encryption: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
-	if err := targetDB.Ping(); err != nil {
-		return fmt.Errorf("failed to ping target database: %w", err)
+// canSuppressLogging reports whether the admin may turn off statement logging for its transactions
+// It is probed once in a transaction that is rolled back. RDS and most managed servers do not allow it.
+func (c *PostgresClient) canSuppressLogging(ctx context.Context) (bool, error) {
+	if c.suppressLogging != nil {
+		return *c.suppressLogging, nil
 	}
 
-	// Build privilege string
-	privStr := strings.Join(privileges, ", ")
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	allowed := true
+	for _, setting := range passwordLoggingSettings {
+		if _, err := tx.ExecContext(ctx, setting); err != nil {
+			if !isPostgresError(err, postgresErrInsufficientPrivilege) {
+				return false, fmt.Errorf("failed to check statement logging privileges: %w", err)
+			}
+			allowed = false
+			break
+		}
+	}
+	c.suppressLogging = &allowed
+	return allowed, nil
+}
 
-	// Grant database-level privileges
-	query := fmt.Sprintf("GRANT %s ON DATABASE %s TO %s", privStr, quoteIdentifier(dbName), quoteIdentifier(username))
-	_, err = c.db.ExecContext(ctx, query)
+// PasswordLoggingRisk returns the setting that makes the server log password statements, empty if
+// they are not logged. Statements are not logged when the admin may turn off logging for them,
+// otherwise log_statement ddl, mod and all log them, and so does a log_min_duration_statement of 0.
+func (c *PostgresClient) PasswordLoggingRisk(ctx context.Context) (string, error) {
+	suppressLogging, err := c.canSuppressLogging(ctx)
+	if err != nil || suppressLogging {
+		return "", err
+	}
+
+	var logStatement, minDuration string
+	err = c.db.QueryRowContext(ctx,
+		"SELECT current_setting('log_statement'), current_setting('log_min_duration_statement')").
+		Scan(&logStatement, &minDuration)
 	if err != nil {
-		return fmt.Errorf("failed to grant database privileges: %w", err)
+		return "", fmt.Errorf("failed to read logging settings: %w", err)
+	}
+	switch {
+	case logStatement == "ddl" || logStatement == "mod" || logStatement == "all":
+		return "log_statement = " + logStatement, nil
+	case minDuration == "0":
+		return "log_min_duration_statement = 0", nil
 	}
+	return "", nil
+}
 
-	// Grant schema-level privileges
-	schemaQuery := fmt.Sprintf("GRANT ALL ON SCHEMA public TO %s", quoteIdentifier(username))
-	_, err = targetDB.ExecContext(ctx, schemaQuery)
+// MissingAdminPrivileges returns CREATEDB and CREATEROLE if the admin role lacks them, a superuser
+// lacks nothing
+func (c *PostgresClient) MissingAdminPrivileges(ctx context.Context) ([]string, error) {
+	var superuser, createDB, createRole bool
+	err := c.db.QueryRowContext(ctx,
+		"SELECT rolsuper, rolcreatedb, rolcreaterole FROM pg_roles WHERE rolname = current_user").
+		Scan(&superuser, &createDB, &createRole)
 	if err != nil {
-		return fmt.Errorf("failed to grant schema privileges: %w", err)
+		return nil, fmt.Errorf("failed to read admin privileges: %w", err)
 	}
+	return missingPostgresAdminPrivileges(superuser, createDB, createRole), nil
+}
 
-	// Grant table privileges (for existing and future tables)
-	tableQuery := fmt.Sprintf("GRANT ALL ON ALL TABLES IN SCHEMA public TO %s", quoteIdentifier(username))
-	_, err = targetDB.ExecContext(ctx, tableQuery)
+func missingPostgresAdminPrivileges(superuser, createDB, createRole bool) []string {
+	if superuser {
+		return nil
+	}
+	var missing []string
+	if !createDB {
+		missing = append(missing, "CREATEDB")
+	}
+	if !createRole {
+		missing = append(missing, "CREATEROLE")
+	}
+	return missing
+}
+
+// forceScram reports whether the server supports SCRAM-SHA-256 but its password_encryption is another method
+func (c *PostgresClient) forceScram(ctx context.Context) (bool, error) {
+	version, err := c.ServerVersion(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to grant table privileges: %w", err)
+		return false, err
+	}
+	if !version.HasCapability("postgres", CapabilityScram) {
+		return false, nil
 	}
+	if c.passwordEncryption == "" {
+		if err := c.db.QueryRowContext(ctx, "SHOW password_encryption").Scan(&c.passwordEncryption); err != nil {
+			return false, fmt.Errorf("failed to read password_encryption: %w", err)
+		}
+	}
+	return c.passwordEncryption != "scram-sha-256", nil
+}
 
-	// Grant default privileges for future tables
-	defaultQuery := fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT ALL ON TABLES TO %s", quoteIdentifier(username))
-	_, err = targetDB.ExecContext(ctx, defaultQuery)
+// CreateEntraUser creates a role mapped to a Microsoft Entra principal on Azure Database for PostgreSQL
+// The admin must be a Microsoft Entra administrator connected to the postgres database
+func (c *PostgresClient) CreateEntraUser(ctx context.Context, username string, principal EntraPrincipal) error {
+	if c.yugabyte {
+		return ErrEntraNotSupported
+	}
+	principalType, err := entraPostgresPrincipalType(principal.Type)
+	if err != nil {
+		return err
+	}
+	exists, err := c.UserExists(ctx, username)
+	if err != nil || exists {
+		return err
+	}
+	_, err = c.db.ExecContext(ctx, "SELECT * FROM pgaadauth_create_principal_with_oid($1, $2, $3, false, false)",
+		username, principal.ID, principalType)
 	if err != nil {
-		return fmt.Errorf("failed to grant default privileges: %w", err)
+		return fmt.Errorf("failed to create Microsoft Entra user: %w", err)
 	}
+	return nil
+}
+
+// SetPublicKey is not supported for PostgreSQL
+func (c *PostgresClient) SetPublicKey(ctx context.Context, username, publicKey string) error {
+	return ErrKeyPairNotSupported
+}
+
+// SetDefaultWarehouse is not supported for PostgreSQL, it has no warehouses
+func (c *PostgresClient) SetDefaultWarehouse(ctx context.Context, username, warehouse string) error {
+	return ErrWarehouseNotSupported
+}
 
+// SetAccountOptions is not supported, the account options are MariaDB settings
+func (c *PostgresClient) SetAccountOptions(ctx context.Context, username string, opts AccountOptions) error {
+	return ErrAccountOptionsNotSupported
+}
+
+// SetRoleSettings sets and resets configuration parameters of a role with ALTER ROLE
+// Parameter names are validated, they cannot be quoted in ALTER ROLE
+func (c *PostgresClient) SetRoleSettings(ctx context.Context, username string, settings map[string]string, reset []string) error {
+	if err := ValidateRoleSettings(settings); err != nil {
+		return err
+	}
+	for _, name := range reset {
+		if !roleSettingNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid role setting %q", name)
+		}
+	}
+
+	for _, stmt := range postgresRoleSettingStatements(username, settings, reset) {
+		if _, err := c.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply role settings: %w", err)
+		}
+	}
 	return nil
 }
 
-// GrantAllPrivileges grants all privileges on a database to a user
-func (c *PostgresClient) GrantAllPrivileges(ctx context.Context, databaseName, username string) error {
-	return c.GrantPrivileges(ctx, username, databaseName, []string{"ALL"})
+// SetDatabaseConnectionLimit sets the CONNECTION LIMIT of a database
+// Superusers are not subject to the limit
+func (c *PostgresClient) SetDatabaseConnectionLimit(ctx context.Context, databaseName string, limit int32) error {
+	query := fmt.Sprintf("ALTER DATABASE %s CONNECTION LIMIT %d", quoteIdentifier(databaseName), limit)
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to set database connection limit: %w", err)
+	}
+	return nil
 }
 
-// SetPassword sets/updates the password for a user
-func (c *PostgresClient) SetPassword(ctx context.Context, username, password string) error {
-	query := fmt.Sprintf("ALTER USER %s WITH PASSWORD %s", quoteIdentifier(username), quoteLiteral(password))
-	_, err := c.db.ExecContext(ctx, query)
+// DatabaseCharset is not supported, the encoding of a PostgreSQL database is fixed when it is created
+func (c *PostgresClient) DatabaseCharset(_ context.Context, _ string) (string, string, error) {
+	return "", "", ErrCharsetNotSupported
+}
+
+// SetDatabaseCharset is not supported, the encoding of a PostgreSQL database is fixed when it is created
+func (c *PostgresClient) SetDatabaseCharset(_ context.Context, _, _, _ string) error {
+	return ErrCharsetNotSupported
+}
+
+// CreateRole creates a NOLOGIN role
+func (c *PostgresClient) CreateRole(ctx context.Context, roleName string) error {
+	exists, err := c.RoleExists(ctx, roleName)
+	if err != nil || exists {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE ROLE %s NOLOGIN", quoteIdentifier(roleName))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	return nil
+}
+
+// RoleExists checks if a role exists
+// Users and roles share a namespace in PostgreSQL
+func (c *PostgresClient) RoleExists(ctx context.Context, roleName string) (bool, error) {
+	return c.UserExists(ctx, roleName)
+}
+
+// DropRole revokes the privileges of a role on a database and drops the role
+func (c *PostgresClient) DropRole(ctx context.Context, databaseName, roleName string) error {
+	exists, err := c.RoleExists(ctx, roleName)
+	if err != nil || !exists {
+		return err
+	}
+
+	// Privileges in the database block DROP ROLE, the database may already be gone
+	if dbExists, err := c.DatabaseExists(ctx, databaseName); err == nil && dbExists {
+		targetDB, err := c.targetDatabase(ctx, databaseName)
+		if err != nil {
+			return err
+		}
+		if _, err := targetDB.ExecContext(ctx, fmt.Sprintf("DROP OWNED BY %s", quoteIdentifier(roleName))); err != nil {
+			return fmt.Errorf("failed to revoke role privileges: %w", err)
+		}
+		query := fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM %s", quoteIdentifier(databaseName), quoteIdentifier(roleName))
+		if _, err := c.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to revoke role privileges: %w", err)
+		}
+	}
+
+	query := fmt.Sprintf("DROP ROLE IF EXISTS %s", quoteIdentifier(roleName))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop role: %w", err)
+	}
+	return nil
+}
+
+// GrantDatabasePrivileges grants privileges on a database to a role or user
+// Database privileges (CONNECT, CREATE, TEMPORARY) are granted on the database, all others on the
+// current and future tables of the public schema
+func (c *PostgresClient) GrantDatabasePrivileges(ctx context.Context, databaseName, grantee string, privileges []string) error {
+	return c.execPrivilegeStatements(ctx, databaseName, grantee, privileges, false)
+}
+
+// RevokeDatabasePrivileges revokes privileges on a database from a role or user
+// PostgreSQL only warns about privileges that were not granted
+func (c *PostgresClient) RevokeDatabasePrivileges(ctx context.Context, databaseName, grantee string, privileges []string) error {
+	return c.execPrivilegeStatements(ctx, databaseName, grantee, privileges, true)
+}
+
+// execPrivilegeStatements runs the statements granting or revoking privileges on a database
+func (c *PostgresClient) execPrivilegeStatements(ctx context.Context, databaseName, grantee string, privileges []string, revoke bool) error {
+	if err := ValidatePrivileges(privileges); err != nil {
+		return err
+	}
+
+	action := "grant"
+	if revoke {
+		action = "revoke"
+	}
+
+	serverStmts, databaseStmts := postgresPrivilegeStatements(databaseName, grantee, privileges, revoke)
+	if c.yugabyte {
+		databaseStmts = withoutDefaultPrivileges(databaseStmts)
+	}
+	if len(databaseStmts) == 0 {
+		for _, stmt := range serverStmts {
+			if _, err := c.db.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("failed to %s database privileges: %w", action, err)
+			}
+		}
+		return nil
+	}
+
+	// GRANT ... ON DATABASE may run in any database, all statements share the connection to the target
+	targetDB, err := c.targetDatabase(ctx, databaseName)
 	if err != nil {
-		return fmt.Errorf("failed to set password: %w", err)
+		return err
+	}
+	for _, stmt := range serverStmts {
+		if _, err := targetDB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to %s database privileges: %w", action, err)
+		}
+	}
+	for _, stmt := range databaseStmts {
+		if _, err := targetDB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to %s table privileges: %w", action, err)
+		}
 	}
 	return nil
 }
 
+// GrantObjectPrivileges returns ErrObjectGrantsNotSupported, PostgreSQL grants are managed per database
+func (c *PostgresClient) GrantObjectPrivileges(_ context.Context, _, _ string, _ ObjectGrant) error {
+	return ErrObjectGrantsNotSupported
+}
+
+// RevokeObjectPrivileges returns ErrObjectGrantsNotSupported, PostgreSQL grants are managed per database
+func (c *PostgresClient) RevokeObjectPrivileges(_ context.Context, _, _ string, _ ObjectGrant) error {
+	return ErrObjectGrantsNotSupported
+}
+
+// GrantOwnerDefaultPrivileges grants privileges on the current and future tables owner creates in the
+// public schema to grantee. Changing the default privileges of another role requires membership, so
+// the admin user is made a member of owner first, which a role with CREATEROLE may grant itself.
+// YugabyteDB does not support default privileges, it only grants on the current tables.
+func (c *PostgresClient) GrantOwnerDefaultPrivileges(ctx context.Context, databaseName, owner, grantee string, privileges []string) error {
+	if err := ValidatePrivileges(privileges); err != nil {
+		return err
+	}
+	stmts := postgresOwnerDefaultPrivilegeStatements(owner, grantee, privileges)
+	if c.yugabyte {
+		stmts = withoutDefaultPrivileges(stmts)
+	}
+	if len(stmts) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("GRANT %s TO CURRENT_USER", quoteIdentifier(owner))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to grant role %s to the admin user: %w", owner, err)
+	}
+
+	targetDB, err := c.targetDatabase(ctx, databaseName)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if _, err := targetDB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to grant default privileges: %w", err)
+		}
+	}
+	return nil
+}
+
+// GrantRole makes a user a member of a role
+func (c *PostgresClient) GrantRole(ctx context.Context, roleName, username string) error {
+	query := fmt.Sprintf("GRANT %s TO %s", quoteIdentifier(roleName), quoteIdentifier(username))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+	return nil
+}
+
+// RevokeRole removes a user from a role
+func (c *PostgresClient) RevokeRole(ctx context.Context, roleName, username string) error {
+	query := fmt.Sprintf("REVOKE %s FROM %s", quoteIdentifier(roleName), quoteIdentifier(username))
+	if _, err := c.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+	return nil
+}
+
+// ServerVersion returns the PostgreSQL server version reported by SELECT version()
+func (c *PostgresClient) ServerVersion(ctx context.Context) (ServerVersion, error) {
+	if c.version != nil {
+		return *c.version, nil
+	}
+
+	var raw string
+	if err := c.db.QueryRowContext(ctx, "SELECT version()").Scan(&raw); err != nil {
+		return ServerVersion{}, fmt.Errorf("failed to query server version: %w", err)
+	}
+	version := ParseServerVersion(raw)
+	c.version = &version
+	return version, nil
+}
+
+// ListManagedUsers returns the roles whose comment marks them as managed by the operator
+func (c *PostgresClient) ListManagedUsers(ctx context.Context) ([]string, error) {
+	query := `SELECT rolname FROM pg_roles WHERE shobj_description(oid, 'pg_authid') = $1 ORDER BY rolname`
+	return c.queryNames(ctx, query, ManagedMarker)
+}
+
+// ListManagedDatabases returns the databases whose comment marks them as managed by the operator
+func (c *PostgresClient) ListManagedDatabases(ctx context.Context) ([]string, error) {
+	query := `SELECT datname FROM pg_database WHERE shobj_description(oid, 'pg_database') = $1 ORDER BY datname`
+	return c.queryNames(ctx, query, ManagedMarker)
+}
+
+// queryNames runs a query returning a single text column and collects the results
+func (c *PostgresClient) queryNames(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query managed objects: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() // Ignore error on cleanup
+	}()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan managed object: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read managed objects: %w", err)
+	}
+	return names, nil
+}
+
 // GeneratePassword generates a secure random password
 func GeneratePassword(length int) (string, error) {
 	if length < 16 {
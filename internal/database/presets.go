@@ -0,0 +1,75 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Privilege presets, see PresetPrivileges
+const (
+	PresetReadOnly        = "readOnly"
+	PresetReadWrite       = "readWrite"
+	PresetDDL             = "ddl"
+	PresetMigrationRunner = "migrationRunner"
+)
+
+// presetPrivileges are the privileges of each preset by engine family
+// readOnly and readWrite never change the schema, ddl never changes data.
+var presetPrivileges = map[string]map[string][]string{
+	"postgres": {
+		PresetReadOnly:        {"CONNECT", "SELECT"},
+		PresetReadWrite:       {"CONNECT", "SELECT", "INSERT", "UPDATE", "DELETE"},
+		PresetDDL:             {"CONNECT", "CREATE", "TEMPORARY"},
+		PresetMigrationRunner: {"ALL"},
+	},
+	"mysql": {
+		PresetReadOnly:  {"SELECT", "SHOW VIEW"},
+		PresetReadWrite: {"SELECT", "INSERT", "UPDATE", "DELETE", "SHOW VIEW"},
+		PresetDDL: {"CREATE", "ALTER", "DROP", "INDEX", "REFERENCES", "CREATE VIEW", "SHOW VIEW",
+			"TRIGGER", "CREATE ROUTINE", "ALTER ROUTINE"},
+		PresetMigrationRunner: {"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "ALTER", "DROP", "INDEX",
+			"REFERENCES", "CREATE VIEW", "SHOW VIEW", "TRIGGER", "CREATE ROUTINE", "ALTER ROUTINE", "EXECUTE",
+			"LOCK TABLES", "CREATE TEMPORARY TABLES"},
+	},
+	"cassandra": {
+		PresetReadOnly:        {"SELECT"},
+		PresetReadWrite:       {"SELECT", "MODIFY"},
+		PresetDDL:             {"CREATE", "ALTER", "DROP"},
+		PresetMigrationRunner: {"CREATE", "ALTER", "DROP", "SELECT", "MODIFY"},
+	},
+	"snowflake": {
+		PresetReadOnly:        {"USAGE", "SELECT"},
+		PresetReadWrite:       {"USAGE", "SELECT", "INSERT", "UPDATE", "DELETE"},
+		PresetDDL:             {"USAGE", "MODIFY", "CREATE SCHEMA"},
+		PresetMigrationRunner: {"ALL"},
+	},
+}
+
+// PresetPrivileges returns the privileges granted on a database for a privilege preset
+// The privileges are in the form accepted by GrantDatabasePrivileges of the engine's client.
+func PresetPrivileges(engine, preset string) ([]string, error) {
+	presets, ok := presetPrivileges[EngineFamily(engine)]
+	if !ok {
+		return nil, fmt.Errorf("privilege presets are not supported for engine %s", engine)
+	}
+	privileges, ok := presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown privilege preset %q: must be one of %s, %s, %s or %s",
+			preset, PresetReadOnly, PresetReadWrite, PresetDDL, PresetMigrationRunner)
+	}
+	return slices.Clone(privileges), nil
+}
+
+// PresetOwnsDatabase reports whether the user of a preset owns the databases created for it
+// On PostgreSQL the owner of a database can change any schema in it whatever it was granted,
+// so only the presets that change the schema own their database.
+func PresetOwnsDatabase(preset string) bool {
+	return preset == "" || preset == PresetDDL || preset == PresetMigrationRunner
+}
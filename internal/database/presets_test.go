@@ -0,0 +1,64 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPresetPrivileges(t *testing.T) {
+	ddl := []string{"CREATE", "ALTER", "DROP", "ALL", "CREATE SCHEMA"}
+
+	for _, engine := range []string{"postgres", "postgresql", "yugabyte", "mysql", "mariadb", "cassandra", "snowflake"} {
+		dml := []string{"INSERT", "UPDATE", "DELETE"}
+		if engine == "cassandra" {
+			// MODIFY covers INSERT, UPDATE and DELETE on Cassandra, on Snowflake it alters the database
+			dml = append(dml, "MODIFY")
+		}
+		for _, preset := range []string{PresetReadOnly, PresetReadWrite, PresetDDL, PresetMigrationRunner} {
+			privileges, err := PresetPrivileges(engine, preset)
+			if err != nil {
+				t.Fatalf("PresetPrivileges(%s, %s) error = %v", engine, preset, err)
+			}
+			if err := ValidatePrivileges(privileges); err != nil {
+				t.Errorf("PresetPrivileges(%s, %s) = %v: %v", engine, preset, privileges, err)
+			}
+			for _, privilege := range privileges {
+				if (preset == PresetReadOnly || preset == PresetReadWrite) && slices.Contains(ddl, privilege) {
+					t.Errorf("PresetPrivileges(%s, %s) contains DDL privilege %s", engine, preset, privilege)
+				}
+				if (preset == PresetReadOnly || preset == PresetDDL) && slices.Contains(dml, privilege) {
+					t.Errorf("PresetPrivileges(%s, %s) contains DML privilege %s", engine, preset, privilege)
+				}
+			}
+		}
+	}
+
+	if _, err := PresetPrivileges("postgres", "admin"); err == nil {
+		t.Error("PresetPrivileges() accepted an unknown preset")
+	}
+	if _, err := PresetPrivileges("oracle", PresetReadOnly); err == nil {
+		t.Error("PresetPrivileges() accepted an unknown engine")
+	}
+}
+
+func TestPresetOwnsDatabase(t *testing.T) {
+	tests := map[string]bool{
+		"":                    true,
+		PresetReadOnly:        false,
+		PresetReadWrite:       false,
+		PresetDDL:             true,
+		PresetMigrationRunner: true,
+	}
+	for preset, want := range tests {
+		if got := PresetOwnsDatabase(preset); got != want {
+			t.Errorf("PresetOwnsDatabase(%q) = %v, want %v", preset, got, want)
+		}
+	}
+}
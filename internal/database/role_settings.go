@@ -0,0 +1,67 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrRoleSettingsNotSupported is returned when the engine has no per-role configuration parameters
+var ErrRoleSettingsNotSupported = errors.New("engine does not support role settings")
+
+// roleSettingNamePattern matches PostgreSQL configuration parameter names,
+// including custom parameters of extensions such as auto_explain.log_min_duration
+var roleSettingNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
+// ValidateRoleSettings checks that every setting name is a configuration parameter name
+func ValidateRoleSettings(settings map[string]string) error {
+	for name := range settings {
+		if !roleSettingNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid role setting %q: must be a configuration parameter name such as statement_timeout", name)
+		}
+	}
+	return nil
+}
+
+// postgresSettingValue renders a setting value as a list of literals
+// List parameters such as search_path take one literal per element, so the value is split at
+// commas and double quotes around elements are removed: PostgreSQL quotes the elements itself
+func postgresSettingValue(value string) string {
+	parts := strings.Split(value, ",")
+	literals := make([]string, 0, len(parts))
+	for _, part := range parts {
+		element := strings.Trim(strings.TrimSpace(part), `"`)
+		literals = append(literals, quoteLiteral(element))
+	}
+	return strings.Join(literals, ", ")
+}
+
+// postgresRoleSettingStatements returns the ALTER ROLE statements setting the given parameters and
+// resetting the parameters in reset, in a stable order
+func postgresRoleSettingStatements(roleName string, settings map[string]string, reset []string) []string {
+	role := quoteIdentifier(roleName)
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stmts := make([]string, 0, len(reset)+len(names))
+	for _, name := range reset {
+		stmts = append(stmts, fmt.Sprintf("ALTER ROLE %s RESET %s", role, name))
+	}
+	for _, name := range names {
+		stmts = append(stmts, fmt.Sprintf("ALTER ROLE %s SET %s = %s", role, name, postgresSettingValue(settings[name])))
+	}
+	return stmts
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateRoleSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]string
+		wantErr  bool
+	}{
+		{name: "empty"},
+		{name: "parameters", settings: map[string]string{"statement_timeout": "30s", "auto_explain.log_min_duration": "1s"}},
+		{name: "upper case", settings: map[string]string{"Statement_Timeout": "30s"}, wantErr: true},
+		{name: "injection", settings: map[string]string{"work_mem = 1; DROP ROLE x": "1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRoleSettings(tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRoleSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostgresRoleSettingStatements(t *testing.T) {
+	got := postgresRoleSettingStatements("app", map[string]string{
+		"statement_timeout": "30s",
+		"search_path":       `"$user", public`,
+		"application_name":  "it's",
+	}, []string{"work_mem"})
+
+	want := []string{
+		`ALTER ROLE "app" RESET work_mem`,
+		`ALTER ROLE "app" SET application_name = 'it''s'`,
+		`ALTER ROLE "app" SET search_path = '$user', 'public'`,
+		`ALTER ROLE "app" SET statement_timeout = '30s'`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("postgresRoleSettingStatements() = %q, want %q", got, want)
+	}
+}
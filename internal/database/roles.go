@@ -0,0 +1,175 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrRolesNotSupported is returned when the server does not support roles
+var ErrRolesNotSupported = errors.New("database server does not support roles")
+
+// privilegePattern matches a privilege keyword such as SELECT or ALL PRIVILEGES
+// Privileges are written into GRANT statements, so anything else is rejected
+var privilegePattern = regexp.MustCompile(`^[A-Za-z]+( [A-Za-z]+)*$`)
+
+// postgresDatabasePrivileges are the PostgreSQL privileges granted on the database itself,
+// all other privileges are granted on the tables of the public schema
+var postgresDatabasePrivileges = map[string]bool{
+	"CONNECT":   true,
+	"CREATE":    true,
+	"TEMPORARY": true,
+	"TEMP":      true,
+}
+
+// ValidatePrivileges checks that every privilege is a plain privilege keyword
+func ValidatePrivileges(privileges []string) error {
+	for _, privilege := range privileges {
+		if !privilegePattern.MatchString(privilege) {
+			return fmt.Errorf("invalid privilege %q: must be a privilege keyword such as SELECT or ALL PRIVILEGES", privilege)
+		}
+	}
+	return nil
+}
+
+// isAllPrivileges reports whether the privilege stands for all privileges
+func isAllPrivileges(privilege string) bool {
+	p := strings.ToUpper(privilege)
+	return p == "ALL" || p == "ALL PRIVILEGES"
+}
+
+// postgresPrivilegeStatements returns the statements granting or revoking privileges on a database:
+// those on the database itself and those run in the target database
+// ALL covers everything on the database, the public schema and its current and future tables and
+// sequences. Table privileges are only usable with CONNECT, which is added to grants but not to
+// revokes, and carry the sequence privileges of postgresSequencePrivileges.
+func postgresPrivilegeStatements(dbName, grantee string, privileges []string, revoke bool) (serverStmts, databaseStmts []string) {
+	verb, preposition := "GRANT", "TO"
+	if revoke {
+		verb, preposition = "REVOKE", "FROM"
+	}
+	target := quoteIdentifier(grantee)
+
+	var dbPrivs, tablePrivs []string
+	for _, privilege := range privileges {
+		p := strings.ToUpper(privilege)
+		switch {
+		case isAllPrivileges(p):
+			return []string{fmt.Sprintf("%s ALL ON DATABASE %s %s %s", verb, quoteIdentifier(dbName), preposition, target)},
+				[]string{
+					fmt.Sprintf("%s ALL ON SCHEMA public %s %s", verb, preposition, target),
+					fmt.Sprintf("%s ALL ON ALL TABLES IN SCHEMA public %s %s", verb, preposition, target),
+					fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public %s ALL ON TABLES %s %s", verb, preposition, target),
+					fmt.Sprintf("%s ALL ON ALL SEQUENCES IN SCHEMA public %s %s", verb, preposition, target),
+					fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public %s ALL ON SEQUENCES %s %s", verb, preposition, target),
+				}
+		case postgresDatabasePrivileges[p]:
+			dbPrivs = append(dbPrivs, p)
+		default:
+			tablePrivs = append(tablePrivs, p)
+		}
+	}
+
+	if !revoke && len(tablePrivs) > 0 && !slices.Contains(dbPrivs, "CONNECT") {
+		dbPrivs = append(dbPrivs, "CONNECT")
+	}
+	if len(dbPrivs) > 0 {
+		serverStmts = append(serverStmts, fmt.Sprintf("%s %s ON DATABASE %s %s %s",
+			verb, strings.Join(dbPrivs, ", "), quoteIdentifier(dbName), preposition, target))
+	}
+	if len(tablePrivs) > 0 {
+		privs := strings.Join(tablePrivs, ", ")
+		if !revoke {
+			databaseStmts = append(databaseStmts, fmt.Sprintf("GRANT USAGE ON SCHEMA public TO %s", target))
+		}
+		databaseStmts = append(databaseStmts,
+			fmt.Sprintf("%s %s ON ALL TABLES IN SCHEMA public %s %s", verb, privs, preposition, target),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public %s %s ON TABLES %s %s", verb, privs, preposition, target),
+		)
+		if seqPrivs := postgresSequencePrivileges(tablePrivs); len(seqPrivs) > 0 {
+			privs := strings.Join(seqPrivs, ", ")
+			databaseStmts = append(databaseStmts,
+				fmt.Sprintf("%s %s ON ALL SEQUENCES IN SCHEMA public %s %s", verb, privs, preposition, target),
+				fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public %s %s ON SEQUENCES %s %s", verb, privs, preposition, target),
+			)
+		}
+	}
+	return serverStmts, databaseStmts
+}
+
+// postgresSequencePrivileges returns the privileges on sequences that go with table privileges
+// SELECT allows currval, INSERT needs USAGE for the nextval of serial and identity columns and
+// UPDATE allows setval
+func postgresSequencePrivileges(tablePrivs []string) []string {
+	var seqPrivs []string
+	for _, privilege := range []string{"SELECT", "INSERT", "UPDATE"} {
+		if !slices.Contains(tablePrivs, privilege) {
+			continue
+		}
+		if privilege == "INSERT" {
+			privilege = "USAGE"
+		}
+		seqPrivs = append(seqPrivs, privilege)
+	}
+	return seqPrivs
+}
+
+// postgresOwnerDefaultPrivilegeStatements returns the statements run in the target database that grant
+// the table privileges among privileges on the current and future tables of owner to grantee
+// Database privileges are skipped, ALL stands for all table privileges.
+func postgresOwnerDefaultPrivilegeStatements(owner, grantee string, privileges []string) []string {
+	var tablePrivs []string
+	for _, privilege := range privileges {
+		p := strings.ToUpper(privilege)
+		switch {
+		case isAllPrivileges(p):
+			tablePrivs = []string{"ALL"}
+		case postgresDatabasePrivileges[p]:
+		case !slices.Contains(tablePrivs, "ALL"):
+			tablePrivs = append(tablePrivs, p)
+		}
+	}
+	if len(tablePrivs) == 0 {
+		return nil
+	}
+
+	privs := strings.Join(tablePrivs, ", ")
+	stmts := []string{
+		fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA public TO %s", privs, quoteIdentifier(grantee)),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA public GRANT %s ON TABLES TO %s",
+			quoteIdentifier(owner), privs, quoteIdentifier(grantee)),
+	}
+	seqPrivs := postgresSequencePrivileges(tablePrivs)
+	if slices.Contains(tablePrivs, "ALL") {
+		seqPrivs = []string{"ALL"}
+	}
+	if len(seqPrivs) > 0 {
+		privs := strings.Join(seqPrivs, ", ")
+		stmts = append(stmts,
+			fmt.Sprintf("GRANT %s ON ALL SEQUENCES IN SCHEMA public TO %s", privs, quoteIdentifier(grantee)),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA public GRANT %s ON SEQUENCES TO %s",
+				quoteIdentifier(owner), privs, quoteIdentifier(grantee)),
+		)
+	}
+	return stmts
+}
+
+// withoutDefaultPrivileges removes the ALTER DEFAULT PRIVILEGES statements, which YugabyteDB rejects
+func withoutDefaultPrivileges(stmts []string) []string {
+	var filtered []string
+	for _, stmt := range stmts {
+		if !strings.HasPrefix(stmt, "ALTER DEFAULT PRIVILEGES") {
+			filtered = append(filtered, stmt)
+		}
+	}
+	return filtered
+}
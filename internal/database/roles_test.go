@@ -0,0 +1,176 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidatePrivileges(t *testing.T) {
+	tests := []struct {
+		name       string
+		privileges []string
+		wantErr    bool
+	}{
+		{name: "empty", privileges: nil},
+		{name: "keywords", privileges: []string{"SELECT", "insert", "ALL PRIVILEGES"}},
+		{name: "statement injection", privileges: []string{"SELECT; DROP TABLE users"}, wantErr: true},
+		{name: "column list", privileges: []string{"SELECT (id)"}, wantErr: true},
+		{name: "empty privilege", privileges: []string{""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePrivileges(tt.privileges)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePrivileges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostgresPrivilegeStatements(t *testing.T) {
+	tests := []struct {
+		name         string
+		privileges   []string
+		revoke       bool
+		wantServer   []string
+		wantDatabase []string
+	}{
+		{
+			name:       "all",
+			privileges: []string{"SELECT", "all"},
+			wantServer: []string{`GRANT ALL ON DATABASE "app" TO "readers"`},
+			wantDatabase: []string{
+				`GRANT ALL ON SCHEMA public TO "readers"`,
+				`GRANT ALL ON ALL TABLES IN SCHEMA public TO "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT ALL ON TABLES TO "readers"`,
+				`GRANT ALL ON ALL SEQUENCES IN SCHEMA public TO "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT ALL ON SEQUENCES TO "readers"`,
+			},
+		},
+		{
+			name:       "table privileges add connect",
+			privileges: []string{"select", "UPDATE"},
+			wantServer: []string{`GRANT CONNECT ON DATABASE "app" TO "readers"`},
+			wantDatabase: []string{
+				`GRANT USAGE ON SCHEMA public TO "readers"`,
+				`GRANT SELECT, UPDATE ON ALL TABLES IN SCHEMA public TO "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, UPDATE ON TABLES TO "readers"`,
+				`GRANT SELECT, UPDATE ON ALL SEQUENCES IN SCHEMA public TO "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, UPDATE ON SEQUENCES TO "readers"`,
+			},
+		},
+		{
+			name:       "insert needs sequence usage",
+			privileges: []string{"INSERT", "DELETE"},
+			wantServer: []string{`GRANT CONNECT ON DATABASE "app" TO "readers"`},
+			wantDatabase: []string{
+				`GRANT USAGE ON SCHEMA public TO "readers"`,
+				`GRANT INSERT, DELETE ON ALL TABLES IN SCHEMA public TO "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT INSERT, DELETE ON TABLES TO "readers"`,
+				`GRANT USAGE ON ALL SEQUENCES IN SCHEMA public TO "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE ON SEQUENCES TO "readers"`,
+			},
+		},
+		{
+			name:       "database privileges only",
+			privileges: []string{"CONNECT", "TEMPORARY"},
+			wantServer: []string{`GRANT CONNECT, TEMPORARY ON DATABASE "app" TO "readers"`},
+		},
+		{
+			name:       "revoke table privileges keeps connect",
+			privileges: []string{"DELETE"},
+			revoke:     true,
+			wantDatabase: []string{
+				`REVOKE DELETE ON ALL TABLES IN SCHEMA public FROM "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE DELETE ON TABLES FROM "readers"`,
+			},
+		},
+		{
+			name:       "revoke all",
+			privileges: []string{"ALL PRIVILEGES"},
+			revoke:     true,
+			wantServer: []string{`REVOKE ALL ON DATABASE "app" FROM "readers"`},
+			wantDatabase: []string{
+				`REVOKE ALL ON SCHEMA public FROM "readers"`,
+				`REVOKE ALL ON ALL TABLES IN SCHEMA public FROM "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE ALL ON TABLES FROM "readers"`,
+				`REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM "readers"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE ALL ON SEQUENCES FROM "readers"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, db := postgresPrivilegeStatements("app", "readers", tt.privileges, tt.revoke)
+			if !reflect.DeepEqual(server, tt.wantServer) {
+				t.Errorf("server statements = %q, want %q", server, tt.wantServer)
+			}
+			if !reflect.DeepEqual(db, tt.wantDatabase) {
+				t.Errorf("database statements = %q, want %q", db, tt.wantDatabase)
+			}
+		})
+	}
+}
+
+func TestPostgresOwnerDefaultPrivilegeStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		privileges []string
+		want       []string
+	}{
+		{
+			name:       "all",
+			privileges: []string{"SELECT", "ALL PRIVILEGES"},
+			want: []string{
+				`GRANT ALL ON ALL TABLES IN SCHEMA public TO "app"`,
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app_migrator" IN SCHEMA public GRANT ALL ON TABLES TO "app"`,
+				`GRANT ALL ON ALL SEQUENCES IN SCHEMA public TO "app"`,
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app_migrator" IN SCHEMA public GRANT ALL ON SEQUENCES TO "app"`,
+			},
+		},
+		{
+			name:       "database privileges are skipped",
+			privileges: []string{"CONNECT", "select", "INSERT"},
+			want: []string{
+				`GRANT SELECT, INSERT ON ALL TABLES IN SCHEMA public TO "app"`,
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app_migrator" IN SCHEMA public GRANT SELECT, INSERT ON TABLES TO "app"`,
+				`GRANT SELECT, USAGE ON ALL SEQUENCES IN SCHEMA public TO "app"`,
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app_migrator" IN SCHEMA public GRANT SELECT, USAGE ON SEQUENCES TO "app"`,
+			},
+		},
+		{
+			name:       "database privileges only",
+			privileges: []string{"CONNECT", "TEMPORARY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := postgresOwnerDefaultPrivilegeStatements("app_migrator", "app", tt.privileges)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postgresOwnerDefaultPrivilegeStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutDefaultPrivileges(t *testing.T) {
+	_, stmts := postgresPrivilegeStatements("app", "readers", []string{"SELECT"}, false)
+	want := []string{
+		`GRANT USAGE ON SCHEMA public TO "readers"`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA public TO "readers"`,
+		`GRANT SELECT ON ALL SEQUENCES IN SCHEMA public TO "readers"`,
+	}
+	if got := withoutDefaultPrivileges(stmts); !reflect.DeepEqual(got, want) {
+		t.Errorf("withoutDefaultPrivileges() = %q, want %q", got, want)
+	}
+}
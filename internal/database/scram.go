@@ -0,0 +1,71 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// scramIterations is the iteration count of the SCRAM-SHA-256 verifiers, the PostgreSQL default
+const scramIterations = 4096
+
+// scramSaltLength is the length of the random salt of a SCRAM-SHA-256 verifier, the PostgreSQL default
+const scramSaltLength = 16
+
+// ScramSHA256Verifier hashes a password into a PostgreSQL SCRAM-SHA-256 verifier
+// PostgreSQL stores a verifier given as the password of CREATE ROLE or ALTER ROLE as it is, so the
+// plaintext password never reaches the server. Passwords are not normalized with SASLprep, which
+// leaves printable ASCII unchanged; other passwords are rejected.
+func ScramSHA256Verifier(password string) (string, error) {
+	if !isPrintableASCII(password) {
+		return "", fmt.Errorf("only passwords of printable ASCII characters can be hashed client-side")
+	}
+	salt := make([]byte, scramSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return scramSHA256Verifier(password, salt, scramIterations)
+}
+
+// scramSHA256Verifier returns the verifier of a password with the given salt and iteration count
+func scramSHA256Verifier(password string, salt []byte, iterations int) (string, error) {
+	saltedPassword, err := pbkdf2.Key(sha256.New, password, salt, iterations, sha256.Size)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	clientKey := scramHMAC(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	serverKey := scramHMAC(saltedPassword, "Server Key")
+
+	return fmt.Sprintf("SCRAM-SHA-256$%d:%s$%s:%s", iterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(storedKey[:]),
+		base64.StdEncoding.EncodeToString(serverKey)), nil
+}
+
+// scramHMAC returns the HMAC-SHA-256 of message with key
+func scramHMAC(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+// isPrintableASCII reports whether s only contains printable ASCII characters
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScramSHA256Verifier(t *testing.T) {
+	salt := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	got, err := scramSHA256Verifier("correct horse battery staple", salt, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$ONYbSJBXtKl6bP6PVqw8pm9e7EiacprLnoUQPFS80Hw=:IPOtHuGJ2HifEQg74W2XXqqCrCyQG55GbPRHa6g6n9w="
+	if got != want {
+		t.Errorf("scramSHA256Verifier() = %q, want %q", got, want)
+	}
+
+	first, err := ScramSHA256Verifier("s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := ScramSHA256Verifier("s3cret")
+	if !strings.HasPrefix(first, "SCRAM-SHA-256$4096:") || first == second {
+		t.Errorf("ScramSHA256Verifier() = %q and %q, want verifiers with random salts", first, second)
+	}
+
+	if _, err := ScramSHA256Verifier("pässword"); err == nil {
+		t.Error("ScramSHA256Verifier() accepted a non-ASCII password")
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// statementWatchdogGrace is how much longer than the statement timeout the watchdog waits, so the
+// server cancels a statement with its own error first
+const statementWatchdogGrace = 5 * time.Second
+
+var (
+	statementObserverMu sync.RWMutex
+	statementObserver   func(engine string)
+)
+
+// SetStatementTimeoutObserver registers a function called with the engine family of every statement
+// canceled by the watchdog, e.g. to record it in metrics
+func SetStatementTimeoutObserver(observer func(engine string)) {
+	statementObserverMu.Lock()
+	defer statementObserverMu.Unlock()
+	statementObserver = observer
+}
+
+func observeStatementTimeout(engine string) {
+	statementObserverMu.RLock()
+	observer := statementObserver
+	statementObserverMu.RUnlock()
+	if observer != nil {
+		observer(engine)
+	}
+}
+
+// noStatementTimeoutKey marks the context of statements exempt from the statement timeout
+type noStatementTimeoutKey struct{}
+
+// withoutStatementTimeout returns a context whose statements the watchdog does not cancel, for
+// statements whose duration grows with the data they copy, e.g. CREATE DATABASE ... TEMPLATE
+func withoutStatementTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noStatementTimeoutKey{}, true)
+}
+
+// WithStatementTimeout returns a connection string of an engine whose sessions cancel statements that
+// run longer than timeout on the server, unless the connection string sets the timeout itself
+// PostgreSQL cancels any statement after statement_timeout, including one waiting for a lock. MySQL has
+// no such setting for GRANT and CREATE USER, lock_wait_timeout bounds their wait for metadata locks.
+func WithStatementTimeout(engine, connectionString string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		return connectionString, nil
+	}
+	switch EngineFamily(engine) {
+	case "postgres":
+		return setConnectionParams(engine, connectionString,
+			map[string]string{"statement_timeout": strconv.FormatInt(timeout.Milliseconds(), 10)}, false)
+	case "mysql":
+		seconds := int64((timeout + time.Second - 1) / time.Second)
+		return setConnectionParams(engine, connectionString,
+			map[string]string{"lock_wait_timeout": strconv.FormatInt(seconds, 10)}, false)
+	default:
+		return connectionString, nil
+	}
+}
+
+// watchdogConnector opens connections whose statements are canceled once they run longer than the
+// timeout and statementWatchdogGrace, should the server not cancel them itself, e.g. a statement stuck
+// on a lost connection. Transactions are bounded by the context of BeginTx only, their statements
+// by the watchdog.
+type watchdogConnector struct {
+	driver.Connector
+	engine  string
+	timeout time.Duration
+}
+
+// newWatchdogConnector returns connector with the watchdog, or connector itself without a timeout
+func newWatchdogConnector(engine string, connector driver.Connector, timeout time.Duration) driver.Connector {
+	if timeout <= 0 {
+		return connector
+	}
+	return &watchdogConnector{Connector: connector, engine: EngineFamily(engine), timeout: timeout + statementWatchdogGrace}
+}
+
+// Connect implements driver.Connector
+func (c *watchdogConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &watchdogConn{Conn: conn, engine: c.engine, timeout: c.timeout}, nil
+}
+
+type watchdogConn struct {
+	driver.Conn
+	engine  string
+	timeout time.Duration
+}
+
+// watch returns the context of a statement, canceled by the watchdog after the timeout
+func (c *watchdogConn) watch(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx.Value(noStatementTimeoutKey{}) != nil {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// canceled returns the error of a statement, naming the watchdog if it canceled the statement
+func (c *watchdogConn) canceled(ctx, statementCtx context.Context, err error) error {
+	if err == nil || ctx.Err() != nil || !errors.Is(statementCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	observeStatementTimeout(c.engine)
+	return fmt.Errorf("statement canceled after %s by the statement timeout of the operator: %w", c.timeout, err)
+}
+
+// ExecContext implements driver.ExecerContext
+func (c *watchdogConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	statementCtx, cancel := c.watch(ctx)
+	defer cancel()
+	result, err := execer.ExecContext(statementCtx, query, args)
+	return result, c.canceled(ctx, statementCtx, err)
+}
+
+// QueryContext implements driver.QueryerContext, the watchdog covers reading the rows until they are closed
+func (c *watchdogConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	statementCtx, cancel := c.watch(ctx)
+	rows, err := queryer.QueryContext(statementCtx, query, args)
+	if err != nil {
+		cancel()
+		return nil, c.canceled(ctx, statementCtx, err)
+	}
+	return &watchdogRows{Rows: rows, cancel: cancel}, nil
+}
+
+// PrepareContext implements driver.ConnPrepareContext
+func (c *watchdogConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Prepare(query)
+}
+
+// BeginTx implements driver.ConnBeginTx
+func (c *watchdogConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return nil, fmt.Errorf("the %s driver does not support transactions with a context", c.engine)
+}
+
+// Ping implements driver.Pinger
+func (c *watchdogConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession implements driver.SessionResetter
+func (c *watchdogConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid implements driver.Validator
+func (c *watchdogConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue implements driver.NamedValueChecker
+func (c *watchdogConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// watchdogRows stops the watchdog of a query when its rows are closed
+type watchdogRows struct {
+	driver.Rows
+	cancel context.CancelFunc
+}
+
+// Close implements driver.Rows
+func (r *watchdogRows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
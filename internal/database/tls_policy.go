@@ -0,0 +1,132 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// postgresSSLModes ranks the sslmode values of PostgreSQL from the weakest to the strongest
+var postgresSSLModes = map[string]int{
+	"disable":     0,
+	"allow":       1,
+	"prefer":      2,
+	"require":     3,
+	"verify-ca":   4,
+	"verify-full": 5,
+}
+
+// mysqlInsecureTLS are the values of the tls parameter of MySQL that allow unencrypted connections
+var mysqlInsecureTLS = map[string]bool{
+	"false":     true,
+	"0":         true,
+	"preferred": true,
+}
+
+// TLSPolicy is the encryption required of all admin connections
+// A connection string that sets its own sslmode or tls parameter keeps it, unless it is weaker than
+// the policy, so a single misconfigured connection string cannot downgrade to an unencrypted connection.
+type TLSPolicy struct {
+	// PostgresSSLMode is the sslmode of PostgreSQL and YugabyteDB connection strings without one.
+	// Empty keeps the default of the driver, require.
+	PostgresSSLMode string
+
+	// MySQLRequireTLS sets tls=true on MySQL and MariaDB connection strings without a tls parameter
+	MySQLRequireTLS bool
+}
+
+// Validate checks that PostgresSSLMode is an sslmode of PostgreSQL
+func (p TLSPolicy) Validate() error {
+	if _, ok := postgresSSLModes[p.PostgresSSLMode]; p.PostgresSSLMode != "" && !ok {
+		return fmt.Errorf("unsupported PostgreSQL sslmode %q, expected disable, allow, prefer, require, verify-ca or verify-full",
+			p.PostgresSSLMode)
+	}
+	return nil
+}
+
+// Apply returns the connection string of an engine with the encryption of the policy
+// Engines without a setting of the policy are returned unchanged.
+func (p TLSPolicy) Apply(engine, connectionString string) (string, error) {
+	switch EngineFamily(engine) {
+	case "postgres":
+		if p.PostgresSSLMode == "" {
+			return connectionString, nil
+		}
+		return applyPostgresSSLMode(connectionString, p.PostgresSSLMode)
+	case "mysql":
+		if !p.MySQLRequireTLS {
+			return connectionString, nil
+		}
+		return applyMySQLRequireTLS(connectionString)
+	default:
+		return connectionString, nil
+	}
+}
+
+// applyPostgresSSLMode sets sslmode on a connection URL without one, and rejects a weaker sslmode
+func applyPostgresSSLMode(connectionString, sslMode string) (string, error) {
+	u, err := parseURL(connectionString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse connection URL: %w", err)
+	}
+	query := u.Query()
+	current := query.Get("sslmode")
+	if current == "" {
+		query.Set("sslmode", sslMode)
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+	if rank, ok := postgresSSLModes[current]; ok && rank < postgresSSLModes[sslMode] {
+		return "", fmt.Errorf("sslmode %s of the admin connection string is weaker than the required sslmode %s", current, sslMode)
+	}
+	return connectionString, nil
+}
+
+// applyMySQLRequireTLS sets tls=true on a MySQL URL or DSN without a tls parameter, and rejects
+// parameters that allow unencrypted connections
+func applyMySQLRequireTLS(connectionString string) (string, error) {
+	params, err := mysqlParams(connectionString)
+	if err != nil {
+		return "", err
+	}
+	query, err := url.ParseQuery(params)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the parameters of the connection string: %w", err)
+	}
+	current := query.Get("tls")
+	if current == "" {
+		separator := "?"
+		if params != "" {
+			separator = "&"
+		}
+		return connectionString + separator + "tls=true", nil
+	}
+	if mysqlInsecureTLS[strings.ToLower(current)] {
+		return "", fmt.Errorf("tls=%s of the admin connection string allows unencrypted connections, TLS is required", current)
+	}
+	return connectionString, nil
+}
+
+// mysqlParams returns the parameters of a MySQL URL or DSN, which follow the ? after the database name
+func mysqlParams(connectionString string) (string, error) {
+	if strings.HasPrefix(connectionString, "mysql://") {
+		u, err := parseURL(connectionString)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse connection URL: %w", err)
+		}
+		return u.RawQuery, nil
+	}
+	rest := connectionString
+	if i := strings.LastIndex(rest, ")/"); i >= 0 {
+		rest = rest[i:]
+	}
+	_, params, _ := strings.Cut(rest, "?")
+	return params, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import "testing"
+
+func TestTLSPolicyApply(t *testing.T) {
+	verifyFull := TLSPolicy{PostgresSSLMode: "verify-full"}
+	requireTLS := TLSPolicy{MySQLRequireTLS: true}
+
+	tests := []struct {
+		name             string
+		policy           TLSPolicy
+		engine           string
+		connectionString string
+		want             string
+		wantErr          bool
+	}{
+		{
+			name:             "no policy",
+			engine:           "postgres",
+			connectionString: "postgres://admin:secret@db:5432/postgres?sslmode=disable",
+			want:             "postgres://admin:secret@db:5432/postgres?sslmode=disable",
+		},
+		{
+			name:             "postgres without sslmode",
+			policy:           verifyFull,
+			engine:           "postgres",
+			connectionString: "postgres://admin:secret@db:5432/postgres?connect_timeout=5",
+			want:             "postgres://admin:secret@db:5432/postgres?connect_timeout=5&sslmode=verify-full",
+		},
+		{
+			name:             "yugabyte without sslmode",
+			policy:           verifyFull,
+			engine:           "yugabyte",
+			connectionString: "postgresql://admin@db:5433/yugabyte",
+			want:             "postgresql://admin@db:5433/yugabyte?sslmode=verify-full",
+		},
+		{
+			name:             "postgres with an equal sslmode",
+			policy:           TLSPolicy{PostgresSSLMode: "require"},
+			engine:           "postgres",
+			connectionString: "postgres://admin@db/postgres?sslmode=verify-ca",
+			want:             "postgres://admin@db/postgres?sslmode=verify-ca",
+		},
+		{
+			name:             "postgres with a weaker sslmode",
+			policy:           verifyFull,
+			engine:           "postgres",
+			connectionString: "postgres://admin@db/postgres?sslmode=require",
+			wantErr:          true,
+		},
+		{
+			name:             "mysql url without tls",
+			policy:           requireTLS,
+			engine:           "mysql",
+			connectionString: "mysql://admin:p%3Fss@db:3306/mysql",
+			want:             "mysql://admin:p%3Fss@db:3306/mysql?tls=true",
+		},
+		{
+			name:             "mysql dsn with parameters",
+			policy:           requireTLS,
+			engine:           "mariadb",
+			connectionString: "admin:p?ss@tcp(db:3306)/mysql?parseTime=true",
+			want:             "admin:p?ss@tcp(db:3306)/mysql?parseTime=true&tls=true",
+		},
+		{
+			name:             "mysql dsn with skip-verify",
+			policy:           requireTLS,
+			engine:           "mysql",
+			connectionString: "admin:secret@tcp(db:3306)/mysql?tls=skip-verify",
+			want:             "admin:secret@tcp(db:3306)/mysql?tls=skip-verify",
+		},
+		{
+			name:             "mysql with preferred tls",
+			policy:           requireTLS,
+			engine:           "mysql",
+			connectionString: "mysql://admin@db/mysql?tls=preferred",
+			wantErr:          true,
+		},
+		{
+			name:             "other engine",
+			policy:           TLSPolicy{PostgresSSLMode: "verify-full", MySQLRequireTLS: true},
+			engine:           "cassandra",
+			connectionString: "cassandra://admin@db:9042/system?sslmode=disable",
+			want:             "cassandra://admin@db:9042/system?sslmode=disable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.Apply(tt.engine, tt.connectionString)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTLSPolicyValidate(t *testing.T) {
+	if err := (TLSPolicy{PostgresSSLMode: "verify-full"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (TLSPolicy{PostgresSSLMode: "strict"}).Validate(); err == nil {
+		t.Error("Validate() accepted an unknown sslmode")
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// usernameHashLength is the number of hex characters of the hash that replaces the end of a truncated username
+const usernameHashLength = 8
+
+// MaxUsernameLength returns the longest username the engine accepts, in bytes
+// PostgreSQL truncates longer identifiers silently, MySQL 5.7+ rejects names over 32 characters and
+// MariaDB over 80. Zero means the engine has no limit that a Database name could exceed.
+func MaxUsernameLength(engine string) int {
+	if strings.EqualFold(engine, "mariadb") {
+		return 80
+	}
+	switch EngineFamily(engine) {
+	case "postgres":
+		return 63
+	case "mysql":
+		return 32
+	case "snowflake":
+		return 255
+	default:
+		return 0
+	}
+}
+
+// TruncateUsername shortens a username to maxLength bytes, replacing its end with an underscore and
+// a hash of the full name so that different long names stay distinct. Names that fit are unchanged.
+func TruncateUsername(username string, maxLength int) string {
+	if maxLength <= usernameHashLength+1 || len(username) <= maxLength {
+		return username
+	}
+	sum := sha256.Sum256([]byte(username))
+	return username[:maxLength-usernameHashLength-1] + "_" + hex.EncodeToString(sum[:])[:usernameHashLength]
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaxUsernameLength(t *testing.T) {
+	tests := map[string]int{
+		"postgres":  63,
+		"yugabyte":  63,
+		"mysql":     32,
+		"mariadb":   80,
+		"snowflake": 255,
+		"cassandra": 0,
+	}
+	for engine, want := range tests {
+		if got := MaxUsernameLength(engine); got != want {
+			t.Errorf("MaxUsernameLength(%s) = %d, want %d", engine, got, want)
+		}
+	}
+}
+
+func TestTruncateUsername(t *testing.T) {
+	short := "orders"
+	if got := TruncateUsername(short, 32); got != short {
+		t.Errorf("TruncateUsername(%s) = %s, want it unchanged", short, got)
+	}
+
+	long := "customer_order_history_reporting_service"
+	got := TruncateUsername(long, 32)
+	if len(got) != 32 {
+		t.Errorf("TruncateUsername() = %s with %d characters, want 32", got, len(got))
+	}
+	if !strings.HasPrefix(got, "customer_order_history_") {
+		t.Errorf("TruncateUsername() = %s, want the start of the name kept", got)
+	}
+	if again := TruncateUsername(long, 32); again != got {
+		t.Errorf("TruncateUsername() = %s then %s, want a deterministic result", got, again)
+	}
+	other := TruncateUsername("customer_order_history_reporting_worker", 32)
+	if other == got {
+		t.Errorf("TruncateUsername() = %s for two different names, want distinct names", got)
+	}
+}
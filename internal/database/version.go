@@ -0,0 +1,120 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Server capabilities derived from the server version
+const (
+	// CapabilityRoles indicates that the server supports roles
+	// PostgreSQL, Cassandra and Snowflake always do, MySQL since 8.0 and MariaDB since 10.0.5
+	CapabilityRoles = "roles"
+
+	// CapabilitySecurePublicSchema indicates that PUBLIC cannot create objects in the public schema
+	// of new databases by default, which is the case since PostgreSQL 15
+	CapabilitySecurePublicSchema = "secure-public-schema"
+
+	// CapabilityScram indicates that passwords can be stored as SCRAM-SHA-256 verifiers,
+	// which is the case since PostgreSQL 10
+	CapabilityScram = "scram-sha-256"
+
+	// CapabilityAccountLocking indicates that ALTER USER supports ACCOUNT LOCK and ACCOUNT UNLOCK,
+	// which is the case since MySQL 5.7.6 and MariaDB 10.4.2
+	CapabilityAccountLocking = "account-locking"
+
+	// CapabilityPasswordExpiration indicates that ALTER USER supports PASSWORD EXPIRE INTERVAL,
+	// which is the case since MySQL 5.7.4 and MariaDB 10.4.3
+	CapabilityPasswordExpiration = "password-expiration"
+)
+
+// versionNumberPattern matches the first dotted version number in a server version string
+var versionNumberPattern = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// ServerVersion is the parsed version of a database server
+type ServerVersion struct {
+	// Raw is the version string reported by the server
+	Raw string
+
+	Major int
+	Minor int
+	Patch int
+
+	// MariaDB is set for MariaDB servers, which report their own version numbers
+	MariaDB bool
+}
+
+// ParseServerVersion parses the output of SELECT version() or SELECT @@version
+// Examples: "PostgreSQL 15.4 on x86_64-pc-linux-gnu, ...", "8.0.35", "10.11.6-MariaDB-log"
+func ParseServerVersion(raw string) ServerVersion {
+	v := ServerVersion{
+		Raw:     strings.TrimSpace(raw),
+		MariaDB: strings.Contains(raw, "MariaDB"),
+	}
+
+	m := versionNumberPattern.FindStringSubmatch(raw)
+	if m == nil {
+		return v
+	}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	return v
+}
+
+// AtLeast reports whether the version is at least major.minor.patch
+func (v ServerVersion) AtLeast(major, minor, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// Capabilities returns the capabilities of a server of the given engine with this version
+func (v ServerVersion) Capabilities(engine string) []string {
+	var capabilities []string
+	switch EngineFamily(engine) {
+	case "postgres":
+		capabilities = append(capabilities, CapabilityRoles)
+		if v.AtLeast(10, 0, 0) {
+			capabilities = append(capabilities, CapabilityScram)
+		}
+		if v.AtLeast(15, 0, 0) {
+			capabilities = append(capabilities, CapabilitySecurePublicSchema)
+		}
+	case "mysql":
+		if (v.MariaDB && v.AtLeast(10, 0, 5)) || (!v.MariaDB && v.AtLeast(8, 0, 0)) {
+			capabilities = append(capabilities, CapabilityRoles)
+		}
+		if (v.MariaDB && v.AtLeast(10, 4, 2)) || (!v.MariaDB && v.AtLeast(5, 7, 6)) {
+			capabilities = append(capabilities, CapabilityAccountLocking)
+		}
+		if (v.MariaDB && v.AtLeast(10, 4, 3)) || (!v.MariaDB && v.AtLeast(5, 7, 4)) {
+			capabilities = append(capabilities, CapabilityPasswordExpiration)
+		}
+	case "cassandra", "snowflake":
+		capabilities = append(capabilities, CapabilityRoles)
+	}
+	return capabilities
+}
+
+// HasCapability reports whether a server of the given engine with this version has the capability
+func (v ServerVersion) HasCapability(engine, capability string) bool {
+	for _, c := range v.Capabilities(engine) {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
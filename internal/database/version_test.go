@@ -0,0 +1,107 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want ServerVersion
+	}{
+		{
+			name: "postgres",
+			raw:  "PostgreSQL 15.4 on x86_64-pc-linux-gnu, compiled by gcc (GCC) 7.3.1 20180712 (Red Hat 7.3.1-12), 64-bit",
+			want: ServerVersion{Major: 15, Minor: 4},
+		},
+		{
+			name: "postgres development build",
+			raw:  "PostgreSQL 17devel on aarch64-unknown-linux-gnu",
+			want: ServerVersion{Major: 17},
+		},
+		{
+			name: "mysql",
+			raw:  "8.0.35",
+			want: ServerVersion{Major: 8, Minor: 0, Patch: 35},
+		},
+		{
+			name: "mariadb",
+			raw:  "10.11.6-MariaDB-log",
+			want: ServerVersion{Major: 10, Minor: 11, Patch: 6, MariaDB: true},
+		},
+		{
+			name: "unparsable",
+			raw:  "unknown",
+			want: ServerVersion{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Raw = tt.raw
+			if got := ParseServerVersion(tt.raw); got != tt.want {
+				t.Errorf("ParseServerVersion(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerVersionAtLeast(t *testing.T) {
+	v := ServerVersion{Major: 10, Minor: 0, Patch: 5}
+
+	tests := []struct {
+		major, minor, patch int
+		want                bool
+	}{
+		{9, 6, 0, true},
+		{10, 0, 5, true},
+		{10, 0, 6, false},
+		{10, 1, 0, false},
+		{11, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := v.AtLeast(tt.major, tt.minor, tt.patch); got != tt.want {
+			t.Errorf("AtLeast(%d, %d, %d) = %v, want %v", tt.major, tt.minor, tt.patch, got, tt.want)
+		}
+	}
+}
+
+func TestServerVersionCapabilities(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine string
+		raw    string
+		want   []string
+	}{
+		{name: "postgres 9.6", engine: "postgres", raw: "PostgreSQL 9.6.24", want: []string{CapabilityRoles}},
+		{name: "postgres 14", engine: "postgres", raw: "PostgreSQL 14.9", want: []string{CapabilityRoles, CapabilityScram}},
+		{name: "postgres 15", engine: "postgresql", raw: "PostgreSQL 15.4", want: []string{CapabilityRoles, CapabilityScram, CapabilitySecurePublicSchema}},
+		{name: "mysql 5.7", engine: "mysql", raw: "5.7.44-log", want: []string{CapabilityAccountLocking, CapabilityPasswordExpiration}},
+		{name: "mysql 8", engine: "mysql", raw: "8.0.35", want: []string{CapabilityRoles, CapabilityAccountLocking, CapabilityPasswordExpiration}},
+		{name: "mariadb 10.0.4", engine: "mariadb", raw: "10.0.4-MariaDB", want: nil},
+		{name: "mariadb 10.4.2", engine: "mariadb", raw: "10.4.2-MariaDB", want: []string{CapabilityRoles, CapabilityAccountLocking}},
+		{name: "mariadb 10.11", engine: "mariadb", raw: "10.11.6-MariaDB", want: []string{CapabilityRoles, CapabilityAccountLocking, CapabilityPasswordExpiration}},
+		{name: "cassandra", engine: "cassandra", raw: "4.1.3", want: []string{CapabilityRoles}},
+		{name: "snowflake", engine: "snowflake", raw: "8.40.1", want: []string{CapabilityRoles}},
+		{name: "unknown engine", engine: "oracle", raw: "19.0.0", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ParseServerVersion(tt.raw)
+			if got := v.Capabilities(tt.engine); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Capabilities(%q) = %v, want %v", tt.engine, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package manifests
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	databaseuseroperator "opzkit/database-user-operator"
+)
+
+// webhookPath is the path of the validating webhook served by the manager for Databases
+const webhookPath = "/validate-database-opzkit-io-v1alpha1-database"
+
+// Options configure the rendered installation
+type Options struct {
+	// Name prefixes the names of the resources, like the release name of the Helm chart
+	Name string
+	// Namespace is the namespace of the manager
+	Namespace string
+	// CreateNamespace renders the Namespace
+	CreateNamespace bool
+	// Image is the image of the manager, e.g. a mirror in the registry of an air-gapped cluster
+	Image string
+	// ImagePullPolicy defaults to IfNotPresent
+	ImagePullPolicy string
+	// Replicas of the manager, one of which is the elected leader
+	Replicas int
+	// Args are the flags of the manager baked into the Deployment
+	Args []string
+	// ProbePort is the port of --health-probe-bind-address, 8081 by default
+	ProbePort int
+	// TerminationGracePeriod lets in-flight reconciliations finish, it must exceed
+	// --graceful-shutdown-timeout. Defaults to 150s like the Helm chart.
+	TerminationGracePeriod time.Duration
+	// Webhooks renders the webhook Service, its cert-manager certificate and the
+	// ValidatingWebhookConfiguration, for managers started with --enable-webhooks
+	Webhooks bool
+
+	// CloneJobs, HookJobs, SecretProviderClasses, RestartTargets and Adoption grant the permissions
+	// of the optional features like the values of the same name of the Helm chart
+	CloneJobs             bool
+	HookJobs              bool
+	SecretProviderClasses bool
+	RestartTargets        bool
+	Adoption              bool
+	// ClusterDatabases, DatabaseRoles and DatabaseGrants render the ClusterRoles of the controllers
+	// started with --enable-cluster-databases, --enable-database-roles and --enable-database-grants
+	ClusterDatabases bool
+	DatabaseRoles    bool
+	DatabaseGrants   bool
+}
+
+// object is a Kubernetes manifest, rendered with sorted keys like the Helm chart
+type object = map[string]interface{}
+
+// Render returns the installation of the operator as a single multi-document YAML: the
+// CustomResourceDefinitions, RBAC and Deployment, and the webhook configuration if enabled
+func Render(opts Options) ([]byte, error) {
+	if opts.Name == "" || opts.Namespace == "" {
+		return nil, fmt.Errorf("name and namespace are required")
+	}
+	if opts.Image == "" {
+		return nil, fmt.Errorf("image is required")
+	}
+	if opts.ImagePullPolicy == "" {
+		opts.ImagePullPolicy = "IfNotPresent"
+	}
+	if opts.ProbePort == 0 {
+		opts.ProbePort = 8081
+	}
+	if opts.TerminationGracePeriod == 0 {
+		opts.TerminationGracePeriod = 150 * time.Second
+	}
+	if opts.Replicas < 1 {
+		return nil, fmt.Errorf("replicas must be at least 1, got %d", opts.Replicas)
+	}
+
+	var docs [][]byte
+	add := func(objects ...object) error {
+		for _, o := range objects {
+			data, err := yaml.Marshal(o)
+			if err != nil {
+				return fmt.Errorf("failed to render %s: %w", o["kind"], err)
+			}
+			docs = append(docs, data)
+		}
+		return nil
+	}
+
+	if opts.CreateNamespace {
+		if err := add(object{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   opts.metadata(opts.Namespace, false),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	crds, err := crds()
+	if err != nil {
+		return nil, err
+	}
+	docs = append(docs, crds...)
+
+	rbac, err := opts.rbac()
+	if err != nil {
+		return nil, err
+	}
+	if err := add(rbac...); err != nil {
+		return nil, err
+	}
+	if opts.Webhooks {
+		if err := add(opts.webhookCertificate()...); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(opts.deployment()); err != nil {
+		return nil, err
+	}
+	if opts.Webhooks {
+		if err := add(opts.webhookConfiguration()); err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+// crds returns the embedded CustomResourceDefinitions sorted by file name
+func crds() ([][]byte, error) {
+	files, err := fs.Glob(databaseuseroperator.CRDs, "helm/database-user-operator/crds/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var docs [][]byte
+	for _, file := range files {
+		data, err := fs.ReadFile(databaseuseroperator.CRDs, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path.Base(file), err)
+		}
+		docs = append(docs, bytes.TrimPrefix(data, []byte("---\n")))
+	}
+	return docs, nil
+}
+
+// labels returns the labels of all rendered resources
+func (o Options) labels() map[string]string {
+	labels := o.selectorLabels()
+	labels["app.kubernetes.io/managed-by"] = "database-user-operator"
+	return labels
+}
+
+// selectorLabels returns the labels selecting the pods of the manager
+func (o Options) selectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":     "database-user-operator",
+		"app.kubernetes.io/instance": o.Name,
+	}
+}
+
+// metadata returns the metadata of a resource, namespaced resources are created in the namespace of the manager
+func (o Options) metadata(name string, namespaced bool) object {
+	metadata := object{
+		"name":   name,
+		"labels": o.labels(),
+	}
+	if namespaced {
+		metadata["namespace"] = o.Namespace
+	}
+	return metadata
+}
+
+// serviceAccountSubject returns the subject binding a role to the ServiceAccount of the manager
+func (o Options) serviceAccountSubject() []object {
+	return []object{{
+		"kind":      "ServiceAccount",
+		"name":      o.Name,
+		"namespace": o.Namespace,
+	}}
+}
+
+// rule returns a policy rule of a Role or ClusterRole
+func rule(apiGroup string, resources []string, verbs ...string) object {
+	return object{
+		"apiGroups": []string{apiGroup},
+		"resources": resources,
+		"verbs":     verbs,
+	}
+}
+
+// managerRules returns the rules of the ClusterRole of the manager, which like the Helm chart only
+// grants the permissions of the optional features that are enabled
+func (o Options) managerRules() []object {
+	rules := []object{
+		rule("", []string{"events"}, "create", "patch"),
+		rule("", []string{"namespaces"}, "get"),
+		rule("", []string{"secrets"}, "get", "list", "watch"),
+		rule("database.opzkit.io", []string{"databases"}, "create", "delete", "get", "list", "patch", "update", "watch"),
+		rule("database.opzkit.io", []string{"databases/finalizers"}, "update"),
+		rule("database.opzkit.io", []string{"databases/status"}, "get", "patch", "update"),
+	}
+	if o.CloneJobs || o.HookJobs {
+		rules = append(rules,
+			rule("", []string{"secrets"}, "create", "delete"),
+			rule("batch", []string{"jobs"}, "create", "get"))
+	}
+	if o.SecretProviderClasses {
+		rules = append(rules, rule("secrets-store.csi.x-k8s.io", []string{"secretproviderclasses"}, "create", "get", "update"))
+	}
+	if o.RestartTargets {
+		rules = append(rules, rule("apps", []string{"deployments", "statefulsets"}, "get", "patch"))
+	}
+	if o.Adoption {
+		rules = append(rules, rule("database.opzkit.io", []string{"adoptionreports"}, "create", "get", "list", "update", "watch"))
+	}
+	return rules
+}
+
+// controllerRules returns the rules of the ClusterRole of an optional controller reconciling resource
+func controllerRules(resource string, verbs ...string) []object {
+	return []object{
+		rule("database.opzkit.io", []string{resource}, verbs...),
+		rule("database.opzkit.io", []string{resource + "/finalizers"}, "update"),
+		rule("database.opzkit.io", []string{resource + "/status"}, "get", "patch", "update"),
+	}
+}
+
+// clusterRole returns a ClusterRole and the ClusterRoleBinding granting it to the manager
+func (o Options) clusterRole(prefix string, rules []object) []object {
+	return []object{
+		{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "ClusterRole",
+			"metadata":   o.metadata(prefix+"-role", false),
+			"rules":      rules,
+		},
+		{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "ClusterRoleBinding",
+			"metadata":   o.metadata(prefix+"-rolebinding", false),
+			"roleRef": object{
+				"apiGroup": "rbac.authorization.k8s.io",
+				"kind":     "ClusterRole",
+				"name":     prefix + "-role",
+			},
+			"subjects": o.serviceAccountSubject(),
+		},
+	}
+}
+
+// rbac returns the ServiceAccount of the manager, the ClusterRoles of the enabled features and the
+// leader election Role with their bindings, like the Helm chart
+func (o Options) rbac() ([]object, error) {
+	leaderElectionRole, err := o.embeddedRole(databaseuseroperator.LeaderElectionRole, o.Name+"-leader-election-role", true)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []object{{
+		"apiVersion": "v1",
+		"kind":       "ServiceAccount",
+		"metadata":   o.metadata(o.Name, true),
+	}}
+	objects = append(objects, o.clusterRole(o.Name+"-manager", o.managerRules())...)
+	if o.ClusterDatabases {
+		objects = append(objects, o.clusterRole(o.Name+"-clusterdatabase-manager",
+			controllerRules("clusterdatabases", "delete", "get", "list", "patch", "update", "watch"))...)
+	}
+	if o.DatabaseRoles {
+		objects = append(objects, o.clusterRole(o.Name+"-databaserole-manager",
+			controllerRules("databaseroles", "get", "list", "patch", "update", "watch"))...)
+	}
+	if o.DatabaseGrants {
+		objects = append(objects, o.clusterRole(o.Name+"-databasegrant-manager",
+			controllerRules("databasegrants", "get", "list", "patch", "update", "watch"))...)
+	}
+
+	return append(objects,
+		leaderElectionRole,
+		object{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "RoleBinding",
+			"metadata":   o.metadata(o.Name+"-leader-election-rolebinding", true),
+			"roleRef": object{
+				"apiGroup": "rbac.authorization.k8s.io",
+				"kind":     "Role",
+				"name":     o.Name + "-leader-election-role",
+			},
+			"subjects": o.serviceAccountSubject(),
+		},
+	), nil
+}
+
+// embeddedRole returns an embedded Role renamed for the installation
+func (o Options) embeddedRole(data []byte, name string, namespaced bool) (object, error) {
+	role := object{}
+	if err := yaml.Unmarshal(data, &role); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded role %s: %w", name, err)
+	}
+	role["metadata"] = o.metadata(name, namespaced)
+	return role, nil
+}
+
+// deployment returns the Deployment of the manager with the baked in flags
+func (o Options) deployment() object {
+	probe := func(path string, initialDelay, period int) object {
+		return object{
+			"httpGet":             object{"path": path, "port": o.ProbePort},
+			"initialDelaySeconds": initialDelay,
+			"periodSeconds":       period,
+		}
+	}
+	container := object{
+		"name":            "manager",
+		"image":           o.Image,
+		"imagePullPolicy": o.ImagePullPolicy,
+		"command":         []string{"/manager"},
+		"livenessProbe":   probe("/healthz", 15, 20),
+		"readinessProbe":  probe("/readyz", 5, 10),
+		"resources": object{
+			"limits":   object{"cpu": "500m", "memory": "128Mi"},
+			"requests": object{"cpu": "10m", "memory": "64Mi"},
+		},
+		"securityContext": object{
+			"allowPrivilegeEscalation": false,
+			"capabilities":             object{"drop": []string{"ALL"}},
+		},
+	}
+	if len(o.Args) > 0 {
+		container["args"] = o.Args
+	}
+	podSpec := object{
+		"serviceAccountName":            o.Name,
+		"securityContext":               object{"runAsNonRoot": true},
+		"terminationGracePeriodSeconds": int64(o.TerminationGracePeriod / time.Second),
+		"containers":                    []object{container},
+	}
+	if o.Webhooks {
+		container["ports"] = []object{{"containerPort": 9443, "name": "webhook-server", "protocol": "TCP"}}
+		container["volumeMounts"] = []object{{
+			"name":      "webhook-certs",
+			"mountPath": "/tmp/k8s-webhook-server/serving-certs",
+			"readOnly":  true,
+		}}
+		podSpec["volumes"] = []object{{
+			"name":   "webhook-certs",
+			"secret": object{"secretName": o.Name + "-webhook-cert"},
+		}}
+	}
+
+	return object{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   o.metadata(o.Name, true),
+		"spec": object{
+			"replicas": o.Replicas,
+			"selector": object{"matchLabels": o.selectorLabels()},
+			"template": object{
+				"metadata": object{
+					"annotations": object{"kubectl.kubernetes.io/default-container": "manager"},
+					"labels":      o.selectorLabels(),
+				},
+				"spec": podSpec,
+			},
+		},
+	}
+}
+
+// webhookService returns the name of the Service in front of the webhook server
+func (o Options) webhookService() string {
+	return o.Name + "-webhook"
+}
+
+// webhookCertificate returns the Service of the webhook and the self-signed cert-manager
+// certificate it is served with, like the Helm chart
+func (o Options) webhookCertificate() []object {
+	service := o.webhookService()
+	return []object{
+		{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   o.metadata(service, true),
+			"spec": object{
+				"type":     "ClusterIP",
+				"ports":    []object{{"name": "webhook", "port": 443, "protocol": "TCP", "targetPort": "webhook-server"}},
+				"selector": o.selectorLabels(),
+			},
+		},
+		{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Issuer",
+			"metadata":   o.metadata(o.Name+"-selfsigned", true),
+			"spec":       object{"selfSigned": object{}},
+		},
+		{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata":   o.metadata(service, true),
+			"spec": object{
+				"dnsNames": []string{
+					service + "." + o.Namespace + ".svc",
+					service + "." + o.Namespace + ".svc.cluster.local",
+				},
+				"issuerRef":  object{"kind": "Issuer", "name": o.Name + "-selfsigned"},
+				"secretName": o.Name + "-webhook-cert",
+			},
+		},
+	}
+}
+
+// webhookConfiguration returns the ValidatingWebhookConfiguration of the Database webhook
+// The webhook only returns warnings, it must never block Database changes.
+func (o Options) webhookConfiguration() object {
+	metadata := o.metadata(o.Name, false)
+	metadata["annotations"] = object{"cert-manager.io/inject-ca-from": o.Namespace + "/" + o.webhookService()}
+	return object{
+		"apiVersion": "admissionregistration.k8s.io/v1",
+		"kind":       "ValidatingWebhookConfiguration",
+		"metadata":   metadata,
+		"webhooks": []object{{
+			"name":                    "vdatabase-v1alpha1.kb.io",
+			"admissionReviewVersions": []string{"v1"},
+			"clientConfig": object{
+				"service": object{
+					"name":      o.webhookService(),
+					"namespace": o.Namespace,
+					"path":      webhookPath,
+				},
+			},
+			"failurePolicy": "Ignore",
+			"sideEffects":   "None",
+			"rules": []object{{
+				"apiGroups":   []string{"database.opzkit.io"},
+				"apiVersions": []string{"v1alpha1"},
+				"operations":  []string{"CREATE", "UPDATE"},
+				"resources":   []string{"databases"},
+			}},
+		}},
+	}
+}
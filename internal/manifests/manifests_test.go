@@ -0,0 +1,281 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package manifests
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// kinds returns the kinds and names of the rendered documents
+func kinds(t *testing.T, data []byte) []string {
+	t.Helper()
+	var out []string
+	for _, doc := range bytes.Split(data, []byte("\n---\n")) {
+		var o struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(doc, &o); err != nil {
+			t.Fatalf("document is not valid YAML: %v\n%s", err, doc)
+		}
+		out = append(out, o.Kind+"/"+o.Metadata.Name)
+	}
+	return out
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		wantKinds []string
+		wantErr   string
+	}{
+		{
+			name: "default",
+			opts: Options{Name: "dbuo", Namespace: "db-system", CreateNamespace: true, Image: "registry.local/dbuo:v1", Replicas: 1},
+			wantKinds: []string{
+				"Namespace/db-system",
+				"CustomResourceDefinition/adoptionreports.database.opzkit.io",
+				"CustomResourceDefinition/clusterdatabases.database.opzkit.io",
+				"CustomResourceDefinition/databasegrants.database.opzkit.io",
+				"CustomResourceDefinition/databaseroles.database.opzkit.io",
+				"CustomResourceDefinition/databases.database.opzkit.io",
+				"ServiceAccount/dbuo",
+				"ClusterRole/dbuo-manager-role",
+				"ClusterRoleBinding/dbuo-manager-rolebinding",
+				"Role/dbuo-leader-election-role",
+				"RoleBinding/dbuo-leader-election-rolebinding",
+				"Deployment/dbuo",
+			},
+		},
+		{
+			name: "webhooks",
+			opts: Options{Name: "dbuo", Namespace: "db-system", Image: "registry.local/dbuo:v1", Replicas: 2, Webhooks: true},
+			wantKinds: []string{
+				"CustomResourceDefinition/adoptionreports.database.opzkit.io",
+				"CustomResourceDefinition/clusterdatabases.database.opzkit.io",
+				"CustomResourceDefinition/databasegrants.database.opzkit.io",
+				"CustomResourceDefinition/databaseroles.database.opzkit.io",
+				"CustomResourceDefinition/databases.database.opzkit.io",
+				"ServiceAccount/dbuo",
+				"ClusterRole/dbuo-manager-role",
+				"ClusterRoleBinding/dbuo-manager-rolebinding",
+				"Role/dbuo-leader-election-role",
+				"RoleBinding/dbuo-leader-election-rolebinding",
+				"Service/dbuo-webhook",
+				"Issuer/dbuo-selfsigned",
+				"Certificate/dbuo-webhook",
+				"Deployment/dbuo",
+				"ValidatingWebhookConfiguration/dbuo",
+			},
+		},
+		{name: "no image", opts: Options{Name: "dbuo", Namespace: "db-system", Replicas: 1}, wantErr: "image is required"},
+		{name: "no replicas", opts: Options{Name: "dbuo", Namespace: "db-system", Image: "dbuo:v1"}, wantErr: "replicas"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Render(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Render() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := kinds(t, data)
+			if strings.Join(got, "\n") != strings.Join(tt.wantKinds, "\n") {
+				t.Errorf("Render() documents =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.wantKinds, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderDeployment(t *testing.T) {
+	data, err := Render(Options{
+		Name:                   "dbuo",
+		Namespace:              "db-system",
+		Image:                  "registry.local/dbuo:v1",
+		Replicas:               1,
+		Args:                   []string{"--secret-gc-interval=6h", "--health-probe-bind-address=:9090"},
+		ProbePort:              9090,
+		TerminationGracePeriod: 90 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	docs := bytes.Split(data, []byte("\n---\n"))
+
+	var deployment struct {
+		Metadata struct {
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Template struct {
+				Spec struct {
+					ServiceAccountName            string `json:"serviceAccountName"`
+					TerminationGracePeriodSeconds int    `json:"terminationGracePeriodSeconds"`
+					Containers                    []struct {
+						Image         string   `json:"image"`
+						Args          []string `json:"args"`
+						LivenessProbe struct {
+							HTTPGet struct {
+								Port int `json:"port"`
+							} `json:"httpGet"`
+						} `json:"livenessProbe"`
+					} `json:"containers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(docs[len(docs)-1], &deployment); err != nil {
+		t.Fatal(err)
+	}
+
+	pod := deployment.Spec.Template.Spec
+	if deployment.Metadata.Namespace != "db-system" || pod.ServiceAccountName != "dbuo" || pod.TerminationGracePeriodSeconds != 90 {
+		t.Errorf("deployment = %+v", deployment)
+	}
+	if len(pod.Containers) != 1 {
+		t.Fatalf("containers = %+v, want the manager", pod.Containers)
+	}
+	manager := pod.Containers[0]
+	if manager.Image != "registry.local/dbuo:v1" || strings.Join(manager.Args, " ") != "--secret-gc-interval=6h --health-probe-bind-address=:9090" {
+		t.Errorf("manager container = %+v", manager)
+	}
+	if manager.LivenessProbe.HTTPGet.Port != 9090 {
+		t.Errorf("liveness probe port = %d, want 9090", manager.LivenessProbe.HTTPGet.Port)
+	}
+}
+
+// rbacRule is a policy rule of a Role or ClusterRole
+type rbacRule struct {
+	APIGroups []string `json:"apiGroups"`
+	Resources []string `json:"resources"`
+	Verbs     []string `json:"verbs"`
+}
+
+// roles returns the rules of the Roles and ClusterRoles among the documents by name
+func roles(t *testing.T, docs [][]byte) map[string][]rbacRule {
+	t.Helper()
+	out := map[string][]rbacRule{}
+	for _, doc := range docs {
+		var o struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Rules []rbacRule `json:"rules"`
+		}
+		if err := yaml.Unmarshal(doc, &o); err != nil {
+			t.Fatalf("document is not valid YAML: %v\n%s", err, doc)
+		}
+		if o.Kind == "Role" || o.Kind == "ClusterRole" {
+			out[o.Kind+"/"+o.Metadata.Name] = o.Rules
+		}
+	}
+	return out
+}
+
+// templateAction matches the actions of the Helm template, which all render the release name dbuo
+var templateAction = regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+// chartRoles returns the Roles and ClusterRoles of the RBAC template of the Helm chart by name, with
+// the conditions on the values evaluated by enabled
+func chartRoles(t *testing.T, enabled func(condition string) bool) map[string][]rbacRule {
+	t.Helper()
+	data, err := os.ReadFile("../../helm/database-user-operator/templates/rbac.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	var conditions []bool
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "{{- if"):
+			conditions = append(conditions, enabled(trimmed))
+		case strings.HasPrefix(trimmed, "{{- end"):
+			conditions = conditions[:len(conditions)-1]
+		case strings.HasPrefix(trimmed, "{{"):
+			// The labels of the chart differ from the rendered ones
+		default:
+			active := true
+			for _, c := range conditions {
+				active = active && c
+			}
+			if active {
+				lines = append(lines, templateAction.ReplaceAllString(line, "dbuo"))
+			}
+		}
+	}
+	return roles(t, bytes.Split([]byte(strings.Join(lines, "\n")), []byte("\n---\n")))
+}
+
+func TestRenderRBACMatchesChart(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		// enabled evaluates the conditions of the chart, whose kube-rbac-proxy roles are not rendered
+		enabled func(condition string) bool
+	}{
+		{
+			name: "default",
+			opts: Options{Name: "dbuo", Namespace: "db-system", Image: "dbuo:v1", Replicas: 1},
+			enabled: func(condition string) bool {
+				return strings.Contains(condition, ".Values.rbac.create")
+			},
+		},
+		{
+			name: "all features",
+			opts: Options{
+				Name: "dbuo", Namespace: "db-system", Image: "dbuo:v1", Replicas: 1,
+				CloneJobs: true, HookJobs: true, SecretProviderClasses: true, RestartTargets: true, Adoption: true,
+				ClusterDatabases: true, DatabaseRoles: true, DatabaseGrants: true,
+			},
+			enabled: func(condition string) bool {
+				return !strings.Contains(condition, ".Values.kubeRbacProxy")
+			},
+		},
+		{
+			name: "clone jobs",
+			opts: Options{Name: "dbuo", Namespace: "db-system", Image: "dbuo:v1", Replicas: 1, CloneJobs: true},
+			enabled: func(condition string) bool {
+				return strings.Contains(condition, ".Values.rbac.create") || strings.Contains(condition, ".Values.cloneJobs")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Render(tt.opts)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got := roles(t, bytes.Split(data, []byte("\n---\n")))
+			want := chartRoles(t, tt.enabled)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("rendered roles =\n%+v\nwant the roles of the chart\n%+v", got, want)
+			}
+		})
+	}
+}
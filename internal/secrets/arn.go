@@ -0,0 +1,97 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// secretSuffixPattern matches the random 6 character suffix AWS appends to secret names in ARNs
+var secretSuffixPattern = regexp.MustCompile(`-[A-Za-z0-9]{6}$`)
+
+// SecretARN contains the parsed components of an AWS Secrets Manager secret ARN
+// Format: arn:<partition>:secretsmanager:<region>:<account-id>:secret:<name>[-<suffix>]
+type SecretARN struct {
+	Partition string
+	Region    string
+	AccountID string
+	// Resource is the secret name as it appears in the ARN, including the random suffix if present
+	Resource string
+}
+
+// IsSecretARN reports whether the given secret identifier is an ARN rather than a plain name
+func IsSecretARN(secretID string) bool {
+	return strings.HasPrefix(secretID, "arn:")
+}
+
+// ParseSecretARN parses an AWS Secrets Manager secret ARN
+func ParseSecretARN(arn string) (*SecretARN, error) {
+	parts := strings.SplitN(arn, ":", 7)
+	if len(parts) != 7 || parts[0] != "arn" {
+		return nil, fmt.Errorf("invalid secret ARN %q: expected arn:<partition>:secretsmanager:<region>:<account-id>:secret:<name>", arn)
+	}
+
+	parsed := &SecretARN{
+		Partition: parts[1],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Resource:  parts[6],
+	}
+
+	if !validPartitions[parsed.Partition] {
+		return nil, fmt.Errorf("invalid secret ARN %q: unknown partition %s", arn, parsed.Partition)
+	}
+	if parts[2] != "secretsmanager" {
+		return nil, fmt.Errorf("invalid secret ARN %q: service must be secretsmanager, got %s", arn, parts[2])
+	}
+	if parts[5] != "secret" {
+		return nil, fmt.Errorf("invalid secret ARN %q: resource type must be secret, got %s", arn, parts[5])
+	}
+	if parsed.Region == "" || parsed.AccountID == "" || parsed.Resource == "" {
+		return nil, fmt.Errorf("invalid secret ARN %q: region, account ID and secret name are required", arn)
+	}
+	if partition := PartitionForRegion(parsed.Region); partition != parsed.Partition {
+		return nil, fmt.Errorf("invalid secret ARN %q: region %s belongs to partition %s, not %s", arn, parsed.Region, partition, parsed.Partition)
+	}
+
+	return parsed, nil
+}
+
+// Name returns the secret name of a full ARN returned by AWS, without the random suffix AWS appends
+// A partial ARN has no suffix, so the end of its name, e.g. -backup, would be stripped: its name is
+// Resource, see SecretNameFromID.
+func (a *SecretARN) Name() string {
+	return secretSuffixPattern.ReplaceAllString(a.Resource, "")
+}
+
+// MatchesName reports whether the ARN refers to a secret with the given name
+func (a *SecretARN) MatchesName(name string) bool {
+	return a.Resource == name || a.Name() == name
+}
+
+// String returns the ARN in its canonical string form
+func (a *SecretARN) String() string {
+	return fmt.Sprintf("arn:%s:secretsmanager:%s:%s:secret:%s", a.Partition, a.Region, a.AccountID, a.Resource)
+}
+
+// SecretNameFromID returns the plain secret name for the identifier of a secret to create
+// Names are returned unchanged. An ARN of a secret that does not exist yet is a partial ARN, AWS only
+// appends the random suffix on creation, so its name is taken as it is: the ARN of
+// rds/postgres/app-backup names rds/postgres/app-backup, not rds/postgres/app.
+func SecretNameFromID(secretID string) (string, error) {
+	if !IsSecretARN(secretID) {
+		return secretID, nil
+	}
+	arn, err := ParseSecretARN(secretID)
+	if err != nil {
+		return "", err
+	}
+	return arn.Resource, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"testing"
+)
+
+func TestParseSecretARN(t *testing.T) {
+	tests := []struct {
+		name          string
+		arn           string
+		wantErr       bool
+		wantPartition string
+		wantRegion    string
+		wantName      string
+	}{
+		{
+			name:          "commercial partition with suffix",
+			arn:           "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/myapp-AbC123",
+			wantPartition: "aws",
+			wantRegion:    "us-east-1",
+			wantName:      "rds/postgres/myapp",
+		},
+		{
+			name:          "GovCloud partition",
+			arn:           "arn:aws-us-gov:secretsmanager:us-gov-west-1:123456789012:secret:myapp-x1Y2z3",
+			wantPartition: "aws-us-gov",
+			wantRegion:    "us-gov-west-1",
+			wantName:      "myapp",
+		},
+		{
+			name:          "China partition",
+			arn:           "arn:aws-cn:secretsmanager:cn-north-1:123456789012:secret:myapp-x1Y2z3",
+			wantPartition: "aws-cn",
+			wantRegion:    "cn-north-1",
+			wantName:      "myapp",
+		},
+		{
+			name:          "partial ARN without suffix",
+			arn:           "arn:aws:secretsmanager:eu-west-1:123456789012:secret:myapp",
+			wantPartition: "aws",
+			wantRegion:    "eu-west-1",
+			wantName:      "myapp",
+		},
+		{
+			name:    "unknown partition",
+			arn:     "arn:aws-foo:secretsmanager:us-east-1:123456789012:secret:myapp",
+			wantErr: true,
+		},
+		{
+			name:    "region outside of partition",
+			arn:     "arn:aws:secretsmanager:us-gov-west-1:123456789012:secret:myapp",
+			wantErr: true,
+		},
+		{
+			name:    "wrong service",
+			arn:     "arn:aws:ssm:us-east-1:123456789012:parameter:myapp",
+			wantErr: true,
+		},
+		{
+			name:    "missing region",
+			arn:     "arn:aws:secretsmanager::123456789012:secret:myapp",
+			wantErr: true,
+		},
+		{
+			name:    "not an ARN",
+			arn:     "rds/postgres/myapp",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSecretARN(tt.arn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSecretARN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Partition != tt.wantPartition {
+				t.Errorf("Partition = %v, want %v", got.Partition, tt.wantPartition)
+			}
+			if got.Region != tt.wantRegion {
+				t.Errorf("Region = %v, want %v", got.Region, tt.wantRegion)
+			}
+			if got.Name() != tt.wantName {
+				t.Errorf("Name() = %v, want %v", got.Name(), tt.wantName)
+			}
+			if got.String() != tt.arn {
+				t.Errorf("String() = %v, want %v", got.String(), tt.arn)
+			}
+		})
+	}
+}
+
+func TestSecretNameFromID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "plain name unchanged",
+			id:   "rds/postgres/myapp",
+			want: "rds/postgres/myapp",
+		},
+		{
+			name: "partial ARN",
+			id:   "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/myapp",
+			want: "rds/postgres/myapp",
+		},
+		{
+			name: "partial ARN ending like a suffix",
+			id:   "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-backup",
+			want: "rds/postgres/app-backup",
+		},
+		{
+			name:    "malformed ARN",
+			id:      "arn:aws:secretsmanager",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SecretNameFromID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SecretNameFromID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SecretNameFromID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
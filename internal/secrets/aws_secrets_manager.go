@@ -10,88 +10,23 @@ package secrets
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
-	"text/template"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 )
 
-// ValidAWSRegions contains all valid AWS regions
-var ValidAWSRegions = map[string]bool{
-	// US regions
-	"us-east-1":     true,
-	"us-east-2":     true,
-	"us-west-1":     true,
-	"us-west-2":     true,
-	"us-gov-west-1": true,
-	"us-gov-east-1": true,
-
-	// Africa
-	"af-south-1": true,
-
-	// Asia Pacific
-	"ap-east-1":      true,
-	"ap-south-1":     true,
-	"ap-south-2":     true,
-	"ap-northeast-1": true,
-	"ap-northeast-2": true,
-	"ap-northeast-3": true,
-	"ap-southeast-1": true,
-	"ap-southeast-2": true,
-	"ap-southeast-3": true,
-	"ap-southeast-4": true,
-
-	// Canada
-	"ca-central-1": true,
-	"ca-west-1":    true,
-
-	// Europe
-	"eu-central-1": true,
-	"eu-central-2": true,
-	"eu-west-1":    true,
-	"eu-west-2":    true,
-	"eu-west-3":    true,
-	"eu-south-1":   true,
-	"eu-south-2":   true,
-	"eu-north-1":   true,
-
-	// Middle East
-	"me-south-1":   true,
-	"me-central-1": true,
-
-	// South America
-	"sa-east-1": true,
-
-	// China
-	"cn-north-1":     true,
-	"cn-northwest-1": true,
-
-	// Israel
-	"il-central-1": true,
-}
-
-// ValidateRegion checks if the provided region is a valid AWS region
-// Returns nil if valid or empty (empty allows AWS SDK default resolution)
-// Returns error if the region is explicitly provided but invalid
-func ValidateRegion(region string) error {
-	// Allow empty region - AWS SDK will resolve from environment/config/metadata
-	if region == "" {
-		return nil
-	}
-
-	if !ValidAWSRegions[region] {
-		return fmt.Errorf("invalid AWS region: %s", region)
-	}
-
-	return nil
-}
-
 // AWSSecretsManagerClient wraps AWS Secrets Manager operations
 type AWSSecretsManagerClient struct {
 	client *secretsmanager.Client
@@ -105,9 +40,78 @@ type DatabaseSecret struct {
 	DBName     string `json:"DB_NAME"`
 	DBUsername string `json:"DB_USERNAME"`
 	DBPassword string `json:"DB_PASSWORD"`
+	// DBPrivateKey is the PEM encoded private key of key-pair authentication, if enabled
+	DBPrivateKey string `json:"DB_PRIVATE_KEY,omitempty"`
 	// Engine-specific URL field (e.g., POSTGRES_URL, MYSQL_URL)
 	DatabaseURL string `json:"-"` // Will be set dynamically with the correct engine name
 	Engine      string `json:"-"` // Used to determine the URL field name
+	// KeyNames renames keys of the default format, it is not used with a template
+	KeyNames *KeyNames `json:"-"`
+	// ConnectionStringOnly stores DatabaseURL as the whole secret value instead of JSON
+	ConnectionStringOnly bool `json:"-"`
+	// Binary stores the value as SecretBinary instead of SecretString, a template may then render
+	// any text, e.g. a .env or Java properties file
+	Binary bool `json:"-"`
+}
+
+// DatabaseURL builds the engine-specific connection URL stored in the secret
+func DatabaseURL(engine, username, password, host string, port int, dbName string) string {
+	// Normalize engine name for URL scheme
+	urlScheme := engine
+	if strings.HasPrefix(strings.ToLower(engine), "postgres") || strings.ToLower(engine) == "yugabyte" {
+		urlScheme = "postgresql" // YugabyteDB is accessed with PostgreSQL drivers
+	} else if strings.ToLower(engine) == "mariadb" {
+		urlScheme = "mysql" // MariaDB uses mysql:// scheme
+	}
+
+	return fmt.Sprintf("%s://%s:%s@%s/%s",
+		urlScheme,
+		url.QueryEscape(username),
+		url.QueryEscape(password),
+		net.JoinHostPort(host, strconv.Itoa(port)),
+		dbName,
+	)
+}
+
+// ParseDatabaseURL reads the connection details of a URL built by DatabaseURL
+func ParseDatabaseURL(databaseURL string) (*DatabaseSecret, error) {
+	u, err := url.Parse(databaseURL)
+	if err != nil || u.User == nil || u.Host == "" {
+		// The URL contains the password, so it is not part of the error
+		return nil, fmt.Errorf("secret value is neither JSON nor a database URL")
+	}
+	password, _ := u.User.Password()
+	port, _ := strconv.Atoi(u.Port())
+	return &DatabaseSecret{
+		DBHost:      u.Hostname(),
+		DBPort:      port,
+		DBName:      strings.TrimPrefix(u.Path, "/"),
+		DBUsername:  u.User.Username(),
+		DBPassword:  password,
+		DatabaseURL: databaseURL,
+	}, nil
+}
+
+// parseFileValue reads the connection details of a binary value that is not JSON, e.g. a .env or
+// properties file, from the database URL of the whole value or of a KEY=VALUE line
+func parseFileValue(value string) (*DatabaseSecret, error) {
+	if secret, err := ParseDatabaseURL(strings.TrimSpace(value)); err == nil {
+		return secret, nil
+	}
+	for _, line := range strings.Split(value, "\n") {
+		_, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		v = strings.Trim(strings.TrimSpace(v), `"'`)
+		if !strings.Contains(v, "://") {
+			continue
+		}
+		if secret, err := ParseDatabaseURL(v); err == nil {
+			return secret, nil
+		}
+	}
+	return nil, fmt.Errorf("binary secret value contains no database URL")
 }
 
 // ToJSON converts the DatabaseSecret to JSON with the engine-specific URL field
@@ -116,23 +120,34 @@ func (s *DatabaseSecret) ToJSON() ([]byte, error) {
 }
 
 // ToJSONWithTemplate converts the DatabaseSecret to JSON using a custom template
-// If tmplStr is empty, uses the default template
+// If tmplStr is empty, uses the default template. With ConnectionStringOnly the value is the
+// plain database URL, which is not JSON, and with Binary the output of the template is not checked.
 func (s *DatabaseSecret) ToJSONWithTemplate(tmplStr string) ([]byte, error) {
+	if s.ConnectionStringOnly {
+		if s.DatabaseURL == "" {
+			return nil, fmt.Errorf("secret has no database URL")
+		}
+		return []byte(s.DatabaseURL), nil
+	}
 	if tmplStr == "" {
 		return s.toJSONDefault()
 	}
 
-	// Parse and execute the template
-	tmpl, err := template.New("secret").Parse(tmplStr)
+	// Parse and execute the template, on the fields of the secret only
+	tmpl, err := parseTemplate(tmplStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse secret template: %w", err)
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, s); err != nil {
+	if err := tmpl.Execute(&buf, s.data()); err != nil {
 		return nil, fmt.Errorf("failed to execute secret template: %w", err)
 	}
 
+	if s.Binary {
+		return buf.Bytes(), nil
+	}
+
 	// Validate that the output is valid JSON
 	var jsonCheck interface{}
 	if err := json.Unmarshal(buf.Bytes(), &jsonCheck); err != nil {
@@ -145,43 +160,72 @@ func (s *DatabaseSecret) ToJSONWithTemplate(tmplStr string) ([]byte, error) {
 // toJSONDefault converts the DatabaseSecret to JSON using the default format
 func (s *DatabaseSecret) toJSONDefault() ([]byte, error) {
 	// Build map with all fields
+	keys := s.KeyNames.Keys(s.Engine)
 	secretMap := map[string]interface{}{
-		"DB_HOST":     s.DBHost,
-		"DB_PORT":     s.DBPort,
-		"DB_NAME":     s.DBName,
-		"DB_USERNAME": s.DBUsername,
-		"DB_PASSWORD": s.DBPassword,
+		keys["DB_HOST"]:     s.DBHost,
+		keys["DB_PORT"]:     s.DBPort,
+		keys["DB_NAME"]:     s.DBName,
+		keys["DB_USERNAME"]: s.DBUsername,
+		keys["DB_PASSWORD"]: s.DBPassword,
+	}
+	if s.DBPrivateKey != "" {
+		secretMap[privateKeyKey] = s.DBPrivateKey
 	}
 
 	// Add engine-specific URL field
 	if s.DatabaseURL != "" && s.Engine != "" {
-		urlFieldName := strings.ToUpper(s.Engine) + "_URL"
-		// Handle "postgresql" -> "POSTGRES_URL"
-		if strings.HasPrefix(strings.ToLower(s.Engine), "postgres") {
-			urlFieldName = "POSTGRES_URL"
-		}
-		secretMap[urlFieldName] = s.DatabaseURL
+		secretMap[keys[URLFieldName(s.Engine)]] = s.DatabaseURL
 	}
 
 	return json.Marshal(secretMap)
 }
 
-// NewAWSSecretsManagerClient creates a new AWS Secrets Manager client
-func NewAWSSecretsManagerClient(ctx context.Context, region string) (*AWSSecretsManagerClient, error) {
-	var cfg aws.Config
-	var err error
+// ChecksumWithTemplate returns the SHA-256 checksum of the secret rendered with a template, in
+// hex. The rendered JSON is normalized first, so the checksum changes with the content of the
+// secret like UpdateSecretIfChanged writes a new version, and not with the whitespace of a template.
+func (s *DatabaseSecret) ChecksumWithTemplate(tmplStr string) (string, error) {
+	secretJSON, err := s.ToJSONWithTemplate(tmplStr)
+	if err != nil {
+		return "", err
+	}
+	if !json.Valid(secretJSON) {
+		// A connection string or a binary file is hashed as it is
+		sum := sha256.Sum256(secretJSON)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(secretJSON, &value); err != nil {
+		return "", err
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	if region != "" {
-		cfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(region))
-	} else {
-		cfg, err = config.LoadDefaultConfig(ctx)
+// URLFieldName returns the key of the engine-specific URL in the default secret format
+func URLFieldName(engine string) string {
+	// Handle "postgresql" -> "POSTGRES_URL"
+	if strings.HasPrefix(strings.ToLower(engine), "postgres") || strings.ToLower(engine) == "yugabyte" {
+		return "POSTGRES_URL"
 	}
+	return strings.ToUpper(engine) + "_URL"
+}
 
+// NewAWSSecretsManagerClient creates a new AWS Secrets Manager client
+func NewAWSSecretsManagerClient(ctx context.Context, region string) (*AWSSecretsManagerClient, error) {
+	cfg, err := loadAWSConfig(ctx, region)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
-	client := secretsmanager.NewFromConfig(cfg)
+	client := secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+		if endpoint := secretsManagerEndpoint(); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
 
 	return &AWSSecretsManagerClient{
 		client: client,
@@ -196,9 +240,7 @@ func (c *AWSSecretsManagerClient) GetRegion() string {
 
 // SecretExists checks if a secret exists
 func (c *AWSSecretsManagerClient) SecretExists(ctx context.Context, secretName string) (bool, error) {
-	_, err := c.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
-		SecretId: aws.String(secretName),
-	})
+	_, err := c.describeSecret(ctx, secretName)
 	if err != nil {
 		// Check if error is ResourceNotFoundException
 		var notFoundErr *types.ResourceNotFoundException
@@ -211,6 +253,21 @@ func (c *AWSSecretsManagerClient) SecretExists(ctx context.Context, secretName s
 	return true, nil
 }
 
+// SecretScheduledForDeletion reports whether a secret exists but is scheduled for deletion
+// Such a secret cannot be read or written until it is restored.
+func (c *AWSSecretsManagerClient) SecretScheduledForDeletion(ctx context.Context, secretName string) (bool, error) {
+	output, err := c.describeSecret(ctx, secretName)
+	if err != nil {
+		var notFoundErr *types.ResourceNotFoundException
+		if errors.As(err, &notFoundErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to describe secret: %w", err)
+	}
+
+	return output.DeletedDate != nil, nil
+}
+
 // CreateSecret creates a new secret in AWS Secrets Manager
 // If the secret is scheduled for deletion, it will restore it and update the value
 func (c *AWSSecretsManagerClient) CreateSecret(ctx context.Context, secretName, description string, secretValue *DatabaseSecret, tags map[string]string) (string, string, error) {
@@ -218,6 +275,7 @@ func (c *AWSSecretsManagerClient) CreateSecret(ctx context.Context, secretName,
 }
 
 // CreateSecretWithTemplate creates a new secret in AWS Secrets Manager using a custom template
+// secretName may be a plain name or an ARN; for ARNs the secret is created using the name portion
 // If the secret is scheduled for deletion, it will restore it and update the value
 func (c *AWSSecretsManagerClient) CreateSecretWithTemplate(ctx context.Context, secretName, description string, secretValue *DatabaseSecret, tags map[string]string, tmpl string) (string, string, error) {
 	// Marshal secret to JSON with engine-specific URL field or custom template
@@ -226,6 +284,12 @@ func (c *AWSSecretsManagerClient) CreateSecretWithTemplate(ctx context.Context,
 		return "", "", fmt.Errorf("failed to marshal secret value: %w", err)
 	}
 
+	// CreateSecret only accepts a name, so strip the ARN prefix and suffix if an ARN was given
+	name, err := SecretNameFromID(secretName)
+	if err != nil {
+		return "", "", err
+	}
+
 	// Convert tags
 	var awsTags []types.Tag
 	for key, value := range tags {
@@ -237,13 +301,18 @@ func (c *AWSSecretsManagerClient) CreateSecretWithTemplate(ctx context.Context,
 
 	// Create secret
 	input := &secretsmanager.CreateSecretInput{
-		Name:         aws.String(secretName),
-		Description:  aws.String(description),
-		SecretString: aws.String(string(secretJSON)),
-		Tags:         awsTags,
+		Name:        aws.String(name),
+		Description: aws.String(description),
+		Tags:        awsTags,
+	}
+	if secretValue.Binary {
+		input.SecretBinary = secretJSON
+	} else {
+		input.SecretString = aws.String(string(secretJSON))
 	}
 
 	output, err := c.client.CreateSecret(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		// Check if secret is scheduled for deletion
 		var invalidReqErr *types.InvalidRequestException
@@ -302,11 +371,16 @@ func (c *AWSSecretsManagerClient) UpdateSecretWithTemplate(ctx context.Context,
 
 	// Update secret
 	input := &secretsmanager.UpdateSecretInput{
-		SecretId:     aws.String(secretName),
-		SecretString: aws.String(string(secretJSON)),
+		SecretId: aws.String(secretName),
+	}
+	if secretValue.Binary {
+		input.SecretBinary = secretJSON
+	} else {
+		input.SecretString = aws.String(string(secretJSON))
 	}
 
 	output, err := c.client.UpdateSecret(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		// Check if secret doesn't exist
 		var notFoundErr *types.ResourceNotFoundException
@@ -327,6 +401,74 @@ func (c *AWSSecretsManagerClient) UpdateSecretWithTemplate(ctx context.Context,
 	return aws.ToString(output.VersionId), nil
 }
 
+// UpdateSecretIfChanged updates a secret unless its current value already has the same JSON content
+// It returns the ID of the current version and whether a new version was written, so unchanged
+// secrets do not accumulate versions on every reconciliation.
+func (c *AWSSecretsManagerClient) UpdateSecretIfChanged(ctx context.Context, secretName string, secretValue *DatabaseSecret, tmpl string) (string, bool, error) {
+	secretJSON, err := secretValue.ToJSONWithTemplate(tmpl)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal secret value: %w", err)
+	}
+
+	// A failed read falls through to the update, which reports missing and deleted secrets
+	current, err := c.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
+	})
+	if err == nil && !valueChanged(current, secretJSON, secretValue.Binary) {
+		return aws.ToString(current.VersionId), false, nil
+	}
+
+	versionID, err := c.UpdateSecretWithTemplate(ctx, secretName, secretValue, tmpl)
+	if err != nil {
+		return "", false, err
+	}
+	return versionID, true, nil
+}
+
+// SecretContentChanged reports whether UpdateSecretIfChanged would write a new version of a secret
+// without writing it. Missing secrets and secrets marked for deletion return the errors of
+// UpdateSecretIfChanged, any other failed read counts as a change.
+func (c *AWSSecretsManagerClient) SecretContentChanged(ctx context.Context, secretName string, secretValue *DatabaseSecret, tmpl string) (bool, error) {
+	secretJSON, err := secretValue.ToJSONWithTemplate(tmpl)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal secret value: %w", err)
+	}
+
+	current, err := c.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		var notFoundErr *types.ResourceNotFoundException
+		if errors.As(err, &notFoundErr) {
+			return false, &SecretNotFoundError{SecretName: secretName, Err: err}
+		}
+		var invalidReqErr *types.InvalidRequestException
+		if errors.As(err, &invalidReqErr) && strings.Contains(err.Error(), "marked for deletion") {
+			return false, &SecretMarkedForDeletionError{SecretName: secretName, Err: err}
+		}
+		return true, nil
+	}
+	return valueChanged(current, secretJSON, secretValue.Binary), nil
+}
+
+// valueChanged reports whether the current value of a secret differs from a value to write
+// A value moving between SecretString and SecretBinary counts as a change.
+func valueChanged(current *secretsmanager.GetSecretValueOutput, value []byte, binary bool) bool {
+	actual, actualBinary := currentValue(current)
+	return actual == nil || actualBinary != binary || !secretValueEqual(actual, value)
+}
+
+// currentValue returns the value of a secret and whether it is stored as SecretBinary
+func currentValue(output *secretsmanager.GetSecretValueOutput) ([]byte, bool) {
+	if output.SecretBinary != nil {
+		return output.SecretBinary, true
+	}
+	if output.SecretString != nil {
+		return []byte(*output.SecretString), false
+	}
+	return nil, false
+}
+
 // SecretNotFoundError is returned when a secret doesn't exist
 type SecretNotFoundError struct {
 	SecretName string
@@ -357,6 +499,7 @@ func (e *SecretMarkedForDeletionError) Unwrap() error {
 
 // DeleteSecret deletes a secret from AWS Secrets Manager
 // If forceDelete is true, the secret is deleted immediately without recovery window
+// Deleting a secret that is already scheduled for deletion with a recovery window is a no-op
 func (c *AWSSecretsManagerClient) DeleteSecret(ctx context.Context, secretName string, forceDelete bool) error {
 	input := &secretsmanager.DeleteSecretInput{
 		SecretId:                   aws.String(secretName),
@@ -370,12 +513,18 @@ func (c *AWSSecretsManagerClient) DeleteSecret(ctx context.Context, secretName s
 	}
 
 	_, err := c.client.DeleteSecret(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		// Ignore if secret doesn't exist
 		var notFoundErr *types.ResourceNotFoundException
 		if ok := errors.As(err, &notFoundErr); ok {
 			return nil
 		}
+		// Ignore if secret is already scheduled for deletion and no force delete was requested
+		var invalidReqErr *types.InvalidRequestException
+		if !forceDelete && errors.As(err, &invalidReqErr) && strings.Contains(err.Error(), "scheduled for deletion") {
+			return nil
+		}
 		return fmt.Errorf("failed to delete secret: %w", err)
 	}
 
@@ -389,6 +538,7 @@ func (c *AWSSecretsManagerClient) RestoreSecret(ctx context.Context, secretName
 	}
 
 	_, err := c.client.RestoreSecret(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		return fmt.Errorf("failed to restore secret: %w", err)
 	}
@@ -404,6 +554,7 @@ func (c *AWSSecretsManagerClient) UpdateSecretMetadata(ctx context.Context, secr
 	}
 
 	_, err := c.client.UpdateSecret(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		return fmt.Errorf("failed to update secret metadata: %w", err)
 	}
@@ -413,6 +564,14 @@ func (c *AWSSecretsManagerClient) UpdateSecretMetadata(ctx context.Context, secr
 
 // GetSecret retrieves a secret value and handles both old and new formats
 func (c *AWSSecretsManagerClient) GetSecret(ctx context.Context, secretName string) (*DatabaseSecret, error) {
+	return c.GetSecretWithKeyNames(ctx, secretName, nil)
+}
+
+// GetSecretWithKeyNames retrieves a secret value written with renamed keys
+// Keys that are not found under their new name are read under the default name. A value that is
+// not JSON is read as the database URL of the connectionStringOnly format, a binary value also from
+// a KEY=VALUE line holding the URL.
+func (c *AWSSecretsManagerClient) GetSecretWithKeyNames(ctx context.Context, secretName string, keyNames *KeyNames) (*DatabaseSecret, error) {
 	input := &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretName),
 	}
@@ -422,7 +581,18 @@ func (c *AWSSecretsManagerClient) GetSecret(ctx context.Context, secretName stri
 		return nil, fmt.Errorf("failed to get secret value: %w", err)
 	}
 
-	secretString := aws.ToString(output.SecretString)
+	value, binary := currentValue(output)
+	secretString := string(value)
+	if !json.Valid([]byte(secretString)) {
+		if binary {
+			return parseFileValue(secretString)
+		}
+		return ParseDatabaseURL(secretString)
+	}
+	secretString, err = keyNames.renameToDefaults(secretString)
+	if err != nil {
+		return nil, err
+	}
 
 	// Try new format first
 	var secret DatabaseSecret
@@ -456,6 +626,7 @@ func (c *AWSSecretsManagerClient) GetSecret(ctx context.Context, secretName stri
 }
 
 // GetSecretString retrieves a secret value as a raw string
+// A value stored as SecretBinary is returned as it is.
 func (c *AWSSecretsManagerClient) GetSecretString(ctx context.Context, secretName string) (string, error) {
 	input := &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretName),
@@ -466,7 +637,38 @@ func (c *AWSSecretsManagerClient) GetSecretString(ctx context.Context, secretNam
 		return "", fmt.Errorf("failed to get secret value: %w", err)
 	}
 
-	return aws.ToString(output.SecretString), nil
+	value, _ := currentValue(output)
+	return string(value), nil
+}
+
+// VerifySecretContent reads a secret back and checks that its JSON content matches the expected value
+func (c *AWSSecretsManagerClient) VerifySecretContent(ctx context.Context, secretName string, expected []byte) error {
+	actual, err := c.GetSecretString(ctx, secretName)
+	if err != nil {
+		return err
+	}
+	if !secretValueEqual([]byte(actual), expected) {
+		return fmt.Errorf("content of secret %s does not match the expected value", secretName)
+	}
+	return nil
+}
+
+// secretValueEqual reports whether two secret values are equal, JSON documents semantically and
+// other values, e.g. a plain connection string, byte by byte
+func secretValueEqual(a, b []byte) bool {
+	return bytes.Equal(a, b) || jsonEqual(a, b)
+}
+
+// jsonEqual reports whether two JSON documents are semantically equal
+func jsonEqual(a, b []byte) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
 }
 
 // TagSecret adds or updates tags on a secret
@@ -485,6 +687,7 @@ func (c *AWSSecretsManagerClient) TagSecret(ctx context.Context, secretName stri
 	}
 
 	_, err := c.client.TagResource(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		return fmt.Errorf("failed to tag secret: %w", err)
 	}
@@ -504,6 +707,7 @@ func (c *AWSSecretsManagerClient) UntagSecret(ctx context.Context, secretName st
 	}
 
 	_, err := c.client.UntagResource(ctx, input)
+	c.invalidateDescribeCache()
 	if err != nil {
 		return fmt.Errorf("failed to untag secret: %w", err)
 	}
@@ -513,9 +717,7 @@ func (c *AWSSecretsManagerClient) UntagSecret(ctx context.Context, secretName st
 
 // GetSecretTags retrieves the tags on a secret
 func (c *AWSSecretsManagerClient) GetSecretTags(ctx context.Context, secretName string) (map[string]string, error) {
-	output, err := c.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
-		SecretId: aws.String(secretName),
-	})
+	output, err := c.describeSecret(ctx, secretName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe secret: %w", err)
 	}
@@ -530,14 +732,121 @@ func (c *AWSSecretsManagerClient) GetSecretTags(ctx context.Context, secretName
 	return tags, nil
 }
 
+// GetSecretDescription retrieves the description of a secret
+func (c *AWSSecretsManagerClient) GetSecretDescription(ctx context.Context, secretName string) (string, error) {
+	output, err := c.describeSecret(ctx, secretName)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe secret: %w", err)
+	}
+	return aws.ToString(output.Description), nil
+}
+
 // GetSecretARN retrieves the ARN of a secret
 func (c *AWSSecretsManagerClient) GetSecretARN(ctx context.Context, secretName string) (string, error) {
-	output, err := c.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
-		SecretId: aws.String(secretName),
-	})
+	output, err := c.describeSecret(ctx, secretName)
 	if err != nil {
 		return "", fmt.Errorf("failed to describe secret: %w", err)
 	}
 
 	return aws.ToString(output.ARN), nil
 }
+
+// GetSecretVersionID retrieves the ID of the current version of a secret
+// The ID changes whenever a new secret value is stored, e.g. when the secret is rotated
+func (c *AWSSecretsManagerClient) GetSecretVersionID(ctx context.Context, secretName string) (string, error) {
+	output, err := c.describeSecret(ctx, secretName)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe secret: %w", err)
+	}
+
+	versionID := currentVersionID(output.VersionIdsToStages)
+	if versionID == "" {
+		return "", fmt.Errorf("secret %s has no current version", secretName)
+	}
+	return versionID, nil
+}
+
+// currentVersionID returns the ID of the version carrying the AWSCURRENT stage
+func currentVersionID(versionStages map[string][]string) string {
+	for id, stages := range versionStages {
+		for _, stage := range stages {
+			if stage == "AWSCURRENT" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// SecretSummary contains the identifying metadata of a secret returned by ListSecretsByTag and ListSecretsByPrefix
+type SecretSummary struct {
+	Name        string
+	ARN         string
+	CreatedDate time.Time
+}
+
+// ListSecretsByTag lists all secrets carrying the given tag key and value
+// Secrets scheduled for deletion are not included
+func (c *AWSSecretsManagerClient) ListSecretsByTag(ctx context.Context, key, value string) ([]SecretSummary, error) {
+	input := &secretsmanager.ListSecretsInput{
+		Filters: []types.Filter{
+			{Key: types.FilterNameStringTypeTagKey, Values: []string{key}},
+			{Key: types.FilterNameStringTypeTagValue, Values: []string{value}},
+		},
+	}
+
+	// Tag filters match key and value independently, so verify the pair
+	return c.listSecrets(ctx, input, func(entry types.SecretListEntry) bool {
+		return hasTag(entry.Tags, key, value)
+	})
+}
+
+// ListSecretsByPrefix lists all secrets whose name starts with the given prefix
+// Secrets scheduled for deletion are not included
+func (c *AWSSecretsManagerClient) ListSecretsByPrefix(ctx context.Context, prefix string) ([]SecretSummary, error) {
+	input := &secretsmanager.ListSecretsInput{
+		Filters: []types.Filter{
+			{Key: types.FilterNameStringTypeName, Values: []string{prefix}},
+		},
+	}
+
+	// The name filter also matches words inside the name, so verify the prefix
+	return c.listSecrets(ctx, input, func(entry types.SecretListEntry) bool {
+		return strings.HasPrefix(aws.ToString(entry.Name), prefix)
+	})
+}
+
+// listSecrets lists all secrets matching the input filters for which keep returns true
+func (c *AWSSecretsManagerClient) listSecrets(ctx context.Context, input *secretsmanager.ListSecretsInput, keep func(types.SecretListEntry) bool) ([]SecretSummary, error) {
+	var summaries []SecretSummary
+	paginator := secretsmanager.NewListSecretsPaginator(c.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+
+		for _, entry := range page.SecretList {
+			if !keep(entry) {
+				continue
+			}
+			summaries = append(summaries, SecretSummary{
+				Name:        aws.ToString(entry.Name),
+				ARN:         aws.ToString(entry.ARN),
+				CreatedDate: aws.ToTime(entry.CreatedDate),
+			})
+		}
+	}
+
+	return summaries, nil
+}
+
+// hasTag reports whether the tag list contains the given key with the given value
+func hasTag(tags []types.Tag, key, value string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key && aws.ToString(tag.Value) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// describeCacheTTL is how long a DescribeSecret result is reused, long enough to cover the existence,
+// tag, description, ARN and version checks of one reconciliation with a single call
+const describeCacheTTL = 10 * time.Second
+
+type describeCacheEntry struct {
+	output  *secretsmanager.DescribeSecretOutput
+	expires time.Time
+}
+
+type describeCacheKey struct {
+	region   string
+	secretID string
+}
+
+var (
+	describeCacheMu sync.Mutex
+	// describeCache is shared by all clients, since a reconciliation may create several clients of a region
+	describeCache = map[describeCacheKey]describeCacheEntry{}
+	// describeCacheGeneration changes on every write, so a DescribeSecret that raced with a write is not cached
+	describeCacheGeneration uint64
+)
+
+// describeSecret returns the DescribeSecret result of a secret, from the cache if it was described
+// less than describeCacheTTL ago and not written since. Errors are not cached.
+func (c *AWSSecretsManagerClient) describeSecret(ctx context.Context, secretName string) (*secretsmanager.DescribeSecretOutput, error) {
+	key := describeCacheKey{region: c.region, secretID: secretName}
+	describeCacheMu.Lock()
+	entry, ok := describeCache[key]
+	generation := describeCacheGeneration
+	describeCacheMu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.output, nil
+	}
+
+	output, err := c.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	describeCacheMu.Lock()
+	defer describeCacheMu.Unlock()
+	if generation == describeCacheGeneration {
+		for k, e := range describeCache {
+			if !now.Before(e.expires) {
+				delete(describeCache, k)
+			}
+		}
+		describeCache[key] = describeCacheEntry{output: output, expires: now.Add(describeCacheTTL)}
+	}
+	return output, nil
+}
+
+// invalidateDescribeCache drops the cached DescribeSecret results of the region of the client
+// Every write drops all of them, since the written secret may be cached under its name and its ARN.
+func (c *AWSSecretsManagerClient) invalidateDescribeCache() {
+	describeCacheMu.Lock()
+	defer describeCacheMu.Unlock()
+	describeCacheGeneration++
+	for k := range describeCache {
+		if k.region == c.region {
+			delete(describeCache, k)
+		}
+	}
+}
+
+// resetDescribeCache drops all cached DescribeSecret results, e.g. of a replaced endpoint
+func resetDescribeCache() {
+	describeCacheMu.Lock()
+	defer describeCacheMu.Unlock()
+	describeCacheGeneration++
+	describeCache = map[describeCacheKey]describeCacheEntry{}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDescribeSecretCache(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	c := newTestSecretsManagerClient(t, func(w http.ResponseWriter, req *http.Request) {
+		operation := strings.TrimPrefix(req.Header.Get("X-Amz-Target"), "secretsmanager.")
+		mu.Lock()
+		calls[operation]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if operation == "DescribeSecret" {
+			_, _ = w.Write([]byte(`{"ARN":"arn:aws:secretsmanager:us-east-1:123456789012:secret:rds/postgres/app-AbCdEf",` +
+				`"Name":"rds/postgres/app","Tags":[{"Key":"team","Value":"a"}],"VersionIdsToStages":{"v1":["AWSCURRENT"]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	ctx := context.Background()
+	describeCalls := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls["DescribeSecret"]
+	}
+
+	// One reconciliation checks the existence, tags, ARN and version of the secret
+	if exists, err := c.SecretExists(ctx, "rds/postgres/app"); err != nil || !exists {
+		t.Fatalf("SecretExists() = %v, %v", exists, err)
+	}
+	if _, err := c.GetSecretTags(ctx, "rds/postgres/app"); err != nil {
+		t.Fatalf("GetSecretTags() error = %v", err)
+	}
+	if _, err := c.GetSecretARN(ctx, "rds/postgres/app"); err != nil {
+		t.Fatalf("GetSecretARN() error = %v", err)
+	}
+	if _, err := c.GetSecretVersionID(ctx, "rds/postgres/app"); err != nil {
+		t.Fatalf("GetSecretVersionID() error = %v", err)
+	}
+	if got := describeCalls(); got != 1 {
+		t.Errorf("DescribeSecret called %d times, want 1", got)
+	}
+
+	// A write drops the cached result
+	if err := c.TagSecret(ctx, "rds/postgres/app", map[string]string{"team": "b"}); err != nil {
+		t.Fatalf("TagSecret() error = %v", err)
+	}
+	if _, err := c.GetSecretTags(ctx, "rds/postgres/app"); err != nil {
+		t.Fatalf("GetSecretTags() error = %v", err)
+	}
+	if got := describeCalls(); got != 2 {
+		t.Errorf("DescribeSecret called %d times after a write, want 2", got)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// MaxDescriptionLength is the longest secret description AWS Secrets Manager accepts
+const MaxDescriptionLength = 2048
+
+// DescriptionData is the data available to secret description templates
+type DescriptionData struct {
+	// Kind is Database or ClusterDatabase
+	Kind string
+	// Namespace is the namespace of a Database, empty for a ClusterDatabase
+	Namespace string
+	// Name is the name of the resource
+	Name string
+	// UID is the UID of the resource, appended to every description
+	UID string
+	// Cluster is the name of the Kubernetes cluster configured with --cluster-name
+	Cluster string
+	// DatabaseName is the name of the database on the server
+	DatabaseName string
+	// Engine is the database engine
+	Engine string
+	// Username is the name of the database user
+	Username string
+}
+
+// RenderDescription renders a secret description template and appends the kind and UID of the
+// owning resource. Descriptions longer than MaxDescriptionLength are truncated before the UID.
+func RenderDescription(tmplStr string, data DescriptionData) (string, error) {
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(tmplStr)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse description template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute description template: %w", err)
+	}
+
+	description := strings.TrimSpace(buf.String())
+	if data.UID == "" {
+		return truncate(description, MaxDescriptionLength), nil
+	}
+	suffix := fmt.Sprintf("[%s %s]", data.Kind, data.UID)
+	if description == "" {
+		return suffix, nil
+	}
+	return truncate(description, MaxDescriptionLength-len(suffix)-1) + " " + suffix, nil
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
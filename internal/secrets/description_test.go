@@ -0,0 +1,81 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderDescription(t *testing.T) {
+	data := DescriptionData{
+		Kind:         "Database",
+		Namespace:    "payments",
+		Name:         "orders",
+		UID:          "0b6c1a2e-5d1f-4a4e-9f0e-2b1d3c4e5f60",
+		Cluster:      "prod-eu",
+		DatabaseName: "orders",
+		Engine:       "postgres",
+		Username:     "orders_app",
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		data    DescriptionData
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "plain text",
+			tmpl: "Orders service credentials",
+			data: data,
+			want: "Orders service credentials [Database 0b6c1a2e-5d1f-4a4e-9f0e-2b1d3c4e5f60]",
+		},
+		{
+			name: "template",
+			tmpl: "{{ .Engine }} user {{ .Username }} of {{ .Namespace }}/{{ .Name }} in {{ .Cluster }}",
+			data: data,
+			want: "postgres user orders_app of payments/orders in prod-eu [Database 0b6c1a2e-5d1f-4a4e-9f0e-2b1d3c4e5f60]",
+		},
+		{
+			name: "without UID",
+			tmpl: "{{ .DatabaseName }}",
+			data: DescriptionData{DatabaseName: "orders"},
+			want: "orders",
+		},
+		{name: "unknown field", tmpl: "{{ .Owner }}", data: data, wantErr: true},
+		{name: "invalid syntax", tmpl: "{{ .Name", data: data, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderDescription(tt.tmpl, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderDescription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDescriptionTruncates(t *testing.T) {
+	got, err := RenderDescription(strings.Repeat("é", MaxDescriptionLength), DescriptionData{Kind: "Database", UID: "uid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) > MaxDescriptionLength || !utf8.ValidString(got) {
+		t.Errorf("description has %d bytes, want at most %d of valid UTF-8", len(got), MaxDescriptionLength)
+	}
+	if !strings.HasSuffix(got, " [Database uid]") || !strings.HasPrefix(got, "é") {
+		t.Errorf("description = %q, want the template output truncated before the UID", got[len(got)-40:])
+	}
+}
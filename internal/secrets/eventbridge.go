@@ -0,0 +1,93 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// DefaultEventSource is the source of the events the operator puts on an EventBridge bus
+const DefaultEventSource = "opzkit.database-user-operator"
+
+// EventBridgePublisher puts events on an EventBridge bus
+type EventBridgePublisher struct {
+	client *eventbridge.Client
+	bus    string
+	source string
+}
+
+// ParseEventBusRegion returns the region of an event bus, empty for a bus name
+// ARN format: arn:<partition>:events:<region>:<account-id>:event-bus/<name>
+func ParseEventBusRegion(bus string) (string, error) {
+	if !strings.HasPrefix(bus, "arn:") {
+		if bus == "" || strings.Contains(bus, "/") {
+			return "", fmt.Errorf("invalid event bus name %q", bus)
+		}
+		return "", nil
+	}
+	parts := strings.SplitN(bus, ":", 6)
+	if len(parts) != 6 || parts[2] != "events" || parts[3] == "" || !strings.HasPrefix(parts[5], "event-bus/") {
+		return "", fmt.Errorf("invalid event bus ARN %q: expected arn:<partition>:events:<region>:<account-id>:event-bus/<name>", bus)
+	}
+	if !validPartitions[parts[1]] {
+		return "", fmt.Errorf("invalid event bus ARN %q: unknown partition %s", bus, parts[1])
+	}
+	if err := ValidateRegion(parts[3]); err != nil {
+		return "", fmt.Errorf("invalid event bus ARN %q: %w", bus, err)
+	}
+	return parts[3], nil
+}
+
+// NewEventBridgePublisher creates a publisher of events of the source to a bus, given by name or ARN
+// The bus of a name is in the region of the environment, the bus of an ARN in its region.
+func NewEventBridgePublisher(ctx context.Context, bus, source string) (*EventBridgePublisher, error) {
+	region, err := ParseEventBusRegion(bus)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadAWSConfig(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	if source == "" {
+		source = DefaultEventSource
+	}
+	return &EventBridgePublisher{client: eventbridge.NewFromConfig(cfg), bus: bus, source: source}, nil
+}
+
+// Put puts an event with the detail type and the JSON of detail on the bus
+func (p *EventBridgePublisher) Put(ctx context.Context, detailType string, detail any) error {
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+	output, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{
+		Entries: []types.PutEventsRequestEntry{{
+			EventBusName: aws.String(p.bus),
+			Source:       aws.String(p.source),
+			DetailType:   aws.String(detailType),
+			Detail:       aws.String(string(data)),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put event on bus %s: %w", p.bus, err)
+	}
+	// PutEvents reports rejected entries in the output instead of an error
+	if output.FailedEntryCount > 0 && len(output.Entries) > 0 {
+		entry := output.Entries[0]
+		return fmt.Errorf("event bus %s rejected the event: %s %s", p.bus, aws.ToString(entry.ErrorCode), aws.ToString(entry.ErrorMessage))
+	}
+	return nil
+}
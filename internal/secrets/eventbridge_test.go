@@ -0,0 +1,37 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import "testing"
+
+func TestParseEventBusRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		bus        string
+		wantRegion string
+		wantErr    bool
+	}{
+		{name: "name", bus: "default"},
+		{name: "ARN", bus: "arn:aws:events:eu-west-1:123456789012:event-bus/platform", wantRegion: "eu-west-1"},
+		{name: "empty", bus: "", wantErr: true},
+		{name: "rule ARN", bus: "arn:aws:events:eu-west-1:123456789012:rule/restart", wantErr: true},
+		{name: "unknown partition", bus: "arn:aws-mars:events:eu-west-1:123456789012:event-bus/platform", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, err := ParseEventBusRegion(tt.bus)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEventBusRegion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if region != tt.wantRegion {
+				t.Errorf("region = %s, want %s", region, tt.wantRegion)
+			}
+		})
+	}
+}
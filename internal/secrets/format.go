@@ -0,0 +1,61 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// legacySecret is the v1 secret structure with lowercase keys
+type legacySecret struct {
+	Engine   string      `json:"engine"`
+	Host     string      `json:"host"`
+	Port     json.Number `json:"port"`
+	DBName   string      `json:"dbname"`
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+}
+
+// ConvertLegacySecret parses a secret value and converts it to the v2 structure if it uses the v1 format
+// legacy is false if the secret already uses the v2 format, in which case the returned secret is nil
+// The engine of the returned secret is taken from the v1 "engine" key and may be empty
+func ConvertLegacySecret(secretString string) (secret *DatabaseSecret, legacy bool, err error) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(secretString), &keys); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal secret value: %w", err)
+	}
+	if _, ok := keys["DB_PASSWORD"]; ok {
+		return nil, false, nil
+	}
+	if _, ok := keys["password"]; !ok {
+		return nil, false, fmt.Errorf("secret has neither DB_PASSWORD nor password key")
+	}
+
+	var old legacySecret
+	if err := json.Unmarshal([]byte(secretString), &old); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal v1 secret value: %w", err)
+	}
+	if old.Password == "" {
+		return nil, false, fmt.Errorf("v1 secret has an empty password")
+	}
+
+	port, _ := old.Port.Int64()
+	secret = &DatabaseSecret{
+		DBHost:     old.Host,
+		DBPort:     int(port),
+		DBName:     old.DBName,
+		DBUsername: old.Username,
+		DBPassword: old.Password,
+		Engine:     old.Engine,
+	}
+	if secret.Engine != "" {
+		secret.DatabaseURL = DatabaseURL(secret.Engine, secret.DBUsername, secret.DBPassword, secret.DBHost, secret.DBPort, secret.DBName)
+	}
+	return secret, true, nil
+}
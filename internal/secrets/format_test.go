@@ -0,0 +1,115 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"testing"
+)
+
+func TestConvertLegacySecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		wantLegacy bool
+		wantErr    bool
+		want       *DatabaseSecret
+	}{
+		{
+			name:       "v2 secret",
+			secret:     `{"DB_HOST":"db","DB_PORT":5432,"DB_NAME":"app","DB_USERNAME":"app","DB_PASSWORD":"pw"}`,
+			wantLegacy: false,
+		},
+		{
+			name:       "v1 secret with engine",
+			secret:     `{"engine":"postgres","host":"db","port":5432,"dbname":"app","username":"app","password":"p@ss"}`,
+			wantLegacy: true,
+			want: &DatabaseSecret{
+				DBHost:      "db",
+				DBPort:      5432,
+				DBName:      "app",
+				DBUsername:  "app",
+				DBPassword:  "p@ss",
+				Engine:      "postgres",
+				DatabaseURL: "postgresql://app:p%40ss@db:5432/app",
+			},
+		},
+		{
+			name:       "v1 secret without engine and string port",
+			secret:     `{"host":"db","port":"3306","dbname":"app","username":"app","password":"pw"}`,
+			wantLegacy: true,
+			want: &DatabaseSecret{
+				DBHost:     "db",
+				DBPort:     3306,
+				DBName:     "app",
+				DBUsername: "app",
+				DBPassword: "pw",
+			},
+		},
+		{
+			name:    "v1 secret with empty password",
+			secret:  `{"host":"db","password":""}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			secret:  `{"token":"abc"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			secret:  `postgresql://app:pw@db/app`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, legacy, err := ConvertLegacySecret(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertLegacySecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if legacy != tt.wantLegacy {
+				t.Errorf("ConvertLegacySecret() legacy = %v, want %v", legacy, tt.wantLegacy)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ConvertLegacySecret() = %+v, want nil", got)
+				}
+				return
+			}
+			if *got != *tt.want {
+				t.Errorf("ConvertLegacySecret() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabaseURL(t *testing.T) {
+	tests := []struct {
+		engine string
+		want   string
+	}{
+		{engine: "postgres", want: "postgresql://user:p%40ss@db:5432/app"},
+		{engine: "postgresql", want: "postgresql://user:p%40ss@db:5432/app"},
+		{engine: "mysql", want: "mysql://user:p%40ss@db:5432/app"},
+		{engine: "mariadb", want: "mysql://user:p%40ss@db:5432/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			if got := DatabaseURL(tt.engine, "user", "p@ss", "db", 5432, "app"); got != tt.want {
+				t.Errorf("DatabaseURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// IPv6 addresses are bracketed, so the port of a pooler stays separate
+	if got, want := DatabaseURL("postgres", "user", "pw", "2001:db8::1", 6432, "app"), "postgresql://user:pw@[2001:db8::1]:6432/app"; got != want {
+		t.Errorf("DatabaseURL() = %v, want %v", got, want)
+	}
+}
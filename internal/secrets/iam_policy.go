@@ -0,0 +1,167 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultSecretPrefix is the prefix of the default secret names, rds/<engine>/<databaseName>
+const DefaultSecretPrefix = "rds/"
+
+// Secrets Manager actions the operator calls on the secrets it manages
+// RestoreSecret recreates secrets scheduled for deletion, DescribeSecret reads tags and versions.
+var managedSecretActions = []string{
+	"secretsmanager:CreateSecret",
+	"secretsmanager:DeleteSecret",
+	"secretsmanager:DescribeSecret",
+	"secretsmanager:GetSecretValue",
+	"secretsmanager:RestoreSecret",
+	"secretsmanager:TagResource",
+	"secretsmanager:UntagResource",
+	"secretsmanager:UpdateSecret",
+}
+
+// Secrets Manager actions the operator calls on the secrets of admin connection strings
+var adminSecretActions = []string{
+	"secretsmanager:DescribeSecret",
+	"secretsmanager:GetSecretValue",
+}
+
+// PolicyOptions describes where an operator deployment reads and writes secrets
+type PolicyOptions struct {
+	// Regions are the regions of the secrets, at least one is required
+	Regions []string
+	// AccountID restricts the secrets to one AWS account, empty allows any account
+	AccountID string
+	// SecretPrefixes are the name prefixes of the secrets the operator writes
+	// Defaults to DefaultSecretPrefix.
+	SecretPrefixes []string
+	// AdminSecretPrefixes are the name prefixes of the secrets holding admin connection strings,
+	// which are only read. Empty leaves out the statement.
+	AdminSecretPrefixes []string
+	// ListSecrets adds secretsmanager:ListSecrets for the secret garbage collector, the adoption
+	// scanner and the migrate-secrets subcommand. ListSecrets cannot be restricted to secrets.
+	ListSecrets bool
+	// SNSTopicARNs are the topics the operator publishes notifications to. Empty leaves out the statement.
+	SNSTopicARNs []string
+	// EventBusARNs are the EventBridge buses the operator publishes secret events to. Empty leaves out the statement.
+	EventBusARNs []string
+}
+
+// PolicyDocument is an IAM policy document
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of an IAM policy document
+type PolicyStatement struct {
+	Sid      string   `json:"Sid"`
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource []string `json:"Resource"`
+}
+
+// LeastPrivilegePolicy returns the IAM policy with the Secrets Manager permissions the operator needs
+// Secrets are matched by name prefix in every region. The regions must be in one partition, as the
+// credentials of the operator are. STS and RDS need no statements: sts:GetCallerIdentity is allowed
+// for every identity and the operator does not call RDS.
+func LeastPrivilegePolicy(opts PolicyOptions) (*PolicyDocument, error) {
+	if len(opts.Regions) == 0 {
+		return nil, fmt.Errorf("at least one region is required")
+	}
+	for _, region := range opts.Regions {
+		if err := ValidateRegion(region); err != nil {
+			return nil, err
+		}
+		if partition, first := PartitionForRegion(region), PartitionForRegion(opts.Regions[0]); partition != first {
+			return nil, fmt.Errorf("region %s is in partition %s but region %s is in partition %s, generate a policy per partition",
+				region, partition, opts.Regions[0], first)
+		}
+	}
+	prefixes := opts.SecretPrefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{DefaultSecretPrefix}
+	}
+
+	policy := &PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{{
+			Sid:      "ManageCredentialSecrets",
+			Effect:   "Allow",
+			Action:   managedSecretActions,
+			Resource: secretResources(opts.Regions, opts.AccountID, prefixes),
+		}},
+	}
+	if len(opts.AdminSecretPrefixes) > 0 {
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Sid:      "ReadAdminConnectionStrings",
+			Effect:   "Allow",
+			Action:   adminSecretActions,
+			Resource: secretResources(opts.Regions, opts.AccountID, opts.AdminSecretPrefixes),
+		})
+	}
+	if opts.ListSecrets {
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Sid:      "ListSecrets",
+			Effect:   "Allow",
+			Action:   []string{"secretsmanager:ListSecrets"},
+			Resource: []string{"*"},
+		})
+	}
+	if len(opts.SNSTopicARNs) > 0 {
+		for _, topicARN := range opts.SNSTopicARNs {
+			if _, err := ParseTopicRegion(topicARN); err != nil {
+				return nil, err
+			}
+		}
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Sid:      "PublishNotifications",
+			Effect:   "Allow",
+			Action:   []string{"sns:Publish"},
+			Resource: opts.SNSTopicARNs,
+		})
+	}
+	if len(opts.EventBusARNs) > 0 {
+		for _, busARN := range opts.EventBusARNs {
+			if region, err := ParseEventBusRegion(busARN); err != nil || region == "" {
+				return nil, fmt.Errorf("invalid event bus ARN %q", busARN)
+			}
+		}
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Sid:      "PublishSecretEvents",
+			Effect:   "Allow",
+			Action:   []string{"events:PutEvents"},
+			Resource: opts.EventBusARNs,
+		})
+	}
+	return policy, nil
+}
+
+// secretResources returns the sorted, deduplicated ARN patterns of the secrets with the given
+// name prefixes. The trailing wildcard also matches the random suffix AWS appends to secret ARNs.
+func secretResources(regions []string, accountID string, prefixes []string) []string {
+	if accountID == "" {
+		accountID = "*"
+	}
+	seen := map[string]bool{}
+	var resources []string
+	for _, region := range regions {
+		for _, prefix := range prefixes {
+			arn := fmt.Sprintf("arn:%s:secretsmanager:%s:%s:secret:%s*", PartitionForRegion(region), region, accountID, prefix)
+			if !seen[arn] {
+				seen[arn] = true
+				resources = append(resources, arn)
+			}
+		}
+	}
+	sort.Strings(resources)
+	return resources
+}
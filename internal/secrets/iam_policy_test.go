@@ -0,0 +1,118 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLeastPrivilegePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          PolicyOptions
+		wantErr       bool
+		wantSids      []string
+		wantResources []string
+	}{
+		{
+			name:          "default prefix",
+			opts:          PolicyOptions{Regions: []string{"us-east-1"}},
+			wantSids:      []string{"ManageCredentialSecrets"},
+			wantResources: []string{"arn:aws:secretsmanager:us-east-1:*:secret:rds/*"},
+		},
+		{
+			name: "several regions",
+			opts: PolicyOptions{
+				Regions:        []string{"us-east-1", "eu-west-1", "us-east-1"},
+				AccountID:      "123456789012",
+				SecretPrefixes: []string{"apps/"},
+			},
+			wantSids: []string{"ManageCredentialSecrets"},
+			wantResources: []string{
+				"arn:aws:secretsmanager:eu-west-1:123456789012:secret:apps/*",
+				"arn:aws:secretsmanager:us-east-1:123456789012:secret:apps/*",
+			},
+		},
+		{
+			name:          "China partition",
+			opts:          PolicyOptions{Regions: []string{"cn-north-1"}},
+			wantSids:      []string{"ManageCredentialSecrets"},
+			wantResources: []string{"arn:aws-cn:secretsmanager:cn-north-1:*:secret:rds/*"},
+		},
+		{
+			name: "admin secrets and list secrets",
+			opts: PolicyOptions{
+				Regions:             []string{"eu-west-1"},
+				AdminSecretPrefixes: []string{"rds/admin/"},
+				ListSecrets:         true,
+			},
+			wantSids:      []string{"ManageCredentialSecrets", "ReadAdminConnectionStrings", "ListSecrets"},
+			wantResources: []string{"arn:aws:secretsmanager:eu-west-1:*:secret:rds/*"},
+		},
+		{
+			name: "notification topic",
+			opts: PolicyOptions{
+				Regions:      []string{"eu-west-1"},
+				SNSTopicARNs: []string{"arn:aws:sns:eu-west-1:123456789012:database-lifecycle"},
+			},
+			wantSids:      []string{"ManageCredentialSecrets", "PublishNotifications"},
+			wantResources: []string{"arn:aws:secretsmanager:eu-west-1:*:secret:rds/*"},
+		},
+		{
+			name: "event bus",
+			opts: PolicyOptions{
+				Regions:      []string{"eu-west-1"},
+				EventBusARNs: []string{"arn:aws:events:eu-west-1:123456789012:event-bus/default"},
+			},
+			wantSids:      []string{"ManageCredentialSecrets", "PublishSecretEvents"},
+			wantResources: []string{"arn:aws:secretsmanager:eu-west-1:*:secret:rds/*"},
+		},
+		{
+			name:    "event bus name",
+			opts:    PolicyOptions{Regions: []string{"eu-west-1"}, EventBusARNs: []string{"default"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid notification topic",
+			opts:    PolicyOptions{Regions: []string{"eu-west-1"}, SNSTopicARNs: []string{"database-lifecycle"}},
+			wantErr: true,
+		},
+		{name: "no region", opts: PolicyOptions{}, wantErr: true},
+		{name: "invalid region", opts: PolicyOptions{Regions: []string{"mars-1"}}, wantErr: true},
+		{name: "several partitions", opts: PolicyOptions{Regions: []string{"us-east-1", "cn-north-1"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := LeastPrivilegePolicy(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LeastPrivilegePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var sids []string
+			for _, statement := range policy.Statement {
+				sids = append(sids, statement.Sid)
+				for _, action := range statement.Action {
+					if action == "secretsmanager:*" {
+						t.Errorf("statement %s allows secretsmanager:*", statement.Sid)
+					}
+				}
+			}
+			if !reflect.DeepEqual(sids, tt.wantSids) {
+				t.Errorf("statements = %v, want %v", sids, tt.wantSids)
+			}
+			if got := policy.Statement[0].Resource; !reflect.DeepEqual(got, tt.wantResources) {
+				t.Errorf("resources = %v, want %v", got, tt.wantResources)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// identityFallbackRegion is used for the STS call when no region is configured
+// STS in us-east-1 is reachable with credentials from any commercial account
+const identityFallbackRegion = "us-east-1"
+
+// CallerIdentity verifies that AWS credentials can be resolved and are accepted by AWS
+// It calls sts:GetCallerIdentity, which requires no IAM permissions, and returns the caller ARN
+func CallerIdentity(ctx context.Context, region string) (string, error) {
+	cfg, err := loadAWSConfig(ctx, region)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Region == "" {
+		cfg.Region = identityFallbackRegion
+	}
+
+	result, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get AWS caller identity: %w", err)
+	}
+
+	return aws.ToString(result.Arn), nil
+}
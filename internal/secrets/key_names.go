@@ -0,0 +1,108 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// privateKeyKey is the key of the private key in the default secret format, it cannot be renamed
+const privateKeyKey = "DB_PRIVATE_KEY"
+
+// KeyNames renames keys of the default secret format, an empty name keeps the default key
+type KeyNames struct {
+	Host     string
+	Port     string
+	Name     string
+	Username string
+	Password string
+	URL      string
+}
+
+// Keys returns the key written for each key of the default secret format, indexed by the default key
+// The default of the URL key depends on the engine. A nil KeyNames returns the default keys.
+func (n *KeyNames) Keys(engine string) map[string]string {
+	keys := n.credentialKeys()
+	var url string
+	if n != nil {
+		url = n.URL
+	}
+	keys[URLFieldName(engine)] = keyOrDefault(url, URLFieldName(engine))
+	return keys
+}
+
+// credentialKeys returns the keys the operator reads back from a secret, indexed by the default key
+func (n *KeyNames) credentialKeys() map[string]string {
+	var names KeyNames
+	if n != nil {
+		names = *n
+	}
+	return map[string]string{
+		"DB_HOST":     keyOrDefault(names.Host, "DB_HOST"),
+		"DB_PORT":     keyOrDefault(names.Port, "DB_PORT"),
+		"DB_NAME":     keyOrDefault(names.Name, "DB_NAME"),
+		"DB_USERNAME": keyOrDefault(names.Username, "DB_USERNAME"),
+		"DB_PASSWORD": keyOrDefault(names.Password, "DB_PASSWORD"),
+	}
+}
+
+// Validate checks that no two keys of the secret of an engine have the same name
+func (n *KeyNames) Validate(engine string) error {
+	seen := map[string]string{privateKeyKey: privateKeyKey}
+	keys := n.Keys(engine)
+	defaults := make([]string, 0, len(keys))
+	for def := range keys {
+		defaults = append(defaults, def)
+	}
+	sort.Strings(defaults)
+	for _, def := range defaults {
+		key := keys[def]
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("secret key %s is used for both %s and %s", key, other, def)
+		}
+		seen[key] = def
+	}
+	return nil
+}
+
+// renameToDefaults returns a secret value with the renamed keys changed back to the default keys
+// Keys that are not found keep their value, so a secret written before the keys were renamed
+// is still read.
+func (n *KeyNames) renameToDefaults(secretString string) (string, error) {
+	if n == nil {
+		return secretString, nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(secretString), &values); err != nil {
+		return "", fmt.Errorf("failed to unmarshal secret value: %w", err)
+	}
+	renamed := map[string]json.RawMessage{}
+	for def, key := range n.credentialKeys() {
+		if value, ok := values[key]; ok && key != def {
+			renamed[def] = value
+			delete(values, key)
+		}
+	}
+	for def, value := range renamed {
+		values[def] = value
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func keyOrDefault(key, def string) string {
+	if key == "" {
+		return def
+	}
+	return key
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// PartitionAWS is the standard AWS commercial partition
+	PartitionAWS = "aws"
+	// PartitionAWSChina is the AWS China partition
+	PartitionAWSChina = "aws-cn"
+	// PartitionAWSGovCloud is the AWS GovCloud (US) partition
+	PartitionAWSGovCloud = "aws-us-gov"
+)
+
+// validPartitions contains the AWS partitions in which Secrets Manager ARNs are accepted
+var validPartitions = map[string]bool{
+	PartitionAWS:         true,
+	PartitionAWSChina:    true,
+	PartitionAWSGovCloud: true,
+}
+
+// partitionRegions maps each supported partition to the pattern its region names follow.
+// The patterns are taken from the AWS SDK partition metadata (regionRegex), which the SDK
+// uses to resolve endpoints, so regions launched after this release are accepted as long as
+// they belong to a known partition.
+var partitionRegions = []struct {
+	partition string
+	pattern   *regexp.Regexp
+}{
+	{partition: PartitionAWS, pattern: regexp.MustCompile(`^(us|eu|ap|sa|ca|me|af|il|mx)-\w+-\d+$`)},
+	{partition: PartitionAWSChina, pattern: regexp.MustCompile(`^cn-\w+-\d+$`)},
+	{partition: PartitionAWSGovCloud, pattern: regexp.MustCompile(`^us-gov-\w+-\d+$`)},
+}
+
+// regionFormat matches the shape of AWS region names, e.g. us-east-1, us-gov-west-1, ap-southeast-4
+var regionFormat = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// PartitionForRegion returns the AWS partition the given region belongs to
+// The AWS SDK resolves Secrets Manager and STS endpoints for the partition from the region,
+// e.g. secretsmanager.cn-north-1.amazonaws.com.cn for aws-cn
+// Like the SDK, regions not matching any partition fall back to the aws partition
+func PartitionForRegion(region string) string {
+	for _, p := range partitionRegions {
+		if p.pattern.MatchString(region) {
+			return p.partition
+		}
+	}
+	return PartitionAWS
+}
+
+// ValidateRegion checks if the provided region is a valid AWS region
+// A region is valid if it matches the region pattern of one of the supported partitions
+// Returns nil if valid or empty (empty allows AWS SDK default resolution)
+// Returns error if the region is explicitly provided but invalid
+func ValidateRegion(region string) error {
+	// Allow empty region - AWS SDK will resolve from environment/config/metadata
+	if region == "" {
+		return nil
+	}
+
+	for _, p := range partitionRegions {
+		if p.pattern.MatchString(region) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid AWS region: %s", region)
+}
+
+// ValidateRegionFormat checks that a region is shaped like an AWS region name
+// without checking that it belongs to a supported partition
+// Returns nil if valid or empty (empty allows AWS SDK default resolution)
+func ValidateRegionFormat(region string) error {
+	if region == "" {
+		return nil
+	}
+	if !regionFormat.MatchString(region) {
+		return fmt.Errorf("invalid AWS region: %s", region)
+	}
+	return nil
+}
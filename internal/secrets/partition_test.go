@@ -0,0 +1,59 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"testing"
+)
+
+func TestPartitionForRegion(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{region: "us-east-1", want: PartitionAWS},
+		{region: "eu-central-2", want: PartitionAWS},
+		{region: "us-gov-west-1", want: PartitionAWSGovCloud},
+		{region: "us-gov-east-1", want: PartitionAWSGovCloud},
+		{region: "cn-north-1", want: PartitionAWSChina},
+		{region: "cn-northwest-1", want: PartitionAWSChina},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			if got := PartitionForRegion(tt.region); got != tt.want {
+				t.Errorf("PartitionForRegion(%q) = %v, want %v", tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRegionFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		region  string
+		wantErr bool
+	}{
+		{name: "empty", region: "", wantErr: false},
+		{name: "known region", region: "us-east-1", wantErr: false},
+		{name: "GovCloud region", region: "us-gov-west-1", wantErr: false},
+		{name: "not yet known region", region: "mx-central-1", wantErr: false},
+		{name: "uppercase", region: "US-EAST-1", wantErr: true},
+		{name: "missing number", region: "us-east", wantErr: true},
+		{name: "availability zone", region: "us-east-1a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRegionFormat(tt.region)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRegionFormat(%q) error = %v, wantErr %v", tt.region, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// ThrottleWindow is how long a throttled AWS request is counted by RecentThrottles
+const ThrottleWindow = 5 * time.Minute
+
+// APICall is an attempt of an AWS API call, retries of a call are attempts of their own
+type APICall struct {
+	Service   string
+	Operation string
+	Region    string
+	// Throttled is true if AWS rejected the attempt because of a rate limit or quota
+	Throttled bool
+	// Failed is true if the attempt returned any error, including throttling
+	Failed bool
+	// Wait is how long the attempt waited for the operator-wide rate limit
+	Wait time.Duration
+}
+
+var (
+	quotaMu     sync.Mutex
+	apiLimiter  *rate.Limiter
+	apiObserver func(APICall)
+	// throttles holds the times of throttled attempts within ThrottleWindow by region
+	throttles = map[string][]time.Time{}
+)
+
+// configureRateLimit sets the token bucket shared by all AWS clients, a rate of 0 removes the limit
+func configureRateLimit(requestsPerSecond float64, burst int) error {
+	if requestsPerSecond < 0 || burst < 0 {
+		return fmt.Errorf("AWS API rate limit and burst must not be negative")
+	}
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+	if requestsPerSecond == 0 {
+		apiLimiter = nil
+		return nil
+	}
+	apiLimiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(burst, 1))
+	return nil
+}
+
+// SetAPICallObserver sets a function called after each attempt of an AWS API call, e.g. to count
+// the calls in metrics. It must not block.
+func SetAPICallObserver(observer func(APICall)) {
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+	apiObserver = observer
+}
+
+// RecentThrottles returns the number of attempts AWS throttled in a region within ThrottleWindow
+// An empty region counts the throttled attempts of all regions.
+func RecentThrottles(region string, now time.Time) int {
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+	count := 0
+	for r := range throttles {
+		pruneThrottles(r, now)
+		if region == "" || r == region {
+			count += len(throttles[r])
+		}
+	}
+	return count
+}
+
+// pruneThrottles removes the throttled attempts of a region older than ThrottleWindow, quotaMu must be held
+func pruneThrottles(region string, now time.Time) {
+	times := throttles[region]
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= ThrottleWindow {
+		i++
+	}
+	if i == len(times) {
+		delete(throttles, region)
+		return
+	}
+	throttles[region] = times[i:]
+}
+
+// recordAPICall tracks a throttled attempt and reports the attempt to the observer
+func recordAPICall(call APICall, now time.Time) {
+	quotaMu.Lock()
+	if call.Throttled {
+		throttles[call.Region] = append(throttles[call.Region], now)
+		pruneThrottles(call.Region, now)
+	}
+	observer := apiObserver
+	quotaMu.Unlock()
+
+	if observer != nil {
+		observer(call)
+	}
+}
+
+// addQuotaMiddleware limits and tracks every attempt of the API calls of a client
+// It runs after the retry middleware, so each retry waits for the rate limit and is counted.
+func addQuotaMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("OperatorAPIQuota", handleQuota), middleware.After)
+}
+
+func handleQuota(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	call := APICall{
+		Service:   awsmiddleware.GetServiceID(ctx),
+		Operation: awsmiddleware.GetOperationName(ctx),
+		Region:    awsmiddleware.GetRegion(ctx),
+	}
+
+	quotaMu.Lock()
+	limiter := apiLimiter
+	quotaMu.Unlock()
+	if limiter != nil {
+		start := time.Now()
+		if err := limiter.Wait(ctx); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("failed to wait for the AWS API rate limit: %w", err)
+		}
+		call.Wait = time.Since(start)
+	}
+
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	if err != nil {
+		call.Failed = true
+		call.Throttled = retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
+	}
+	recordAPICall(call, time.Now())
+	return out, metadata, err
+}
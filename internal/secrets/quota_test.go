@@ -0,0 +1,119 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func resetQuota(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		_ = configureRateLimit(0, 0)
+		SetAPICallObserver(nil)
+		quotaMu.Lock()
+		throttles = map[string][]time.Time{}
+		quotaMu.Unlock()
+	})
+}
+
+func TestRecentThrottles(t *testing.T) {
+	resetQuota(t)
+	now := time.Now()
+	recordAPICall(APICall{Region: "us-east-1", Throttled: true}, now.Add(-10*time.Minute))
+	recordAPICall(APICall{Region: "us-east-1", Throttled: true}, now.Add(-time.Minute))
+	recordAPICall(APICall{Region: "us-east-1", Failed: true}, now)
+	recordAPICall(APICall{Region: "eu-west-1", Throttled: true}, now)
+
+	tests := []struct {
+		name   string
+		region string
+		now    time.Time
+		want   int
+	}{
+		{name: "region", region: "us-east-1", now: now, want: 1},
+		{name: "other region", region: "eu-west-1", now: now, want: 1},
+		{name: "region without throttles", region: "ap-south-1", now: now, want: 0},
+		{name: "all regions", region: "", now: now, want: 2},
+		{name: "after the window", region: "", now: now.Add(ThrottleWindow), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecentThrottles(tt.region, tt.now); got != tt.want {
+				t.Errorf("RecentThrottles(%q) = %d, want %d", tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleQuota(t *testing.T) {
+	resetQuota(t)
+	var calls []APICall
+	SetAPICallObserver(func(call APICall) { calls = append(calls, call) })
+
+	tests := []struct {
+		name          string
+		err           error
+		wantFailed    bool
+		wantThrottled bool
+	}{
+		{name: "success"},
+		{name: "error", err: errors.New("connection reset"), wantFailed: true},
+		{name: "throttled", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, wantFailed: true, wantThrottled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			ctx := awsmiddleware.SetServiceID(context.Background(), "Secrets Manager")
+			next := middleware.FinalizeHandlerFunc(func(context.Context, middleware.FinalizeInput) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, tt.err
+			})
+			if _, _, err := handleQuota(ctx, middleware.FinalizeInput{}, next); !errors.Is(err, tt.err) {
+				t.Fatalf("handleQuota() error = %v, want %v", err, tt.err)
+			}
+			if len(calls) != 1 {
+				t.Fatalf("observer called %d times, want 1", len(calls))
+			}
+			if calls[0].Service != "Secrets Manager" || calls[0].Failed != tt.wantFailed || calls[0].Throttled != tt.wantThrottled {
+				t.Errorf("observed %+v, want failed %v and throttled %v", calls[0], tt.wantFailed, tt.wantThrottled)
+			}
+		})
+	}
+	if got := RecentThrottles("", time.Now()); got != 1 {
+		t.Errorf("RecentThrottles() = %d, want 1", got)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	resetQuota(t)
+	if err := configureRateLimit(-1, 1); err == nil {
+		t.Error("configureRateLimit() with a negative rate succeeded")
+	}
+	if err := configureRateLimit(1, 1); err != nil {
+		t.Fatalf("configureRateLimit() error = %v", err)
+	}
+	next := middleware.FinalizeHandlerFunc(func(context.Context, middleware.FinalizeInput) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+	})
+	// The burst is used up by the first call, the second one has to wait for the next token
+	if _, _, err := handleQuota(context.Background(), middleware.FinalizeInput{}, next); err != nil {
+		t.Fatalf("handleQuota() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := handleQuota(ctx, middleware.FinalizeInput{}, next); err == nil {
+		t.Error("handleQuota() above the rate limit did not wait")
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// SNSPublisher publishes messages to an SNS topic, in the region of the topic
+type SNSPublisher struct {
+	client   *sns.Client
+	topicARN string
+}
+
+// ParseTopicRegion returns the region of an SNS topic ARN
+// Format: arn:<partition>:sns:<region>:<account-id>:<topic>
+func ParseTopicRegion(topicARN string) (string, error) {
+	parts := strings.Split(topicARN, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sns" || parts[3] == "" || parts[5] == "" {
+		return "", fmt.Errorf("invalid SNS topic ARN %q: expected arn:<partition>:sns:<region>:<account-id>:<topic>", topicARN)
+	}
+	if !validPartitions[parts[1]] {
+		return "", fmt.Errorf("invalid SNS topic ARN %q: unknown partition %s", topicARN, parts[1])
+	}
+	if err := ValidateRegion(parts[3]); err != nil {
+		return "", fmt.Errorf("invalid SNS topic ARN %q: %w", topicARN, err)
+	}
+	return parts[3], nil
+}
+
+// NewSNSPublisher creates a publisher of the topic with the configured transport
+func NewSNSPublisher(ctx context.Context, topicARN string) (*SNSPublisher, error) {
+	region, err := ParseTopicRegion(topicARN)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadAWSConfig(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	return &SNSPublisher{client: sns.NewFromConfig(cfg), topicARN: topicARN}, nil
+}
+
+// TopicARN returns the ARN of the topic the publisher sends to
+func (p *SNSPublisher) TopicARN() string {
+	return p.topicARN
+}
+
+// Publish sends a message to the topic. SNS limits subjects to 100 characters, longer ones are cut.
+func (p *SNSPublisher) Publish(ctx context.Context, subject, message string) error {
+	if len(subject) > 100 {
+		subject = subject[:100]
+	}
+	_, err := p.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(p.topicARN),
+		Subject:  aws.String(subject),
+		Message:  aws.String(message),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to SNS topic %s: %w", p.topicARN, err)
+	}
+	return nil
+}
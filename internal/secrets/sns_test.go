@@ -0,0 +1,39 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import "testing"
+
+func TestParseTopicRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		topicARN   string
+		wantRegion string
+		wantErr    bool
+	}{
+		{name: "valid", topicARN: "arn:aws:sns:eu-west-1:123456789012:database-lifecycle", wantRegion: "eu-west-1"},
+		{name: "China partition", topicARN: "arn:aws-cn:sns:cn-north-1:123456789012:alerts", wantRegion: "cn-north-1"},
+		{name: "name only", topicARN: "database-lifecycle", wantErr: true},
+		{name: "other service", topicARN: "arn:aws:sqs:eu-west-1:123456789012:queue", wantErr: true},
+		{name: "unknown partition", topicARN: "arn:aws-mars:sns:eu-west-1:123456789012:alerts", wantErr: true},
+		{name: "no region", topicARN: "arn:aws:sns::123456789012:alerts", wantErr: true},
+		{name: "subscription ARN", topicARN: "arn:aws:sns:eu-west-1:123456789012:alerts:3f5c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, err := ParseTopicRegion(tt.topicARN)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTopicRegion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if region != tt.wantRegion {
+				t.Errorf("region = %s, want %s", region, tt.wantRegion)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// templateData is the data of secret templates, the fields of DatabaseSecret without its methods
+// and pointers, so a template cannot call into the operator
+type templateData struct {
+	DBHost       string
+	DBPort       int
+	DBName       string
+	DBUsername   string
+	DBPassword   string
+	DBPrivateKey string
+	DatabaseURL  string
+	Engine       string
+}
+
+// templateFields are the fields of templateData
+var templateFields = map[string]bool{
+	"DBHost":       true,
+	"DBPort":       true,
+	"DBName":       true,
+	"DBUsername":   true,
+	"DBPassword":   true,
+	"DBPrivateKey": true,
+	"DatabaseURL":  true,
+	"Engine":       true,
+}
+
+var errTemplateCall = errors.New("call is not allowed in secret templates")
+
+// templateFuncs replaces the call builtin, which calls function values of the data
+var templateFuncs = template.FuncMap{
+	"call": func(...interface{}) (interface{}, error) { return nil, errTemplateCall },
+}
+
+var (
+	// literalCredentialKey matches the keys of credentials in templates, e.g. DB_PASSWORD or
+	// spring.datasource.password, but not token_url
+	literalCredentialKey = `[A-Za-z0-9_.-]*(?i:password|passwd|pwd|token|api_?key|access_?key|secret_?key|private_?key)`
+	// literalJSONCredential matches a JSON key of a credential with a literal, non-empty string value
+	literalJSONCredential = regexp.MustCompile(`"(` + literalCredentialKey + `)"\s*:\s*"[^"\n]+"`)
+	// literalFileCredential matches a KEY=VALUE or KEY: VALUE line of a credential with a literal value
+	literalFileCredential = regexp.MustCompile(`(?m)^[ \t]*(` + literalCredentialKey + `)[ \t]*[=:][ \t]*[^\s"'{][^\n]*$`)
+	// literalURLCredential matches the user and password of a literal URL
+	literalURLCredential = regexp.MustCompile(`://[^\s/:@"'{}]+:[^\s/@"'{}]+@`)
+)
+
+// data returns the template data of a secret
+func (s *DatabaseSecret) data() templateData {
+	return templateData{
+		DBHost:       s.DBHost,
+		DBPort:       s.DBPort,
+		DBName:       s.DBName,
+		DBUsername:   s.DBUsername,
+		DBPassword:   s.DBPassword,
+		DBPrivateKey: s.DBPrivateKey,
+		DatabaseURL:  s.DatabaseURL,
+		Engine:       s.Engine,
+	}
+}
+
+// parseTemplate parses a secret template with call disabled
+func parseTemplate(tmplStr string) (*template.Template, error) {
+	return template.New("secret").Funcs(templateFuncs).Parse(tmplStr)
+}
+
+// ValidateSecretTemplate rejects a secret template that
+//   - does not parse, calls functions with call or uses fields that secret templates do not have
+//   - references no field, so every Database would store the same value
+//   - embeds a literal credential, e.g. a password or a URL with a password, which would be copied
+//     into the secret of every Database using the template
+//
+// Errors name the offending key only, never the literal value.
+func ValidateSecretTemplate(tmplStr string) error {
+	tmpl, err := parseTemplate(tmplStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse secret template: %w", err)
+	}
+
+	lint := &templateLint{fields: map[string]bool{}}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			lint.walk(t.Tree.Root)
+		}
+	}
+	if lint.err != nil {
+		return lint.err
+	}
+	if len(lint.fields) == 0 {
+		return fmt.Errorf("secret template references no field such as {{.DBPassword}}, every Database would store the same value")
+	}
+
+	// A literal never spans an action, so each text between actions is checked on its own
+	for _, text := range lint.texts {
+		m := literalJSONCredential.FindStringSubmatch(text)
+		if m == nil {
+			m = literalFileCredential.FindStringSubmatch(text)
+		}
+		if m != nil {
+			return fmt.Errorf("secret template embeds a literal value for key %s, use a field such as {{.DBPassword}} instead", m[1])
+		}
+		if literalURLCredential.MatchString(text) {
+			return fmt.Errorf("secret template embeds a URL with a literal password, use {{.DatabaseURL}} or {{.DBPassword}} instead")
+		}
+	}
+	return nil
+}
+
+// ValidateBinaryTemplate rejects a template of a secret stored as SecretBinary whose output the operator
+// cannot read the password back from: the output must be JSON, a database URL or contain a
+// KEY=<database URL> line, e.g. DATABASE_URL={{.DatabaseURL}} in a .env file
+func ValidateBinaryTemplate(tmplStr, engine string) error {
+	sample := &DatabaseSecret{
+		DBHost:     "db.example.com",
+		DBPort:     5432,
+		DBName:     "app",
+		DBUsername: "app",
+		DBPassword: "password",
+		Engine:     engine,
+		Binary:     true,
+	}
+	sample.DatabaseURL = DatabaseURL(engine, sample.DBUsername, sample.DBPassword, sample.DBHost, sample.DBPort, sample.DBName)
+	value, err := sample.ToJSONWithTemplate(tmplStr)
+	if err != nil {
+		return err
+	}
+	if json.Valid(value) {
+		return nil
+	}
+	if _, err := parseFileValue(string(value)); err != nil {
+		return fmt.Errorf("secret template of a binary secret renders no database URL with the password, " +
+			"add a line such as DATABASE_URL={{.DatabaseURL}}")
+	}
+	return nil
+}
+
+// templateLint collects the fields and the literal text of a template
+type templateLint struct {
+	fields map[string]bool
+	texts  []string
+	err    error
+}
+
+func (l *templateLint) walk(node parse.Node) {
+	if node == nil || l.err != nil {
+		return
+	}
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			l.walk(child)
+		}
+	case *parse.TextNode:
+		l.texts = append(l.texts, string(n.Text))
+	case *parse.ActionNode:
+		l.walk(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			l.walk(cmd)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			l.walk(arg)
+		}
+	case *parse.IdentifierNode:
+		if n.Ident == "call" {
+			l.err = errTemplateCall
+		}
+	case *parse.FieldNode:
+		l.field(n.Ident)
+	case *parse.ChainNode:
+		l.walk(n.Node)
+		l.field(n.Field)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			l.field(n.Ident[1:])
+		}
+	case *parse.IfNode:
+		l.branch(&n.BranchNode)
+	case *parse.RangeNode:
+		l.branch(&n.BranchNode)
+	case *parse.WithNode:
+		l.branch(&n.BranchNode)
+	case *parse.TemplateNode:
+		l.walk(n.Pipe)
+	}
+}
+
+func (l *templateLint) branch(n *parse.BranchNode) {
+	l.walk(n.Pipe)
+	l.walk(n.List)
+	l.walk(n.ElseList)
+}
+
+// field records a field reference, rejecting fields that secret templates do not have
+func (l *templateLint) field(idents []string) {
+	if len(idents) == 0 || l.err != nil {
+		return
+	}
+	if !templateFields[idents[0]] {
+		l.err = fmt.Errorf("field .%s is not available in secret templates, use one of %s", idents[0], templateFieldList())
+		return
+	}
+	if len(idents) > 1 {
+		l.err = fmt.Errorf("field .%s has no fields", idents[0])
+		return
+	}
+	l.fields[idents[0]] = true
+}
+
+func templateFieldList() string {
+	fields := make([]string, 0, len(templateFields))
+	for field := range templateFields {
+		fields = append(fields, "."+field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
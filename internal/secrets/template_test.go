@@ -239,3 +239,81 @@ func TestDatabaseSecret_ToJSON_UsesDefaultTemplate(t *testing.T) {
 			string(jsonDefault), string(jsonWithEmptyTemplate))
 	}
 }
+
+func TestValidateSecretTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr string
+	}{
+		{name: "json", tmpl: `{"host": "{{.DBHost}}", "port": {{.DBPort}}, "password": "{{.DBPassword}}"}`},
+		{name: "env file", tmpl: "DATABASE_URL={{.DatabaseURL}}\nDB_PASSWORD={{.DBPassword}}\nTOKEN_URL=https://auth.example.com\n"},
+		{name: "properties", tmpl: "spring.datasource.url=jdbc:postgresql://{{.DBHost}}:{{.DBPort}}/{{.DBName}}\nspring.datasource.password={{.DBPassword}}\n"},
+		{name: "printf and conditionals", tmpl: `{"key": {{printf "%q" .DBPrivateKey}}{{if eq .Engine "mysql"}}, "tls": true{{end}}}`},
+		{name: "root variable", tmpl: `{{with .DBHost}}{"host": "{{.}}", "user": "{{$.DBUsername}}"}{{end}}`},
+		{name: "parse error", tmpl: `{"host": "{{.DBHost"}`, wantErr: "failed to parse"},
+		{name: "constant", tmpl: `{"host": "db.example.com"}`, wantErr: "references no field"},
+		{name: "call", tmpl: `{"host": "{{call .DBHost}}"}`, wantErr: "call is not allowed"},
+		{name: "method", tmpl: `{"value": "{{.ToJSON}}"}`, wantErr: "field .ToJSON is not available"},
+		{name: "unknown field", tmpl: `{"keys": "{{.KeyNames}}"}`, wantErr: "field .KeyNames is not available"},
+		{name: "literal json password", tmpl: `{"user": "{{.DBUsername}}", "admin_password": "hunter2"}`, wantErr: "literal value for key admin_password"},
+		{name: "literal env token", tmpl: "DATABASE_URL={{.DatabaseURL}}\nAPI_TOKEN=abc123\n", wantErr: "literal value for key API_TOKEN"},
+		{name: "literal url password", tmpl: `{"admin": "postgresql://admin:hunter2@db:5432/postgres", "user": "{{.DBUsername}}"}`, wantErr: "URL with a literal password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSecretTemplate(tt.tmpl)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSecretTemplate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateSecretTemplate() error = %v, want %q", err, tt.wantErr)
+			}
+			if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "abc123") {
+				t.Errorf("ValidateSecretTemplate() error = %v contains the literal credential", err)
+			}
+		})
+	}
+}
+
+func TestValidateBinaryTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  string
+		tmpl    string
+		wantErr bool
+	}{
+		{name: "env file", engine: "postgres", tmpl: "DATABASE_URL={{.DatabaseURL}}\nDB_PASSWORD={{.DBPassword}}\n"},
+		{name: "quoted url", engine: "mysql", tmpl: "export MYSQL_URL=\"{{.DatabaseURL}}\"\n"},
+		{name: "url only", engine: "postgres", tmpl: "{{.DatabaseURL}}"},
+		{name: "json", engine: "postgres", tmpl: `{"password": "{{.DBPassword}}"}`},
+		{name: "url built from fields", engine: "postgres", tmpl: "URL=postgresql://{{.DBUsername}}:{{.DBPassword}}@{{.DBHost}}:{{.DBPort}}/{{.DBName}}\n"},
+		{name: "properties without url", engine: "postgres", tmpl: "spring.datasource.url=jdbc:postgresql://{{.DBHost}}:{{.DBPort}}/{{.DBName}}\nspring.datasource.password={{.DBPassword}}\n", wantErr: true},
+		{name: "password only", engine: "postgres", tmpl: "DB_PASSWORD={{.DBPassword}}\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBinaryTemplate(tt.tmpl, tt.engine)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBinaryTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToJSONWithTemplateSandbox(t *testing.T) {
+	secret := &DatabaseSecret{DBHost: "localhost", DBPassword: "testpass"}
+
+	// Methods of the secret are not available to templates
+	if _, err := secret.ToJSONWithTemplate(`{"value": {{printf "%q" .ToJSON}}}`); err == nil {
+		t.Error("ToJSONWithTemplate() called a method of the secret")
+	}
+	if _, err := secret.ToJSONWithTemplate(`{"value": "{{call .DBHost}}"}`); err == nil {
+		t.Error("ToJSONWithTemplate() allowed call")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// TransportOptions configures how the operator reaches AWS endpoints
+// The zero value uses the defaults of the AWS SDK, including the HTTPS_PROXY and NO_PROXY
+// environment variables and the system certificate pool.
+type TransportOptions struct {
+	// CABundle is the path of a PEM file with certificates trusted in addition to the system pool,
+	// e.g. the CA of a TLS-intercepting proxy or of a self-hosted endpoint
+	CABundle string
+
+	// InsecureSkipVerify disables the verification of the certificates of AWS endpoints
+	InsecureSkipVerify bool
+
+	// ProxyURL is the HTTP proxy for AWS endpoints, it takes precedence over HTTPS_PROXY
+	ProxyURL string
+
+	// SecretsManagerEndpoint replaces the Secrets Manager endpoint of every region, e.g. with a
+	// self-hosted Secrets Manager compatible store
+	SecretsManagerEndpoint string
+
+	// RequestsPerSecond limits the attempts of AWS API calls of the whole operator, so it leaves the
+	// quota of the account to other workloads. 0 disables the limit.
+	RequestsPerSecond float64
+
+	// Burst is the number of attempts allowed at once above RequestsPerSecond
+	Burst int
+}
+
+var (
+	transportMu         sync.RWMutex
+	transportOptions    TransportOptions
+	transportHTTPClient aws.HTTPClient
+)
+
+// ConfigureTransport sets the transport options of all AWS clients created afterwards
+func ConfigureTransport(opts TransportOptions) error {
+	httpClient, err := opts.httpClient()
+	if err != nil {
+		return err
+	}
+	if opts.SecretsManagerEndpoint != "" {
+		if _, err := url.ParseRequestURI(opts.SecretsManagerEndpoint); err != nil {
+			return fmt.Errorf("invalid Secrets Manager endpoint: %w", err)
+		}
+	}
+	if err := configureRateLimit(opts.RequestsPerSecond, opts.Burst); err != nil {
+		return err
+	}
+
+	resetDescribeCache()
+
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	transportOptions = opts
+	transportHTTPClient = httpClient
+	return nil
+}
+
+// httpClient returns the HTTP client of the options, nil if the SDK default client is sufficient
+func (o TransportOptions) httpClient() (aws.HTTPClient, error) {
+	if o.CABundle == "" && !o.InsecureSkipVerify && o.ProxyURL == "" {
+		return nil, nil
+	}
+
+	var rootCAs *x509.CertPool
+	if o.CABundle != "" {
+		pem, err := os.ReadFile(o.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if rootCAs, err = x509.SystemCertPool(); err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", o.CABundle)
+		}
+	}
+
+	var proxy *url.URL
+	if o.ProxyURL != "" {
+		var err error
+		if proxy, err = url.Parse(o.ProxyURL); err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", o.ProxyURL)
+		}
+	}
+
+	return awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		if tr.TLSClientConfig == nil {
+			tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if rootCAs != nil {
+			tr.TLSClientConfig.RootCAs = rootCAs
+		}
+		tr.TLSClientConfig.InsecureSkipVerify = o.InsecureSkipVerify // #nosec G402 -- explicit opt-in
+		if proxy != nil {
+			tr.Proxy = http.ProxyURL(proxy)
+		}
+	}), nil
+}
+
+// loadAWSConfig loads the default AWS configuration for a region with the configured transport
+// An empty region uses the region of the environment.
+func loadAWSConfig(ctx context.Context, region string) (aws.Config, error) {
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	transportMu.RLock()
+	httpClient := transportHTTPClient
+	transportMu.RUnlock()
+	if httpClient != nil {
+		opts = append(opts, config.WithHTTPClient(httpClient))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	cfg.APIOptions = append(cfg.APIOptions, addQuotaMiddleware)
+	return cfg, nil
+}
+
+// secretsManagerEndpoint returns the configured Secrets Manager endpoint, empty for the AWS endpoint
+func secretsManagerEndpoint() string {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return transportOptions.SecretsManagerEndpoint
+}
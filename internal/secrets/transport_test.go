@@ -0,0 +1,112 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testCA is a self-signed CA certificate
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----
+`
+
+func TestTransportOptionsHTTPClient(t *testing.T) {
+	dir := t.TempDir()
+	caBundle := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caBundle, []byte(testCA), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	notPEM := filepath.Join(dir, "not-pem.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		opts       TransportOptions
+		wantClient bool
+		wantErr    bool
+	}{
+		{
+			name: "defaults use the SDK client",
+			opts: TransportOptions{},
+		},
+		{
+			name: "endpoint alone uses the SDK client",
+			opts: TransportOptions{SecretsManagerEndpoint: "https://secrets.example.com"},
+		},
+		{
+			name:       "CA bundle",
+			opts:       TransportOptions{CABundle: caBundle},
+			wantClient: true,
+		},
+		{
+			name:    "missing CA bundle",
+			opts:    TransportOptions{CABundle: filepath.Join(dir, "missing.pem")},
+			wantErr: true,
+		},
+		{
+			name:    "CA bundle without certificates",
+			opts:    TransportOptions{CABundle: notPEM},
+			wantErr: true,
+		},
+		{
+			name:       "skip TLS verification",
+			opts:       TransportOptions{InsecureSkipVerify: true},
+			wantClient: true,
+		},
+		{
+			name:       "proxy",
+			opts:       TransportOptions{ProxyURL: "http://proxy.example.com:3128"},
+			wantClient: true,
+		},
+		{
+			name:    "proxy without host",
+			opts:    TransportOptions{ProxyURL: "proxy"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.opts.httpClient()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("httpClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (client != nil) != tt.wantClient {
+				t.Errorf("httpClient() client = %v, wantClient %v", client, tt.wantClient)
+			}
+		})
+	}
+}
+
+func TestConfigureTransportEndpoint(t *testing.T) {
+	t.Cleanup(func() { _ = ConfigureTransport(TransportOptions{}) })
+
+	if err := ConfigureTransport(TransportOptions{SecretsManagerEndpoint: "not a URL"}); err == nil {
+		t.Error("ConfigureTransport() with invalid endpoint succeeded")
+	}
+	if err := ConfigureTransport(TransportOptions{SecretsManagerEndpoint: "https://secrets.example.com"}); err != nil {
+		t.Fatalf("ConfigureTransport() error = %v", err)
+	}
+	if got := secretsManagerEndpoint(); got != "https://secrets.example.com" {
+		t.Errorf("secretsManagerEndpoint() = %q", got)
+	}
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	databasev1alpha1 "opzkit/database-user-operator/api/v1alpha1"
+	"opzkit/database-user-operator/internal/database"
+	"opzkit/database-user-operator/internal/secrets"
+)
+
+// DefaultProductionNamespaceSelector selects the namespaces considered production
+const DefaultProductionNamespaceSelector = "environment=production"
+
+// defaultConnectionStringKey is the key of spec.connectionStringSecretRef without a key, as in the controller
+const defaultConnectionStringKey = "connectionString"
+
+var databaselog = logf.Log.WithName("database-webhook")
+
+// SetupDatabaseWebhookWithManager registers the Database validating webhook with the manager
+func SetupDatabaseWebhookWithManager(mgr ctrl.Manager, productionNamespaces labels.Selector, validateConnectionStrings bool) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&databasev1alpha1.Database{}).
+		WithValidator(&DatabaseCustomValidator{
+			Reader:                    mgr.GetAPIReader(),
+			ProductionNamespaces:      productionNamespaces,
+			ValidateConnectionStrings: validateConnectionStrings,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-database-opzkit-io-v1alpha1-database,mutating=false,failurePolicy=ignore,sideEffects=None,groups=database.opzkit.io,resources=databases,verbs=create;update,versions=v1alpha1,name=vdatabase-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// DatabaseCustomValidator returns admission warnings for risky Database configurations
+// The warnings are shown by kubectl on apply. A Database is only rejected when spec.databaseName
+// of a created database changes without the allow-rename annotation, and by the optional
+// validation of its admin connection string.
+type DatabaseCustomValidator struct {
+	// Reader reads the namespace and the secrets of the Database, it should not be a cached client
+	Reader client.Reader
+
+	// ProductionNamespaces selects the namespaces in which retainOnDelete=false is flagged
+	// A nil selector disables the check
+	ProductionNamespaces labels.Selector
+
+	// ValidateConnectionStrings rejects a Database whose spec.connectionStringSecretRef does not
+	// contain a connection string of its engine
+	ValidateConnectionStrings bool
+}
+
+var _ admission.CustomValidator = &DatabaseCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *DatabaseCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	db, ok := obj.(*databasev1alpha1.Database)
+	if !ok {
+		return nil, fmt.Errorf("expected a Database object but got %T", obj)
+	}
+	if err := validateUsernameLength(db); err != nil {
+		return nil, err
+	}
+	if err := validateSecretTemplate(db); err != nil {
+		return nil, err
+	}
+	return v.validate(ctx, db)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *DatabaseCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	db, ok := newObj.(*databasev1alpha1.Database)
+	if !ok {
+		return nil, fmt.Errorf("expected a Database object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*databasev1alpha1.Database)
+	if !ok {
+		return nil, fmt.Errorf("expected a Database object for the oldObj but got %T", oldObj)
+	}
+	if err := validateDatabaseNameChange(old, db); err != nil {
+		return nil, err
+	}
+	// A Database admitted before the check is not blocked, e.g. from removing its finalizer
+	if usernameSpecChanged(old, db) {
+		if err := validateUsernameLength(db); err != nil {
+			return nil, err
+		}
+	}
+	if old.Spec.SecretTemplate != db.Spec.SecretTemplate || old.Spec.SecretBinary != db.Spec.SecretBinary {
+		if err := validateSecretTemplate(db); err != nil {
+			return nil, err
+		}
+	}
+	return v.validate(ctx, db)
+}
+
+// validateDatabaseNameChange rejects a change of spec.databaseName once the database is created,
+// which would create a second database, unless the allow-rename annotation asks to rename it
+func validateDatabaseNameChange(old, db *databasev1alpha1.Database) error {
+	if !old.Status.DatabaseCreated || old.Spec.DatabaseName == db.Spec.DatabaseName ||
+		db.Annotations[databasev1alpha1.AnnotationAllowRename] == "true" {
+		return nil
+	}
+	message := fmt.Sprintf("database %s is already created, set the annotation %s=true to rename it",
+		old.Spec.DatabaseName, databasev1alpha1.AnnotationAllowRename)
+	return apierrors.NewInvalid(databasev1alpha1.GroupVersion.WithKind("Database").GroupKind(), db.Name,
+		field.ErrorList{field.Forbidden(field.NewPath("spec", "databaseName"), message)})
+}
+
+// username returns the username of a Database before truncation, spec.username or spec.databaseName
+func username(db *databasev1alpha1.Database) string {
+	if db.Spec.Username != "" {
+		return db.Spec.Username
+	}
+	return db.Spec.DatabaseName
+}
+
+// usernameSpecChanged reports whether an update changes the username or its length limit
+func usernameSpecChanged(old, db *databasev1alpha1.Database) bool {
+	return username(old) != username(db) || old.Spec.Engine != db.Spec.Engine ||
+		old.Spec.UsernameLengthPolicy != db.Spec.UsernameLengthPolicy
+}
+
+// validateUsernameLength rejects a username longer than the engine allows, unless
+// spec.usernameLengthPolicy truncates it
+func validateUsernameLength(db *databasev1alpha1.Database) error {
+	name := username(db)
+	maxLength := database.MaxUsernameLength(string(db.Spec.Engine))
+	if maxLength == 0 || len(name) <= maxLength ||
+		db.Spec.UsernameLengthPolicy == databasev1alpha1.UsernameLengthPolicyTruncate {
+		return nil
+	}
+	path := field.NewPath("spec", "username")
+	if db.Spec.Username == "" {
+		path = field.NewPath("spec", "databaseName")
+	}
+	message := fmt.Sprintf("the username is %d characters long but %s allows at most %d, "+
+		"shorten it or set spec.usernameLengthPolicy to Truncate", len(name), db.Spec.Engine, maxLength)
+	return apierrors.NewInvalid(databasev1alpha1.GroupVersion.WithKind("Database").GroupKind(), db.Name,
+		field.ErrorList{field.Invalid(path, name, message)})
+}
+
+// validateSecretTemplate rejects a spec.secretTemplate that calls functions, renders the same value for
+// every Database or embeds literal credentials, see secrets.ValidateSecretTemplate, and a template of
+// a spec.secretBinary secret the password cannot be read back from, see secrets.ValidateBinaryTemplate
+// The template is not part of the message, it may contain the credentials it is rejected for.
+func validateSecretTemplate(db *databasev1alpha1.Database) error {
+	if db.Spec.SecretTemplate == "" {
+		return nil
+	}
+	err := secrets.ValidateSecretTemplate(db.Spec.SecretTemplate)
+	if err == nil && db.Spec.SecretBinary {
+		err = secrets.ValidateBinaryTemplate(db.Spec.SecretTemplate, string(db.Spec.Engine))
+	}
+	if err != nil {
+		return apierrors.NewInvalid(databasev1alpha1.GroupVersion.WithKind("Database").GroupKind(), db.Name,
+			field.ErrorList{field.Invalid(field.NewPath("spec", "secretTemplate"), field.OmitValueType{}, err.Error())})
+	}
+	return nil
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (v *DatabaseCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns the admission warnings for a Database and the error that rejects it
+func (v *DatabaseCustomValidator) validate(ctx context.Context, db *databasev1alpha1.Database) (admission.Warnings, error) {
+	warnings := v.warnings(ctx, db)
+	if !v.ValidateConnectionStrings {
+		return warnings, nil
+	}
+	warning, err := v.validateConnectionString(ctx, db)
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+	return warnings, err
+}
+
+// validateConnectionString checks that the Kubernetes secret of spec.connectionStringSecretRef contains
+// a connection string of the engine of the Database. A secret that does not exist yet only returns a
+// warning, a secret that cannot be read is left to the controller.
+// The connection string is never part of the returned messages.
+func (v *DatabaseCustomValidator) validateConnectionString(ctx context.Context, db *databasev1alpha1.Database) (string, error) {
+	ref := db.Spec.ConnectionStringSecretRef
+	if ref == nil || v.Reader == nil {
+		return "", nil
+	}
+	path := field.NewPath("spec", "connectionStringSecretRef")
+
+	secret := &corev1.Secret{}
+	err := v.Reader.Get(ctx, types.NamespacedName{Namespace: db.Namespace, Name: ref.Name}, secret)
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("%s: secret %s does not exist, the Database is not reconciled until it is created", path, ref.Name), nil
+	}
+	if err != nil {
+		databaselog.Error(err, "Failed to read secret, skipping connection string validation",
+			"namespace", db.Namespace, "secret", ref.Name)
+		return "", nil
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = defaultConnectionStringKey
+	}
+	connectionString := database.SanitizeConnectionString(string(secret.Data[key]))
+	var invalid string
+	if connectionString == "" {
+		invalid = fmt.Sprintf("key %s of secret %s is missing or empty", key, ref.Name)
+	} else if err := database.CheckConnectionStringEngine(string(db.Spec.Engine), connectionString); err != nil {
+		invalid = fmt.Sprintf("key %s of secret %s: %v", key, ref.Name, err)
+	} else if _, err := database.ParseConnectionInfo(string(db.Spec.Engine), connectionString); err != nil {
+		invalid = fmt.Sprintf("key %s of secret %s is not a valid %s connection string: %v", key, ref.Name, db.Spec.Engine, err)
+	}
+	if invalid == "" {
+		return "", nil
+	}
+	return "", apierrors.NewInvalid(databasev1alpha1.GroupVersion.WithKind("Database").GroupKind(), db.Name,
+		field.ErrorList{field.Invalid(path, ref.Name, invalid)})
+}
+
+// warnings returns the admission warnings for a Database
+func (v *DatabaseCustomValidator) warnings(ctx context.Context, db *databasev1alpha1.Database) admission.Warnings {
+	warnings := specWarnings(db)
+
+	if db.Spec.RetainOnDelete != nil && !*db.Spec.RetainOnDelete {
+		production, err := v.isProductionNamespace(ctx, db.Namespace)
+		if err != nil {
+			databaselog.Error(err, "Failed to read namespace, skipping production check", "namespace", db.Namespace)
+		} else if production {
+			warnings = append(warnings, fmt.Sprintf(
+				"spec.retainOnDelete is false in production namespace %s: deleting the Database drops the database, the user and the secret",
+				db.Namespace))
+		}
+	}
+
+	return warnings
+}
+
+// isProductionNamespace reports whether the namespace matches the production namespace selector
+func (v *DatabaseCustomValidator) isProductionNamespace(ctx context.Context, name string) (bool, error) {
+	if v.ProductionNamespaces == nil || v.ProductionNamespaces.Empty() || v.Reader == nil {
+		return false, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := v.Reader.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return false, err
+	}
+	return v.ProductionNamespaces.Matches(labels.Set(ns.Labels)), nil
+}
+
+// specWarnings returns the warnings that can be derived from the Database spec alone
+func specWarnings(db *databasev1alpha1.Database) admission.Warnings {
+	var warnings admission.Warnings
+
+	if database.EngineFamily(string(db.Spec.Engine)) == "mysql" {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s users are created with the wildcard host '%%' and accept connections from any host",
+			db.Spec.Engine))
+	}
+
+	if db.Spec.UsernameLengthPolicy == databasev1alpha1.UsernameLengthPolicyTruncate {
+		name := username(db)
+		if truncated := database.TruncateUsername(name, database.MaxUsernameLength(string(db.Spec.Engine))); truncated != name {
+			warnings = append(warnings, fmt.Sprintf("username %s is too long for %s and is truncated to %s", name, db.Spec.Engine, truncated))
+		}
+	}
+
+	if db.Spec.PrivilegePreset != "" && len(db.Spec.Privileges) > 0 {
+		warnings = append(warnings, "spec.privileges is ignored because spec.privilegePreset is set")
+	}
+	for _, privilege := range db.Spec.Privileges {
+		p := strings.ToUpper(strings.TrimSpace(privilege))
+		if db.Spec.PrivilegePreset == "" && (p == "ALL" || p == "ALL PRIVILEGES") {
+			warnings = append(warnings, "spec.privileges grants ALL: consider granting only the privileges the application needs, e.g. with spec.privilegePreset")
+			break
+		}
+	}
+
+	if db.Spec.TTL != nil && (db.Spec.RetainOnDelete == nil || *db.Spec.RetainOnDelete) {
+		warnings = append(warnings, "spec.ttl deletes the Database but retainOnDelete keeps the database and user: "+
+			"set retainOnDelete: false to remove them when the ttl elapses")
+	}
+
+	if window := db.Spec.MaintenanceWindow; window != nil && window.TimeZone != "" {
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"spec.maintenanceWindow.timeZone %q is not a known time zone, the Database will not be reconciled", window.TimeZone))
+		}
+	}
+
+	if db.Spec.AWSSecretsManager == nil || len(db.Spec.AWSSecretsManager.Tags) == 0 {
+		warnings = append(warnings, "spec.awsSecretsManager.tags is empty: the secret carries no ownership or cost allocation tags")
+	}
+
+	if names := db.Spec.SecretKeyNames; names != nil {
+		if db.Spec.SecretTemplate != "" {
+			warnings = append(warnings, "spec.secretKeyNames cannot be combined with spec.secretTemplate, the secret will not be stored")
+		} else if err := (*secrets.KeyNames)(names).Validate(string(db.Spec.Engine)); err != nil {
+			warnings = append(warnings, fmt.Sprintf("spec.secretKeyNames is invalid, the secret will not be stored: %v", err))
+		}
+	}
+
+	if db.Spec.SecretFormat == databasev1alpha1.SecretFormatConnectionStringOnly &&
+		(db.Spec.SecretTemplate != "" || db.Spec.SecretKeyNames != nil || (db.Spec.Snowflake != nil && db.Spec.Snowflake.KeyPair)) {
+		warnings = append(warnings, "spec.secretFormat connectionStringOnly cannot be combined with spec.secretTemplate, "+
+			"spec.secretKeyNames or spec.snowflake.keyPair, the secret will not be stored")
+	}
+
+	if err := database.ValidateConnectionParams(string(db.Spec.Engine), db.Spec.ConnectionParams); err != nil {
+		warnings = append(warnings, fmt.Sprintf("spec.connectionParams is invalid, the Database will not be reconciled: %v", err))
+	}
+
+	if db.Spec.AWSSecretsManager != nil && db.Spec.AWSSecretsManager.Description != "" {
+		if _, err := secrets.RenderDescription(db.Spec.AWSSecretsManager.Description, secrets.DescriptionData{}); err != nil {
+			warnings = append(warnings, fmt.Sprintf("spec.awsSecretsManager.description is not a valid template, the secret will not be stored: %v", err))
+		}
+	}
+
+	return warnings
+}
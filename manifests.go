@@ -0,0 +1,23 @@
+/*
+Copyright 2025 OpzKit
+
+Licensed under the MIT License.
+See LICENSE file in the project root for full license information.
+*/
+
+package databaseuseroperator
+
+import "embed"
+
+// The manifests are embedded into the manager binary, which renders them with the
+// generate-manifests subcommand
+
+// CRDs holds the CustomResourceDefinitions installed by the Helm chart
+//
+//go:embed helm/database-user-operator/crds/*.yaml
+var CRDs embed.FS
+
+// LeaderElectionRole is the Role the manager needs for leader election in its namespace
+//
+//go:embed config/rbac/leader_election_role.yaml
+var LeaderElectionRole []byte